@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	apperrors "github.com/harshit-vibes/cf/pkg/internal/errors"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// audit flags
+	auditFix bool
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Find saved problems missing statements, samples or metadata",
+	Long: `Check every problem in the workspace for a statement.md, samples, a
+rating and tags, and list the problems missing any of them.
+
+--fix scrapes the problem page again for missing statements and samples,
+and asks the API for missing ratings and tags. Problems the API has not
+rated, such as gym problems, get an estimated rating.
+
+Examples:
+  cf audit           # Report incomplete problems
+  cf audit --fix     # Re-fetch what is missing`,
+	Args: cobra.NoArgs,
+	RunE: runAudit,
+}
+
+func init() {
+	auditCmd.Flags().BoolVar(&auditFix, "fix", false, "Re-fetch missing statements, samples and metadata")
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	checked, incomplete, err := ws.AuditProblems()
+	if err != nil {
+		return fmt.Errorf("failed to audit problems: %w", err)
+	}
+
+	if !tableOutput() {
+		return render(incomplete, auditColumns())
+	}
+
+	fmt.Printf("\n🔎 Audited %d problems\n", checked)
+	if len(incomplete) == 0 {
+		fmt.Println("✓ Every problem has a statement, samples, a rating and tags")
+		return nil
+	}
+
+	var statements, samples, ratings, tags int
+	for _, a := range incomplete {
+		statements += boolCount(a.MissingStatement)
+		samples += boolCount(a.NoSamples)
+		ratings += boolCount(a.NoRating)
+		tags += boolCount(a.NoTags)
+	}
+	fmt.Printf("   Missing statement: %d\n", statements)
+	fmt.Printf("   No samples:        %d\n", samples)
+	fmt.Printf("   No rating:         %d\n", ratings)
+	fmt.Printf("   No tags:           %d\n\n", tags)
+
+	if err := render(incomplete, auditColumns()); err != nil {
+		return err
+	}
+
+	if !auditFix {
+		fmt.Println("\nRun 'cf audit --fix' to re-fetch what is missing.")
+		return nil
+	}
+	return fixAudit(ws, incomplete)
+}
+
+// fixAudit re-fetches what each incomplete problem is missing
+func fixAudit(ws *workspace.Workspace, incomplete []workspace.ProblemAudit) error {
+	ctx, cancel := commandContext(time.Duration(len(incomplete)) * perProblemTimeout)
+	defer cancel()
+
+	fmt.Printf("\n🔧 Fixing %d problems...\n", len(incomplete))
+	client := getAPIClient()
+	parser := cfweb.NewParserWithClient(nil)
+
+	failed := apperrors.NewMultiError(len(incomplete))
+	fixed := 0
+	for _, a := range incomplete {
+		id := a.Problem.ID
+		err := fixAuditedProblem(ctx, ws, client, parser, a)
+		if stopped(ctx) {
+			reportStopped(ctx, fixed, len(incomplete), "problems")
+			return ctx.Err()
+		}
+		if err != nil {
+			fmt.Printf("  ✗ %s: %v\n", id, err)
+			failed.Add(id, err)
+			continue
+		}
+		fmt.Printf("  ✓ %s. %s\n", id, a.Problem.Name)
+		fixed++
+	}
+	return failed.Err()
+}
+
+// fixAuditedProblem scrapes the problem page when the statement or samples
+// are missing and fills in missing metadata from the API, then saves the problem
+func fixAuditedProblem(ctx context.Context, ws *workspace.Workspace, client *cfapi.Client, parser *cfweb.Parser, a workspace.ProblemAudit) error {
+	p := a.Problem
+
+	var parsed *cfweb.ParsedProblem
+	if a.NeedsScrape() {
+		var err error
+		if parsed, err = parser.ParseProblemContext(ctx, p.ContestID, p.Index); err != nil {
+			return fmt.Errorf("failed to fetch problem page: %w", err)
+		}
+		scraped := parsed.ToSchemaProblem()
+		if a.NoSamples {
+			p.Samples = scraped.Samples
+		}
+		if p.Limits.TimeLimit == "" {
+			p.Limits = scraped.Limits
+		}
+		if p.Metadata.Rating == 0 {
+			p.Metadata.Rating = scraped.Metadata.Rating
+		}
+		if len(p.Metadata.Tags) == 0 {
+			p.Metadata.Tags = scraped.Metadata.Tags
+		}
+	}
+
+	if a.NeedsMetadata() {
+		if err := fillMissingMetadata(ctx, client, p); err != nil {
+			return err
+		}
+	}
+
+	if err := ws.SaveProblem(p); err != nil {
+		return fmt.Errorf("failed to save problem: %w", err)
+	}
+
+	if a.MissingStatement {
+		if strings.TrimSpace(parsed.Statement) == "" {
+			return fmt.Errorf("the problem page has no statement")
+		}
+		if err := ws.SaveStatement(p, parsed.Statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillMissingMetadata fills in an empty rating and tags from the API,
+// estimating the rating of problems the API has not rated
+func fillMissingMetadata(ctx context.Context, client *cfapi.Client, p *v1.Problem) error {
+	apiProblem, err := client.GetProblem(ctx, p.ContestID, p.Index)
+	if err != nil && !errors.Is(err, cfapi.ErrProblemNotFound) {
+		return fmt.Errorf("failed to fetch metadata: %w", err)
+	}
+	if err == nil {
+		if p.Metadata.Rating == 0 && apiProblem.Rating > 0 {
+			p.Metadata.Rating = apiProblem.Rating
+			p.Metadata.RatingEstimated = false
+		}
+		if len(p.Metadata.Tags) == 0 {
+			p.Metadata.Tags = apiProblem.Tags
+		}
+	}
+	estimateMissingRating(ctx, client, p)
+	return nil
+}
+
+// auditColumns describes the fields shown for each incomplete problem
+func auditColumns() []output.Column {
+	return []output.Column{
+		output.Col("Problem", 10, func(a workspace.ProblemAudit) string { return a.Problem.ID }),
+		output.Col("Name", 40, func(a workspace.ProblemAudit) string { return a.Problem.Name }),
+		output.Col("Missing", 0, func(a workspace.ProblemAudit) string { return strings.Join(a.Missing(), ", ") }),
+	}
+}
+
+// boolCount returns 1 for true and 0 for false
+func boolCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var restoreForce bool
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [file]",
+	Short: "Back up the workspace to a .tar.gz archive",
+	Long: `Archive the whole workspace, including problems, notes and progress.
+
+The archive is written to cf-backup-<date>.tar.gz in the current directory
+unless a file is given.
+
+Examples:
+  cf backup
+  cf backup ~/backups/cf.tar.gz`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBackup,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <file> [path]",
+	Short: "Restore a workspace from a backup",
+	Long: `Extract a backup made with 'cf backup' into path (default: current directory).
+
+The archive must contain a valid workspace.yaml; nothing is extracted
+otherwise. Restoring into a non-empty directory requires --force, which
+replaces files from the archive and leaves other files alone.
+
+Examples:
+  cf restore cf-backup-20240101-120000.tar.gz ~/cf-workspace
+  cf restore backup.tar.gz . --force`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runRestore,
+}
+
+func init() {
+	restoreCmd.Flags().BoolVar(&restoreForce, "force", false, "Restore into a non-empty directory")
+}
+
+func runBackup(cmd *cobra.Command, args []string) error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	dest := fmt.Sprintf("cf-backup-%s.tar.gz", time.Now().Format("20060102-150405"))
+	if len(args) > 0 {
+		dest = args[0]
+	}
+
+	if err := ws.Backup(dest); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Backed up workspace %s to %s\n", ws.Root(), dest)
+	return nil
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	path := "."
+	if len(args) > 1 {
+		path = args[1]
+	}
+
+	var opts []workspace.RestoreOption
+	if restoreForce {
+		opts = append(opts, workspace.WithOverwrite())
+	}
+
+	ws := workspace.New(path)
+	err := ws.Restore(args[0], opts...)
+	if errors.Is(err, workspace.ErrNotEmpty) {
+		return fmt.Errorf("%w (use --force to restore anyway)", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Restored workspace to %s\n", path)
+	return nil
+}
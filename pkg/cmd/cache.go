@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage cached tags and status",
+	Long: `Inspect or clear the files cf keeps in its cache directory.
+
+The cache lives in $XDG_CACHE_HOME/cf (or ~/.cache/cf) unless cache_dir is
+set in the config.
+
+Examples:
+  cf cache info
+  cf cache clear`,
+}
+
+var cacheInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show cache location, entry count and size",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheInfo,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove cf's cached files",
+	Long: `Remove the problemset tags and prompt status cf caches on disk.
+
+Only files cf writes are removed, so a cache_dir shared with other files is
+safe to clear. API responses are only cached in memory for the length of a
+command, so there is nothing to clear for them.`,
+	Args: cobra.NoArgs,
+	RunE: runCacheClear,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheInfoCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+func runCacheInfo(cmd *cobra.Command, args []string) error {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return err
+	}
+
+	entries, size, err := cacheUsage(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	fmt.Println("\n🗄️  Cache:")
+	fmt.Println(strings.Repeat("─", 40))
+	fmt.Printf("  Path:    %s\n", dir)
+	fmt.Printf("  Entries: %d\n", entries)
+	fmt.Printf("  Size:    %s\n", formatSize(size))
+	fmt.Println()
+
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return err
+	}
+
+	removed, err := clearCacheDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	fmt.Printf("✓ Cleared %d cache entries from %s\n", removed, dir)
+	return nil
+}
+
+// cacheFiles are the files cf keeps in the cache dir. Only these are
+// counted and cleared, since cache_dir may point at a directory that holds
+// other files too.
+var cacheFiles = []string{tagsCacheFile, statusCacheFile}
+
+// cacheUsage returns the number of cache files in dir and their total size
+// A missing directory is an empty cache
+func cacheUsage(dir string) (int, int64, error) {
+	entries := 0
+	var size int64
+
+	for _, name := range cacheFiles {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		entries++
+		size += info.Size()
+	}
+
+	return entries, size, nil
+}
+
+// clearCacheDir removes the cache files in dir, leaving everything else
+// It returns the number of files removed
+func clearCacheDir(dir string) (int, error) {
+	removed := 0
+	for _, name := range cacheFiles {
+		err := os.Remove(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// formatSize renders a byte count as B, KB or MB
+func formatSize(n int64) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	}
+}
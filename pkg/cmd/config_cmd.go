@@ -1,12 +1,22 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
 	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/health"
+)
+
+var (
+	// config doctor flags
+	doctorYes bool
 )
 
 var configCmd = &cobra.Command{
@@ -25,12 +35,19 @@ var configGetCmd = &cobra.Command{
 If no key is provided, shows all configuration.
 
 Available keys:
-  cf_handle       - Your Codeforces handle
-  cookie          - Browser cookie for authentication
-  difficulty.min  - Minimum problem difficulty
-  difficulty.max  - Maximum problem difficulty
-  daily_goal      - Daily problem solving goal
-  workspace_path  - Path to workspace directory
+  cf_handle            - Your Codeforces handle
+  cookie               - Browser cookie for authentication
+  cf_clearance_ua      - User-Agent of the browser the cookie came from
+  cf_clearance_expires - When cf_clearance expires
+  api_key              - Codeforces API key
+  api_secret           - Codeforces API secret
+  difficulty           - Difficulty band as min-max
+  difficulty.min       - Minimum problem difficulty
+  difficulty.max       - Maximum problem difficulty
+  daily_goal           - Daily problem solving goal
+  default_language     - Language cf submit falls back to
+  workspace_path       - Path to workspace directory
+  cache_dir            - Path to cache directory
 
 Examples:
   cf config get              # Show all config
@@ -45,18 +62,32 @@ var configSetCmd = &cobra.Command{
 	Long: `Set a configuration value.
 
 Available keys:
-  cf_handle       - Your Codeforces handle
-  cookie          - Browser cookie string for authentication
-  difficulty.min  - Minimum problem difficulty (e.g., 800)
-  difficulty.max  - Maximum problem difficulty (e.g., 1400)
-  daily_goal      - Daily problem solving goal (e.g., 3)
-  workspace_path  - Path to workspace directory
+  cf_handle            - Your Codeforces handle
+  cookie               - Browser cookie string for authentication
+  cf_clearance_ua      - User-Agent of the browser the cookie came from
+  cf_clearance_expires - When cf_clearance expires, as RFC 3339 or a
+                         duration from now (e.g. 2h); checks warn 15m before
+  api_key              - Codeforces API key (codeforces.com/settings/api)
+  api_secret           - Codeforces API secret
+  difficulty           - Difficulty band, takes <min> <max> (800-3500),
+                         or 'auto' to suggest one from your rating and
+                         recent solves
+  difficulty.min       - Minimum problem difficulty (e.g., 800)
+  difficulty.max       - Maximum problem difficulty (e.g., 1400)
+  daily_goal           - Daily problem solving goal (e.g., 3)
+  default_language     - Language cf submit uses when the file extension
+                         doesn't tell, e.g. C++20, cpp20 or 89
+  workspace_path       - Path to workspace directory
+  cache_dir            - Path to cache directory (default ~/.cache/cf)
 
 Examples:
   cf config set cf_handle tourist
   cf config set cookie 'JSESSIONID=xxx; 39ce7=xxx; cf_clearance=xxx'
+  cf config set difficulty 1000 1600
+  cf config set difficulty auto
+  cf config set default_language "C++20"
   cf config set difficulty.min 1000`,
-	Args: cobra.ExactArgs(2),
+	Args: configSetArgs,
 	RunE: runConfigSet,
 }
 
@@ -67,11 +98,31 @@ var configPathCmd = &cobra.Command{
 	RunE:  runConfigPath,
 }
 
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Validate and repair configuration",
+	Long: `Check the configuration for common mistakes and offer to fix them.
+
+Checks that the CF handle is set, that the cookie contains the
+JSESSIONID, 39ce7 and cf_clearance cookies, that cf_clearance is not
+about to expire, that the API key and secret are set together, and that
+values have no stray whitespace. Secret values are masked in the output.
+
+Examples:
+  cf config doctor         # Check and ask before rewriting
+  cf config doctor --yes   # Apply fixes without asking`,
+	RunE: runConfigDoctor,
+}
+
 func init() {
 	// Add config subcommands
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configPathCmd)
+	configCmd.AddCommand(configDoctorCmd)
+
+	// config doctor flags
+	configDoctorCmd.Flags().BoolVarP(&doctorYes, "yes", "y", false, "Apply fixes without prompting")
 }
 
 func runConfigGet(cmd *cobra.Command, args []string) error {
@@ -84,11 +135,13 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		// Show all config
 		fmt.Println("\n📋 Configuration:")
 		fmt.Println(strings.Repeat("─", 40))
-		fmt.Printf("  cf_handle:       %s\n", valueOrEmpty(cfg.CFHandle))
-		fmt.Printf("  difficulty.min:  %d\n", cfg.Difficulty.Min)
-		fmt.Printf("  difficulty.max:  %d\n", cfg.Difficulty.Max)
-		fmt.Printf("  daily_goal:      %d\n", cfg.DailyGoal)
-		fmt.Printf("  workspace_path:  %s\n", valueOrEmpty(cfg.WorkspacePath))
+		fmt.Printf("  cf_handle:        %s\n", valueOrEmpty(cfg.CFHandle))
+		fmt.Printf("  difficulty.min:   %d\n", cfg.Difficulty.Min)
+		fmt.Printf("  difficulty.max:   %d\n", cfg.Difficulty.Max)
+		fmt.Printf("  daily_goal:       %d\n", cfg.DailyGoal)
+		fmt.Printf("  default_language: %s\n", valueOrEmpty(cfg.DefaultLanguage))
+		fmt.Printf("  workspace_path:   %s\n", valueOrEmpty(cfg.WorkspacePath))
+		fmt.Printf("  cache_dir:        %s\n", valueOrEmpty(cfg.CacheDir))
 		fmt.Println()
 
 		// Show authentication status
@@ -98,7 +151,14 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		if config.HasCookie() {
 			cookieStatus = "(configured)"
 		}
-		fmt.Printf("  cookie:          %s\n", cookieStatus)
+		fmt.Printf("  cookie:           %s\n", cookieStatus)
+		fmt.Printf("  cf_clearance_ua:  %s\n", valueOrEmpty(cfg.CFClearanceUA))
+		fmt.Printf("  cf_clearance:     %s\n", config.GetCFClearanceStatus())
+		apiKeyStatus := "(not set)"
+		if config.HasAPIKey() {
+			apiKeyStatus = "(configured)"
+		}
+		fmt.Printf("  api_key:          %s\n", apiKeyStatus)
 		fmt.Println()
 
 		return nil
@@ -111,14 +171,28 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		fmt.Println(valueOrEmpty(cfg.CFHandle))
 	case "cookie":
 		fmt.Println(maskValue(cfg.Cookie))
+	case "cf_clearance_ua":
+		fmt.Println(valueOrEmpty(cfg.CFClearanceUA))
+	case "cf_clearance_expires":
+		fmt.Println(valueOrEmpty(cfg.CFClearanceExpires))
+	case "api_key":
+		fmt.Println(maskSecret(cfg.APIKey))
+	case "api_secret":
+		fmt.Println(maskSecret(cfg.APISecret))
+	case "difficulty":
+		fmt.Printf("%d-%d\n", cfg.Difficulty.Min, cfg.Difficulty.Max)
 	case "difficulty.min":
 		fmt.Println(cfg.Difficulty.Min)
 	case "difficulty.max":
 		fmt.Println(cfg.Difficulty.Max)
 	case "daily_goal":
 		fmt.Println(cfg.DailyGoal)
+	case "default_language":
+		fmt.Println(valueOrEmpty(cfg.DefaultLanguage))
 	case "workspace_path":
 		fmt.Println(valueOrEmpty(cfg.WorkspacePath))
+	case "cache_dir":
+		fmt.Println(valueOrEmpty(cfg.CacheDir))
 	default:
 		return fmt.Errorf("unknown config key: %s", key)
 	}
@@ -126,16 +200,64 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// parseExpiry reads an expiry as an RFC 3339 time or a duration from now
+func parseExpiry(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid value for cf_clearance_expires: %s (use e.g. 2024-03-01T18:00:00Z or 2h)", value)
+}
+
+// configSetArgs accepts a key and value, or a min and max for difficulty
+func configSetArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && strings.ToLower(args[0]) == "difficulty" {
+		if len(args) == 2 && strings.ToLower(args[1]) == "auto" {
+			return nil
+		}
+		if len(args) != 3 {
+			return fmt.Errorf("difficulty takes a min and max or 'auto', e.g. 'cf config set difficulty 1000 1600'")
+		}
+		return nil
+	}
+	return cobra.ExactArgs(2)(cmd, args)
+}
+
 func runConfigSet(cmd *cobra.Command, args []string) error {
 	key := strings.ToLower(args[0])
-	value := args[1]
+	value := strings.Join(args[1:], " ")
 
 	var err error
 	switch key {
+	case "difficulty":
+		var min, max int
+		if strings.ToLower(value) == "auto" {
+			if min, max, err = suggestedBand(); err != nil {
+				return err
+			}
+			value = fmt.Sprintf("%d %d", min, max)
+		} else if _, e := fmt.Sscanf(value, "%d %d", &min, &max); e != nil {
+			return fmt.Errorf("invalid value for difficulty: %s", value)
+		}
+		err = config.SetDifficulty(min, max)
 	case "cf_handle":
 		err = config.SetCFHandle(value)
 	case "cookie":
-		err = config.SetCookie(value)
+		err = config.SetCookie(cfweb.SanitizeCookie(value))
+	case "cf_clearance_ua":
+		err = config.Set("cf_clearance_ua", value)
+	case "cf_clearance_expires":
+		expires, e := parseExpiry(value, time.Now())
+		if e != nil {
+			return e
+		}
+		err = config.SetCFClearanceExpires(expires)
+	case "api_key":
+		err = config.Set("api_key", value)
+	case "api_secret":
+		err = config.Set("api_secret", value)
 	case "difficulty.min":
 		var min int
 		if _, e := fmt.Sscanf(value, "%d", &min); e != nil {
@@ -156,29 +278,179 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("invalid value for daily_goal: %s", value)
 		}
 		err = config.SetDailyGoal(goal)
+	case "default_language":
+		lang, e := cfweb.ResolveLanguage(value)
+		if e != nil {
+			return e
+		}
+		value = fmt.Sprintf("%s (%s)", lang.ID, lang.Name)
+		err = config.SetDefaultLanguage(lang.ID)
 	case "workspace_path":
 		err = config.SetWorkspacePath(value)
+	case "cache_dir":
+		err = config.SetCacheDir(value)
 	default:
-		return fmt.Errorf("unknown config key: %s\n\nAvailable keys: cf_handle, cookie, difficulty.min, difficulty.max, daily_goal, workspace_path", key)
+		return fmt.Errorf("unknown config key: %s\n\nAvailable keys: cf_handle, cookie, cf_clearance_ua, cf_clearance_expires, api_key, api_secret, difficulty, difficulty.min, difficulty.max, daily_goal, default_language, workspace_path, cache_dir", key)
 	}
 
 	if err != nil {
 		return fmt.Errorf("failed to set config: %w", err)
 	}
 
+	// Secrets stay out of the terminal scrollback
+	switch key {
+	case "api_key", "api_secret", "cookie":
+		value = maskSecret(value)
+	}
 	fmt.Printf("✓ Set %s = %s\n", key, value)
 	return nil
 }
 
+// suggestedBand asks the API for a practice band for the configured handle
+func suggestedBand() (min, max int, err error) {
+	handle, err := getHandle(nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ctx, cancel := commandContext(fetchTimeout)
+	defer cancel()
+
+	min, max, err = getAPIClient().SuggestDifficultyBand(ctx, handle)
+	if err != nil {
+		return 0, 0, explainAPIError(fmt.Errorf("failed to suggest a difficulty band: %w", err))
+	}
+	return min, max, nil
+}
+
 func runConfigPath(cmd *cobra.Command, args []string) error {
 	fmt.Println("\n📁 Configuration Files:")
 	fmt.Println(strings.Repeat("─", 40))
 	fmt.Printf("  Config: ~/.cf/config.yaml\n")
+	if dir, err := config.GetCacheDir(); err == nil {
+		fmt.Printf("  Cache:  %s\n", dir)
+	}
 	fmt.Println()
 
 	return nil
 }
 
+// configFix is a repair the doctor can apply to the config file
+type configFix struct {
+	key         string
+	value       string
+	description string
+}
+
+func runConfigDoctor(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	fmt.Println("\n🩺 Config Doctor:")
+	fmt.Println(strings.Repeat("─", 40))
+
+	issues := 0
+	report := func(ok bool, label, value, hint string) {
+		icon := "✓"
+		if !ok {
+			icon = "✗"
+			issues++
+		}
+		fmt.Printf("  %s %-16s %s\n", icon, label, value)
+		if !ok && hint != "" {
+			fmt.Printf("    └─ %s\n", hint)
+		}
+	}
+
+	report(config.HasHandle(), "cf_handle", valueOrEmpty(cfg.CFHandle),
+		"Run: cf config set cf_handle YOUR_HANDLE")
+	report(config.HasCookie(), "cookie", maskSecret(cfg.Cookie),
+		"Run: cf config set cookie 'YOUR_COOKIE_STRING'")
+
+	if config.HasCookie() {
+		report(config.HasSessionCookies(), "session cookies",
+			maskSecret(config.CookieValue(cfg.Cookie, config.CookieSession)),
+			"Cookie must include JSESSIONID and 39ce7 from a logged-in browser")
+		hint := "Cookie must include cf_clearance, copy it again from your browser"
+		if config.HasCFClearance() {
+			hint = "cf_clearance is about to expire, run 'cf setup' to refresh it"
+		}
+		report(config.HasCFClearance() && !config.CFClearanceNeedsRefresh(health.ClearanceRefreshWindow),
+			"cf_clearance", config.GetCFClearanceStatus(), hint)
+	}
+
+	// The API key is optional, but only works together with its secret
+	apiKeySet, apiSecretSet := cfg.APIKey != "", cfg.APISecret != ""
+	report(apiKeySet || !apiSecretSet, "api_key", maskSecret(cfg.APIKey),
+		"Run: cf config set api_key YOUR_KEY (codeforces.com/settings/api)")
+	report(apiSecretSet || !apiKeySet, "api_secret", maskSecret(cfg.APISecret),
+		"Run: cf config set api_secret YOUR_SECRET (codeforces.com/settings/api)")
+
+	// Collect repairs for whitespace and formatting mistakes
+	var fixes []configFix
+	if handle := strings.TrimSpace(cfg.CFHandle); handle != cfg.CFHandle {
+		fixes = append(fixes, configFix{"cf_handle", handle, "Trim whitespace from cf_handle"})
+	}
+	if cookie := config.NormalizeCookie(cfg.Cookie); cookie != cfg.Cookie {
+		fixes = append(fixes, configFix{"cookie", cookie, "Normalize cookie formatting"})
+	}
+	if key := strings.TrimSpace(cfg.APIKey); key != cfg.APIKey {
+		fixes = append(fixes, configFix{"api_key", key, "Trim whitespace from api_key"})
+	}
+	if secret := strings.TrimSpace(cfg.APISecret); secret != cfg.APISecret {
+		fixes = append(fixes, configFix{"api_secret", secret, "Trim whitespace from api_secret"})
+	}
+	if path := strings.TrimSpace(cfg.WorkspacePath); path != cfg.WorkspacePath {
+		fixes = append(fixes, configFix{"workspace_path", path, "Trim whitespace from workspace_path"})
+	}
+
+	fmt.Println()
+
+	if len(fixes) == 0 {
+		if issues == 0 {
+			fmt.Println("✓ Configuration looks good")
+		} else {
+			fmt.Printf("%d issue(s) need manual fixes, see hints above\n", issues)
+		}
+		return nil
+	}
+
+	fmt.Println("🔧 Repairable:")
+	for _, fix := range fixes {
+		fmt.Printf("  • %s\n", fix.description)
+	}
+	fmt.Println()
+
+	if !doctorYes && !confirm(cmd.InOrStdin(), "Rewrite config file?") {
+		fmt.Println("No changes made.")
+		return nil
+	}
+
+	for _, fix := range fixes {
+		if err := config.Set(fix.key, fix.value); err != nil {
+			return fmt.Errorf("failed to apply fix for %s: %w", fix.key, err)
+		}
+	}
+
+	fmt.Printf("✓ Applied %d fix(es)\n", len(fixes))
+	return nil
+}
+
+// confirm asks a yes/no question and returns true on "y" or "yes"
+func confirm(r io.Reader, prompt string) bool {
+	fmt.Printf("%s [y/N]: ", prompt)
+
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
 func valueOrEmpty(s string) string {
 	if s == "" {
 		return "(not set)"
@@ -195,3 +467,14 @@ func maskValue(s string) string {
 	}
 	return s[:4] + "..." + s[len(s)-4:]
 }
+
+// maskSecret hides all but the last 4 characters of a secret
+func maskSecret(s string) string {
+	if s == "" {
+		return "(not set)"
+	}
+	if len(s) <= 4 {
+		return "****"
+	}
+	return "****" + s[len(s)-4:]
+}
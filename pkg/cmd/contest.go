@@ -2,20 +2,43 @@ package cmd
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
 )
 
 var (
 	// contest list flags
-	contestShowGym    bool
-	contestLimit      int
-	contestPhase      string
+	contestShowGym bool
+	contestLimit   int
+	contestPhase   string
+	contestDiv     string
+	contestType    string
+	contestName    string
+
+	// contest standings flags
+	standingsCSV        string
+	standingsLimit      int
+	standingsUnofficial bool
+
+	// contest standings watch flags
+	standingsWatchInterval time.Duration
+
+	// contest register flags
+	registerTeam string
 )
 
 var contestCmd = &cobra.Command{
@@ -37,7 +60,10 @@ Shows upcoming and recent contests by default.
 Examples:
   cf contest list              # List recent contests
   cf contest list --gym        # List gym contests
-  cf contest list --limit 50   # Show more contests`,
+  cf contest list --limit 50   # Show more contests
+  cf contest list --div 2      # Only Div. 2 rounds
+  cf contest list --name educational  # Only Educational rounds
+  cf contest list --type ICPC  # Only ICPC-style contests`,
 	RunE: runContestList,
 }
 
@@ -52,23 +78,127 @@ Examples:
 	RunE: runContestProblems,
 }
 
+var contestStandingsCmd = &cobra.Command{
+	Use:   "standings <contest_id>",
+	Short: "Show or export contest standings",
+	Long: `Display contest standings or export them to CSV.
+
+The CSV has rank, handle, points and penalty columns, followed by
+points, attempts and submit time columns for each problem. Team
+members are joined with ';'.
+
+Examples:
+  cf contest standings 1234                       # Show top 20 rows
+  cf contest standings 1234 --csv standings.csv   # Export all rows
+  cf contest standings 1234 --csv out.csv --unofficial`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContestStandings,
+}
+
+var contestVirtualCmd = &cobra.Command{
+	Use:   "virtual <contest_id> [handle]",
+	Short: "Show the rank of a virtual participation",
+	Long: `Compute the rank a virtual participation would have had in the official
+standings, using ICPC scoring: problems solved, then penalty time with
+20 minutes per rejected attempt.
+
+Examples:
+  cf contest virtual 1234            # Your virtual participation
+  cf contest virtual 1234 tourist    # Another user's`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runContestVirtual,
+}
+
+var contestReportCmd = &cobra.Command{
+	Use:   "report <contest_id> [handle]",
+	Short: "Review your results in a contest",
+	Long: `Show a post-contest review: your rank, points and rating change, and for
+each problem whether you solved it, when, and after how many rejected attempts.
+
+Parts the API cannot provide, such as rating changes not applied yet, are
+reported and the rest is still shown.
+Uses your configured handle if none is given.
+
+Examples:
+  cf contest report 1325
+  cf contest report 1325 tourist -o json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runContestReport,
+}
+
+var contestStandingsWatchCmd = &cobra.Command{
+	Use:   "watch <contest_id> [handle...]",
+	Short: "Show who is gaining points during a live contest",
+	Long: `Poll the standings of a running contest and print the points each
+handle gained since the previous poll, to keep an eye on rivals.
+
+Your configured handle is always tracked. Runs until Ctrl-C.
+
+Examples:
+  cf contest standings watch 1325 tourist Petr
+  cf contest standings watch 1325 tourist --interval 1m`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runContestStandingsWatch,
+}
+
+var contestRegisterCmd = &cobra.Command{
+	Use:   "register <contest_id>",
+	Short: "Register for an upcoming contest",
+	Long: `Register your handle for a contest that is open for registration.
+Registering needs the browser cookie from 'cf setup'.
+
+With --team, the team of that ID or name is registered instead of you
+alone; an unknown team lists the teams the registration page offers.
+
+Examples:
+  cf contest register 2000
+  cf contest register 2000 --team "My Team"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContestRegister,
+}
+
 func init() {
 	// Add contest subcommands
 	contestCmd.AddCommand(contestListCmd)
 	contestCmd.AddCommand(contestProblemsCmd)
+	contestCmd.AddCommand(contestStandingsCmd)
+	contestCmd.AddCommand(contestVirtualCmd)
+	contestCmd.AddCommand(contestReportCmd)
+	contestCmd.AddCommand(contestRegisterCmd)
+	contestStandingsCmd.AddCommand(contestStandingsWatchCmd)
+
+	// contest standings flags
+	contestStandingsCmd.Flags().StringVar(&standingsCSV, "csv", "", "Write standings to a CSV file")
+	contestStandingsCmd.Flags().IntVar(&standingsLimit, "limit", 20, "Maximum number of rows (0 for all, --csv exports all by default)")
+	contestStandingsCmd.Flags().BoolVar(&standingsUnofficial, "unofficial", false, "Include unofficial participants")
+
+	// contest standings watch flags
+	contestStandingsWatchCmd.Flags().DurationVar(&standingsWatchInterval, "interval", 30*time.Second, "Time between polls")
+	contestStandingsWatchCmd.ValidArgsFunction = completeHandles
+
+	// contest register flags
+	contestRegisterCmd.Flags().StringVar(&registerTeam, "team", "", "Register with this team (ID or name) instead of individually")
 
 	// contest list flags
 	contestListCmd.Flags().BoolVar(&contestShowGym, "gym", false, "Show gym contests instead of regular contests")
 	contestListCmd.Flags().IntVar(&contestLimit, "limit", 20, "Maximum number of contests to display")
 	contestListCmd.Flags().StringVar(&contestPhase, "phase", "", "Filter by phase (BEFORE, CODING, FINISHED)")
+	contestListCmd.Flags().StringVar(&contestDiv, "div", "", "Filter by division (1, 2, 3, 4)")
+	contestListCmd.Flags().StringVar(&contestType, "type", "", "Filter by contest type (CF, IOI, ICPC)")
+	contestListCmd.Flags().StringVar(&contestName, "name", "", "Filter by name substring (e.g. educational)")
 }
 
 func runContestList(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := commandContext(fetchTimeout)
 	defer cancel()
 
 	client := getAPIClient()
-	contests, err := client.GetContests(ctx, contestShowGym)
+	filter := cfapi.ContestFilter{
+		Name:     contestName,
+		Division: contestDiv,
+		Type:     contestType,
+	}
+	contests, err := client.GetContestsFiltered(ctx, contestShowGym, filter)
 	if err != nil {
 		return fmt.Errorf("failed to fetch contests: %w", err)
 	}
@@ -104,6 +234,8 @@ func runContestList(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%-8s %-50s %-12s %s\n", "ID", "Name", "Phase", "Start Time")
 	fmt.Println(strings.Repeat("─", 100))
 
+	registered, regErr := registeredContests(ctx, client, contests, config.GetCFHandle())
+
 	for _, c := range contests {
 		name := c.Name
 		if len(name) > 48 {
@@ -116,32 +248,82 @@ func runContestList(cmd *cobra.Command, args []string) error {
 			startTime = c.StartTime().Format("Jan 02, 2006 15:04")
 		}
 
-		fmt.Printf("%-8d %-50s %s%-12s\033[0m %s\n",
+		fmt.Printf("%-8d %-50s %s%-12s\033[0m %s%s\n",
 			c.ID,
 			name,
 			phaseColor,
 			c.Phase,
 			startTime,
+			registrationNote(registered[c.ID]),
 		)
 	}
 
 	fmt.Println()
+	if regErr != nil {
+		fmt.Printf("⚠️  Could not check registration: %v\n\n", regErr)
+	}
 	return nil
 }
 
+// registeredContests returns the shown upcoming and running contests handle
+// is registered for, and the first error from checking them
+// Running contests are checked on the API's standings; upcoming ones have
+// none yet and are read from the registration page, which needs the
+// browser cookie and is skipped without it.
+func registeredContests(ctx context.Context, client *cfapi.Client, contests []cfapi.Contest, handle string) (map[int]bool, error) {
+	registered := make(map[int]bool)
+	if handle == "" {
+		return registered, nil
+	}
+
+	submitter, _ := newSubmitter()
+	var firstErr error
+	for _, c := range contests {
+		var ok bool
+		var err error
+		switch c.Phase {
+		case cfapi.PhaseCoding:
+			ok, err = client.IsRegistered(ctx, c.ID, handle)
+		case cfapi.PhaseBefore:
+			if submitter == nil {
+				continue
+			}
+			ok, err = submitter.IsRegistered(c.ID)
+		default:
+			continue
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("contest %d: %w", c.ID, err)
+			}
+			continue
+		}
+		registered[c.ID] = ok
+	}
+	return registered, firstErr
+}
+
+// registrationNote returns the marker shown beside a registered contest
+func registrationNote(registered bool) string {
+	if !registered {
+		return ""
+	}
+	return "  \033[32m✓ you are registered\033[0m"
+}
+
 func runContestProblems(cmd *cobra.Command, args []string) error {
 	var contestID int
 	if _, err := fmt.Sscanf(args[0], "%d", &contestID); err != nil {
 		return fmt.Errorf("invalid contest ID: %s", args[0])
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := commandContext(fetchTimeout)
 	defer cancel()
 
 	client := getAPIClient()
 	standings, err := client.GetContestStandings(ctx, contestID, 1, 1, nil, false)
 	if err != nil {
-		return fmt.Errorf("failed to get contest: %w", err)
+		return explainAPIError(fmt.Errorf("failed to get contest: %w", err))
 	}
 
 	contest := standings.Contest
@@ -192,6 +374,140 @@ func runContestProblems(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runContestStandings(cmd *cobra.Command, args []string) error {
+	var contestID int
+	if _, err := fmt.Sscanf(args[0], "%d", &contestID); err != nil {
+		return fmt.Errorf("invalid contest ID: %s", args[0])
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	// Export everything unless a limit was given explicitly
+	count := standingsLimit
+	if standingsCSV != "" && !cmd.Flags().Changed("limit") {
+		count = 0
+	}
+
+	client := getAPIClient()
+	standings, err := client.GetContestStandings(ctx, contestID, 1, count, nil, standingsUnofficial)
+	if err != nil {
+		return explainAPIError(fmt.Errorf("failed to get standings: %w", err))
+	}
+
+	if standingsCSV != "" {
+		f, err := os.Create(standingsCSV)
+		if err != nil {
+			return fmt.Errorf("failed to create CSV file: %w", err)
+		}
+		defer f.Close()
+
+		if err := writeStandingsCSV(f, standings); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+
+		fmt.Printf("✓ Exported %d rows to %s\n", len(standings.Rows), standingsCSV)
+		return nil
+	}
+
+	if !tableOutput() {
+		return render(standings.Rows, standingsColumns(len(standings.Problems)))
+	}
+
+	fmt.Printf("\n%s - Standings\n\n", standings.Contest.Name)
+	if err := render(standings.Rows, standingsColumns(len(standings.Problems))); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// standingsColumns describes the fields shown for each standings row
+func standingsColumns(problemCount int) []output.Column {
+	return []output.Column{
+		output.Col("Rank", 6, func(r cfapi.RanklistRow) string { return strconv.Itoa(r.Rank) }),
+		output.Col("Handle", 30, func(r cfapi.RanklistRow) string { return partyHandles(r.Party) }),
+		output.Col("Points", 8, func(r cfapi.RanklistRow) string { return formatPoints(r.Points) }).AlignRight(),
+		output.Col("Penalty", 8, func(r cfapi.RanklistRow) string { return strconv.Itoa(r.Penalty) }).AlignRight(),
+		output.Col("Solved", 0, func(r cfapi.RanklistRow) string {
+			solved := 0
+			for _, pr := range r.ProblemResults {
+				if pr.Points > 0 {
+					solved++
+				}
+			}
+			return fmt.Sprintf("%d/%d", solved, problemCount)
+		}),
+	}
+}
+
+// writeStandingsCSV writes standings as CSV with per-problem columns
+func writeStandingsCSV(w io.Writer, standings *cfapi.ContestStandings) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"rank", "handle", "points", "penalty"}
+	for _, p := range standings.Problems {
+		header = append(header, p.Index+"_points", p.Index+"_attempts", p.Index+"_time")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range standings.Rows {
+		record := []string{
+			strconv.Itoa(row.Rank),
+			partyHandles(row.Party),
+			formatPoints(row.Points),
+			strconv.Itoa(row.Penalty),
+		}
+
+		for i := range standings.Problems {
+			if i >= len(row.ProblemResults) {
+				record = append(record, "", "", "")
+				continue
+			}
+			r := row.ProblemResults[i]
+
+			submitTime := ""
+			if r.BestSubmissionTimeSeconds > 0 {
+				submitTime = formatContestTime(r.BestSubmissionTimeSeconds)
+			}
+			record = append(record,
+				formatPoints(r.Points),
+				strconv.Itoa(r.RejectedAttemptCount),
+				submitTime,
+			)
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// partyHandles joins a party's member handles with ';'
+func partyHandles(p cfapi.Party) string {
+	handles := make([]string, len(p.Members))
+	for i, m := range p.Members {
+		handles[i] = m.Handle
+	}
+	return strings.Join(handles, ";")
+}
+
+// formatPoints formats points without trailing zeros
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
+
+// formatContestTime formats seconds since contest start as h:mm
+func formatContestTime(seconds int64) string {
+	return fmt.Sprintf("%d:%02d", seconds/3600, (seconds/60)%60)
+}
+
 // getPhaseColor returns ANSI color code for contest phase
 func getPhaseColor(phase string) string {
 	switch phase {
@@ -208,6 +524,252 @@ func getPhaseColor(phase string) string {
 	}
 }
 
+func runContestRegister(cmd *cobra.Command, args []string) error {
+	var contestID int
+	if _, err := fmt.Sscanf(args[0], "%d", &contestID); err != nil {
+		return fmt.Errorf("invalid contest ID: %s", args[0])
+	}
+
+	submitter, err := newSubmitter()
+	if err != nil {
+		return err
+	}
+
+	err = submitter.RegisterForContest(contestID, registerTeam)
+	switch {
+	case errors.Is(err, cfweb.ErrAlreadyRegistered):
+		fmt.Printf("✓ Already registered for contest %d\n", contestID)
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to register for contest %d: %w", contestID, err)
+	}
+
+	fmt.Printf("✓ Registered for contest %d\n", contestID)
+	return nil
+}
+
+func runContestVirtual(cmd *cobra.Command, args []string) error {
+	var contestID int
+	if _, err := fmt.Sscanf(args[0], "%d", &contestID); err != nil {
+		return fmt.Errorf("invalid contest ID: %s", args[0])
+	}
+
+	handle, err := getHandle(args[1:])
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	client := getAPIClient()
+	submissions, err := client.GetUserSubmissions(ctx, handle, 1, 10000)
+	if err != nil {
+		return fmt.Errorf("failed to get submissions: %w", err)
+	}
+
+	virtual := virtualSubmissions(submissions, contestID)
+	if len(virtual) == 0 {
+		return fmt.Errorf("%s has no virtual participation in contest %d", handle, contestID)
+	}
+
+	solveTimes := cfapi.ContestSolveTimes(virtual, contestID)
+	penalties := cfapi.RejectedAttempts(virtual, contestID)
+
+	rank, err := client.VirtualRank(ctx, contestID, solveTimes, penalties)
+	if err != nil {
+		return fmt.Errorf("failed to compute rank: %w", err)
+	}
+
+	penalty := penalties * cfapi.ICPCPenaltyMinutes
+	for _, d := range solveTimes {
+		penalty += int(d / time.Minute)
+	}
+
+	fmt.Printf("\nVirtual participation of %s in contest %d:\n\n", handle, contestID)
+	fmt.Printf("  Solved:   %d\n", len(solveTimes))
+	fmt.Printf("  Penalty:  %d (%d rejected attempts)\n", penalty, penalties)
+	fmt.Printf("  Rank:     %d\n\n", rank)
+
+	return nil
+}
+
+func runContestReport(cmd *cobra.Command, args []string) error {
+	var contestID int
+	if _, err := fmt.Sscanf(args[0], "%d", &contestID); err != nil {
+		return fmt.Errorf("invalid contest ID: %s", args[0])
+	}
+
+	handle, err := getHandle(args[1:])
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	report, err := getAPIClient().ContestReport(ctx, contestID, handle)
+	if report == nil {
+		return explainAPIError(fmt.Errorf("failed to get contest report: %w", err))
+	}
+
+	if !tableOutput() {
+		return render(report, nil)
+	}
+
+	if err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	return printContestReport(contestID, report)
+}
+
+// printContestReport prints the summary and problem results of a report
+func printContestReport(contestID int, r *cfapi.ContestReport) error {
+	name := fmt.Sprintf("Contest %d", contestID)
+	if r.Contest != nil && r.Contest.Name != "" {
+		name = r.Contest.Name
+	}
+	fmt.Printf("\n%s - %s\n\n", name, r.Handle)
+
+	switch {
+	case r.Standing == nil && len(r.Problems) > 0:
+		fmt.Printf("  %s did not take part in the contest\n", r.Handle)
+	case r.Standing != nil:
+		fmt.Printf("  Rank:     %d\n", r.Standing.Rank)
+		fmt.Printf("  Solved:   %d of %d\n", r.SolvedCount(), len(r.Problems))
+		fmt.Printf("  Points:   %s (penalty %d)\n", formatPoints(r.Standing.Points), r.Standing.Penalty)
+	}
+	if rc := r.RatingChange; rc != nil {
+		fmt.Printf("  Rating:   %d → %d (%+d)\n", rc.OldRating, rc.NewRating, rc.RatingDelta())
+	}
+	fmt.Println()
+
+	if len(r.Problems) == 0 {
+		return nil
+	}
+	return render(r.Problems, reportProblemColumns())
+}
+
+// reportProblemColumns describes the columns of a contest report's problems
+func reportProblemColumns() []output.Column {
+	return []output.Column{
+		output.Col("Index", 6, func(p cfapi.ReportProblem) string { return p.Index }),
+		output.Col("Name", 40, func(p cfapi.ReportProblem) string { return p.Name }),
+		output.Col("Result", 8, func(p cfapi.ReportProblem) string {
+			switch {
+			case p.Solved:
+				return "✓"
+			case p.RejectedAttempts > 0:
+				return "✗"
+			default:
+				return "-"
+			}
+		}),
+		output.Col("Time", 6, func(p cfapi.ReportProblem) string {
+			if !p.Solved {
+				return "-"
+			}
+			return formatContestTime(p.SolveSeconds)
+		}),
+		output.Col("Rejected", 0, func(p cfapi.ReportProblem) string { return strconv.Itoa(p.RejectedAttempts) }),
+	}
+}
+
+func runContestStandingsWatch(cmd *cobra.Command, args []string) error {
+	contestID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid contest ID: %s", args[0])
+	}
+	handles := watchedHandles(args[1:], config.GetCFHandle())
+	if len(handles) == 0 {
+		return fmt.Errorf("no handles to watch; pass some or set yours with 'cf config set cf_handle <handle>'")
+	}
+
+	interval := standingsWatchInterval
+	if interval < cfapi.MinRequestInterval {
+		fmt.Printf("⚠️  Interval raised to %v, the API rate limit\n", cfapi.MinRequestInterval)
+		interval = cfapi.MinRequestInterval
+	}
+
+	ctx, cancel := watchContext()
+	defer cancel()
+
+	client := getAPIClient()
+	first := true
+
+	fmt.Printf("👀 Watching %s in contest %d every %v (Ctrl-C to stop)\n\n", strings.Join(handles, ", "), contestID, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		delta, err := client.StandingsDelta(ctx, contestID, handles)
+		switch {
+		case stopped(ctx):
+			return nil
+		case err != nil:
+			fmt.Printf("  ✗ %v\n", explainAPIError(err))
+		default:
+			if first {
+				delta = nil // Show where everyone starts
+				first = false
+			}
+			fmt.Print(formatStandingsDelta(time.Now(), handles, delta, client.StandingsPoints(contestID)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchedHandles returns the handles to watch with own added, without duplicates
+func watchedHandles(handles []string, own string) []string {
+	var watched []string
+	seen := make(map[string]bool)
+	for _, h := range append(slices.Clone(handles), own) {
+		if h == "" || seen[strings.ToLower(h)] {
+			continue
+		}
+		seen[strings.ToLower(h)] = true
+		watched = append(watched, h)
+	}
+	return watched
+}
+
+// formatStandingsDelta formats one poll: every handle's points on the first
+// poll (delta nil), then only the handles that gained points
+func formatStandingsDelta(at time.Time, handles []string, delta map[string]int, points map[string]float64) string {
+	var b strings.Builder
+	for _, h := range handles {
+		total, ok := points[h]
+		switch {
+		case !ok:
+			if delta == nil {
+				fmt.Fprintf(&b, "  %s  %-20s not in the standings\n", at.Format("15:04"), h)
+			}
+		case delta == nil:
+			fmt.Fprintf(&b, "  %s  %-20s %s\n", at.Format("15:04"), h, formatPoints(total))
+		case delta[h] > 0:
+			fmt.Fprintf(&b, "  %s  %-20s +%d (%s)\n", at.Format("15:04"), h, delta[h], formatPoints(total))
+		}
+	}
+	return b.String()
+}
+
+// virtualSubmissions returns the submissions of a contest made during a virtual participation
+func virtualSubmissions(subs []cfapi.Submission, contestID int) []cfapi.Submission {
+	var virtual []cfapi.Submission
+	for _, s := range subs {
+		if s.ContestID == contestID && s.Author.ParticipantType == cfapi.ParticipantVirtual {
+			virtual = append(virtual, s)
+		}
+	}
+	return virtual
+}
+
 // formatDuration formats a duration as hours and minutes
 func formatDuration(d time.Duration) string {
 	hours := int(d.Hours())
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// commandTimeout is set by --timeout; zero keeps each command's own default
+var commandTimeout time.Duration
+
+// Default budgets passed to commandContext, all overridden by --timeout
+const (
+	// fetchTimeout is for a single fetch, such as one problem or a user's info
+	fetchTimeout = 30 * time.Second
+	// queryTimeout is for commands combining several large requests, such as
+	// the problemset with a user's submissions
+	queryTimeout = time.Minute
+	// bulkTimeout is for commands fetching page after page, such as parsing
+	// a whole contest or syncing every saved problem
+	bulkTimeout = 5 * time.Minute
+	// verdictTimeout is how long submit --notify waits for judging to finish
+	verdictTimeout = 5 * time.Minute
+	// runTimeout is for compiling and running a solution against its tests
+	runTimeout = 2 * time.Minute
+	// perProblemTimeout is the budget per problem of commands that scale
+	// with the number of problems they fetch
+	perProblemTimeout = 10 * time.Second
+	// completionTimeout keeps shell completion responsive
+	completionTimeout = 5 * time.Second
+)
+
+// commandContext returns the context for a command that talks to Codeforces
+// It is cancelled on Ctrl-C and after --timeout, or fallback if the flag is
+// not set, so long operations can be stopped part way through.
+func commandContext(fallback time.Duration) (context.Context, context.CancelFunc) {
+	timeout := fallback
+	if commandTimeout > 0 {
+		timeout = commandTimeout
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// watchContext returns the context for a command that runs until Ctrl-C,
+// or until --timeout if the flag is set
+func watchContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	if commandTimeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// stopped returns true if ctx was interrupted or timed out
+func stopped(ctx context.Context) bool {
+	return ctx.Err() != nil
+}
+
+// reportStopped prints how much of a bulk operation finished before ctx ended
+// Nothing is printed if ctx is still live
+func reportStopped(ctx context.Context, done, total int, what string) {
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		fmt.Printf("⚠️  Interrupted after %d of %d %s\n", done, total, what)
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		fmt.Printf("⚠️  Timed out after %d of %d %s; raise the limit with --timeout\n", done, total, what)
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+)
+
+// parseBrowserCookies reads the Codeforces cookies out of text pasted from
+// the browser: a document.cookie or Cookie header value, Set-Cookie lines or
+// rows of the devtools cookie table, over one or more lines. The cf_clearance
+// expiry comes from expiresAt when it is set, otherwise from the Expires or
+// Max-Age of the cf_clearance line or table row, or a separate
+// "Expires: <date>" line. The credentials carry only the cookie and expiry.
+func parseBrowserCookies(raw string, expiresAt time.Time) (*config.Credentials, error) {
+	cookie := cfweb.SanitizeCookie(raw)
+	found := false
+	for _, name := range []string{config.CookieSession, config.CookieCE7, config.CookieCFClearance} {
+		if config.CookieValue(cookie, name) != "" {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no Codeforces cookies found; copy the Cookie request header from codeforces.com")
+	}
+
+	creds := &config.Credentials{Cookie: cookie, CFClearanceExpires: expiresAt}
+	if expiresAt.IsZero() && config.CookieValue(cookie, config.CookieCFClearance) != "" {
+		creds.CFClearanceExpires = clearanceExpiry(raw, time.Now())
+	}
+	return creds, nil
+}
+
+// clearanceExpiry finds the cf_clearance expiry in a cookie paste, or
+// returns the zero time
+func clearanceExpiry(raw string, now time.Time) time.Time {
+	var annotated time.Time
+	for _, line := range strings.Split(strings.ReplaceAll(raw, "\r", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+
+		// A devtools table row: name, value, domain, path, expires, ...
+		if fields := strings.Split(line, "\t"); len(fields) > 4 && !strings.Contains(line, "=") {
+			if strings.TrimSpace(fields[0]) == config.CookieCFClearance {
+				if t, ok := parseCookieTime(fields[4]); ok {
+					return t
+				}
+			}
+			continue
+		}
+
+		if strings.Contains(line, config.CookieCFClearance+"=") {
+			if t, ok := attributeExpiry(line, now); ok {
+				return t
+			}
+			continue
+		}
+
+		// A separate "Expires: <date>" note
+		if name, value, ok := cutAnnotation(line); ok && strings.EqualFold(name, "expires") {
+			if t, ok := parseCookieTime(value); ok {
+				annotated = t
+			}
+		}
+	}
+	return annotated
+}
+
+// attributeExpiry reads the Max-Age or Expires attribute of a Set-Cookie
+// line; Max-Age wins as it does in browsers
+func attributeExpiry(line string, now time.Time) (time.Time, bool) {
+	var expires time.Time
+	found := false
+	for _, part := range strings.Split(line, ";") {
+		name, value, _ := strings.Cut(part, "=")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				return now.Add(time.Duration(seconds) * time.Second), true
+			}
+		case "expires":
+			if t, ok := parseCookieTime(value); ok {
+				expires, found = t, true
+			}
+		}
+	}
+	return expires, found
+}
+
+// cutAnnotation splits "Name: value" or "Name=value"
+func cutAnnotation(line string) (string, string, bool) {
+	if name, value, ok := strings.Cut(line, ":"); ok && !strings.ContainsAny(name, "=;") {
+		return strings.TrimSpace(name), value, true
+	}
+	name, value, ok := strings.Cut(line, "=")
+	return strings.TrimSpace(name), value, ok
+}
+
+// parseCookieTime parses a cookie expiry as written by Set-Cookie headers
+// or shown in the devtools cookie table
+func parseCookieTime(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t, true
+	}
+	// Netscape form, e.g. Wed, 21-Oct-2026 07:28:00 GMT
+	if t, err := time.Parse("Mon, 02-Jan-2006 15:04:05 MST", value); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+)
+
+var (
+	// export flags
+	exportOut string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export your Codeforces data",
+}
+
+var exportSolvedCmd = &cobra.Command{
+	Use:   "solved [handle]",
+	Short: "Export the problems you solved",
+	Long: `Write the problems a user solved, newest first, to stdout or a file.
+
+By default each line is a problem ID: contestId/index for problemset
+problems (1325/A) and gym/contestId/index for gym problems (gym/100001/B).
+With -o json, writes a JSON array with names, ratings and tags.
+Uses your configured handle if none is given.
+
+Examples:
+  cf export solved > solved.txt
+  cf export solved tourist -o json --out solved.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExportSolved,
+}
+
+func init() {
+	exportCmd.AddCommand(exportSolvedCmd)
+	exportSolvedCmd.ValidArgsFunction = completeHandles
+
+	exportSolvedCmd.Flags().StringVar(&exportOut, "out", "", "Write to a file instead of stdout")
+}
+
+func runExportSolved(cmd *cobra.Command, args []string) error {
+	if outputFormat == output.FormatCSV {
+		return fmt.Errorf("export solved supports table (plain IDs) and json output")
+	}
+
+	handle, err := getHandle(args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	export := func(w io.Writer) error {
+		client := getAPIClient()
+		var err error
+		if outputFormat == output.FormatJSON {
+			err = client.ExportSolvedJSON(ctx, handle, w)
+		} else {
+			err = client.ExportSolved(ctx, handle, w)
+		}
+		if err != nil {
+			return explainAPIError(fmt.Errorf("failed to export solved problems: %w", err))
+		}
+		recordHandle(args, handle)
+		return nil
+	}
+
+	if exportOut == "" {
+		return export(os.Stdout)
+	}
+	if err := writeFileAtomic(exportOut, export); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Exported solved problems of %s to %s\n", handle, exportOut)
+	return nil
+}
+
+// writeFileAtomic writes path through write, into a temporary file in the
+// same directory that replaces path only once write and closing succeed,
+// so a failed export never leaves a truncated file behind
+func writeFileAtomic(path string, write func(io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	// CreateTemp makes the file private; exports are as readable as os.Create's
+	if err := f.Chmod(0644); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
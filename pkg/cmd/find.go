@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+)
+
+var (
+	// find flags
+	findLimit int
+)
+
+var findCmd = &cobra.Command{
+	Use:   "find <name>",
+	Short: "Find problems by name",
+	Long: `Search the Codeforces problemset by problem name, for when you remember
+a problem but not its ID.
+
+Matching ignores case and punctuation and tolerates missing letters. Exact
+names come first, then names starting with or containing the query.
+
+Examples:
+  cf find "theatre square"
+  cf find watermelon --limit 3`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runFind,
+}
+
+func init() {
+	findCmd.Flags().IntVar(&findLimit, "limit", 10, "Maximum number of matches to show")
+}
+
+func runFind(cmd *cobra.Command, args []string) error {
+	query := strings.Join(args, " ")
+
+	ctx, cancel := commandContext(fetchTimeout)
+	defer cancel()
+
+	problems, err := getAPIClient().FindProblemsByName(ctx, query)
+	if err != nil {
+		return explainAPIError(fmt.Errorf("failed to search problems: %w", err))
+	}
+
+	total := len(problems)
+	if findLimit > 0 && total > findLimit {
+		problems = problems[:findLimit]
+	}
+	if !tableOutput() {
+		return render(problems, findColumns())
+	}
+
+	if total == 0 {
+		fmt.Printf("No problems named like %q\n", query)
+		return nil
+	}
+	if err := render(problems, findColumns()); err != nil {
+		return err
+	}
+	if total > len(problems) {
+		fmt.Printf("\n%d more matches; refine the name or raise --limit\n", total-len(problems))
+	}
+	fmt.Printf("\nFetch one with 'cf problem fetch %d %s'\n", problems[0].ContestID, problems[0].Index)
+	return nil
+}
+
+// findColumns describes the fields shown for each match
+func findColumns() []output.Column {
+	return []output.Column{
+		output.Col("Contest", 7, func(p cfapi.Problem) string { return strconv.Itoa(p.ContestID) }).AlignRight(),
+		output.Col("Index", 5, func(p cfapi.Problem) string { return p.Index }),
+		output.Col("Name", 50, func(p cfapi.Problem) string { return p.Name }),
+		output.Col("Rating", 6, func(p cfapi.Problem) string {
+			if p.Rating > 0 {
+				return strconv.Itoa(p.Rating)
+			}
+			return "-"
+		}).AlignRight(),
+	}
+}
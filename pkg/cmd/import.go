@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// import solved flags
+	importHandle string
+	importCreate bool
+	importYes    bool
+)
+
+// maxImportCreate is the number of missing problems --create parses without
+// asking, since each one is a problem page request
+const maxImportCreate = 50
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import progress made outside cf",
+}
+
+var importSolvedCmd = &cobra.Command{
+	Use:   "solved",
+	Short: "Mark the problems you already solved on Codeforces as solved",
+	Long: `Backfill the practice status of workspace problems from your accepted
+Codeforces submissions, for problems solved before you used cf.
+
+Every solved problem that is in the workspace is marked solved; attempts,
+notes and timings are left as they are. Solved problems missing from the
+workspace are skipped, or parsed into it first with --create. Parsing more
+than 50 problems asks first (or not, with --yes); declining parses the first
+50, and running the import again continues with the rest.
+Uses your configured handle unless --handle is given.
+
+Examples:
+  cf import solved
+  cf import solved --handle tourist --create`,
+	Args: cobra.NoArgs,
+	RunE: runImportSolved,
+}
+
+func init() {
+	importCmd.AddCommand(importSolvedCmd)
+
+	importSolvedCmd.Flags().StringVar(&importHandle, "handle", "", "Codeforces handle (default: the configured one)")
+	importSolvedCmd.Flags().BoolVar(&importCreate, "create", false, "Parse solved problems missing from the workspace")
+	importSolvedCmd.Flags().BoolVarP(&importYes, "yes", "y", false, "Parse any number of missing problems without asking")
+	cobra.CheckErr(importSolvedCmd.RegisterFlagCompletionFunc("handle", completeHandles))
+}
+
+// importResult summarizes an import of solved problems
+type importResult struct {
+	Updated int                // Problems newly marked solved
+	Already int                // Problems that were already solved
+	Missing []cfapi.ProblemRef // Solved problems not in the workspace
+}
+
+func runImportSolved(cmd *cobra.Command, args []string) error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	var handleArgs []string
+	if importHandle != "" {
+		handleArgs = []string{importHandle}
+	}
+	handle, err := getHandle(handleArgs)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	solved, err := getAPIClient().GetSolvedProblems(ctx, handle)
+	cancel()
+	if err != nil {
+		return explainAPIError(fmt.Errorf("failed to get solved problems: %w", err))
+	}
+
+	if importCreate {
+		refs := limitImportCreate(cmd.InOrStdin(), missingSolvedProblems(ws, solved), importYes)
+		if len(refs) > 0 {
+			if err := fetchListProblems(ws, refs); err != nil {
+				// Problems that were saved are still imported below
+				fmt.Printf("⚠️  %v\n", err)
+			}
+		}
+	}
+
+	// --create may take longer than a query, so marking gets its own budget
+	ctx, cancel = commandContext(queryTimeout)
+	defer cancel()
+
+	result, err := importSolvedProblems(ctx, ws, solved)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Marked %d problems solved for %s", result.Updated, handle)
+	if result.Already > 0 {
+		fmt.Printf(" (%d already solved)", result.Already)
+	}
+	fmt.Println()
+	if len(result.Missing) > 0 {
+		fmt.Printf("  %d solved problems are not in the workspace; add them with --create\n", len(result.Missing))
+	}
+	return nil
+}
+
+// limitImportCreate asks before parsing more than maxImportCreate problems
+// and keeps only the first maxImportCreate if the answer is no
+func limitImportCreate(r io.Reader, refs []cfapi.ProblemRef, yes bool) []cfapi.ProblemRef {
+	if len(refs) <= maxImportCreate || yes {
+		return refs
+	}
+	prompt := fmt.Sprintf("Parse all %d missing problems? That is one page request per problem", len(refs))
+	if confirm(r, prompt) {
+		return refs
+	}
+	fmt.Printf("  Parsing the first %d; run the import again for the rest\n", maxImportCreate)
+	return refs[:maxImportCreate]
+}
+
+// importSolvedProblems marks the workspace problems among solved as solved
+// Problems without a contest, such as acm.sgu.ru ones, are ignored.
+func importSolvedProblems(ctx context.Context, ws *workspace.Workspace, solved []cfapi.Problem) (importResult, error) {
+	var result importResult
+
+	for _, p := range solved {
+		if stopped(ctx) {
+			return result, ctx.Err()
+		}
+		if p.ContestID == 0 {
+			continue
+		}
+		if !ws.ProblemExists("codeforces", p.ContestID, p.Index) {
+			result.Missing = append(result.Missing, cfapi.ProblemRef{ContestID: p.ContestID, Index: p.Index})
+			continue
+		}
+
+		problem, err := ws.LoadProblem("codeforces", p.ContestID, p.Index)
+		if err != nil {
+			return result, err
+		}
+		if problem.Practice.Status == v1.StatusSolved {
+			result.Already++
+			continue
+		}
+
+		practice := problem.Practice
+		practice.Status = v1.StatusSolved
+		if err := ws.UpdatePractice("codeforces", p.ContestID, p.Index, &practice); err != nil {
+			return result, fmt.Errorf("failed to update %s: %w", p.ProblemID(), err)
+		}
+		result.Updated++
+	}
+
+	return result, nil
+}
+
+// missingSolvedProblems returns the solved problems not in the workspace
+func missingSolvedProblems(ws *workspace.Workspace, solved []cfapi.Problem) []cfapi.ProblemRef {
+	var refs []cfapi.ProblemRef
+	for _, p := range solved {
+		if p.ContestID != 0 && !ws.ProblemExists("codeforces", p.ContestID, p.Index) {
+			refs = append(refs, cfapi.ProblemRef{ContestID: p.ContestID, Index: p.Index})
+		}
+	}
+	return refs
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+)
+
+var leaderboardCmd = &cobra.Command{
+	Use:   "leaderboard <file>",
+	Short: "Rank a roster of handles by rating",
+	Long: `Show the Codeforces rating of every handle in a roster file, highest first.
+
+The file has one or more handles per line; text after '#' is ignored.
+Handles that don't exist are reported and left out of the leaderboard.
+
+Examples:
+  cf leaderboard team.txt
+  cf leaderboard team.txt -o csv > ratings.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLeaderboard,
+}
+
+// leaderboardRow is a user with their leaderboard position
+type leaderboardRow struct {
+	Place int `json:"place"`
+	cfapi.User
+}
+
+func runLeaderboard(cmd *cobra.Command, args []string) error {
+	handles, err := readHandlesFile(args[0])
+	if err != nil {
+		return err
+	}
+	if len(handles) == 0 {
+		return fmt.Errorf("no handles in %s", args[0])
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	users, err := getAPIClient().GetUsersBulk(ctx, handles)
+	var missing *cfapi.MissingHandlesError
+	if err != nil && !errors.As(err, &missing) {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+
+	rows := make([]leaderboardRow, len(users))
+	for i, u := range users {
+		rows[i] = leaderboardRow{Place: i + 1, User: u}
+	}
+
+	// Missing handles go to stderr so json and csv output stays parseable
+	if missing != nil {
+		warnMissingHandles(cmd.ErrOrStderr(), missing.Handles)
+	}
+
+	out := cmd.OutOrStdout()
+	if !tableOutput() {
+		return renderTo(out, rows, leaderboardColumns())
+	}
+
+	fmt.Fprintf(out, "🏆 %d users\n\n", len(rows))
+	return renderTo(out, rows, leaderboardColumns())
+}
+
+// warnMissingHandles reports the roster handles Codeforces doesn't know
+func warnMissingHandles(w io.Writer, handles []string) {
+	for _, h := range handles {
+		fmt.Fprintf(w, "⚠️  Handle not found: %s\n", h)
+	}
+	fmt.Fprintln(w)
+}
+
+// readHandlesFile reads the handles of a roster file
+func readHandlesFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open roster: %w", err)
+	}
+	defer f.Close()
+
+	var handles []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		handles = append(handles, strings.FieldsFunc(text, func(r rune) bool {
+			return r == ',' || r == ';' || r == ' ' || r == '\t'
+		})...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read roster: %w", err)
+	}
+	return handles, nil
+}
+
+// leaderboardColumns describes the columns of the leaderboard
+func leaderboardColumns() []output.Column {
+	rankColor := func(r leaderboardRow) string { return getRankColor(r.Rating) }
+
+	return []output.Column{
+		output.Col("#", 4, func(r leaderboardRow) string { return strconv.Itoa(r.Place) }),
+		output.WithColor(output.Col("Handle", 24, func(r leaderboardRow) string { return r.Handle }), rankColor),
+		output.WithColor(output.Col("Rating", 6, func(r leaderboardRow) string { return displayRating(&r.User) }), rankColor),
+		output.Col("Max", 6, func(r leaderboardRow) string {
+			if r.IsUnrated() {
+				return ""
+			}
+			return strconv.Itoa(r.MaxRating)
+		}),
+		output.Col("Rank", 0, func(r leaderboardRow) string { return displayRank(&r.User) }),
+	}
+}
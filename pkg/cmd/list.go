@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	apperrors "github.com/harshit-vibes/cf/pkg/internal/errors"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// list add flags
+	listFile  string
+	listFetch bool
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Follow curated problem lists",
+	Long: `Keep named problem lists, such as CSES or a coach's sheet, in your workspace.
+
+Lists only store problem references; whether a problem is solved comes from
+your workspace practice data, so 'cf sync' keeps list progress up to date.
+
+Without a subcommand, shows every list with its progress.
+
+Examples:
+  cf list add dp-sheet 1325A 1352C 4A
+  cf list add cses --file cses.txt --fetch
+  cf list show dp-sheet`,
+	Args: cobra.NoArgs,
+	RunE: runListAll,
+}
+
+var listAddCmd = &cobra.Command{
+	Use:   "add <name> [problems...]",
+	Short: "Add problems to a list, creating it if needed",
+	Long: `Add problems to a list, creating it if needed.
+
+Problems are given as arguments or, with --file, read from a file with one
+or more references per line (e.g. 1325A or 1325/A); text after '#' is ignored.
+With --fetch, problems not yet in the workspace are parsed into it.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runListAdd,
+}
+
+var listShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show the problems of a list and your progress",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runListShow,
+}
+
+func init() {
+	listCmd.AddCommand(listAddCmd)
+	listCmd.AddCommand(listShowCmd)
+
+	listAddCmd.Flags().StringVar(&listFile, "file", "", "Read problem references from a file")
+	listAddCmd.Flags().BoolVar(&listFetch, "fetch", false, "Parse list problems missing from the workspace")
+}
+
+// listSummary is a row of the list overview
+type listSummary struct {
+	Name   string `json:"name"`
+	Solved int    `json:"solved"`
+	Total  int    `json:"total"`
+}
+
+func runListAll(cmd *cobra.Command, args []string) error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	names, err := ws.ListNames()
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]listSummary, 0, len(names))
+	for _, name := range names {
+		solved, total, err := ws.ListProgress(name)
+		if err != nil {
+			return err
+		}
+		summaries = append(summaries, listSummary{Name: name, Solved: solved, Total: total})
+	}
+
+	if tableOutput() && len(summaries) == 0 {
+		fmt.Println("No problem lists. Create one with 'cf list add <name> <problems...>'.")
+		return nil
+	}
+	return render(summaries, listSummaryColumns())
+}
+
+func runListAdd(cmd *cobra.Command, args []string) error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	added, err := parseListRefs(args[1:])
+	if err != nil {
+		return err
+	}
+	if listFile != "" {
+		fromFile, err := readListFile(listFile)
+		if err != nil {
+			return err
+		}
+		added = append(added, fromFile...)
+	}
+	if len(added) == 0 {
+		return fmt.Errorf("no problems given; pass references or --file")
+	}
+
+	var refs []cfapi.ProblemRef
+	if list, err := ws.LoadList(name); err == nil {
+		refs = list.Problems
+	}
+	before := len(refs)
+	if err := ws.SaveList(name, append(refs, added...)); err != nil {
+		return err
+	}
+
+	list, err := ws.LoadList(name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Added %d problems to %s (%d total)\n", len(list.Problems)-before, name, len(list.Problems))
+
+	if listFetch {
+		return fetchListProblems(ws, list.Problems)
+	}
+	return nil
+}
+
+func runListShow(cmd *cobra.Command, args []string) error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	entries, err := ws.ListEntries(args[0])
+	if err != nil {
+		return err
+	}
+
+	if !tableOutput() {
+		return render(entries, listEntryColumns())
+	}
+
+	solved := 0
+	for _, e := range entries {
+		if e.Status == v1.StatusSolved {
+			solved++
+		}
+	}
+	fmt.Printf("📋 %s: %d/%d solved\n\n", args[0], solved, len(entries))
+	return render(entries, listEntryColumns())
+}
+
+// fetchListProblems parses the list problems that are not in the workspace
+func fetchListProblems(ws *workspace.Workspace, refs []cfapi.ProblemRef) error {
+	var missing []cfapi.ProblemRef
+	for _, r := range refs {
+		if !ws.ProblemExists("codeforces", r.ContestID, r.Index) {
+			missing = append(missing, r)
+		}
+	}
+	if len(missing) == 0 {
+		fmt.Println("✓ All list problems are already in the workspace")
+		return nil
+	}
+
+	ctx, cancel := commandContext(time.Duration(len(missing)) * perProblemTimeout)
+	defer cancel()
+
+	fmt.Printf("Fetching %d problems...\n", len(missing))
+	client := getAPIClient()
+	parser := cfweb.NewParserWithClient(nil)
+	problems, errs := parser.ParseProblemsConcurrentContext(ctx, missing, cfweb.DefaultConcurrency)
+
+	failed := apperrors.NewMultiError(len(missing))
+	fetched := 0
+	for i, ref := range missing {
+		id := fmt.Sprintf("%d%s", ref.ContestID, ref.Index)
+		problem, err := problems[i], errs[i]
+		if err != nil {
+			fmt.Printf("  ✗ Failed to fetch %s: %v\n", id, err)
+			failed.Add(id, err)
+			continue
+		}
+		if _, err := saveParsedProblem(ctx, ws, client, problem, nil); err != nil {
+			fmt.Printf("  ✗ Skipped %s: %v\n", id, err)
+			failed.Add(id, err)
+			continue
+		}
+		fmt.Printf("  ✓ %s. %s\n", id, problem.Name)
+		fetched++
+	}
+
+	if stopped(ctx) {
+		reportStopped(ctx, fetched, len(missing), "problems")
+		return ctx.Err()
+	}
+	return failed.Err()
+}
+
+// parseListRefs parses problem references such as 1325A or 1325/A
+func parseListRefs(args []string) ([]cfapi.ProblemRef, error) {
+	refs := make([]cfapi.ProblemRef, 0, len(args))
+	for _, arg := range args {
+		contestID, index, err := cfapi.ParseProblemRef(arg)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, cfapi.ProblemRef{ContestID: contestID, Index: index})
+	}
+	return refs, nil
+}
+
+// readListFile reads the problem references of a list file
+func readListFile(path string) ([]cfapi.ProblemRef, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open list file: %w", err)
+	}
+	defer f.Close()
+
+	var refs []cfapi.ProblemRef
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		parsed, err := parseListRefs(strings.Fields(text))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		refs = append(refs, parsed...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read list file: %w", err)
+	}
+	return refs, nil
+}
+
+// listSummaryColumns describes the columns of the list overview
+func listSummaryColumns() []output.Column {
+	return []output.Column{
+		output.Col("Name", 24, func(s listSummary) string { return s.Name }),
+		output.Col("Solved", 0, func(s listSummary) string { return fmt.Sprintf("%d/%d", s.Solved, s.Total) }),
+	}
+}
+
+// listEntryColumns describes the columns of a list's problems
+func listEntryColumns() []output.Column {
+	return []output.Column{
+		output.Col("ID", 10, func(e workspace.ListEntry) string { return e.String() }),
+		output.Col("Name", 50, func(e workspace.ListEntry) string {
+			if e.Name == "" {
+				return "-"
+			}
+			return e.Name
+		}),
+		output.Col("Rating", 6, func(e workspace.ListEntry) string {
+			if e.Rating > 0 {
+				return strconv.Itoa(e.Rating)
+			}
+			return "-"
+		}),
+		output.Col("Status", 0, func(e workspace.ListEntry) string { return string(e.Status) }),
+	}
+}
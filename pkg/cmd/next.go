@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+)
+
+var (
+	// next flags
+	nextNoOpen bool
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next <contest_id> [handle]",
+	Short: "Parse and open the next contest problem you have not solved",
+	Long: `Find the first problem of a contest, in index order, that you have not
+solved, parse it into your workspace and open it in the browser.
+
+Uses your configured handle if none is given.
+
+Examples:
+  cf next 1325
+  cf next 1325 --no-open`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runNext,
+}
+
+func init() {
+	nextCmd.Flags().BoolVar(&nextNoOpen, "no-open", false, "Do not open the problem in the browser")
+}
+
+func runNext(cmd *cobra.Command, args []string) error {
+	contestID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid contest ID: %s", args[0])
+	}
+
+	handle, err := getHandle(args[1:])
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	client := getAPIClient()
+	next, err := client.NextUnsolved(ctx, contestID, handle)
+	if errors.Is(err, cfapi.ErrAllSolved) {
+		fmt.Printf("🎉 %s solved every problem of contest %d\n", handle, contestID)
+		return nil
+	}
+	if err != nil {
+		return explainAPIError(fmt.Errorf("failed to find the next problem: %w", err))
+	}
+
+	fmt.Printf("➡️  Next: %s. %s", next.ProblemID(), next.Name)
+	if next.Rating > 0 {
+		fmt.Printf(" (%d)", next.Rating)
+	}
+	fmt.Println()
+
+	if ws, err := requireWorkspace(); err != nil {
+		fmt.Printf("  Not saved: %v\n", err)
+	} else if !ws.ProblemExists("codeforces", next.ContestID, next.Index) {
+		parser := cfweb.NewParserWithClient(nil)
+		problem, err := parser.ParseProblemContext(ctx, next.ContestID, next.Index)
+		if err != nil {
+			fmt.Printf("  ⚠️  Could not parse the problem: %v\n", err)
+		} else {
+			if _, err := saveParsedProblem(ctx, ws, client, problem, next); err != nil {
+				return err
+			}
+			fmt.Println("  ✓ Saved to workspace")
+		}
+	}
+
+	url := next.ContestURL()
+	if next.IsGym() {
+		url = next.GymURL()
+	}
+	fmt.Printf("  %s\n", url)
+	if !nextNoOpen {
+		if err := openBrowser(url); err != nil {
+			fmt.Printf("  ⚠️  Could not open the browser: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// openBrowser opens url in the default browser without waiting for it
+func openBrowser(url string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", url)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		c = exec.Command("xdg-open", url)
+	}
+	return c.Start()
+}
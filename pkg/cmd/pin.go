@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin [problem]",
+	Short: "Pin a workspace problem you are working on",
+	Long: `Pin a problem so it is listed first by 'cf problem list'.
+
+Without arguments, lists the pinned problems.
+
+Examples:
+  cf pin 1325A     # Pin problem 1325A
+  cf pin           # List pinned problems
+  cf unpin 1325A   # Remove the pin`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPin,
+}
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin <problem>",
+	Short: "Remove the pin from a workspace problem",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUnpin,
+}
+
+func runPin(cmd *cobra.Command, args []string) error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		pinned, err := ws.ListPinned()
+		if err != nil {
+			return err
+		}
+		if !tableOutput() {
+			return render(pinned, pinnedColumns())
+		}
+		if len(pinned) == 0 {
+			fmt.Println("No pinned problems. Pin one with 'cf pin <problem>'.")
+			return nil
+		}
+		printPinned(pinned)
+		return nil
+	}
+
+	contestID, index, err := cfapi.ParseProblemRef(args[0])
+	if err != nil {
+		return err
+	}
+	if err := ws.Pin("codeforces", contestID, index); err != nil {
+		return err
+	}
+
+	fmt.Printf("📌 Pinned %d%s\n", contestID, index)
+	return nil
+}
+
+func runUnpin(cmd *cobra.Command, args []string) error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	contestID, index, err := cfapi.ParseProblemRef(args[0])
+	if err != nil {
+		return err
+	}
+	if err := ws.Unpin("codeforces", contestID, index); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Unpinned %d%s\n", contestID, index)
+	return nil
+}
+
+// pinnedColumns describes the columns of the pinned problems list
+func pinnedColumns() []output.Column {
+	return []output.Column{
+		output.Col("ID", 10, func(p *v1.Problem) string { return p.ID }),
+		output.Col("Name", 50, func(p *v1.Problem) string { return p.Name }),
+		output.Col("Rating", 6, func(p *v1.Problem) string {
+			if p.Metadata.Rating > 0 {
+				return strconv.Itoa(p.Metadata.Rating)
+			}
+			return "-"
+		}),
+		output.Col("Status", 0, func(p *v1.Problem) string { return string(p.Practice.Status) }),
+	}
+}
+
+// printPinned prints the pinned problems section
+func printPinned(pinned []*v1.Problem) {
+	fmt.Printf("📌 Pinned (%d):\n\n", len(pinned))
+	if err := render(pinned, pinnedColumns()); err != nil {
+		fmt.Printf("  %v\n", err)
+	}
+	fmt.Println()
+}
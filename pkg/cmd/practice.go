@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// practice flags
+	practiceTag   string
+	practiceCount int
+)
+
+var practiceCmd = &cobra.Command{
+	Use:   "practice [handle]",
+	Short: "Recommend problems to practice a tag",
+	Long: `Recommend unsolved problems with a tag, to work on a weak area.
+
+Problems come from the difficulty band suggested by your rating and recent
+solves (see 'cf today --adaptive'), most solved first so the classics of the
+topic come up before obscure ones. Tags accept the same shorthands as
+'cf problem list', and a mistyped tag gets the closest known tags suggested.
+Uses your configured handle if none is given.
+
+Examples:
+  cf practice --tag dp
+  cf practice --tag "number theory" --count 10 tourist`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPractice,
+}
+
+func init() {
+	practiceCmd.Flags().StringVar(&practiceTag, "tag", "", "Tag to practice (required)")
+	practiceCmd.Flags().IntVar(&practiceCount, "count", 5, "Number of problems to recommend")
+	practiceCmd.MarkFlagRequired("tag")
+	practiceCmd.RegisterFlagCompletionFunc("tag", completeTags)
+	practiceCmd.ValidArgsFunction = completeHandles
+}
+
+func runPractice(cmd *cobra.Command, args []string) error {
+	handle, err := getHandle(args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	problems, err := getAPIClient().RecommendForTag(ctx, handle, practiceTag, practiceCount)
+	if err != nil {
+		return explainAPIError(fmt.Errorf("failed to recommend problems: %w", err))
+	}
+
+	if !tableOutput() {
+		return render(problems, problemColumns())
+	}
+	if len(problems) == 0 {
+		fmt.Printf("No unsolved %s problems in your difficulty band\n", practiceTag)
+		return nil
+	}
+
+	fmt.Printf("🎯 %s problems for %s:\n\n", practiceTag, handle)
+	if err := render(problems, problemColumns()); err != nil {
+		return err
+	}
+	fmt.Printf("\nFetch one with 'cf problem fetch %d %s'\n", problems[0].ContestID, problems[0].Index)
+	return nil
+}
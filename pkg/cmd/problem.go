@@ -3,13 +3,18 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
 	"github.com/harshit-vibes/cf/pkg/external/cfweb"
 	"github.com/harshit-vibes/cf/pkg/internal/config"
+	apperrors "github.com/harshit-vibes/cf/pkg/internal/errors"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
 	"github.com/harshit-vibes/cf/pkg/internal/workspace"
 )
 
@@ -20,6 +25,10 @@ var (
 	problemMaxRating int
 	problemLimit     int
 	excludeSolved    bool
+	problemByContest bool
+
+	// problem parse/fetch flags
+	problemStrict bool
 )
 
 var problemCmd = &cobra.Command{
@@ -36,12 +45,15 @@ var problemParseCmd = &cobra.Command{
 	Short: "Parse a problem from Codeforces",
 	Long: `Parse a problem from Codeforces and display its details.
 
+The problem can be given as contest ID and index, or as a single
+reference like 1325A, 1325/A or 1325-A.
 The problem will be saved to your workspace if one is configured.
 
 Examples:
   cf problem parse 1 A       # Parse problem A from contest 1
-  cf problem parse 1234 B    # Parse problem B from contest 1234`,
-	Args: cobra.ExactArgs(2),
+  cf problem parse 1234 B    # Parse problem B from contest 1234
+  cf problem parse 1325A     # Parse problem A from contest 1325`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: runProblemParse,
 }
 
@@ -51,12 +63,19 @@ var problemListCmd = &cobra.Command{
 	Long: `List problems from the Codeforces problemset.
 
 Filter by tags, rating range, and exclude already solved problems.
+Without --min-rating or --max-rating, the configured difficulty band is used;
+pass --min-rating 0 to list problems of every rating.
+Tags are case-insensitive and may be shorthands such as nt for number theory;
+add your own under tag_aliases in the config.
 
 Examples:
-  cf problem list                          # List all problems
+  cf problem list                          # Problems in your difficulty band
+  cf problem list --min-rating 0           # Problems of every rating
   cf problem list --tag dp --tag graphs    # Filter by tags
-  cf problem list --rating 800-1200        # Filter by rating range
-  cf problem list --limit 20               # Limit results`,
+  cf problem list --tag nt --tag bs        # number theory, binary search
+  cf problem list --min-rating 800 --max-rating 1200  # Filter by rating range
+  cf problem list --limit 20               # Limit results
+  cf problem list --by-contest             # Workspace problems grouped by contest`,
 	RunE: runProblemList,
 }
 
@@ -65,11 +84,13 @@ var problemFetchCmd = &cobra.Command{
 	Short: "Fetch problem(s) to workspace",
 	Long: `Fetch a problem or all problems from a contest to your workspace.
 
-If problem_index is provided, fetches only that problem.
+If problem_index is provided, or the argument is a problem reference
+like 1325A, fetches only that problem.
 Otherwise, fetches all problems from the contest.
 
 Examples:
   cf problem fetch 1 A      # Fetch problem A from contest 1
+  cf problem fetch 1325A    # Fetch problem A from contest 1325
   cf problem fetch 1234     # Fetch all problems from contest 1234`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runProblemFetch,
@@ -83,49 +104,177 @@ func init() {
 
 	// problem list flags
 	problemListCmd.Flags().StringArrayVar(&problemTags, "tag", nil, "Filter by tag (can be specified multiple times)")
+	_ = problemListCmd.RegisterFlagCompletionFunc("tag", completeTags)
 	problemListCmd.Flags().IntVar(&problemMinRating, "min-rating", 0, "Minimum problem rating")
 	problemListCmd.Flags().IntVar(&problemMaxRating, "max-rating", 0, "Maximum problem rating")
 	problemListCmd.Flags().IntVar(&problemLimit, "limit", 25, "Maximum number of problems to display")
 	problemListCmd.Flags().BoolVar(&excludeSolved, "unsolved", false, "Exclude already solved problems")
+	problemListCmd.Flags().BoolVar(&problemByContest, "by-contest", false, "Show workspace problems grouped by contest")
+
+	// problem parse/fetch and upsolve flags
+	for _, c := range []*cobra.Command{problemParseCmd, problemFetchCmd, upsolveCmd} {
+		c.Flags().BoolVar(&problemStrict, "strict", false, "Fail instead of warning when samples could not be parsed")
+	}
 }
 
-func runProblemParse(cmd *cobra.Command, args []string) error {
+// problemArgs reads a problem from "<contest_id> <index>" or a single reference like "1325A"
+func problemArgs(args []string) (int, string, error) {
+	if len(args) == 1 {
+		return cfapi.ParseProblemRef(args[0])
+	}
+
 	var contestID int
 	if _, err := fmt.Sscanf(args[0], "%d", &contestID); err != nil {
-		return fmt.Errorf("invalid contest ID: %s", args[0])
+		return 0, "", fmt.Errorf("invalid contest ID: %s", args[0])
 	}
-	problemIndex := strings.ToUpper(args[1])
+	return contestID, strings.ToUpper(args[1]), nil
+}
+
+func runProblemParse(cmd *cobra.Command, args []string) error {
+	contestID, problemIndex, err := problemArgs(args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(fetchTimeout)
+	defer cancel()
 
 	parser := cfweb.NewParserWithClient(nil)
-	problem, err := parser.ParseProblem(contestID, problemIndex)
+	problem, err := parser.ParseProblemContext(ctx, contestID, problemIndex)
 	if err != nil {
 		return fmt.Errorf("failed to parse problem: %w", err)
 	}
 
+	// Save to workspace if available
+	client := getAPIClient()
+	ws, wsErr := requireWorkspace()
+	var schemaProblem *v1.Problem
+	if wsErr == nil {
+		schemaProblem, err = saveParsedProblem(ctx, ws, client, problem, nil)
+	} else {
+		err = prepareParsedProblem(ctx, client, problem, nil)
+	}
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("✓ Parsed: %s. %s\n", problem.Index, problem.Name)
 	fmt.Printf("  Rating: %d | Time: %s | Memory: %s\n",
 		problem.Rating, problem.TimeLimit, problem.MemoryLimit)
 	fmt.Printf("  Tags: %v\n", problem.Tags)
 	fmt.Printf("  Samples: %d\n", len(problem.Samples))
+	fmt.Printf("  URL: %s\n", problem.URL)
 
-	// Save to workspace if available
-	cfg := config.Get()
-	if cfg != nil && cfg.WorkspacePath != "" {
-		ws := workspace.New(cfg.WorkspacePath)
-		if ws.Exists() {
-			schemaProblem := problem.ToSchemaProblem()
-			if err := ws.SaveProblem(schemaProblem); err != nil {
-				return fmt.Errorf("failed to save problem: %w", err)
-			}
-			fmt.Printf("✓ Saved to workspace\n")
+	if wsErr != nil {
+		fmt.Printf("  Not saved: %v\n", wsErr)
+		return nil
+	}
+	fmt.Printf("✓ Saved to workspace\n")
+
+	solution := ws.SolutionPath(schemaProblem.Platform, schemaProblem.ContestID, schemaProblem.Index, "")
+	if _, err := os.Stat(solution); err == nil {
+		fmt.Printf("  Solution: %s\n", solution)
+	}
+
+	return nil
+}
+
+// estimateMissingRating estimates the rating of a problem the API has not
+// rated, such as a gym problem, from how many contestants solved it
+func estimateMissingRating(ctx context.Context, client *cfapi.Client, p *v1.Problem) {
+	if p.Metadata.Rating > 0 {
+		return
+	}
+
+	rating, err := client.EstimateDifficulty(ctx, p.ContestID, p.Index)
+	if err != nil {
+		fmt.Printf("⚠️  Could not estimate the rating of %s: %v\n", p.ID, err)
+		return
+	}
+	p.Metadata.Rating = rating
+	p.Metadata.RatingEstimated = true
+}
+
+// prepareParsedProblem checks a parsed problem and fills the metadata the
+// page lacks, from api when the caller already has it, otherwise from the
+// API unless client is nil
+func prepareParsedProblem(ctx context.Context, client *cfapi.Client, problem *cfweb.ParsedProblem, api *cfapi.Problem) error {
+	if err := checkParsedProblem(problem); err != nil {
+		return err
+	}
+
+	if api != nil {
+		if problem.Rating == 0 {
+			problem.Rating = api.Rating
+		}
+		if len(problem.Tags) == 0 {
+			problem.Tags = api.Tags
+		}
+		return nil
+	}
+	if client != nil {
+		if err := problem.EnrichFromAPI(ctx, client); err != nil {
+			fmt.Printf("⚠️  Could not fetch metadata for %d%s from the API: %v\n", problem.ContestID, problem.Index, err)
+		}
+	}
+	return nil
+}
+
+// saveParsedProblem prepares a parsed problem, estimates a missing rating
+// unless client is nil, and saves it to the workspace with its statement.
+// Every command that fetches problems saves them through here.
+func saveParsedProblem(ctx context.Context, ws *workspace.Workspace, client *cfapi.Client, problem *cfweb.ParsedProblem, api *cfapi.Problem) (*v1.Problem, error) {
+	if err := prepareParsedProblem(ctx, client, problem, api); err != nil {
+		return nil, err
+	}
+
+	schemaProblem := problem.ToSchemaProblem()
+	if client != nil {
+		estimateMissingRating(ctx, client, schemaProblem)
+	}
+	if err := ws.SaveProblem(schemaProblem); err != nil {
+		return nil, fmt.Errorf("failed to save problem: %w", err)
+	}
+	if problem.Statement != "" {
+		if err := ws.SaveStatement(schemaProblem, problem.Statement); err != nil {
+			fmt.Printf("⚠️  Could not save the statement of %s: %v\n", schemaProblem.ID, err)
 		}
 	}
+	return schemaProblem, nil
+}
 
+// listRatingBand returns the rating filter for problem list: the flags when
+// either is given, otherwise the configured difficulty band
+func listRatingBand(cmd *cobra.Command) (int, int) {
+	if cmd.Flags().Changed("min-rating") || cmd.Flags().Changed("max-rating") {
+		return problemMinRating, problemMaxRating
+	}
+	if cfg := config.Get(); cfg != nil {
+		return cfg.Difficulty.Min, cfg.Difficulty.Max
+	}
+	return 0, 0
+}
+
+// checkParsedProblem warns when a parsed problem looks incomplete, or fails
+// with --strict so selector breakage is not saved silently
+func checkParsedProblem(problem *cfweb.ParsedProblem) error {
+	err := problem.Validate()
+	if err == nil {
+		return nil
+	}
+	if problemStrict {
+		return err
+	}
+	fmt.Printf("⚠️  %v\n", err)
 	return nil
 }
 
 func runProblemList(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	if problemByContest {
+		return runProblemListByContest()
+	}
+
+	ctx, cancel := commandContext(fetchTimeout)
 	defer cancel()
 
 	client := getAPIClient()
@@ -139,15 +288,18 @@ func runProblemList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	minRating, maxRating := listRatingBand(cmd)
+
+	tags := client.ResolveTags(problemTags)
+	warnUnknownTags(tags)
+
 	// Filter problems
-	problems, err := client.FilterProblems(ctx, problemMinRating, problemMaxRating, problemTags, excludeSolved, handle)
+	problems, err := client.FilterProblems(ctx, minRating, maxRating, tags, excludeSolved, handle)
 	if err != nil {
 		return fmt.Errorf("failed to fetch problems: %w", err)
 	}
-
-	if len(problems) == 0 {
-		fmt.Println("No problems found matching the criteria.")
-		return nil
+	if len(tags) == 0 {
+		saveKnownTags(client.CachedTags())
 	}
 
 	// Limit results
@@ -155,95 +307,206 @@ func runProblemList(cmd *cobra.Command, args []string) error {
 		problems = problems[:problemLimit]
 	}
 
-	// Display problems
-	fmt.Printf("Found %d problems:\n\n", len(problems))
-	fmt.Printf("%-10s %-50s %6s  %s\n", "ID", "Name", "Rating", "Tags")
-	fmt.Println(strings.Repeat("─", 100))
+	if !tableOutput() {
+		return render(problems, problemColumns())
+	}
 
-	for _, p := range problems {
-		name := p.Name
-		if len(name) > 48 {
-			name = name[:45] + "..."
-		}
-		tags := strings.Join(p.Tags, ", ")
-		if len(tags) > 30 {
-			tags = tags[:27] + "..."
+	// Pinned workspace problems come first
+	if ws, err := requireWorkspace(); err == nil {
+		if pinned, err := ws.ListPinned(); err == nil && len(pinned) > 0 {
+			printPinned(pinned)
 		}
+	}
 
-		ratingStr := "-"
-		if p.Rating > 0 {
-			ratingStr = fmt.Sprintf("%d", p.Rating)
-		}
+	if len(problems) == 0 {
+		fmt.Println("No problems found matching the criteria.")
+		return nil
+	}
+
+	fmt.Printf("Found %d problems:\n\n", len(problems))
+	return render(problems, problemColumns())
+}
+
+// problemColumns describes the fields shown for each problem
+func problemColumns() []output.Column {
+	return []output.Column{
+		output.Col("ID", 10, func(p cfapi.Problem) string { return p.ProblemID() }),
+		output.Col("Name", 50, func(p cfapi.Problem) string { return p.Name }),
+		output.Col("Rating", 6, func(p cfapi.Problem) string {
+			if p.Rating > 0 {
+				return strconv.Itoa(p.Rating)
+			}
+			return "-"
+		}).AlignRight(),
+		output.Col("Tags", 30, func(p cfapi.Problem) string { return strings.Join(p.Tags, ", ") }),
+	}
+}
+
+func runProblemListByContest() error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	summaries, err := ws.ContestProgress()
+	if err != nil {
+		return fmt.Errorf("failed to list workspace problems: %w", err)
+	}
 
-		fmt.Printf("%-10s %-50s %6s  %s\n", p.ProblemID(), name, ratingStr, tags)
+	if !tableOutput() {
+		return render(summaries, contestSummaryColumns())
+	}
+
+	if len(summaries) == 0 {
+		fmt.Println("No problems in workspace. Use 'cf problem fetch' to add some.")
+		return nil
+	}
+
+	for _, s := range summaries {
+		fmt.Printf("Contest %d: %d/%d solved\n", s.ContestID, s.Solved, s.Total)
 	}
 
 	return nil
 }
 
-func runProblemFetch(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
+// contestSummaryColumns describes the fields shown for per-contest progress
+func contestSummaryColumns() []output.Column {
+	return []output.Column{
+		output.Col("Contest", 0, func(s workspace.ContestSummary) string { return strconv.Itoa(s.ContestID) }),
+		output.Col("Solved", 0, func(s workspace.ContestSummary) string { return strconv.Itoa(s.Solved) }),
+		output.Col("Saved", 0, func(s workspace.ContestSummary) string { return strconv.Itoa(s.Saved) }),
+		output.Col("Total", 0, func(s workspace.ContestSummary) string { return strconv.Itoa(s.Total) }),
+	}
+}
 
-	var contestID int
-	if _, err := fmt.Sscanf(args[0], "%d", &contestID); err != nil {
-		return fmt.Errorf("invalid contest ID: %s", args[0])
+func runProblemFetch(cmd *cobra.Command, args []string) error {
+	// A single argument is either a problem reference (1325A) or a whole contest (1325)
+	contestID, problemIndex, err := problemArgs(args)
+	if err != nil && len(args) == 1 {
+		var atoiErr error
+		if contestID, atoiErr = strconv.Atoi(args[0]); atoiErr == nil {
+			err = nil
+		}
+	}
+	if err != nil {
+		return err
 	}
 
 	// Check workspace
-	cfg := config.Get()
-	if cfg == nil || cfg.WorkspacePath == "" {
-		return fmt.Errorf("no workspace configured. Run 'cf init' first")
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
 	}
-	ws := workspace.New(cfg.WorkspacePath)
-	if !ws.Exists() {
-		return fmt.Errorf("workspace not found at %s. Run 'cf init' first", cfg.WorkspacePath)
+
+	// A whole contest is many pages, so it gets the bulk budget
+	timeout := fetchTimeout
+	if problemIndex == "" {
+		timeout = bulkTimeout
 	}
+	ctx, cancel := commandContext(timeout)
+	defer cancel()
 
 	parser := cfweb.NewParserWithClient(nil)
 
-	if len(args) == 2 {
+	if problemIndex != "" {
 		// Fetch single problem
-		problemIndex := strings.ToUpper(args[1])
-		problem, err := parser.ParseProblem(contestID, problemIndex)
+		problem, err := parser.ParseProblemContext(ctx, contestID, problemIndex)
 		if err != nil {
 			return fmt.Errorf("failed to parse problem: %w", err)
 		}
-
-		schemaProblem := problem.ToSchemaProblem()
-		if err := ws.SaveProblem(schemaProblem); err != nil {
-			return fmt.Errorf("failed to save problem: %w", err)
+		if _, err := saveParsedProblem(ctx, ws, getAPIClient(), problem, nil); err != nil {
+			return err
 		}
 
 		fmt.Printf("✓ Fetched %s. %s to workspace\n", problem.Index, problem.Name)
 	} else {
-		// Fetch all problems from contest
+		// Fetch all problems from contest; the one ctx deadline covers
+		// listing the problems and parsing every one of them
 		client := getAPIClient()
-		standings, err := client.GetContestStandings(ctx, contestID, 1, 1, nil, false)
+		apiProblems, problems, errs, err := fetchContestProblems(ctx, client, parser, contestID)
 		if err != nil {
-			return fmt.Errorf("failed to get contest problems: %w", err)
+			return err
 		}
 
-		fmt.Printf("Fetching %d problems from contest %d...\n", len(standings.Problems), contestID)
+		fetched, failed := saveFetchedProblems(ctx, ws, client, apiProblems, problems, errs)
 
-		for _, p := range standings.Problems {
-			problem, err := parser.ParseProblem(contestID, p.Index)
-			if err != nil {
-				fmt.Printf("  ✗ Failed to fetch %s: %v\n", p.Index, err)
-				continue
-			}
-
-			schemaProblem := problem.ToSchemaProblem()
-			if err := ws.SaveProblem(schemaProblem); err != nil {
-				fmt.Printf("  ✗ Failed to save %s: %v\n", p.Index, err)
-				continue
-			}
+		if stopped(ctx) {
+			reportStopped(ctx, fetched, len(apiProblems), "problems")
+			return ctx.Err()
+		}
 
-			fmt.Printf("  ✓ %s. %s\n", problem.Index, problem.Name)
+		if err := ws.SaveContestProblemCount("codeforces", contestID, len(apiProblems)); err != nil {
+			fmt.Printf("⚠️  Could not cache contest problem count: %v\n", err)
 		}
 
+		if err := failed.Err(); err != nil {
+			fmt.Printf("⚠️  Fetched %d of %d problems from contest %d\n", fetched, len(apiProblems), contestID)
+			return err
+		}
 		fmt.Printf("✓ Fetched contest %d to workspace\n", contestID)
 	}
 
 	return nil
 }
+
+// fetchContestProblems lists the problems of a contest from the API standings
+// and parses them. When the API cannot list them, the problems are read from
+// the contest page instead. Results are aligned with the returned problems.
+func fetchContestProblems(ctx context.Context, client *cfapi.Client, parser *cfweb.Parser, contestID int) ([]cfapi.Problem, []*cfweb.ParsedProblem, []error, error) {
+	standings, err := client.GetContestStandings(ctx, contestID, 1, 1, nil, false)
+	if err == nil {
+		fmt.Printf("Fetching %d problems from contest %d...\n", len(standings.Problems), contestID)
+
+		refs := make([]cfapi.ProblemRef, len(standings.Problems))
+		for i, p := range standings.Problems {
+			refs[i] = cfapi.ProblemRef{ContestID: contestID, Index: p.Index}
+		}
+		problems, errs := parser.ParseProblemsConcurrentContext(ctx, refs, cfweb.DefaultConcurrency)
+		return standings.Problems, problems, errs, nil
+	}
+	if stopped(ctx) {
+		return nil, nil, nil, ctx.Err()
+	}
+
+	fmt.Printf("⚠️  Could not get contest problems from the API: %v\n", err)
+	fmt.Printf("Fetching problems from the contest %d page...\n", contestID)
+	result, pageErr := parser.ParseContestContext(ctx, contestID, cfweb.DefaultConcurrency)
+	if pageErr != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get contest problems: %w", pageErr)
+	}
+
+	apiProblems := make([]cfapi.Problem, len(result.Refs))
+	for i, r := range result.Refs {
+		apiProblems[i] = cfapi.Problem{ContestID: r.ContestID, Index: r.Index}
+	}
+	return apiProblems, result.Problems, result.Errs, nil
+}
+
+// saveFetchedProblems saves each parsed contest problem to the workspace,
+// filling missing metadata from the API problem at the same position and
+// estimating ratings the API lacks unless client is nil.
+// Failed items are collected rather than stopping the rest.
+func saveFetchedProblems(ctx context.Context, ws *workspace.Workspace, client *cfapi.Client, apiProblems []cfapi.Problem, parsed []*cfweb.ParsedProblem, errs []error) (int, *apperrors.MultiError) {
+	failed := apperrors.NewMultiError(len(apiProblems))
+	saved := 0
+
+	for i, p := range apiProblems {
+		problem, err := parsed[i], errs[i]
+		if err != nil {
+			fmt.Printf("  ✗ Failed to fetch %s: %v\n", p.Index, err)
+			failed.Add(p.Index, err)
+			continue
+		}
+		// Standings already carry API metadata, use it to fill gaps
+		if _, err := saveParsedProblem(ctx, ws, client, problem, &apiProblems[i]); err != nil {
+			fmt.Printf("  ✗ Skipped %s: %v\n", p.Index, err)
+			failed.Add(p.Index, err)
+			continue
+		}
+
+		fmt.Printf("  ✓ %s. %s\n", problem.Index, problem.Name)
+		saved++
+	}
+
+	return saved, failed
+}
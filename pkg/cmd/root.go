@@ -2,18 +2,20 @@
 package cmd
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
 	"github.com/spf13/cobra"
 
-	"github.com/harshit-vibes/cf/pkg/external/cfapi"
 	"github.com/harshit-vibes/cf/pkg/external/cfweb"
 	exthealth "github.com/harshit-vibes/cf/pkg/external/health"
 	"github.com/harshit-vibes/cf/pkg/internal/config"
+	apperrors "github.com/harshit-vibes/cf/pkg/internal/errors"
 	"github.com/harshit-vibes/cf/pkg/internal/health"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
 	"github.com/harshit-vibes/cf/pkg/internal/workspace"
 	"github.com/harshit-vibes/cf/pkg/tui"
 )
@@ -25,8 +27,10 @@ var (
 	BuildDate = "unknown"
 
 	// Command line flags
-	skipChecks bool
-	verbose    bool
+	skipChecks   bool
+	verbose      bool
+	outputFormat string
+	healthFix    bool
 )
 
 var rootCmd = &cobra.Command{
@@ -49,16 +53,29 @@ Run 'cf' without arguments to launch the interactive TUI.`,
 }
 
 func runPreChecks(cmd *cobra.Command, args []string) error {
-	// Skip health checks for version and help commands
-	if cmd.Name() == "version" || cmd.Name() == "help" {
+	if err := output.Validate(outputFormat); err != nil {
+		return err
+	}
+
+	// Skip health checks for version and help commands, for setup, which is
+	// how a failing configuration gets fixed, and for status, which runs on
+	// every shell prompt
+	if cmd.Name() == "version" || cmd.Name() == "help" || cmd.Name() == "setup" || cmd.Name() == "status" {
 		return nil
 	}
-	return runStartupChecks()
+	return runStartupChecks(cmd.OutOrStdout())
 }
 
+// exitPartial is the exit code of a bulk command where only some items failed
+const exitPartial = 2
+
 // Execute runs the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		var multi *apperrors.MultiError
+		if errors.As(err, &multi) && multi.Partial() {
+			os.Exit(exitPartial)
+		}
 		os.Exit(1)
 	}
 }
@@ -70,19 +87,47 @@ func init() {
 	// Add flags
 	rootCmd.PersistentFlags().BoolVar(&skipChecks, "skip-checks", false, "Skip startup health checks")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Verbose output")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", output.FormatTable, "Output format (table, json, csv)")
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", 0, "Timeout for network operations, e.g. 2m (default: 30s for single fetches, 5m for bulk fetch and sync)")
 
 	// Core commands
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(healthCmd)
+	healthCmd.Flags().BoolVar(&healthFix, "fix", false, "Apply all available auto-fixes and re-check")
 	rootCmd.AddCommand(tuiCmd)
 
 	// Feature commands
 	rootCmd.AddCommand(problemCmd)
+	rootCmd.AddCommand(findCmd)
 	rootCmd.AddCommand(userCmd)
+	rootCmd.AddCommand(leaderboardCmd)
 	rootCmd.AddCommand(contestCmd)
 	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(streakCmd)
+	rootCmd.AddCommand(todayCmd)
+	rootCmd.AddCommand(practiceCmd)
+	rootCmd.AddCommand(upsolveCmd)
+	rootCmd.AddCommand(nextCmd)
+	rootCmd.AddCommand(testCmd)
+	rootCmd.AddCommand(submitCmd)
+	rootCmd.AddCommand(submissionCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(pinCmd)
+	rootCmd.AddCommand(unpinCmd)
+	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(setupCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(workspaceCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+	rootCmd.AddCommand(selftestCmd)
 
 	// Legacy parse command (deprecated, redirects to problem parse)
 	rootCmd.AddCommand(parseCmd)
@@ -106,23 +151,45 @@ func initConfig() {
 	}
 }
 
-func runStartupChecks() error {
+func runStartupChecks(out io.Writer) error {
 	if skipChecks {
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := commandContext(fetchTimeout)
 	defer cancel()
 
+	// Run checks
+	report := newHealthChecker().Run(ctx)
+
+	// Display results; warnings are left out of json/csv output so it stays parseable
+	quiet := outputFormat != output.FormatTable && report.CanProceed
+	if verbose || (report.OverallStatus != health.StatusHealthy && !quiet) {
+		displayHealthReport(out, report)
+	}
+
+	if !report.CanProceed {
+		fmt.Fprintln(out, "\n❌ Cannot proceed due to critical errors. Please fix the issues above.")
+		return fmt.Errorf("startup checks failed")
+	}
+
+	if report.OverallStatus == health.StatusDegraded && !quiet {
+		fmt.Fprintln(out, "\n⚠️  Some features may be unavailable. See warnings above.")
+	}
+
+	return nil
+}
+
+// newHealthChecker returns a checker with all internal and external checks
+func newHealthChecker() *health.Checker {
 	checker := health.NewChecker()
 
-	// Get workspace path
-	cfg := config.Get()
-	wsPath := "."
-	if cfg != nil && cfg.WorkspacePath != "" {
-		wsPath = cfg.WorkspacePath
+	ws := workspace.New(workspacePath())
+	if workspacePath() == "." {
+		if found, err := workspace.DiscoverWorkspace("."); err == nil {
+			ws = found
+		}
 	}
-	ws := workspace.New(wsPath)
 
 	// Internal checks
 	checker.AddCheck(&health.ConfigCheck{})
@@ -131,36 +198,113 @@ func runStartupChecks() error {
 	checker.AddCheck(health.NewSchemaVersionCheck(ws))
 
 	// External checks
-	apiClient := cfapi.NewClient()
+	apiClient := getAPIClient()
 	parser := cfweb.NewParserWithClient(nil)
 
 	checker.AddCheck(exthealth.NewCFAPICheck(apiClient))
 	checker.AddCheck(exthealth.NewCFWebCheck(parser))
 	checker.AddCheck(exthealth.NewCFHandleCheck(apiClient))
 
-	// Run checks
+	return checker
+}
+
+// runHealthFix applies every available auto-fix, prints guidance for issues
+// that need the user, and re-runs the checks to confirm
+func runHealthFix(out io.Writer) error {
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	checker := newHealthChecker()
 	report := checker.Run(ctx)
+	displayHealthReport(out, report)
+
+	fixes := checker.FixAll(ctx, report)
+
+	fmt.Fprintln(out, "\n🔧 Fixes:")
+	if len(fixes) == 0 {
+		fmt.Fprintln(out, "  Nothing to auto-fix")
+	}
+	for _, fix := range fixes {
+		if fix.Err != nil {
+			fmt.Fprintf(out, "  ✗ %s: %v\n", fix.Name, fix.Err)
+		} else {
+			fmt.Fprintf(out, "  ✓ %s fixed\n", fix.Name)
+		}
+	}
+
+	for _, result := range report.Results {
+		if result.Status == health.StatusHealthy {
+			continue
+		}
+		if result.Action == health.ActionManualFix || result.Action == health.ActionUserPrompt {
+			fmt.Fprintf(out, "  ⚠ %s needs your attention: %s\n", result.Name, result.Message)
+			if result.Details != "" {
+				fmt.Fprintf(out, "    └─ %s\n", result.Details)
+			}
+		}
+	}
 
-	// Display results
-	if verbose || report.OverallStatus != health.StatusHealthy {
-		displayHealthReport(report)
+	if len(fixes) == 0 {
+		return nil
 	}
 
+	report = checker.Run(ctx)
+	displayHealthReport(out, report)
+
 	if !report.CanProceed {
-		fmt.Println("\n❌ Cannot proceed due to critical errors. Please fix the issues above.")
-		return fmt.Errorf("startup checks failed")
+		return fmt.Errorf("critical issues remain after fixing")
+	}
+	return nil
+}
+
+// workspacePath returns the configured workspace path, or the current directory
+func workspacePath() string {
+	cfg := config.Get()
+	if cfg != nil && cfg.WorkspacePath != "" {
+		return cfg.WorkspacePath
 	}
+	return "."
+}
+
+// render writes v to stdout in the format selected by --output
+func render(v any, columns []output.Column) error {
+	return renderTo(os.Stdout, v, columns)
+}
+
+// renderTo writes v to w in the format selected by --output
+// Commands pass cmd.OutOrStdout() so their output can be captured.
+func renderTo(w io.Writer, v any, columns []output.Column) error {
+	return output.Render(w, outputFormat, v, columns)
+}
 
-	if report.OverallStatus == health.StatusDegraded {
-		fmt.Println("\n⚠️  Some features may be unavailable. See warnings above.")
+// tableOutput returns true if results are printed as human-readable tables
+func tableOutput() bool {
+	return outputFormat == output.FormatTable
+}
+
+// requireWorkspace locates the workspace and fails with a clear hint if it is missing
+// Without a configured path, the workspace is discovered from the current
+// directory upwards, so commands work anywhere inside it.
+func requireWorkspace() (*workspace.Workspace, error) {
+	path := workspacePath()
+	if path == "." {
+		ws, err := workspace.DiscoverWorkspace(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w. Run 'cf init' first", err)
+		}
+		return ws, nil
 	}
 
-	return nil
+	ws := workspace.New(path)
+	if !ws.Exists() {
+		return nil, fmt.Errorf("workspace not found at %s. Run 'cf init' first", path)
+	}
+	return ws, nil
 }
 
-func displayHealthReport(report *health.Report) {
-	fmt.Printf("\n🔍 Health Check Report (took %s)\n", report.Duration.Round(time.Millisecond))
-	fmt.Println("─────────────────────────────────")
+func displayHealthReport(out io.Writer, report *health.Report) {
+	fmt.Fprintf(out, "\n🔍 Health Check Report (took %s)\n", report.Duration.Round(time.Millisecond))
+	fmt.Fprintln(out, "─────────────────────────────────")
 
 	for _, result := range report.Results {
 		var icon string
@@ -173,14 +317,14 @@ func displayHealthReport(report *health.Report) {
 			icon = "✗"
 		}
 
-		fmt.Printf("%s %-20s %s\n", icon, result.Name, result.Message)
+		fmt.Fprintf(out, "%s %-20s %s\n", icon, result.Name, result.Message)
 		if result.Details != "" && result.Status != health.StatusHealthy {
-			fmt.Printf("  └─ %s\n", result.Details)
+			fmt.Fprintf(out, "  └─ %s\n", result.Details)
 		}
 	}
 
-	fmt.Println("─────────────────────────────────")
-	fmt.Printf("Status: %s | Schema: %s\n", report.OverallStatus, report.CurrentSchemaVersion)
+	fmt.Fprintln(out, "─────────────────────────────────")
+	fmt.Fprintf(out, "Status: %s | Schema: %s\n", report.OverallStatus, report.CurrentSchemaVersion)
 }
 
 // versionCmd shows version information
@@ -188,9 +332,10 @@ var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("cf %s\n", Version)
-		fmt.Printf("  Commit:     %s\n", Commit)
-		fmt.Printf("  Build Date: %s\n", BuildDate)
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "cf %s\n", Version)
+		fmt.Fprintf(out, "  Commit:     %s\n", Commit)
+		fmt.Fprintf(out, "  Build Date: %s\n", BuildDate)
 	},
 }
 
@@ -232,9 +377,13 @@ var healthCmd = &cobra.Command{
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if healthFix {
+			return runHealthFix(cmd.OutOrStdout())
+		}
+
 		// Force verbose output
 		verbose = true
-		return runStartupChecks()
+		return runStartupChecks(cmd.OutOrStdout())
 	},
 }
 
@@ -242,7 +391,7 @@ var healthCmd = &cobra.Command{
 var parseCmd = &cobra.Command{
 	Use:        "parse <contest_id> <problem_index>",
 	Short:      "[Deprecated] Use 'cf problem parse' instead",
-	Args:       cobra.ExactArgs(2),
+	Args:       cobra.RangeArgs(1, 2),
 	Deprecated: "use 'cf problem parse' instead",
 	Hidden:     true,
 	RunE: func(cmd *cobra.Command, args []string) error {
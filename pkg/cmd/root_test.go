@@ -2,14 +2,26 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
 	"github.com/harshit-vibes/cf/pkg/internal/config"
 	"github.com/harshit-vibes/cf/pkg/internal/health"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -67,11 +79,15 @@ func TestVersionCommand(t *testing.T) {
 }
 
 func TestVersionCommand_Output(t *testing.T) {
-	// Verify that the version command doesn't panic
-	// Note: The version command uses fmt.Printf which goes to stdout,
-	// not to the command's output writer
+	var buf bytes.Buffer
+	versionCmd.SetOut(&buf)
+	defer versionCmd.SetOut(nil)
+
 	versionCmd.Run(versionCmd, []string{})
-	// If we get here without panic, the test passes
+
+	if got := buf.String(); !strings.HasPrefix(got, "cf "+Version) || !strings.Contains(got, "Build Date:") {
+		t.Errorf("version output = %q", got)
+	}
 }
 
 func TestInitCommand(t *testing.T) {
@@ -150,6 +166,16 @@ func TestHealthCommand_SkipsPreRun(t *testing.T) {
 	}
 }
 
+func TestHealthCommand_FixFlag(t *testing.T) {
+	flag := healthCmd.Flags().Lookup("fix")
+	if flag == nil {
+		t.Fatal("health should have --fix flag")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("--fix default = %v, want false", flag.DefValue)
+	}
+}
+
 func TestParseCommand(t *testing.T) {
 	if parseCmd == nil {
 		t.Fatal("parseCmd should not be nil")
@@ -210,6 +236,24 @@ func TestPersistentFlags(t *testing.T) {
 	if verboseFlag == nil {
 		t.Error("--verbose flag should be defined")
 	}
+
+	outputFlag := rootCmd.PersistentFlags().Lookup("output")
+	if outputFlag == nil {
+		t.Fatal("--output flag should be defined")
+	}
+	if outputFlag.Shorthand != "o" || outputFlag.DefValue != "table" {
+		t.Errorf("--output shorthand/default = %q/%q, want o/table", outputFlag.Shorthand, outputFlag.DefValue)
+	}
+}
+
+func TestRunPreChecks_InvalidOutput(t *testing.T) {
+	old := outputFormat
+	defer func() { outputFormat = old }()
+
+	outputFormat = "xml"
+	if err := runPreChecks(versionCmd, []string{}); err == nil {
+		t.Error("runPreChecks() should reject an unknown --output format")
+	}
 }
 
 func TestRunPreChecks_VersionCommand(t *testing.T) {
@@ -235,7 +279,7 @@ func TestRunStartupChecks_SkipChecks(t *testing.T) {
 	skipChecks = true
 	defer func() { skipChecks = false }()
 
-	err := runStartupChecks()
+	err := runStartupChecks(io.Discard)
 	if err != nil {
 		t.Errorf("runStartupChecks should return nil when skipChecks=true, got: %v", err)
 	}
@@ -390,8 +434,15 @@ func TestDisplayHealthReport_AllStatuses(t *testing.T) {
 		CanProceed:    true,
 	}
 
-	// Capture output and verify it doesn't panic
-	displayHealthReport(report)
+	var buf bytes.Buffer
+	displayHealthReport(&buf, report)
+
+	got := buf.String()
+	for _, want := range []string{"✓ Check1", "⚠ Check2", "✗ Check3", "└─ Some issue here", "Status: degraded"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report should contain %q, got:\n%s", want, got)
+		}
+	}
 }
 
 func TestDisplayHealthReport_HealthyReport(t *testing.T) {
@@ -411,7 +462,7 @@ func TestDisplayHealthReport_HealthyReport(t *testing.T) {
 	}
 
 	// Should not panic
-	displayHealthReport(report)
+	displayHealthReport(io.Discard, report)
 }
 
 func TestDisplayHealthReport_EmptyReport(t *testing.T) {
@@ -424,7 +475,7 @@ func TestDisplayHealthReport_EmptyReport(t *testing.T) {
 	}
 
 	// Should not panic
-	displayHealthReport(report)
+	displayHealthReport(io.Discard, report)
 }
 
 func TestRunStartupChecks_WithChecks(t *testing.T) {
@@ -435,6 +486,8 @@ func TestRunStartupChecks_WithChecks(t *testing.T) {
 	// Use temp directory as home
 	tmpDir := t.TempDir()
 	os.Setenv("HOME", tmpDir)
+	// The workspace defaults to the working directory
+	t.Chdir(tmpDir)
 
 	// Set up config with test handle
 	config.SetGlobalConfig(&config.Config{CFHandle: "testuser"})
@@ -443,7 +496,7 @@ func TestRunStartupChecks_WithChecks(t *testing.T) {
 	skipChecks = false
 	verbose = false
 
-	err := runStartupChecks()
+	err := runStartupChecks(io.Discard)
 	// May succeed or fail depending on network, but should not panic
 	_ = err
 }
@@ -456,12 +509,14 @@ func TestRunStartupChecks_Verbose(t *testing.T) {
 	// Use temp directory
 	tmpDir := t.TempDir()
 	os.Setenv("HOME", tmpDir)
+	// The workspace defaults to the working directory
+	t.Chdir(tmpDir)
 
 	skipChecks = false
 	verbose = true
 	defer func() { verbose = false }()
 
-	err := runStartupChecks()
+	err := runStartupChecks(io.Discard)
 	// Should print verbose output
 	_ = err
 }
@@ -498,6 +553,8 @@ func TestHealthCommand_RunE(t *testing.T) {
 	// Use temp directory
 	tmpDir := t.TempDir()
 	os.Setenv("HOME", tmpDir)
+	// The workspace defaults to the working directory
+	t.Chdir(tmpDir)
 
 	// Set up config with test handle
 	config.SetGlobalConfig(&config.Config{CFHandle: "testuser"})
@@ -575,6 +632,8 @@ func TestRunStartupChecks_WithCookie(t *testing.T) {
 	// Use temp directory
 	tmpDir := t.TempDir()
 	os.Setenv("HOME", tmpDir)
+	// The workspace defaults to the working directory
+	t.Chdir(tmpDir)
 
 	// Set up config with test handle and cookie
 	config.SetGlobalConfig(&config.Config{
@@ -585,7 +644,7 @@ func TestRunStartupChecks_WithCookie(t *testing.T) {
 	skipChecks = false
 	verbose = false
 
-	err := runStartupChecks()
+	err := runStartupChecks(io.Discard)
 	// May succeed or fail, but tests the cookie authentication path
 	_ = err
 }
@@ -598,6 +657,8 @@ func TestRunStartupChecks_WithWorkspace(t *testing.T) {
 	// Use temp directory
 	tmpDir := t.TempDir()
 	os.Setenv("HOME", tmpDir)
+	// The workspace defaults to the working directory
+	t.Chdir(tmpDir)
 
 	// Set up config with test handle
 	config.SetGlobalConfig(&config.Config{CFHandle: "testuser"})
@@ -615,7 +676,7 @@ func TestRunStartupChecks_WithWorkspace(t *testing.T) {
 	skipChecks = false
 	verbose = false
 
-	err := runStartupChecks()
+	err := runStartupChecks(io.Discard)
 	_ = err
 }
 
@@ -636,6 +697,2119 @@ func TestDisplayHealthReport_WithDetails(t *testing.T) {
 		CanProceed:    true,
 	}
 
-	// Should not panic and should show details
-	displayHealthReport(report)
+	var buf bytes.Buffer
+	displayHealthReport(&buf, report)
+
+	if !strings.Contains(buf.String(), "Unable to reach codeforces.com") {
+		t.Errorf("report should show details of degraded checks, got:\n%s", buf.String())
+	}
+}
+
+func TestWorkspacePath_DefaultsToCurrentDir(t *testing.T) {
+	config.SetGlobalConfig(&config.Config{})
+	defer config.SetGlobalConfig(nil)
+
+	if got := workspacePath(); got != "." {
+		t.Errorf("workspacePath() = %v, want .", got)
+	}
+}
+
+func TestRequireWorkspace_Missing(t *testing.T) {
+	tmpDir := t.TempDir()
+	config.SetGlobalConfig(&config.Config{WorkspacePath: tmpDir})
+	defer config.SetGlobalConfig(nil)
+
+	_, err := requireWorkspace()
+	if err == nil {
+		t.Fatal("requireWorkspace should fail when workspace is missing")
+	}
+	if !strings.Contains(err.Error(), "cf init") {
+		t.Errorf("error should suggest 'cf init', got: %v", err)
+	}
+}
+
+func TestRequireWorkspace_Exists(t *testing.T) {
+	tmpDir := t.TempDir()
+	initCmd.SetOut(new(bytes.Buffer))
+	if err := initCmd.RunE(initCmd, []string{tmpDir}); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	config.SetGlobalConfig(&config.Config{WorkspacePath: tmpDir})
+	defer config.SetGlobalConfig(nil)
+
+	ws, err := requireWorkspace()
+	if err != nil {
+		t.Fatalf("requireWorkspace() error = %v", err)
+	}
+	if ws.Root() != tmpDir {
+		t.Errorf("ws.Root() = %v, want %v", ws.Root(), tmpDir)
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "(not set)"},
+		{"abc", "****"},
+		{"abcd", "****"},
+		{"secretvalue1234", "****1234"},
+	}
+
+	for _, tt := range tests {
+		if got := maskSecret(tt.in); got != tt.want {
+			t.Errorf("maskSecret(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := confirm(strings.NewReader(tt.input), "Continue?"); got != tt.want {
+			t.Errorf("confirm(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestUserAgent_IncludesVersion(t *testing.T) {
+	if got := userAgent(); got != "cf/"+Version {
+		t.Errorf("userAgent() = %q, want %q", got, "cf/"+Version)
+	}
+}
+
+func TestWriteStandingsCSV(t *testing.T) {
+	standings := &cfapi.ContestStandings{
+		Problems: []cfapi.Problem{{Index: "A"}, {Index: "B"}},
+		Rows: []cfapi.RanklistRow{
+			{
+				Party:   cfapi.Party{Members: []cfapi.Member{{Handle: "tourist"}}},
+				Rank:    1,
+				Points:  2,
+				Penalty: 35,
+				ProblemResults: []cfapi.ProblemResult{
+					{Points: 1, BestSubmissionTimeSeconds: 300},
+					{Points: 1, RejectedAttemptCount: 2, BestSubmissionTimeSeconds: 3900},
+				},
+			},
+			{
+				Party:   cfapi.Party{Members: []cfapi.Member{{Handle: "alice"}, {Handle: "bob"}}},
+				Rank:    2,
+				Points:  1,
+				Penalty: 10,
+				ProblemResults: []cfapi.ProblemResult{
+					{Points: 1, BestSubmissionTimeSeconds: 600},
+					{RejectedAttemptCount: 1},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeStandingsCSV(&buf, standings); err != nil {
+		t.Fatalf("writeStandingsCSV() error = %v", err)
+	}
+
+	want := "rank,handle,points,penalty,A_points,A_attempts,A_time,B_points,B_attempts,B_time\n" +
+		"1,tourist,2,35,1,0,0:05,1,2,1:05\n" +
+		"2,alice;bob,1,10,1,0,0:10,0,1,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeStandingsCSV() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestStandingsColumns_CSV(t *testing.T) {
+	rows := []cfapi.RanklistRow{
+		{
+			Party:          cfapi.Party{Members: []cfapi.Member{{Handle: "tourist"}}},
+			Rank:           1,
+			Points:         2,
+			Penalty:        35,
+			ProblemResults: []cfapi.ProblemResult{{Points: 1}, {Points: 1}, {}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := output.Render(&buf, output.FormatCSV, rows, standingsColumns(3)); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "Rank,Handle,Points,Penalty,Solved\n1,tourist,2,35,2/3\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestSubmissionColumns_CSV(t *testing.T) {
+	subs := []cfapi.Submission{
+		{
+			CreationTimeSeconds: time.Date(2024, 1, 15, 10, 30, 0, 0, time.Local).Unix(),
+			Problem:             cfapi.Problem{ContestID: 1325, Index: "A", Name: "EhAb AnD gCd"},
+			Verdict:             cfapi.VerdictOK,
+			ProgrammingLanguage: "GNU C++17",
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := output.Render(&buf, output.FormatCSV, subs, submissionColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "Time,Problem,Name,Verdict,Language\nJan 15 10:30,1325A,EhAb AnD gCd,OK,GNU C++17\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestContestStandingsCommand_Flags(t *testing.T) {
+	for _, name := range []string{"csv", "limit", "unofficial"} {
+		if contestStandingsCmd.Flags().Lookup(name) == nil {
+			t.Errorf("contest standings should have --%s flag", name)
+		}
+	}
+}
+
+// apiTransport serves a fixed API response body
+type apiTransport struct {
+	body string
+}
+
+func (a *apiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(a.body)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+// useAPIResponse makes the commands' API clients answer every request with
+// body until the test ends
+func useAPIResponse(t *testing.T, body string) {
+	t.Helper()
+	orig := newAPIClient
+	newAPIClient = func(opts ...cfapi.ClientOption) *cfapi.Client {
+		return orig(append(opts, cfapi.WithHTTPClient(&http.Client{Transport: &apiTransport{body: body}}))...)
+	}
+	t.Cleanup(func() { newAPIClient = orig })
+}
+
+func TestSyncProblemMetadata(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	stale := v1.NewProblem(1, "A", "Theatre Square")
+	stale.Practice.Status = v1.StatusSolved
+	stale.Notes.Approach = "ceil division"
+	current := v1.NewProblem(2, "B", "Current")
+	current.Metadata = v1.ProblemMetadata{Rating: 800, Tags: []string{"math"}}
+	missing := v1.NewProblem(3, "C", "Gone")
+	for _, p := range []*v1.Problem{stale, current, missing} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+
+	client := cfapi.NewClient(cfapi.WithHTTPClient(&http.Client{Transport: &apiTransport{
+		body: `{"status":"OK","result":{"problems":[` +
+			`{"contestId":1,"index":"A","name":"Theatre Square","rating":1000,"tags":["math"]},` +
+			`{"contestId":2,"index":"B","name":"Current","rating":800,"tags":["math"]}` +
+			`],"problemStatistics":[]}}`,
+	}}))
+
+	result, err := syncProblemMetadata(context.Background(), ws, client)
+	if err != nil {
+		t.Fatalf("syncProblemMetadata() error = %v", err)
+	}
+	if result.Total != 3 || result.Updated != 1 || result.Skipped != 1 {
+		t.Errorf("result = %+v, want Total 3, Updated 1, Skipped 1", result)
+	}
+
+	loaded, err := ws.LoadProblem("codeforces", 1, "A")
+	if err != nil {
+		t.Fatalf("LoadProblem() error = %v", err)
+	}
+	if loaded.Metadata.Rating != 1000 {
+		t.Errorf("Rating = %d, want 1000", loaded.Metadata.Rating)
+	}
+	if loaded.Practice.Status != v1.StatusSolved || loaded.Notes.Approach != "ceil division" {
+		t.Error("sync should preserve practice data and notes")
+	}
+}
+
+func TestProblemArgs(t *testing.T) {
+	tests := []struct {
+		args      []string
+		contestID int
+		index     string
+		wantErr   bool
+	}{
+		{[]string{"1325", "a"}, 1325, "A", false},
+		{[]string{"1325A"}, 1325, "A", false},
+		{[]string{"1325/b"}, 1325, "B", false},
+		{[]string{"100001-C"}, 100001, "C", false},
+		{[]string{"abc", "A"}, 0, "", true},
+		{[]string{"abcA"}, 0, "", true},
+		{[]string{"1325"}, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			contestID, index, err := problemArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("problemArgs(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if !tt.wantErr && (contestID != tt.contestID || index != tt.index) {
+				t.Errorf("problemArgs(%v) = (%d, %q), want (%d, %q)", tt.args, contestID, index, tt.contestID, tt.index)
+			}
+		})
+	}
+}
+
+func TestProgressFromSubmissions(t *testing.T) {
+	day := time.Date(2024, 1, 15, 10, 0, 0, 0, time.Local)
+	subs := []cfapi.Submission{
+		// Newest first, as returned by the API
+		{CreationTimeSeconds: day.AddDate(0, 0, 2).Unix(), Problem: cfapi.Problem{ContestID: 1, Index: "A"}, Verdict: cfapi.VerdictOK},
+		{CreationTimeSeconds: day.AddDate(0, 0, 1).Unix(), Problem: cfapi.Problem{ContestID: 2, Index: "A"}, Verdict: cfapi.VerdictOK},
+		{CreationTimeSeconds: day.AddDate(0, 0, 1).Unix(), Problem: cfapi.Problem{ContestID: 3, Index: "A"}, Verdict: "WRONG_ANSWER"},
+		{CreationTimeSeconds: day.Unix(), Problem: cfapi.Problem{ContestID: 1, Index: "A"}, Verdict: cfapi.VerdictOK},
+	}
+
+	progress := progressFromSubmissions(subs)
+	if progress.TotalSolved != 2 {
+		t.Errorf("TotalSolved = %d, want 2 (duplicates and rejections skipped)", progress.TotalSolved)
+	}
+	if progress.LongestStreak != 2 {
+		t.Errorf("LongestStreak = %d, want 2", progress.LongestStreak)
+	}
+	if days := progress.SolvedByDay(); days["2024-01-15"] != 1 || days["2024-01-16"] != 1 {
+		t.Errorf("SolvedByDay() = %v, want one solve on Jan 15 and 16", days)
+	}
+}
+
+func TestRenderHeatmap(t *testing.T) {
+	// Wednesday, so a 7-day window spans two calendar weeks
+	end := time.Date(2024, 1, 17, 12, 0, 0, 0, time.Local)
+	solved := map[string]int{"2024-01-17": 1, "2024-01-12": 5}
+
+	out := renderHeatmap(solved, end, 7)
+	lines := strings.Split(out, "\n")
+	if !strings.HasPrefix(lines[0], "  Mon") || !strings.HasPrefix(lines[6], "  Sun") {
+		t.Fatalf("renderHeatmap() rows should be labelled Mon..Sun, got:\n%s", out)
+	}
+	if got := strings.Count(out, "■"); got != 2+3 { // two solve days plus the legend
+		t.Errorf("renderHeatmap() drew %d filled cells, want 5:\n%s", got, out)
+	}
+	if got := strings.Count(out, "·"); got != 5+1 {
+		t.Errorf("renderHeatmap() drew %d empty cells, want 6:\n%s", got, out)
+	}
+}
+
+func TestStreakCommand_Flags(t *testing.T) {
+	for _, name := range []string{"days", "rebuild"} {
+		if streakCmd.Flags().Lookup(name) == nil {
+			t.Errorf("streak should have --%s flag", name)
+		}
+	}
+}
+
+func TestUserInfoColumns_Unrated(t *testing.T) {
+	var buf bytes.Buffer
+	if err := output.Render(&buf, output.FormatTable, cfapi.User{Handle: "newbie"}, userInfoColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	got := buf.String()
+	if !strings.Contains(got, "Rating:") || !strings.Contains(got, "Unrated") {
+		t.Errorf("unrated user should show Unrated, got:\n%s", got)
+	}
+	if strings.Contains(got, "Max Rating") {
+		t.Errorf("unrated user should not show a max rating, got:\n%s", got)
+	}
+}
+
+func TestGetRankColor_Unrated(t *testing.T) {
+	if got := getRankColor(0); got != "\033[90m" {
+		t.Errorf("getRankColor(0) = %q, want gray", got)
+	}
+}
+
+func TestCacheUsageAndClear(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "notes"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, tagsCacheFile), []byte("12345"), 0644)
+	os.WriteFile(filepath.Join(dir, statusCacheFile), []byte("123"), 0644)
+	// Someone else's files in a shared cache_dir
+	os.WriteFile(filepath.Join(dir, "other.json"), []byte("keep"), 0644)
+	os.WriteFile(filepath.Join(dir, "notes", "todo.txt"), []byte("keep"), 0644)
+
+	entries, size, err := cacheUsage(dir)
+	if err != nil {
+		t.Fatalf("cacheUsage() error = %v", err)
+	}
+	if entries != 2 || size != 8 {
+		t.Errorf("cacheUsage() = %d entries, %d bytes, want 2, 8", entries, size)
+	}
+
+	removed, err := clearCacheDir(dir)
+	if err != nil {
+		t.Fatalf("clearCacheDir() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("clearCacheDir() removed %d, want 2", removed)
+	}
+	for _, name := range cacheFiles {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed", name)
+		}
+	}
+	for _, kept := range []string{"other.json", filepath.Join("notes", "todo.txt")} {
+		if _, err := os.Stat(filepath.Join(dir, kept)); err != nil {
+			t.Errorf("clearCacheDir() should leave %s alone: %v", kept, err)
+		}
+	}
+}
+
+func TestCacheUsage_MissingDir(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "nope")
+
+	entries, size, err := cacheUsage(missing)
+	if err != nil || entries != 0 || size != 0 {
+		t.Errorf("cacheUsage(missing) = %d, %d, %v, want empty cache", entries, size, err)
+	}
+	if removed, err := clearCacheDir(missing); err != nil || removed != 0 {
+		t.Errorf("clearCacheDir(missing) = %d, %v, want 0, nil", removed, err)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{512, "512 B"},
+		{2048, "2.0 KB"},
+		{3 * 1024 * 1024, "3.0 MB"},
+	}
+	for _, tt := range tests {
+		if got := formatSize(tt.n); got != tt.want {
+			t.Errorf("formatSize(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestStatsCommand_RawLanguagesFlag(t *testing.T) {
+	if statsCmd.Flags().Lookup("raw-languages") == nil {
+		t.Error("stats should have --raw-languages flag")
+	}
+}
+
+func TestPickDailyProblem(t *testing.T) {
+	problems := []cfapi.Problem{
+		{ContestID: 1, Index: "A"}, // Unrated problems are never picked
+		{ContestID: 2, Index: "A", Rating: 800},
+		{ContestID: 3, Index: "A", Rating: 900},
+		{ContestID: 4, Index: "A", Rating: 1000},
+	}
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	first, ok := pickDailyProblem(problems, "tourist", day)
+	if !ok {
+		t.Fatal("pickDailyProblem() found no problem")
+	}
+	if first.Rating == 0 {
+		t.Errorf("pickDailyProblem() picked unrated problem %s", first.ProblemID())
+	}
+	if again, _ := pickDailyProblem(problems, "tourist", day.Add(time.Hour)); again.ProblemID() != first.ProblemID() {
+		t.Errorf("pickDailyProblem() not stable for a day: %s then %s", first.ProblemID(), again.ProblemID())
+	}
+
+	if _, ok := pickDailyProblem(problems[:1], "tourist", day); ok {
+		t.Error("pickDailyProblem() should fail without rated problems")
+	}
+}
+
+func TestDailyPickColumns_CSV(t *testing.T) {
+	picks := []workspace.DailyPick{
+		{Date: "2024-03-02", ProblemID: "1000B"},
+		{Date: "2024-03-01", ProblemID: "1325A", Status: v1.StatusSolved},
+	}
+
+	var buf bytes.Buffer
+	if err := output.Render(&buf, output.FormatCSV, picks, dailyPickColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "Date,Problem,Status\n2024-03-02,1000B,-\n2024-03-01,1325A,solved\n"
+	if buf.String() != want {
+		t.Errorf("CSV = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestVirtualSubmissions(t *testing.T) {
+	subs := []cfapi.Submission{
+		{ContestID: 10, Author: cfapi.Party{ParticipantType: cfapi.ParticipantVirtual}},
+		{ContestID: 10, Author: cfapi.Party{ParticipantType: cfapi.ParticipantContestant}},
+		{ContestID: 11, Author: cfapi.Party{ParticipantType: cfapi.ParticipantVirtual}},
+	}
+
+	if got := virtualSubmissions(subs, 10); len(got) != 1 {
+		t.Errorf("virtualSubmissions() returned %d, want 1", len(got))
+	}
+}
+
+func TestCollectCredentials_Prompts(t *testing.T) {
+	input := strings.NewReader("tourist\nkey123\nsecret456\nJSESSIONID=abc ;39ce7=def\n")
+	var out bytes.Buffer
+
+	creds, err := collectCredentials(input, &out, config.Credentials{}, config.Credentials{}, true)
+	if err != nil {
+		t.Fatalf("collectCredentials() error = %v", err)
+	}
+
+	want := config.Credentials{Handle: "tourist", APIKey: "key123", APISecret: "secret456", Cookie: "JSESSIONID=abc; 39ce7=def"}
+	if creds != want {
+		t.Errorf("collectCredentials() = %+v, want %+v", creds, want)
+	}
+	if !strings.Contains(out.String(), "Developer Tools") {
+		t.Error("collectCredentials() should explain where to copy the cookie from")
+	}
+}
+
+func TestCollectCredentials_KeepsCurrentOnEmptyAnswer(t *testing.T) {
+	current := config.Credentials{Handle: "tourist", Cookie: "JSESSIONID=abc"}
+	input := strings.NewReader("\n\n\n")
+	var out bytes.Buffer
+
+	creds, err := collectCredentials(input, &out, config.Credentials{}, current, true)
+	if err != nil {
+		t.Fatalf("collectCredentials() error = %v", err)
+	}
+	if creds.Handle != "tourist" || creds.Cookie != "JSESSIONID=abc" || creds.APIKey != "" {
+		t.Errorf("collectCredentials() = %+v, want current values kept", creds)
+	}
+}
+
+func TestCollectCredentials_ClearanceUserAgent(t *testing.T) {
+	input := strings.NewReader("tourist\n\nJSESSIONID=abc; cf_clearance=xyz\nMozilla/5.0 (X11)\n")
+	var out bytes.Buffer
+
+	creds, err := collectCredentials(input, &out, config.Credentials{}, config.Credentials{}, true)
+	if err != nil {
+		t.Fatalf("collectCredentials() error = %v", err)
+	}
+	if creds.CFClearanceUA != "Mozilla/5.0 (X11)" {
+		t.Errorf("CFClearanceUA = %q, want the browser User-Agent", creds.CFClearanceUA)
+	}
+	if !strings.Contains(out.String(), "User-Agent") {
+		t.Error("collectCredentials() should ask for the User-Agent with a cf_clearance cookie")
+	}
+}
+
+func TestCollectCredentials_NonInteractive(t *testing.T) {
+	flags := config.Credentials{Handle: "tourist", APIKey: "key"}
+
+	// Nothing is read from input without interactive
+	_, err := collectCredentials(strings.NewReader("ignored\n"), io.Discard, flags, config.Credentials{}, false)
+	if err == nil || !strings.Contains(err.Error(), "--api-secret") {
+		t.Errorf("collectCredentials() error = %v, want missing secret error", err)
+	}
+
+	_, err = collectCredentials(strings.NewReader(""), io.Discard, config.Credentials{}, config.Credentials{}, false)
+	if err == nil || !strings.Contains(err.Error(), "--handle") {
+		t.Errorf("collectCredentials() error = %v, want missing handle error", err)
+	}
+}
+
+func TestUpsolveCmd_Args(t *testing.T) {
+	if err := upsolveCmd.Args(upsolveCmd, []string{}); err == nil {
+		t.Error("upsolve should require a contest ID")
+	}
+	if err := upsolveCmd.Args(upsolveCmd, []string{"1325", "tourist"}); err != nil {
+		t.Errorf("upsolve should accept a contest ID and handle: %v", err)
+	}
+	if err := runUpsolve(upsolveCmd, []string{"abc"}); err == nil || !strings.Contains(err.Error(), "invalid contest ID") {
+		t.Errorf("runUpsolve() error = %v, want invalid contest ID", err)
+	}
+	if upsolveCmd.Flags().Lookup("strict") == nil {
+		t.Error("upsolve should have a --strict flag like problem fetch")
+	}
+}
+
+func TestUpsolveStatus(t *testing.T) {
+	tests := []struct {
+		rejected int
+		want     string
+	}{
+		{0, "not attempted"},
+		{1, "attempted, 1 rejected"},
+		{3, "attempted, 3 rejected"},
+	}
+	for _, tt := range tests {
+		u := &cfapi.UpsolveProblem{RejectedAttempts: tt.rejected}
+		if got := upsolveStatus(u); got != tt.want {
+			t.Errorf("upsolveStatus(%d) = %q, want %q", tt.rejected, got, tt.want)
+		}
+	}
+}
+
+func TestRunRestore(t *testing.T) {
+	wsDir := t.TempDir()
+	ws := workspace.New(wsDir)
+	if err := ws.Init("Test", "tourist"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	if err := ws.Backup(archive); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	// Restoring over the live workspace needs --force
+	err := runRestore(restoreCmd, []string{archive, wsDir})
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("runRestore() error = %v, want --force hint", err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "restored")
+	if err := runRestore(restoreCmd, []string{archive, dest}); err != nil {
+		t.Fatalf("runRestore() error = %v", err)
+	}
+	if !workspace.New(dest).Exists() {
+		t.Error("restored workspace should exist")
+	}
+}
+
+func TestSyncSubmissions(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "tourist"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	client := cfapi.NewClient(cfapi.WithHTTPClient(&http.Client{Transport: &apiTransport{
+		body: `{"status":"OK","result":[` +
+			`{"id":3,"creationTimeSeconds":1700000200,"problem":{"contestId":1,"index":"A","rating":800},"verdict":"OK"},` +
+			`{"id":2,"creationTimeSeconds":1700000100,"problem":{"contestId":1,"index":"A","rating":800},"verdict":"WRONG_ANSWER"},` +
+			`{"id":1,"creationTimeSeconds":1700000000,"problem":{"contestId":2,"index":"B","rating":900},"verdict":"OK"}` +
+			`]}`,
+	}}))
+
+	newSubs, solved, err := syncSubmissions(context.Background(), ws, client, "tourist")
+	if err != nil {
+		t.Fatalf("syncSubmissions() error = %v", err)
+	}
+	if newSubs != 3 || solved != 2 {
+		t.Errorf("syncSubmissions() = %d, %d, want 3, 2", newSubs, solved)
+	}
+
+	state, err := ws.LoadSyncState("tourist")
+	if err != nil {
+		t.Fatalf("LoadSyncState() error = %v", err)
+	}
+	if state.SubmissionCursor != 3 {
+		t.Errorf("cursor = %d, want 3", state.SubmissionCursor)
+	}
+
+	// Nothing above the cursor: progress is left as it was
+	newSubs, solved, err = syncSubmissions(context.Background(), ws, client, "tourist")
+	if err != nil {
+		t.Fatalf("syncSubmissions() error = %v", err)
+	}
+	if newSubs != 0 || solved != 0 {
+		t.Errorf("second syncSubmissions() = %d, %d, want 0, 0", newSubs, solved)
+	}
+
+	progress, err := ws.LoadProgress()
+	if err != nil {
+		t.Fatalf("LoadProgress() error = %v", err)
+	}
+	if progress.TotalSolved != 2 {
+		t.Errorf("TotalSolved = %d, want 2", progress.TotalSolved)
+	}
+}
+
+func TestCommandContext_TimeoutFlag(t *testing.T) {
+	origTimeout := commandTimeout
+	defer func() { commandTimeout = origTimeout }()
+
+	commandTimeout = 0
+	ctx, cancel := commandContext(time.Hour)
+	deadline, ok := ctx.Deadline()
+	cancel()
+	if !ok || time.Until(deadline) < 59*time.Minute {
+		t.Errorf("commandContext() deadline = %v, want the fallback", deadline)
+	}
+
+	commandTimeout = 10 * time.Millisecond
+	ctx, cancel = commandContext(time.Hour)
+	defer cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("--timeout should override the fallback")
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("ctx.Err() = %v, want DeadlineExceeded", ctx.Err())
+	}
+}
+
+func TestCommandContext_Interrupt(t *testing.T) {
+	ctx, cancel := commandContext(time.Minute)
+	defer cancel()
+
+	proc, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := proc.Signal(os.Interrupt); err != nil {
+		t.Skipf("cannot send interrupt: %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Ctrl-C should cancel the command context")
+	}
+
+	// API calls made with the context stop instead of running to completion
+	client := cfapi.NewClient(cfapi.WithHTTPClient(&http.Client{Transport: &apiTransport{body: `{"status":"OK","result":[]}`}}))
+	if _, err := client.GetUserInfo(ctx, []string{"tourist"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetUserInfo() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestProblemStrictFlag(t *testing.T) {
+	for _, c := range []*cobra.Command{problemParseCmd, problemFetchCmd} {
+		if c.Flags().Lookup("strict") == nil {
+			t.Errorf("%s should have a --strict flag", c.Name())
+		}
+	}
+
+	// A complete problem passes either way
+	origStrict := problemStrict
+	defer func() { problemStrict = origStrict }()
+	problemStrict = true
+	if err := checkParsedProblem(&cfweb.ParsedProblem{ContestID: 1, Index: "A"}); err != nil {
+		t.Errorf("checkParsedProblem() = %v, want nil", err)
+	}
+}
+
+func TestConfigSetArgs_Difficulty(t *testing.T) {
+	if err := configSetArgs(configSetCmd, []string{"difficulty", "1000", "1600"}); err != nil {
+		t.Errorf("configSetArgs(difficulty min max) = %v, want nil", err)
+	}
+	if err := configSetArgs(configSetCmd, []string{"difficulty", "auto"}); err != nil {
+		t.Errorf("configSetArgs(difficulty auto) = %v, want nil", err)
+	}
+	if err := configSetArgs(configSetCmd, []string{"difficulty", "1000"}); err == nil {
+		t.Error("configSetArgs(difficulty min) should require a max")
+	}
+	if err := configSetArgs(configSetCmd, []string{"cf_handle", "tourist"}); err != nil {
+		t.Errorf("configSetArgs(key value) = %v, want nil", err)
+	}
+	if err := configSetArgs(configSetCmd, []string{"cf_handle", "a", "b"}); err == nil {
+		t.Error("configSetArgs should reject extra values for other keys")
+	}
+
+	if err := runConfigSet(configSetCmd, []string{"difficulty", "1600", "1200"}); err == nil || !strings.Contains(err.Error(), "above max") {
+		t.Errorf("runConfigSet(difficulty 1600 1200) error = %v, want min above max", err)
+	}
+}
+
+func TestSaveFetchedProblems_PartialFailure(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	apiProblems := []cfapi.Problem{
+		{ContestID: 1325, Index: "A", Rating: 800},
+		{ContestID: 1325, Index: "B"},
+		{ContestID: 1325, Index: "C"},
+	}
+	parsed := []*cfweb.ParsedProblem{
+		{ContestID: 1325, Index: "A", Name: "EhAb AnD gCd", Statement: "Find x and y."},
+		nil,
+		{ContestID: 1325, Index: "C", Name: "Ehab and Path-etic MEXs"},
+	}
+	errParse := errors.New("parse failed")
+	errs := []error{nil, errParse, nil}
+
+	saved, failed := saveFetchedProblems(context.Background(), ws, nil, apiProblems, parsed, errs)
+	if saved != 2 {
+		t.Errorf("saved = %d, want 2", saved)
+	}
+	if failed.Len() != 1 || !failed.Partial() || !errors.Is(failed.Err(), errParse) {
+		t.Errorf("failed = %v, want one partial failure wrapping the parse error", failed.Err())
+	}
+
+	for _, index := range []string{"A", "C"} {
+		if _, err := ws.LoadProblem("codeforces", 1325, index); err != nil {
+			t.Errorf("LoadProblem(%s) error = %v, want saved", index, err)
+		}
+	}
+	if _, err := ws.LoadProblem("codeforces", 1325, "B"); !errors.Is(err, workspace.ErrProblemNotFound) {
+		t.Errorf("LoadProblem(B) error = %v, want ErrProblemNotFound", err)
+	}
+
+	loaded, err := ws.LoadProblem("codeforces", 1325, "A")
+	if err != nil {
+		t.Fatalf("LoadProblem(A) error = %v", err)
+	}
+	if loaded.Metadata.Rating != 800 {
+		t.Errorf("Rating = %d, want 800 from the API problem", loaded.Metadata.Rating)
+	}
+
+	statement := filepath.Join(ws.ProblemPath("codeforces", 1325, "A"), workspace.StatementFile)
+	if _, err := os.Stat(statement); err != nil {
+		t.Errorf("statement of A not saved: %v", err)
+	}
+}
+
+func TestExplainAPIError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&cfapi.APIError{Comment: "handle: User not found", Field: "handle"}, "check the handle"},
+		{&cfapi.APIError{Comment: "contestId: Contest not found", Field: "contestId"}, "check the contest ID"},
+		{&cfapi.APIError{Comment: "apiKey: Incorrect API key", Field: "apiKey"}, "cf setup"},
+		{cfapi.ErrServerMaintenance, "under maintenance"},
+	}
+	for _, tt := range tests {
+		err := explainAPIError(fmt.Errorf("failed: %w", tt.err))
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("explainAPIError(%v) = %v, want %q", tt.err, err, tt.want)
+		}
+	}
+
+	plain := errors.New("network down")
+	if err := explainAPIError(plain); err != plain {
+		t.Errorf("explainAPIError() should leave other errors alone, got %v", err)
+	}
+}
+
+func TestSyncProblemMetadata_KeepsEstimate(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	unrated := v1.NewProblem(2000, "A", "Fresh")
+	unrated.Metadata = v1.ProblemMetadata{Rating: 1200, RatingEstimated: true, Tags: []string{"math"}}
+	rated := v1.NewProblem(2000, "B", "Rated")
+	rated.Metadata = v1.ProblemMetadata{Rating: 1600, RatingEstimated: true}
+	for _, p := range []*v1.Problem{unrated, rated} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+
+	client := cfapi.NewClient(cfapi.WithHTTPClient(&http.Client{Transport: &apiTransport{
+		body: `{"status":"OK","result":{"problems":[` +
+			`{"contestId":2000,"index":"A","name":"Fresh","tags":["math"]},` +
+			`{"contestId":2000,"index":"B","name":"Rated","rating":1900}` +
+			`],"problemStatistics":[]}}`,
+	}}))
+
+	result, err := syncProblemMetadata(context.Background(), ws, client)
+	if err != nil {
+		t.Fatalf("syncProblemMetadata() error = %v", err)
+	}
+	if result.Updated != 1 {
+		t.Errorf("Updated = %d, want 1 (only the newly rated problem)", result.Updated)
+	}
+
+	a, err := ws.LoadProblem("codeforces", 2000, "A")
+	if err != nil {
+		t.Fatalf("LoadProblem(A) error = %v", err)
+	}
+	if a.Metadata.Rating != 1200 || !a.Metadata.RatingEstimated {
+		t.Errorf("A metadata = %+v, want the estimate kept", a.Metadata)
+	}
+
+	b, err := ws.LoadProblem("codeforces", 2000, "B")
+	if err != nil {
+		t.Fatalf("LoadProblem(B) error = %v", err)
+	}
+	if b.Metadata.Rating != 1900 || b.Metadata.RatingEstimated {
+		t.Errorf("B metadata = %+v, want the API rating", b.Metadata)
+	}
+}
+
+func TestChangedSubmissions(t *testing.T) {
+	seen := make(map[int64]string)
+
+	// Newest first, as the API returns them
+	poll := []cfapi.Submission{
+		{ID: 2, Verdict: cfapi.VerdictTesting, PassedTestCount: 3},
+		{ID: 1, Verdict: cfapi.VerdictOK},
+	}
+	changed := changedSubmissions(seen, poll)
+	if len(changed) != 2 || changed[0].ID != 1 {
+		t.Fatalf("first poll = %v, want both submissions oldest first", changed)
+	}
+
+	if changed := changedSubmissions(seen, poll); len(changed) != 0 {
+		t.Errorf("unchanged poll = %v, want none", changed)
+	}
+
+	poll[0] = cfapi.Submission{ID: 2, Verdict: cfapi.VerdictWrongAnswer, PassedTestCount: 4}
+	poll = append([]cfapi.Submission{{ID: 3}}, poll...)
+	changed = changedSubmissions(seen, poll)
+	if len(changed) != 2 || changed[0].ID != 2 || changed[1].ID != 3 {
+		t.Errorf("poll after judging = %v, want submissions 2 and 3", changed)
+	}
+}
+
+func TestWatchLine(t *testing.T) {
+	tests := []struct {
+		sub  cfapi.Submission
+		want string
+	}{
+		{cfapi.Submission{Verdict: cfapi.VerdictWrongAnswer, PassedTestCount: 4}, "on test 5"},
+		{cfapi.Submission{Verdict: cfapi.VerdictTesting, PassedTestCount: 2}, "passed 2"},
+		{cfapi.Submission{}, "IN QUEUE"},
+		{cfapi.Submission{Verdict: cfapi.VerdictOK, TimeConsumedMillis: 46, MemoryConsumedBytes: 4096}, "46 ms, 4 KB"},
+	}
+	for _, tt := range tests {
+		tt.sub.Problem = cfapi.Problem{ContestID: 1325, Index: "A"}
+		line := watchLine(tt.sub)
+		if !strings.Contains(line, tt.want) || !strings.Contains(line, "1325A") {
+			t.Errorf("watchLine(%s) = %q, want %q", tt.sub.Verdict, line, tt.want)
+		}
+	}
+}
+
+func TestPrintWatched_JSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	stream, err := output.NewStream(&buf, output.FormatJSON, submissionColumns())
+	if err != nil {
+		t.Fatalf("NewStream() error = %v", err)
+	}
+
+	// Two polls must still give one JSON object per line
+	for _, id := range []int64{1, 2} {
+		if err := printWatched(&buf, stream, []cfapi.Submission{{ID: id}}); err != nil {
+			t.Fatalf("printWatched() error = %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		var s cfapi.Submission
+		if err := json.Unmarshal([]byte(line), &s); err != nil {
+			t.Errorf("line %q is not a submission: %v", line, err)
+		}
+	}
+}
+
+func TestSelftestEnabled(t *testing.T) {
+	tests := []struct {
+		live bool
+		env  string
+		want bool
+	}{
+		{false, "", false},
+		{true, "", true},
+		{false, "1", true},
+		{false, "0", false},
+		{true, "0", true},
+	}
+	for _, tt := range tests {
+		if got := selftestEnabled(tt.live, tt.env); got != tt.want {
+			t.Errorf("selftestEnabled(%v, %q) = %v, want %v", tt.live, tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestRunSelftestChecks(t *testing.T) {
+	checks := []selftestCheck{
+		{name: "ok", run: func() error { return nil }},
+		{name: "broken", run: func() error { return errors.New("selectors not found: title") }},
+		{name: "skipped", skip: "no cookie"},
+	}
+
+	err := runSelftestChecks(checks)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Errorf("runSelftestChecks() = %v, want 1 of 3 failed", err)
+	}
+
+	if err := runSelftestChecks(checks[:1]); err != nil {
+		t.Errorf("runSelftestChecks(passing) = %v, want nil", err)
+	}
+}
+
+func TestReadListFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sheet.txt")
+	content := "# Week 1\n1325A 1325/b\n\n4A # watermelon\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	refs, err := readListFile(path)
+	if err != nil {
+		t.Fatalf("readListFile() error = %v", err)
+	}
+	want := []string{"1325A", "1325B", "4A"}
+	if len(refs) != len(want) {
+		t.Fatalf("readListFile() = %v, want %v", refs, want)
+	}
+	for i := range want {
+		if refs[i].String() != want[i] {
+			t.Errorf("refs[%d] = %s, want %s", i, refs[i], want[i])
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("1325A\nnot-a-problem\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := readListFile(path); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("readListFile() error = %v, want line 2 reported", err)
+	}
+}
+
+func TestTruncateLines(t *testing.T) {
+	if got := truncateLines("a\nb\n", 5); got != "a\nb" {
+		t.Errorf("truncateLines() = %q, want %q", got, "a\nb")
+	}
+	if got := truncateLines("a\nb\nc\nd\n", 2); got != "a\nb\n... 2 more lines" {
+		t.Errorf("truncateLines() = %q, want the first 2 lines and a count", got)
+	}
+}
+
+func TestCompleteTags(t *testing.T) {
+	orig := config.Get()
+	defer config.SetGlobalConfig(orig)
+	config.SetGlobalConfig(&config.Config{
+		CacheDir:   t.TempDir(),
+		TagAliases: map[string]string{"numt": "number theory"},
+	})
+
+	saveKnownTags([]string{"dp", "dsu", "number theory"})
+
+	got, directive := completeTags(problemListCmd, nil, "D")
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("directive = %v, want NoFileComp", directive)
+	}
+	want := []string{"dfs\tdfs and similar", "dnc\tdivide and conquer", "dp", "ds\tdata structures", "dsu"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("completeTags(D) = %q, want %q", got, want)
+	}
+
+	got, _ = completeTags(problemListCmd, nil, "num")
+	if strings.Join(got, ",") != "number theory,numt\tnumber theory" {
+		t.Errorf("completeTags(num) = %q, want the tag and the configured alias", got)
+	}
+}
+
+func TestListRatingBand(t *testing.T) {
+	orig := config.Get()
+	defer config.SetGlobalConfig(orig)
+	config.SetGlobalConfig(&config.Config{Difficulty: config.DifficultyRange{Min: 800, Max: 1400}})
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().IntVar(&problemMinRating, "min-rating", 0, "")
+		cmd.Flags().IntVar(&problemMaxRating, "max-rating", 0, "")
+		return cmd
+	}
+
+	if lo, hi := listRatingBand(newCmd()); lo != 800 || hi != 1400 {
+		t.Errorf("listRatingBand() = %d-%d, want the configured 800-1400", lo, hi)
+	}
+
+	// --min-rating 0 lifts the band
+	cmd := newCmd()
+	if err := cmd.Flags().Set("min-rating", "0"); err != nil {
+		t.Fatal(err)
+	}
+	if lo, hi := listRatingBand(cmd); lo != 0 || hi != 0 {
+		t.Errorf("listRatingBand(--min-rating 0) = %d-%d, want no bounds", lo, hi)
+	}
+}
+
+func TestSourceExtension(t *testing.T) {
+	tests := map[string]string{
+		"GNU C++17":            ".cpp",
+		"C++20 (GCC 13-64)":    ".cpp",
+		"PyPy 3-64":            ".py",
+		"Java 21 64bit":        ".java",
+		"Rust 2021":            ".rs",
+		"GNU C11":              ".c",
+		"Some Esoteric Lang 1": ".txt",
+	}
+	for lang, want := range tests {
+		if got := sourceExtension(lang); got != want {
+			t.Errorf("sourceExtension(%q) = %q, want %q", lang, got, want)
+		}
+	}
+}
+
+func TestFindSubmission(t *testing.T) {
+	subs := []cfapi.Submission{{ID: 1}, {ID: 2, ContestID: 1325}}
+	if got := findSubmission(subs, 2); got == nil || got.ContestID != 1325 {
+		t.Errorf("findSubmission(2) = %+v, want the second submission", got)
+	}
+	if got := findSubmission(subs, 3); got != nil {
+		t.Errorf("findSubmission(3) = %+v, want nil", got)
+	}
+}
+
+func TestSubmissionGet_InvalidID(t *testing.T) {
+	if err := runSubmissionGet(submissionGetCmd, []string{"abc"}); err == nil || !strings.Contains(err.Error(), "invalid submission ID") {
+		t.Errorf("runSubmissionGet(abc) error = %v, want an invalid ID error", err)
+	}
+}
+
+func TestReadHandlesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "team.txt")
+	content := "# Team A\ntourist petr\nBenq, jiangly # captains\n\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	handles, err := readHandlesFile(path)
+	if err != nil {
+		t.Fatalf("readHandlesFile() error = %v", err)
+	}
+	if got := strings.Join(handles, ","); got != "tourist,petr,Benq,jiangly" {
+		t.Errorf("readHandlesFile() = %s, want tourist,petr,Benq,jiangly", got)
+	}
+
+	if _, err := readHandlesFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("readHandlesFile() should fail for a missing file")
+	}
+}
+
+func TestRecordHandle_History(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	orig := config.Get()
+	defer config.SetGlobalConfig(orig)
+	config.SetGlobalConfig(&config.Config{CFHandle: "me"})
+
+	// getHandle alone must not record, the API has not seen the handle yet
+	if _, err := getHandle([]string{"ghost"}); err != nil {
+		t.Fatalf("getHandle() error = %v", err)
+	}
+	for _, h := range []string{"tourist", "not a handle!", "petr"} {
+		recordHandle([]string{h}, h)
+	}
+	recordHandle(nil, "me")
+
+	got, _ := completeHandles(userInfoCmd, nil, "")
+	if strings.Join(got, ",") != "petr,tourist,me" {
+		t.Errorf("completeHandles() = %v, want recent handles then the configured one", got)
+	}
+	got, _ = completeHandles(userInfoCmd, nil, "T")
+	if strings.Join(got, ",") != "tourist" {
+		t.Errorf("completeHandles(T) = %v, want [tourist]", got)
+	}
+}
+
+func TestImportSolvedProblems(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "tourist"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	unsolved := v1.NewProblem(1, "A", "Theatre Square")
+	unsolved.Practice.AttemptCount = 2
+	done := v1.NewProblem(2, "B", "Done")
+	done.Practice.Status = v1.StatusSolved
+	for _, p := range []*v1.Problem{unsolved, done} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+
+	solved := []cfapi.Problem{
+		{ContestID: 1, Index: "A"},
+		{ContestID: 2, Index: "B"},
+		{ContestID: 3, Index: "C"},
+		{Index: "9"}, // acm.sgu.ru problem without a contest
+	}
+	result, err := importSolvedProblems(context.Background(), ws, solved)
+	if err != nil {
+		t.Fatalf("importSolvedProblems() error = %v", err)
+	}
+	if result.Updated != 1 || result.Already != 1 {
+		t.Errorf("result = %+v, want 1 updated and 1 already solved", result)
+	}
+	if len(result.Missing) != 1 || result.Missing[0].String() != "3C" {
+		t.Errorf("Missing = %v, want [3C]", result.Missing)
+	}
+
+	loaded, err := ws.LoadProblem("codeforces", 1, "A")
+	if err != nil {
+		t.Fatalf("LoadProblem() error = %v", err)
+	}
+	if loaded.Practice.Status != v1.StatusSolved || loaded.Practice.AttemptCount != 2 {
+		t.Errorf("Practice = %+v, want solved with attempts kept", loaded.Practice)
+	}
+	if ws.ProblemExists("codeforces", 3, "C") {
+		t.Error("absent problem should be skipped, not created")
+	}
+	if refs := missingSolvedProblems(ws, solved); len(refs) != 1 || refs[0].String() != "3C" {
+		t.Errorf("missingSolvedProblems() = %v, want [3C]", refs)
+	}
+}
+
+func TestLimitImportCreate(t *testing.T) {
+	refs := make([]cfapi.ProblemRef, maxImportCreate+10)
+	for i := range refs {
+		refs[i] = cfapi.ProblemRef{ContestID: i + 1, Index: "A"}
+	}
+
+	if got := limitImportCreate(strings.NewReader(""), refs[:maxImportCreate], false); len(got) != maxImportCreate {
+		t.Errorf("got %d refs at the limit, want all %d without asking", len(got), maxImportCreate)
+	}
+	if got := limitImportCreate(strings.NewReader("n\n"), refs, false); len(got) != maxImportCreate {
+		t.Errorf("got %d refs after declining, want the first %d", len(got), maxImportCreate)
+	}
+	if got := limitImportCreate(strings.NewReader("y\n"), refs, false); len(got) != len(refs) {
+		t.Errorf("got %d refs after confirming, want %d", len(got), len(refs))
+	}
+	if got := limitImportCreate(strings.NewReader(""), refs, true); len(got) != len(refs) {
+		t.Errorf("got %d refs with --yes, want %d", len(got), len(refs))
+	}
+}
+
+// contestPageTransport serves a contest page with problems A and B and
+// their problem pages
+type contestPageTransport struct{}
+
+func (contestPageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := `<html><table class="problems"><tr><th>#</th></tr>` +
+		`<tr><td class="id"><a href="/contest/1/problem/A">A</a></td><td><a href="/contest/1/problem/A">First</a></td></tr>` +
+		`<tr><td class="id"><a href="/contest/1/problem/B">B</a></td><td><a href="/contest/1/problem/B">Second</a></td></tr>` +
+		`</table></html>`
+	if i := strings.Index(req.URL.Path, "/problem/"); i >= 0 {
+		index := req.URL.Path[i+len("/problem/"):]
+		body = fmt.Sprintf(`<div class="problem-statement"><div class="title">%s. Problem %s</div></div>`, index, index)
+	}
+	return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+}
+
+func TestFetchContestProblems_ContestPageFallback(t *testing.T) {
+	client := cfapi.NewClient(cfapi.WithHTTPClient(&http.Client{Transport: &apiTransport{
+		body: `{"status":"FAILED","comment":"contestId: Contest with id 1 has not started"}`,
+	}}))
+	parser := cfweb.NewParserWithClient(&http.Client{Transport: contestPageTransport{}})
+
+	apiProblems, problems, errs, err := fetchContestProblems(context.Background(), client, parser, 1)
+	if err != nil {
+		t.Fatalf("fetchContestProblems() error = %v", err)
+	}
+	if len(apiProblems) != 2 || len(problems) != 2 || len(errs) != 2 {
+		t.Fatalf("got %d/%d/%d results, want 2 each", len(apiProblems), len(problems), len(errs))
+	}
+	for i, index := range []string{"A", "B"} {
+		if apiProblems[i].Index != index || errs[i] != nil || problems[i].Name != "Problem "+index {
+			t.Errorf("problem %d = %+v, %+v, %v, want %s", i, apiProblems[i], problems[i], errs[i], index)
+		}
+	}
+}
+
+func TestVerdictNotification(t *testing.T) {
+	problem := cfapi.Problem{ContestID: 1325, Index: "A", Name: "EhAb AnD gCd"}
+
+	title, message := verdictNotification(cfapi.Submission{Problem: problem, Verdict: cfapi.VerdictOK, TimeConsumedMillis: 46, MemoryConsumedBytes: 4096})
+	if title != "1325A: OK" || !strings.Contains(message, "46 ms, 4 KB") {
+		t.Errorf("OK notification = %q, %q", title, message)
+	}
+
+	_, message = verdictNotification(cfapi.Submission{Problem: problem, Verdict: cfapi.VerdictWrongAnswer, PassedTestCount: 2})
+	if !strings.Contains(message, "on test 3") {
+		t.Errorf("WA notification message = %q, want the failing test", message)
+	}
+}
+
+func TestReportProblemColumns(t *testing.T) {
+	problems := []cfapi.ReportProblem{
+		{Problem: cfapi.Problem{Index: "A"}, Solved: true, SolveSeconds: 754},
+		{Problem: cfapi.Problem{Index: "B"}, RejectedAttempts: 2},
+		{Problem: cfapi.Problem{Index: "C"}},
+	}
+	var buf bytes.Buffer
+	if err := output.Render(&buf, output.FormatCSV, problems, reportProblemColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "Index,Name,Result,Time,Rejected\nA,,✓,0:12,0\nB,,✗,-,2\nC,,-,-,0\n"
+	if buf.String() != want {
+		t.Errorf("CSV = %q, want %q", buf.String(), want)
+	}
+}
+
+// submitTransport serves the Codeforces submit flow, or fails every request
+// like a dropped connection while offline
+type submitTransport struct {
+	offline bool
+	posts   int
+}
+
+func (s *submitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if s.offline {
+		return nil, fmt.Errorf("dial tcp: connection refused")
+	}
+
+	body := `<html><meta name="X-Csrf-Token" content="test-csrf"></html>`
+	switch {
+	case req.Method == http.MethodPost:
+		s.posts++
+		body = `<html>Submission recorded</html>`
+	case strings.HasSuffix(req.URL.Path, "/my"):
+		body = fmt.Sprintf(`<table class="status-frame-datatable"><tr data-submission-id="%d"><td class="id-cell">A</td>`+
+			`<td class="status-cell">In queue</td></tr></table>`, 1000+s.posts)
+	}
+	return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+}
+
+func newTestSubmitter(t *testing.T, transport http.RoundTripper) *cfweb.Submitter {
+	t.Helper()
+	session, err := cfweb.NewSessionWithCookie("JSESSIONID=test; 39ce7=test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	session.SetHandle("testuser")
+	session.Client().Transport = transport
+
+	submitter, err := cfweb.NewSubmitter(session)
+	if err != nil {
+		t.Fatalf("NewSubmitter() error = %v", err)
+	}
+	return submitter
+}
+
+func TestSubmit_QueueAndRetryPending(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	transport := &submitTransport{offline: true}
+	submitter := newTestSubmitter(t, transport)
+
+	for _, index := range []string{"A", "B"} {
+		p := workspace.PendingSubmission{ContestID: 1, Index: index, LanguageID: 54, Source: "int main() {}"}
+		_, err := sendSubmission(submitter, p)
+		if err == nil {
+			t.Fatal("sendSubmission() succeeded while offline")
+		}
+		if err := queueOnTransient(ws, p, err); !strings.Contains(err.Error(), "--retry-pending") {
+			t.Errorf("queueOnTransient() error = %v, want a retry hint", err)
+		}
+	}
+
+	// A rejected submission is reported as is and not queued
+	if err := queueOnTransient(ws, workspace.PendingSubmission{ContestID: 2, Index: "A"}, fmt.Errorf("contest is over")); err.Error() != "contest is over" {
+		t.Errorf("queueOnTransient() error = %v, want the original error", err)
+	}
+
+	pending, err := ws.PendingSubmissions()
+	if err != nil || len(pending) != 2 {
+		t.Fatalf("PendingSubmissions() = %d, %v, want 2 queued", len(pending), err)
+	}
+
+	// Still offline: everything stays queued
+	result, err := replayPendingSubmissions(ws, submitter)
+	if err != nil || result.Remaining != 2 || len(result.Sent) != 0 {
+		t.Fatalf("offline replay = %+v, %v, want 2 remaining", result, err)
+	}
+
+	transport.offline = false
+	result, err = replayPendingSubmissions(ws, submitter)
+	if err != nil {
+		t.Fatalf("replayPendingSubmissions() error = %v", err)
+	}
+	if len(result.Sent) != 2 || result.Remaining != 0 || transport.posts != 2 {
+		t.Errorf("replay = %+v with %d posts, want 2 sent", result, transport.posts)
+	}
+	if pending, _ := ws.PendingSubmissions(); len(pending) != 0 {
+		t.Errorf("%d submissions still queued after replay", len(pending))
+	}
+}
+
+func TestFindColumns(t *testing.T) {
+	problems := []cfapi.Problem{{ContestID: 1, Index: "A", Name: "Theatre Square", Rating: 1000}}
+	var buf bytes.Buffer
+	if err := output.Render(&buf, output.FormatCSV, problems, findColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "Contest,Index,Name,Rating\n1,A,Theatre Square,1000\n"
+	if buf.String() != want {
+		t.Errorf("CSV = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestParseExpiry(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if got, err := parseExpiry("2024-03-01T18:00:00Z", now); err != nil || !got.Equal(now.Add(6*time.Hour)) {
+		t.Errorf("parseExpiry(RFC 3339) = %v, %v", got, err)
+	}
+	if got, err := parseExpiry("90m", now); err != nil || !got.Equal(now.Add(90*time.Minute)) {
+		t.Errorf("parseExpiry(duration) = %v, %v", got, err)
+	}
+	for _, bad := range []string{"tomorrow", "-1h"} {
+		if _, err := parseExpiry(bad, now); err == nil {
+			t.Errorf("parseExpiry(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFormatStandingsDelta(t *testing.T) {
+	at := time.Date(2024, 3, 1, 14, 5, 0, 0, time.UTC)
+	handles := watchedHandles([]string{"tourist", "Petr", "nobody", "TOURIST"}, "tourist")
+	if strings.Join(handles, ",") != "tourist,Petr,nobody" {
+		t.Fatalf("watchedHandles() = %v", handles)
+	}
+	points := map[string]float64{"tourist": 1500, "Petr": 500}
+
+	first := formatStandingsDelta(at, handles, nil, points)
+	want := "  14:05  tourist              1500\n  14:05  Petr                 500\n  14:05  nobody               not in the standings\n"
+	if first != want {
+		t.Errorf("first poll = %q, want %q", first, want)
+	}
+
+	// Later polls only show who gained
+	later := formatStandingsDelta(at, handles, map[string]int{"tourist": 1000, "Petr": 0}, points)
+	if later != "  14:05  tourist              +1000 (1500)\n" {
+		t.Errorf("later poll = %q", later)
+	}
+}
+
+func TestProblemAttemptColumns_WrongAnswersThenAC(t *testing.T) {
+	sub := func(verdict string, at int64) cfapi.Submission {
+		return cfapi.Submission{
+			Problem:             cfapi.Problem{ContestID: 1325, Index: "B", Name: "CopyCopyCopy"},
+			Verdict:             verdict,
+			CreationTimeSeconds: at,
+		}
+	}
+	attempts := cfapi.GroupByProblem([]cfapi.Submission{
+		sub(cfapi.VerdictOK, 300),
+		sub(cfapi.VerdictWrongAnswer, 200),
+		sub(cfapi.VerdictWrongAnswer, 100),
+	})
+
+	var buf bytes.Buffer
+	if err := output.Render(&buf, output.FormatTable, attempts, problemAttemptColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "3 attempts, AC") {
+		t.Errorf("two WAs then an AC should show 3 attempts, AC, got:\n%s", got)
+	}
+
+	if userSubmissionsCmd.Flags().Lookup("by-problem") == nil {
+		t.Error("user submissions should have --by-problem flag")
+	}
+}
+
+func TestRunUserInfo_WritesToCommandOutput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.SetGlobalConfig(&config.Config{})
+	defer config.SetGlobalConfig(nil)
+
+	useAPIResponse(t, `{"status":"OK","result":[{"handle":"tourist","rating":3800,"maxRating":3979,"rank":"legendary grandmaster","maxRank":"legendary grandmaster"}]}`)
+
+	var buf bytes.Buffer
+	userInfoCmd.SetOut(&buf)
+	defer userInfoCmd.SetOut(nil)
+
+	if err := runUserInfo(userInfoCmd, []string{"tourist"}); err != nil {
+		t.Fatalf("runUserInfo() error = %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"tourist", "3800", "3979", "legendary grandmaster"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("user info output should contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunUserInfo_RecordsHandleAfterLookup(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.SetGlobalConfig(&config.Config{})
+	defer config.SetGlobalConfig(nil)
+	userInfoCmd.SetOut(io.Discard)
+	defer userInfoCmd.SetOut(nil)
+
+	t.Run("unknown handle", func(t *testing.T) {
+		useAPIResponse(t, `{"status":"FAILED","comment":"handles: User with handle ghost not found"}`)
+		if err := runUserInfo(userInfoCmd, []string{"ghost"}); err == nil {
+			t.Fatal("runUserInfo() should fail for an unknown handle")
+		}
+		if got := config.RecentHandles(); len(got) != 0 {
+			t.Errorf("RecentHandles() = %v after a failed lookup, want none", got)
+		}
+	})
+
+	// The handle is recorded as the API spells it
+	useAPIResponse(t, `{"status":"OK","result":[{"handle":"tourist","rating":3800}]}`)
+	if err := runUserInfo(userInfoCmd, []string{"TOURIST"}); err != nil {
+		t.Fatalf("runUserInfo() error = %v", err)
+	}
+	if got := config.RecentHandles(); strings.Join(got, ",") != "tourist" {
+		t.Errorf("RecentHandles() = %v, want [tourist]", got)
+	}
+}
+
+func TestRunUserRating_UsesInjectedClient(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.SetGlobalConfig(&config.Config{})
+	defer config.SetGlobalConfig(nil)
+
+	useAPIResponse(t, `{"status":"OK","result":[`+
+		`{"contestId":1,"contestName":"Round 1","handle":"mocked","rank":10,"oldRating":1500,"newRating":1620},`+
+		`{"contestId":2,"contestName":"Round 2","handle":"mocked","rank":50,"oldRating":1620,"newRating":1580}]}`)
+
+	var buf bytes.Buffer
+	userRatingCmd.SetOut(&buf)
+	defer userRatingCmd.SetOut(nil)
+
+	if err := runUserRating(userRatingCmd, []string{"mocked"}); err != nil {
+		t.Fatalf("runUserRating() error = %v", err)
+	}
+
+	got := buf.String()
+	if !strings.Contains(got, "Rating history for mocked (2 contests)") || !strings.Contains(got, "+80") {
+		t.Errorf("rating output should come from the injected client, got:\n%s", got)
+	}
+}
+
+func TestSubmitLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		lang     int
+		fallback string
+		want     int
+	}{
+		{"explicit lang wins", "main.cpp", 91, "cpp20", 91},
+		{"explicit lang for unknown file", "notes.txt", 31, "", 31},
+		{"unambiguous extension", "main.go", 0, "cpp20", 32},
+		{"ambiguous extension uses default", "main.cpp", 0, "C++20", 89},
+		{"unknown extension uses default", "solution.txt", 0, "pypy3", 70},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := submitLanguage(tt.file, tt.lang, tt.fallback)
+			if err != nil {
+				t.Fatalf("submitLanguage() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("submitLanguage(%s, %d, %q) = %d, want %d", tt.file, tt.lang, tt.fallback, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubmitLanguage_Undetermined(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		fallback string
+	}{
+		{"ambiguous without default", "main.cpp", ""},
+		{"default of another extension", "main.py", "cpp20"},
+		{"unknown extension without default", "solution.txt", ""},
+		{"invalid default", "solution.txt", "cobol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := submitLanguage(tt.file, 0, tt.fallback); err == nil {
+				t.Errorf("submitLanguage(%s, 0, %q) should fail", tt.file, tt.fallback)
+			}
+		})
+	}
+}
+
+func TestSubmitNotification(t *testing.T) {
+	accepted := &cfweb.SubmissionResult{SubmissionID: 7, Verdict: "Accepted", Outcome: cfweb.VerdictAccepted, Time: 46 * time.Millisecond, Memory: 4096}
+	title, message := submitNotification("1325A", accepted)
+	if title != "1325A: Accepted" || message != "46 ms, 4 KB" {
+		t.Errorf("accepted notification = %q, %q", title, message)
+	}
+
+	rejected := &cfweb.SubmissionResult{SubmissionID: 8, Verdict: "Wrong answer on test 3", Outcome: cfweb.VerdictWrongAnswer}
+	title, message = submitNotification("1325A", rejected)
+	if title != "1325A: Wrong answer on test 3" || message != "submission 8" {
+		t.Errorf("rejected notification = %q, %q", title, message)
+	}
+
+	if submitCmd.Flags().Lookup("notify") == nil {
+		t.Error("submit should have a --notify flag")
+	}
+}
+
+func TestFixAuditedProblem_Metadata(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	p := v1.NewProblem(1, "A", "Theatre Square")
+	p.Samples = []v1.Sample{{Index: 1, Input: "6 6 4", Output: "4"}}
+	p.Practice.Status = v1.StatusSolved
+	if err := ws.SaveProblem(p); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+	if err := ws.SaveStatement(p, "Pave the square."); err != nil {
+		t.Fatalf("SaveStatement() error = %v", err)
+	}
+
+	_, incomplete, err := ws.AuditProblems()
+	if err != nil || len(incomplete) != 1 || incomplete[0].NeedsScrape() {
+		t.Fatalf("AuditProblems() = %v, %v, want one problem missing metadata", incomplete, err)
+	}
+
+	client := cfapi.NewClient(cfapi.WithHTTPClient(&http.Client{Transport: &apiTransport{
+		body: `{"status":"OK","result":{"problems":[{"contestId":1,"index":"A","name":"Theatre Square","rating":1000,"tags":["math"]}],"problemStatistics":[]}}`,
+	}}))
+	if err := fixAuditedProblem(context.Background(), ws, client, nil, incomplete[0]); err != nil {
+		t.Fatalf("fixAuditedProblem() error = %v", err)
+	}
+
+	loaded, err := ws.LoadProblem("codeforces", 1, "A")
+	if err != nil {
+		t.Fatalf("LoadProblem() error = %v", err)
+	}
+	if loaded.Metadata.Rating != 1000 || !slices.Equal(loaded.Metadata.Tags, []string{"math"}) {
+		t.Errorf("Metadata = %+v, want rating and tags from the API", loaded.Metadata)
+	}
+	if loaded.Practice.Status != v1.StatusSolved || len(loaded.Samples) != 1 {
+		t.Errorf("fixing metadata lost practice or samples: %+v", loaded)
+	}
+
+	if _, incomplete, _ := ws.AuditProblems(); len(incomplete) != 0 {
+		t.Errorf("AuditProblems() after fix = %v, want none", incomplete)
+	}
+}
+
+func TestFormatStatus(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	rated := &statusSnapshot{
+		Handle:      "tourist",
+		Rating:      3800,
+		NextContest: "Educational Codeforces Round 170 (Rated for Div. 2)",
+		NextStart:   now.Add(2*time.Hour + 10*time.Minute),
+	}
+
+	tests := []struct {
+		name   string
+		handle string
+		snap   *statusSnapshot
+		streak int
+		want   string
+	}{
+		{"full", "tourist", rated, 12, "@tourist 3800 (LGM) • streak 12 • next: Edu Round 170 in 2h"},
+		{"no streak", "tourist", rated, 0, "@tourist 3800 (LGM) • next: Edu Round 170 in 2h"},
+		{"unrated", "newbie", &statusSnapshot{Handle: "newbie"}, 1, "@newbie • streak 1"},
+		{"contest started", "tourist", &statusSnapshot{Handle: "tourist", Rating: 1500, NextContest: "Codeforces Round 900", NextStart: now.Add(-time.Minute)}, 0, "@tourist 1500 (S)"},
+		{"offline", "tourist", nil, 3, "@tourist • streak 3"},
+		{"no handle", "", nil, 0, "cf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatStatus(tt.handle, tt.snap, tt.streak, now); got != tt.want {
+				t.Errorf("formatStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// offlineTransport fails every request like an unreachable API
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("dial tcp: network is unreachable")
+}
+
+func TestCurrentStatus_Offline(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	orig := newAPIClient
+	newAPIClient = func(opts ...cfapi.ClientOption) *cfapi.Client {
+		return orig(append(opts, cfapi.WithHTTPClient(&http.Client{Transport: offlineTransport{}}))...)
+	}
+	t.Cleanup(func() { newAPIClient = orig })
+
+	if snap := currentStatus("tourist"); snap != nil {
+		t.Fatalf("currentStatus() = %+v, want nil without a cache", snap)
+	}
+	if got := formatStatus("tourist", nil, 0, time.Now()); got != "@tourist" {
+		t.Errorf("formatStatus() offline = %q, want %q", got, "@tourist")
+	}
+
+	// A stale cached status is better than none
+	saveStatusSnapshot(&statusSnapshot{Handle: "tourist", Rating: 3800, FetchedAt: time.Now().Add(-time.Hour)})
+	snap := currentStatus("Tourist")
+	if snap == nil || snap.Rating != 3800 {
+		t.Fatalf("currentStatus() = %+v, want the stale cache", snap)
+	}
+	if got := formatStatus("tourist", snap, 0, time.Now()); got != "@tourist 3800 (LGM)" {
+		t.Errorf("formatStatus() = %q", got)
+	}
+}
+
+func TestCurrentStatus_UsesFreshCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	useAPIResponse(t, `{"status":"OK","result":[{"handle":"tourist","rating":3900}]}`)
+
+	saveStatusSnapshot(&statusSnapshot{Handle: "tourist", Rating: 3800, FetchedAt: time.Now()})
+	if snap := currentStatus("tourist"); snap == nil || snap.Rating != 3800 {
+		t.Errorf("currentStatus() = %+v, want the fresh cache without a request", snap)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"24h", now.Add(-24 * time.Hour)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)},
+		{"2024-03-01T18:00:00Z", time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if err != nil {
+			t.Errorf("parseSince(%q) error = %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "yesterday", "-2h", "0d"} {
+		if _, err := parseSince(bad, now); err == nil {
+			t.Errorf("parseSince(%q) should fail", bad)
+		}
+	}
+}
+
+func TestRunUserSubmissions_Since(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.SetGlobalConfig(&config.Config{})
+	defer config.SetGlobalConfig(nil)
+
+	now := time.Now()
+	submission := func(id int, ago time.Duration, name, verdict string) string {
+		return fmt.Sprintf(`{"id":%d,"creationTimeSeconds":%d,"problem":{"contestId":1,"index":"A","name":%q},"verdict":%q}`,
+			id, now.Add(-ago).Unix(), name, verdict)
+	}
+	useAPIResponse(t, `{"status":"OK","result":[`+
+		submission(3, time.Hour, "Newest", "OK")+","+
+		submission(2, 2*time.Hour, "Earlier", "WRONG_ANSWER")+","+
+		submission(1, 48*time.Hour, "Ancient", "OK")+`]}`)
+
+	origSince, origLimit, origVerdict := submissionsSince, submissionsLimit, submissionsVerdict
+	defer func() { submissionsSince, submissionsLimit, submissionsVerdict = origSince, origLimit, origVerdict }()
+
+	tests := []struct {
+		name    string
+		limit   int
+		verdict string
+		want    []string
+		notWant []string
+	}{
+		{"cutoff excludes older", 10, "", []string{"Newest", "Earlier"}, []string{"Ancient"}},
+		{"limit caps the window", 1, "", []string{"Newest"}, []string{"Earlier", "Ancient"}},
+		{"verdict within the window", 10, "OK", []string{"Newest"}, []string{"Earlier", "Ancient"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submissionsSince, submissionsLimit, submissionsVerdict = "24h", tt.limit, tt.verdict
+
+			var buf bytes.Buffer
+			userSubmissionsCmd.SetOut(&buf)
+			defer userSubmissionsCmd.SetOut(nil)
+
+			if err := runUserSubmissions(userSubmissionsCmd, []string{"mocked"}); err != nil {
+				t.Fatalf("runUserSubmissions() error = %v", err)
+			}
+			got := buf.String()
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("output should list %q, got:\n%s", w, got)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(got, w) {
+					t.Errorf("output should not list %q, got:\n%s", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRunWorkspaceStats(t *testing.T) {
+	tmpDir := t.TempDir()
+	config.SetGlobalConfig(&config.Config{WorkspacePath: tmpDir})
+	defer config.SetGlobalConfig(nil)
+
+	ws := workspace.New(tmpDir)
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	workspaceStatsCmd.SetOut(&buf)
+	defer workspaceStatsCmd.SetOut(nil)
+
+	if err := runWorkspaceStats(workspaceStatsCmd, nil); err != nil {
+		t.Fatalf("runWorkspaceStats() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "No problems in the workspace") {
+		t.Errorf("empty workspace output = %q", buf.String())
+	}
+
+	solved := v1.NewProblem(1, "A", "Solved")
+	solved.Metadata = v1.ProblemMetadata{Rating: 1300, Tags: []string{"dp"}}
+	solved.Practice = v1.PracticeData{Status: v1.StatusSolved, TimeSpent: 5400}
+	todo := v1.NewProblem(2, "B", "Todo")
+	todo.Practice.Status = v1.StatusTodo
+	for _, p := range []*v1.Problem{solved, todo} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+
+	buf.Reset()
+	if err := runWorkspaceStats(workspaceStatsCmd, nil); err != nil {
+		t.Fatalf("runWorkspaceStats() error = %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{"2 problems", "Solved:     1", "Todo:       1", "1h 30m", "1200-1399", "dp"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("workspace stats output should contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestBucketStart(t *testing.T) {
+	for bucket, want := range map[string]int{"800-999": 800, "1200-1399": 1200, "2400+": 2400} {
+		if got := bucketStart(bucket); got != want {
+			t.Errorf("bucketStart(%q) = %d, want %d", bucket, got, want)
+		}
+	}
+}
+
+// stalledTransport never answers, returning only once the request's
+// context ends
+type stalledTransport struct{}
+
+func (stalledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestCommandContext_Defaults(t *testing.T) {
+	origTimeout := commandTimeout
+	defer func() { commandTimeout = origTimeout }()
+
+	commandTimeout = 0
+	ctx, cancel := commandContext(bulkTimeout)
+	deadline, _ := ctx.Deadline()
+	cancel()
+	if time.Until(deadline) < bulkTimeout-time.Minute {
+		t.Errorf("bulk commands should get %v, deadline in %v", bulkTimeout, time.Until(deadline))
+	}
+
+	// A shorter --timeout wins over even the bulk default
+	commandTimeout = time.Second
+	ctx, cancel = commandContext(bulkTimeout)
+	deadline, _ = ctx.Deadline()
+	cancel()
+	if time.Until(deadline) > time.Second {
+		t.Errorf("--timeout 1s should override %v, deadline in %v", bulkTimeout, time.Until(deadline))
+	}
+}
+
+func TestRunUserInfo_TimeoutCancels(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config.SetGlobalConfig(&config.Config{})
+	defer config.SetGlobalConfig(nil)
+
+	orig := newAPIClient
+	newAPIClient = func(opts ...cfapi.ClientOption) *cfapi.Client {
+		return orig(append(opts, cfapi.WithHTTPClient(&http.Client{Transport: stalledTransport{}}))...)
+	}
+	defer func() { newAPIClient = orig }()
+
+	origTimeout := commandTimeout
+	defer func() { commandTimeout = origTimeout }()
+	commandTimeout = 20 * time.Millisecond
+
+	done := make(chan error, 1)
+	go func() { done <- runUserInfo(userInfoCmd, []string{"tourist"}) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("runUserInfo() error = %v, want DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("--timeout should cancel the stalled request")
+	}
+}
+
+func TestRegisteredContests(t *testing.T) {
+	useAPIResponse(t, `{"status":"OK","result":{"contest":{"id":1},"problems":[],"rows":[{"party":{"members":[{"handle":"me"}]}}]}}`)
+	orig := config.Get()
+	defer config.SetGlobalConfig(orig)
+	config.SetGlobalConfig(&config.Config{CFHandle: "me"})
+
+	contests := []cfapi.Contest{
+		{ID: 1, Phase: cfapi.PhaseCoding},
+		{ID: 2, Phase: cfapi.PhaseBefore},
+		{ID: 3, Phase: cfapi.PhaseFinished},
+	}
+	got, err := registeredContests(context.Background(), getAPIClient(), contests, "me")
+	if err != nil {
+		t.Fatalf("registeredContests() error = %v", err)
+	}
+	// Without a cookie the upcoming contest cannot be checked
+	if len(got) != 1 || !got[1] {
+		t.Errorf("registeredContests() = %v, want only the running contest", got)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solved.txt")
+	if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed write keeps the previous file and leaves no temporary file
+	err := writeFileAtomic(path, func(w io.Writer) error {
+		fmt.Fprintln(w, "partial")
+		return fmt.Errorf("fetch failed")
+	})
+	if err == nil || err.Error() != "fetch failed" {
+		t.Fatalf("writeFileAtomic() error = %v, want the write error", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old\n" {
+		t.Errorf("file = %q after a failed write, want it unchanged", data)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the export", len(entries))
+	}
+
+	if err := writeFileAtomic(path, func(w io.Writer) error {
+		_, err := fmt.Fprintln(w, "1325/A")
+		return err
+	}); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "1325/A\n" {
+		t.Errorf("file = %q, want the new export", data)
+	}
+}
+
+func TestRunLeaderboard_MissingHandlesOnStderr(t *testing.T) {
+	useAPIResponse(t, `{"status":"OK","result":[{"handle":"tourist","rating":3800}]}`)
+	old := outputFormat
+	defer func() { outputFormat = old }()
+	outputFormat = output.FormatJSON
+
+	roster := filepath.Join(t.TempDir(), "team.txt")
+	if err := os.WriteFile(roster, []byte("tourist\nbad!handle\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	leaderboardCmd.SetOut(&stdout)
+	leaderboardCmd.SetErr(&stderr)
+	defer leaderboardCmd.SetOut(nil)
+	defer leaderboardCmd.SetErr(nil)
+
+	if err := runLeaderboard(leaderboardCmd, []string{roster}); err != nil {
+		t.Fatalf("runLeaderboard() error = %v", err)
+	}
+	if !strings.Contains(stderr.String(), "Handle not found: bad!handle") {
+		t.Errorf("stderr = %q, want the missing handle", stderr.String())
+	}
+	var rows []map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &rows); err != nil || len(rows) != 1 {
+		t.Errorf("stdout = %q, want a JSON array of one row (%v)", stdout.String(), err)
+	}
+}
+
+func TestParseBrowserCookies(t *testing.T) {
+	expires := time.Date(2026, 10, 21, 7, 28, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{
+			"document.cookie with an Expires note",
+			"JSESSIONID=8F3A; 39ce7=CFx1; cf_clearance=Qk.9-1700000000-1.2.1.1; evercookie_png=abc\nExpires: Wed, 21 Oct 2026 07:28:00 GMT\n",
+		},
+		{
+			"Set-Cookie lines",
+			"Set-Cookie: JSESSIONID=8F3A; Path=/; HttpOnly\r\n" +
+				"Set-Cookie: 39ce7=CFx1; Domain=codeforces.com\r\n" +
+				"Set-Cookie: cf_clearance=Qk.9-1700000000-1.2.1.1; Expires=Wed, 21-Oct-2026 07:28:00 GMT; Domain=.codeforces.com; Secure\r\n",
+		},
+		{
+			"devtools cookie table",
+			"JSESSIONID\t8F3A\tcodeforces.com\t/\tSession\t42\n" +
+				"39ce7\tCFx1\tcodeforces.com\t/\t2027-01-01T00:00:00.000Z\t13\n" +
+				"cf_clearance\tQk.9-1700000000-1.2.1.1\t.codeforces.com\t/\t2026-10-21T07:28:00.000Z\t300\t✓\t✓\tNone\n" +
+				"_ga\tGA1.2\t.google.com\t/\t2027-01-01T00:00:00.000Z\t30\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds, err := parseBrowserCookies(tt.raw, time.Time{})
+			if err != nil {
+				t.Fatalf("parseBrowserCookies() error = %v", err)
+			}
+			for name, want := range map[string]string{
+				config.CookieSession:     "8F3A",
+				config.CookieCE7:         "CFx1",
+				config.CookieCFClearance: "Qk.9-1700000000-1.2.1.1",
+			} {
+				if got := config.CookieValue(creds.Cookie, name); got != want {
+					t.Errorf("%s = %q, want %q (cookie %q)", name, got, want, creds.Cookie)
+				}
+			}
+			if !creds.CFClearanceExpires.Equal(expires) {
+				t.Errorf("CFClearanceExpires = %v, want %v", creds.CFClearanceExpires, expires)
+			}
+		})
+	}
+}
+
+func TestParseBrowserCookies_ExpiryGivenOrMaxAge(t *testing.T) {
+	given := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	raw := "cf_clearance=abc; Max-Age=3600; Path=/"
+
+	creds, err := parseBrowserCookies(raw, given)
+	if err != nil {
+		t.Fatalf("parseBrowserCookies() error = %v", err)
+	}
+	if !creds.CFClearanceExpires.Equal(given) {
+		t.Errorf("CFClearanceExpires = %v, want the given expiry %v", creds.CFClearanceExpires, given)
+	}
+
+	creds, err = parseBrowserCookies(raw, time.Time{})
+	if err != nil {
+		t.Fatalf("parseBrowserCookies() error = %v", err)
+	}
+	if left := time.Until(creds.CFClearanceExpires); left < 59*time.Minute || left > time.Hour {
+		t.Errorf("CFClearanceExpires in %v, want about an hour from Max-Age", left)
+	}
+}
+
+func TestParseBrowserCookies_NoCodeforcesCookies(t *testing.T) {
+	if _, err := parseBrowserCookies("_ga=GA1.2; theme=dark", time.Time{}); err == nil {
+		t.Error("parseBrowserCookies() should fail without Codeforces cookies")
+	}
+
+	creds, err := parseBrowserCookies("JSESSIONID=abc\nExpires: 2026-10-21T07:28:00Z", time.Time{})
+	if err != nil {
+		t.Fatalf("parseBrowserCookies() error = %v", err)
+	}
+	if !creds.CFClearanceExpires.IsZero() {
+		t.Errorf("CFClearanceExpires = %v, want none without cf_clearance", creds.CFClearanceExpires)
+	}
+}
+
+func TestCollectCredentials_SanitizesCookie(t *testing.T) {
+	flags := config.Credentials{Handle: "tourist", Cookie: " JSESSIONID=abc; Path=/; HttpOnly\n cf_clearance=xyz; Secure ", CFClearanceUA: "UA"}
+	creds, err := collectCredentials(strings.NewReader(""), io.Discard, flags, config.Credentials{}, false)
+	if err != nil {
+		t.Fatalf("collectCredentials() error = %v", err)
+	}
+	if creds.Cookie != "JSESSIONID=abc; cf_clearance=xyz" {
+		t.Errorf("Cookie = %q, want the attributes stripped", creds.Cookie)
+	}
+}
+
+func TestConfigHelp_KeysAligned(t *testing.T) {
+	for _, c := range []*cobra.Command{configGetCmd, configSetCmd} {
+		_, keys, _ := strings.Cut(c.Long, "Available keys:\n")
+		keys, _, _ = strings.Cut(keys, "\n\n")
+
+		column := -1
+		for _, line := range strings.Split(keys, "\n") {
+			i := strings.Index(line, " - ")
+			if strings.TrimSpace(line[:max(i, 0)]) == "" {
+				continue // Continuation of the previous description
+			}
+			if column == -1 {
+				column = i
+			}
+			if i != column {
+				t.Errorf("%s help: %q has its dash at column %d, want %d", c.Name(), line, i, column)
+			}
+		}
+	}
+}
+
+func TestRunConfigSet_UnknownLanguage(t *testing.T) {
+	orig := config.Get()
+	defer config.SetGlobalConfig(orig)
+	config.SetGlobalConfig(&config.Config{DefaultLanguage: "cpp20"})
+
+	// Languages are resolved before anything is saved
+	if err := runConfigSet(configSetCmd, []string{"default_language", "Brainfuck"}); err == nil {
+		t.Error("runConfigSet() should reject unknown languages")
+	}
+	if got := config.GetDefaultLanguage(); got != "cpp20" {
+		t.Errorf("GetDefaultLanguage() = %q after a rejected value, want cpp20", got)
+	}
+}
+
+func TestCompilerCommands(t *testing.T) {
+	orig := config.Get()
+	defer config.SetGlobalConfig(orig)
+
+	// A known language keeps its default run command
+	config.SetGlobalConfig(&config.Config{Compilers: map[string]config.Compiler{"py": {Compile: ""}}})
+	if _, err := compilerCommands(); err != nil {
+		t.Errorf("compilerCommands() error = %v, want py to keep its default run", err)
+	}
+
+	config.SetGlobalConfig(&config.Config{Compilers: map[string]config.Compiler{"hs": {Compile: "ghc -o {bin} {src}"}}})
+	if _, err := compilerCommands(); err == nil {
+		t.Error("compilerCommands() should reject a new language without a run command")
+	}
+
+	config.SetGlobalConfig(&config.Config{Compilers: map[string]config.Compiler{
+		"cpp": {Compile: "clang++ -O2 -o {bin} {source}", Run: "{bin}"},
+	}})
+	if _, err := compilerCommands(); err == nil || !strings.Contains(err.Error(), "{source}") {
+		t.Errorf("compilerCommands() error = %v, want the unknown placeholder", err)
+	}
 }
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+)
+
+// selftestEnv enables the live self-tests without the --live flag, for CI
+const selftestEnv = "CF_SELFTEST"
+
+var (
+	// selftest flags
+	selftestLive    bool
+	selftestContest int
+)
+
+var selftestCmd = &cobra.Command{
+	Use:    "selftest",
+	Short:  "Maintenance checks against live Codeforces",
+	Hidden: true,
+}
+
+var selftestParseCmd = &cobra.Command{
+	Use:   "parse",
+	Short: "Check that the page selectors still match Codeforces",
+	Long: `Fetch a problem page and a submit page from Codeforces and report the
+selectors that no longer match, so DOM changes are caught before users hit them.
+
+The checks make live requests, so they only run with --live or with
+CF_SELFTEST=1 in the environment. The submit page check needs a cookie and
+handle from 'cf setup' and is skipped without them.
+
+Exits non-zero if any check fails.
+
+Examples:
+  cf selftest parse --live
+  CF_SELFTEST=1 cf selftest parse --contest 1325`,
+	Args: cobra.NoArgs,
+	RunE: runSelftestParse,
+}
+
+func init() {
+	selftestCmd.AddCommand(selftestParseCmd)
+
+	selftestParseCmd.Flags().BoolVar(&selftestLive, "live", false, "Run the checks against live Codeforces")
+	selftestParseCmd.Flags().IntVar(&selftestContest, "contest", 1, "Contest whose submit page is checked")
+}
+
+// selftestCheck is one live structure check; a nil run means it was skipped
+type selftestCheck struct {
+	name string
+	skip string
+	run  func() error
+}
+
+func runSelftestParse(cmd *cobra.Command, args []string) error {
+	fmt.Printf("Selector version: %s (%s)\n\n", cfweb.CurrentVersion.Version, cfweb.CurrentVersion.Description)
+
+	if !selftestEnabled(selftestLive, os.Getenv(selftestEnv)) {
+		return fmt.Errorf("live self-test disabled: pass --live or set %s=1", selftestEnv)
+	}
+
+	return runSelftestChecks(selftestParseChecks(selftestContest))
+}
+
+// selftestEnabled returns true if the live checks were asked for by flag or env
+func selftestEnabled(live bool, env string) bool {
+	switch env {
+	case "1", "true", "yes":
+		return true
+	}
+	return live
+}
+
+// selftestParseChecks returns the problem page and submit page checks
+func selftestParseChecks(contestID int) []selftestCheck {
+	session := newWebSession()
+
+	parser := cfweb.NewParserWithClient(nil)
+	if session != nil {
+		parser = cfweb.NewParser(session)
+	}
+
+	checks := []selftestCheck{
+		{name: "Problem page", run: parser.VerifyPageStructure},
+	}
+
+	submit := selftestCheck{name: fmt.Sprintf("Submit page (contest %d)", contestID)}
+	if session == nil || !session.IsReadyForSubmission() {
+		submit.skip = "needs a cookie and handle, run 'cf setup'"
+	} else if submitter, err := cfweb.NewSubmitter(session); err != nil {
+		submit.skip = err.Error()
+	} else {
+		submit.run = func() error { return submitter.VerifySubmitPage(contestID) }
+	}
+
+	return append(checks, submit)
+}
+
+// runSelftestChecks runs each check, prints its result and returns an error
+// if any failed
+func runSelftestChecks(checks []selftestCheck) error {
+	failed := 0
+	for _, c := range checks {
+		if c.run == nil {
+			fmt.Printf("  - %s: skipped (%s)\n", c.name, c.skip)
+			continue
+		}
+		if err := c.run(); err != nil {
+			fmt.Printf("  ✗ %s: %v\n", c.name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  ✓ %s\n", c.name)
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d of %d selector checks failed (selector version: %s)",
+			failed, len(checks), cfweb.CurrentVersion.Version)
+	}
+	fmt.Println("✓ Selectors match")
+	return nil
+}
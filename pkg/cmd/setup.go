@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+)
+
+var (
+	setupHandle         string
+	setupAPIKey         string
+	setupAPISecret      string
+	setupCookie         string
+	setupUserAgent      string
+	setupCookieExpires  string
+	setupNonInteractive bool
+	setupSkipValidation bool
+)
+
+var setupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Set up your Codeforces account",
+	Long: `Interactively configure your handle, optional API key and browser cookie.
+
+Credentials are validated against Codeforces before they are saved to
+~/.cf/config.yaml. Values passed as flags are not prompted for; use
+--non-interactive to skip all prompts in scripts.
+
+Examples:
+  cf setup
+  cf setup --handle tourist --non-interactive
+  cf setup --handle tourist --cookie 'JSESSIONID=xxx; 39ce7=xxx' --non-interactive`,
+	Args: cobra.NoArgs,
+	RunE: runSetup,
+}
+
+func init() {
+	setupCmd.Flags().StringVar(&setupHandle, "handle", "", "Codeforces handle")
+	setupCmd.Flags().StringVar(&setupAPIKey, "api-key", "", "API key from codeforces.com/settings/api")
+	setupCmd.Flags().StringVar(&setupAPISecret, "api-secret", "", "API secret")
+	setupCmd.Flags().StringVar(&setupCookie, "cookie", "", "Browser cookie string")
+	setupCmd.Flags().StringVar(&setupUserAgent, "user-agent", "", "User-Agent of the browser the cookie came from")
+	setupCmd.Flags().StringVar(&setupCookieExpires, "cf-clearance-expires", "", "When cf_clearance expires, as RFC 3339 or a duration from now")
+	setupCmd.Flags().BoolVar(&setupNonInteractive, "non-interactive", false, "Do not prompt; use flags and existing config only")
+	setupCmd.Flags().BoolVar(&setupSkipValidation, "skip-validation", false, "Save without checking credentials against Codeforces")
+}
+
+const cookieInstructions = `
+  To copy your browser cookie:
+    1. Log in to codeforces.com
+    2. Open Developer Tools (F12) and go to the Network tab
+    3. Reload the page and select the request to codeforces.com
+    4. Copy the whole "Cookie" request header
+  It should contain JSESSIONID, 39ce7 and cf_clearance. Rows copied from
+  the Application > Cookies table work too, and carry cf_clearance's expiry.
+`
+
+const userAgentInstructions = `
+  cf_clearance only works with the browser that received it. Copy the
+  "User-Agent" request header from the same request.
+`
+
+func runSetup(cmd *cobra.Command, args []string) error {
+	current := config.Credentials{}
+	if cfg := config.Get(); cfg != nil {
+		current = config.Credentials{
+			Handle:        cfg.CFHandle,
+			APIKey:        cfg.APIKey,
+			APISecret:     cfg.APISecret,
+			Cookie:        cfg.Cookie,
+			CFClearanceUA: cfg.CFClearanceUA,
+		}
+	}
+
+	flags := config.Credentials{
+		Handle:        setupHandle,
+		APIKey:        setupAPIKey,
+		APISecret:     setupAPISecret,
+		Cookie:        setupCookie,
+		CFClearanceUA: setupUserAgent,
+	}
+	if setupCookieExpires != "" {
+		expires, err := parseExpiry(setupCookieExpires, time.Now())
+		if err != nil {
+			return err
+		}
+		flags.CFClearanceExpires = expires
+	}
+
+	fmt.Println("\n🔧 cf setup")
+	fmt.Println(strings.Repeat("─", 40))
+
+	creds, err := collectCredentials(cmd.InOrStdin(), cmd.OutOrStdout(), flags, current, !setupNonInteractive)
+	if err != nil {
+		return err
+	}
+
+	if !setupSkipValidation {
+		ctx, cancel := commandContext(fetchTimeout)
+		defer cancel()
+
+		if err := validateCredentials(ctx, creds); err != nil {
+			return err
+		}
+	}
+
+	if err := config.SaveCredentials(creds); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n✓ Saved credentials for %s\n", creds.Handle)
+	printCookieStatus(creds.Cookie)
+	return nil
+}
+
+// collectCredentials fills in credentials from flags, then prompts, then the
+// current config. Without interactive, missing values keep their current value.
+func collectCredentials(r io.Reader, w io.Writer, flags, current config.Credentials, interactive bool) (config.Credentials, error) {
+	in := bufio.NewReader(r)
+	ask := func(value, label, fallback string, secret bool) string {
+		if value != "" || !interactive {
+			if value == "" {
+				return fallback
+			}
+			return value
+		}
+		return promptValue(in, w, label, fallback, secret)
+	}
+
+	creds := config.Credentials{}
+
+	creds.Handle = ask(flags.Handle, "Codeforces handle", current.Handle, false)
+	if creds.Handle == "" {
+		return creds, fmt.Errorf("a handle is required (use --handle)")
+	}
+
+	creds.APIKey = ask(flags.APIKey, "API key (optional, from codeforces.com/settings/api)", current.APIKey, true)
+	if creds.APIKey != "" {
+		creds.APISecret = ask(flags.APISecret, "API secret", current.APISecret, true)
+		if creds.APISecret == "" {
+			return creds, fmt.Errorf("an API secret is required with an API key (use --api-secret)")
+		}
+	}
+
+	if flags.Cookie == "" && interactive {
+		fmt.Fprint(w, cookieInstructions)
+	}
+	if cookie := ask(flags.Cookie, "Cookie (optional)", current.Cookie, true); cookie != "" {
+		parsed, err := parseBrowserCookies(cookie, flags.CFClearanceExpires)
+		if err != nil {
+			return creds, err
+		}
+		creds.Cookie = parsed.Cookie
+		creds.CFClearanceExpires = parsed.CFClearanceExpires
+	}
+
+	if config.CookieValue(creds.Cookie, config.CookieCFClearance) != "" {
+		if flags.CFClearanceUA == "" && interactive {
+			fmt.Fprint(w, userAgentInstructions)
+		}
+		creds.CFClearanceUA = ask(flags.CFClearanceUA, "Browser User-Agent", current.CFClearanceUA, false)
+	}
+
+	return creds, nil
+}
+
+// promptValue asks for a value, returning fallback on an empty answer
+// Secret fallbacks are masked in the prompt
+func promptValue(in *bufio.Reader, w io.Writer, label, fallback string, secret bool) string {
+	if fallback != "" {
+		shown := fallback
+		if secret {
+			shown = maskSecret(fallback)
+		}
+		fmt.Fprintf(w, "%s [%s]: ", label, shown)
+	} else {
+		fmt.Fprintf(w, "%s: ", label)
+	}
+
+	answer, _ := in.ReadString('\n')
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer
+	}
+	return fallback
+}
+
+// validateCredentials checks the handle, API key and cookie against Codeforces
+func validateCredentials(ctx context.Context, creds config.Credentials) error {
+	fmt.Println("\nValidating...")
+
+	client := newAPIClient(cfapi.WithUserAgent(userAgent()))
+	if _, err := client.GetUserInfo(ctx, []string{creds.Handle}); err != nil {
+		return fmt.Errorf("handle %s could not be verified: %w", creds.Handle, err)
+	}
+	fmt.Printf("  ✓ Handle %s found\n", creds.Handle)
+
+	if creds.APIKey != "" {
+		signed := newAPIClient(cfapi.WithUserAgent(userAgent()), cfapi.WithAPIKey(creds.APIKey, creds.APISecret))
+		if _, err := signed.GetUserInfo(ctx, []string{creds.Handle}); err != nil {
+			return fmt.Errorf("API key rejected: %w", err)
+		}
+		fmt.Println("  ✓ API key accepted")
+	}
+
+	if creds.Cookie != "" {
+		session, err := cfweb.NewSessionWithCookie(creds.Cookie, cfweb.WithUserAgent(creds.CFClearanceUA))
+		if err != nil {
+			return err
+		}
+		if err := session.Validate(); err != nil {
+			return fmt.Errorf("cookie rejected (copy a fresh one from your browser): %w", err)
+		}
+		fmt.Println("  ✓ Cookie logs in")
+	}
+
+	return nil
+}
+
+// printCookieStatus reports which Codeforces cookies were provided
+func printCookieStatus(cookie string) {
+	if cookie == "" {
+		fmt.Println("  No cookie set; web features that need a login are unavailable.")
+		return
+	}
+
+	for _, name := range []string{config.CookieSession, config.CookieCE7, config.CookieCFClearance} {
+		icon := "✓"
+		if config.CookieValue(cookie, name) == "" {
+			icon = "✗"
+		}
+		fmt.Printf("  %s %s\n", icon, name)
+	}
+	if _, known := config.GetCFClearanceExpires(); known {
+		fmt.Printf("  %s; run 'cf setup' again before then.\n", config.GetCFClearanceStatus())
+		return
+	}
+	fmt.Println("  cf_clearance expires periodically; run 'cf setup' again if requests start failing.")
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -10,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
 )
 
 var statsCmd = &cobra.Command{
@@ -21,13 +21,17 @@ Shows solved problems by rating, tags, and recent activity.
 
 Examples:
   cf stats           # Your statistics
-  cf stats tourist   # tourist's statistics`,
+  cf stats tourist   # tourist's statistics
+  cf stats --raw-languages  # Split languages by compiler`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runStats,
 }
 
+var statsRawLanguages bool
+
 func init() {
-	// Stats is a top-level command, no subcommands
+	statsCmd.ValidArgsFunction = completeHandles
+	statsCmd.Flags().BoolVar(&statsRawLanguages, "raw-languages", false, "Show compiler names instead of language families")
 }
 
 func runStats(cmd *cobra.Command, args []string) error {
@@ -36,7 +40,7 @@ func runStats(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := commandContext(queryTimeout)
 	defer cancel()
 
 	client := getAPIClient()
@@ -44,21 +48,23 @@ func runStats(cmd *cobra.Command, args []string) error {
 	// Get user info
 	users, err := client.GetUserInfo(ctx, []string{handle})
 	if err != nil {
-		return fmt.Errorf("failed to get user info: %w", err)
+		return explainAPIError(fmt.Errorf("failed to get user info: %w", err))
 	}
 	if len(users) == 0 {
 		return fmt.Errorf("user %s not found", handle)
 	}
 	user := users[0]
+	recordHandle(args, user.Handle)
 
 	// Get submissions
 	submissions, err := client.GetUserSubmissions(ctx, handle, 1, 10000)
 	if err != nil {
-		return fmt.Errorf("failed to get submissions: %w", err)
+		return explainAPIError(fmt.Errorf("failed to get submissions: %w", err))
 	}
 
 	// Calculate stats
 	stats := calculateStats(submissions)
+	stats.ByLanguage = cfapi.LanguageStats(submissions, statsRawLanguages)
 
 	// Display
 	fmt.Printf("\n📊 Statistics for %s\n", handle)
@@ -66,14 +72,52 @@ func runStats(cmd *cobra.Command, args []string) error {
 
 	// User summary
 	rankColor := getRankColor(user.Rating)
-	fmt.Printf("\n%s%s\033[0m (Rating: %s%d\033[0m)\n",
-		rankColor, user.Rank, rankColor, user.Rating)
+	if user.IsUnrated() {
+		fmt.Printf("\n%sUnrated\033[0m\n", rankColor)
+	} else {
+		fmt.Printf("\n%s%s\033[0m (Rating: %s%d\033[0m)\n",
+			rankColor, displayRank(&user), rankColor, user.Rating)
+	}
 
 	// Overall stats
 	fmt.Printf("\n📈 Overall:\n")
 	fmt.Printf("   Total Solved:     %d unique problems\n", stats.TotalSolved)
 	fmt.Printf("   Total Submissions: %d\n", stats.TotalSubmissions)
 	fmt.Printf("   Acceptance Rate:  %.1f%%\n", stats.AcceptanceRate)
+	fmt.Printf("   Contests:         %d participated\n", stats.Contests)
+
+	// Verdict breakdown
+	fmt.Printf("\n🧪 By Verdict:\n")
+	verdicts := make([]string, 0, len(stats.ByVerdict))
+	for v := range stats.ByVerdict {
+		verdicts = append(verdicts, v)
+	}
+	sort.Slice(verdicts, func(i, j int) bool {
+		return stats.ByVerdict[verdicts[i]] > stats.ByVerdict[verdicts[j]]
+	})
+
+	for _, v := range verdicts {
+		fmt.Printf("   %s%-26s\033[0m %5d\n", getVerdictColor(v), v, stats.ByVerdict[v])
+	}
+
+	// Language breakdown
+	fmt.Printf("\n💻 By Language:\n")
+	languages := make([]string, 0, len(stats.ByLanguage))
+	for l := range stats.ByLanguage {
+		languages = append(languages, l)
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if stats.ByLanguage[languages[i]] != stats.ByLanguage[languages[j]] {
+			return stats.ByLanguage[languages[i]] > stats.ByLanguage[languages[j]]
+		}
+		return languages[i] < languages[j]
+	})
+
+	for _, l := range languages {
+		count := stats.ByLanguage[l]
+		percent := float64(count) / float64(stats.TotalSubmissions) * 100
+		fmt.Printf("   %-26s %5d  %5.1f%%\n", l, count, percent)
+	}
 
 	// Problems by rating
 	fmt.Printf("\n⭐ By Rating:\n")
@@ -141,32 +185,71 @@ func runStats(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Workspace progress belongs to the configured handle
+	if len(args) == 0 {
+		printWeeklyTrend()
+	}
+
 	fmt.Println()
 	return nil
 }
 
+// printWeeklyTrend snapshots the workspace progress and prints the
+// week-over-week change in solved problems
+func printWeeklyTrend() {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return
+	}
+	progress, err := ws.LoadProgress()
+	if err != nil {
+		return
+	}
+
+	progress.Snapshot()
+	if err := ws.SaveProgress(progress); err != nil {
+		fmt.Printf("   ⚠️  Could not save progress snapshot: %v\n", err)
+	}
+
+	fmt.Printf("   %s\n", formatTrend(progress.Trend(7*24*time.Hour)))
+}
+
+// formatTrend describes a weekly trend report
+func formatTrend(r v1.TrendReport) string {
+	switch {
+	case r.Complete:
+		return fmt.Sprintf("This Week:   %d solved (%+d vs last week)", r.Current.TotalSolved, r.SolvedChange())
+	case r.Current.Taken.IsZero():
+		return "This Week:   trend shown after a week of 'cf sync' or 'cf stats'"
+	default:
+		return fmt.Sprintf("This Week:   %d solved", r.Current.TotalSolved)
+	}
+}
+
 type Stats struct {
 	TotalSolved      int
 	TotalSubmissions int
 	AcceptanceRate   float64
 	ByRating         map[int]int
 	ByTag            map[string]int
+	ByVerdict        map[string]int
+	ByLanguage       map[string]int
+	Contests         int // Contests with a submission during the contest
 }
 
 func calculateStats(submissions []cfapi.Submission) Stats {
 	stats := Stats{
-		ByRating: make(map[int]int),
-		ByTag:    make(map[string]int),
+		ByRating:       make(map[int]int),
+		ByTag:          make(map[string]int),
+		ByVerdict:      cfapi.VerdictStats(submissions),
+		AcceptanceRate: cfapi.AcceptanceRate(submissions) * 100,
+		Contests:       len(cfapi.ParticipatedContests(submissions)),
 	}
 
 	seen := make(map[string]bool)
-	accepted := 0
 
 	for _, s := range submissions {
 		stats.TotalSubmissions++
-		if s.IsAccepted() {
-			accepted++
-		}
 
 		// Track unique solved problems
 		key := s.Problem.ProblemID()
@@ -190,10 +273,6 @@ func calculateStats(submissions []cfapi.Submission) Stats {
 		}
 	}
 
-	if stats.TotalSubmissions > 0 {
-		stats.AcceptanceRate = float64(accepted) / float64(stats.TotalSubmissions) * 100
-	}
-
 	return stats
 }
 
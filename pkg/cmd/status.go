@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/tui/styles"
+)
+
+const (
+	// statusCacheFile holds the last fetched status in the cache dir
+	statusCacheFile = "status.json"
+	// statusCacheTTL is how long the cached status is used without a request
+	statusCacheTTL = 10 * time.Minute
+	// statusTimeout bounds the requests of one status refresh
+	statusTimeout = 2 * time.Second
+	// statusContestWindow is how far ahead the next contest is looked for
+	statusContestWindow = 7 * 24 * time.Hour
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print a one-line status for shell prompts",
+	Long: `Print your handle, rating, solve streak and the next contest on one line,
+e.g. "@tourist 3800 (LGM) • streak 12 • next: Edu Round 170 in 2h".
+
+Rating and contests are cached for 10 minutes and refreshed with a short
+timeout. Offline, the last cached status is used, or the line is cut down
+to what is known locally. The command never fails, so it is safe to call
+from a prompt:
+
+  PS1='$(cf status) \$ '`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	handle := config.GetCFHandle()
+	fmt.Fprintln(cmd.OutOrStdout(), formatStatus(handle, currentStatus(handle), localStreak(), time.Now()))
+	return nil
+}
+
+// statusSnapshot is the rating and next contest of a handle at FetchedAt
+type statusSnapshot struct {
+	Handle      string    `json:"handle"`
+	Rating      int       `json:"rating"`
+	NextContest string    `json:"nextContest,omitempty"`
+	NextStart   time.Time `json:"nextStart,omitempty"`
+	FetchedAt   time.Time `json:"fetchedAt"`
+}
+
+// currentStatus returns the status of handle, from the cache while it is
+// fresh and from the API otherwise. A failed refresh falls back to the stale
+// cache, and with none returns nil.
+func currentStatus(handle string) *statusSnapshot {
+	if handle == "" {
+		return nil
+	}
+
+	cached := loadStatusSnapshot(handle)
+	if cached != nil && time.Since(cached.FetchedAt) < statusCacheTTL {
+		return cached
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+
+	snap, err := fetchStatus(ctx, getAPIClient(), handle)
+	if err != nil {
+		return cached
+	}
+	saveStatusSnapshot(snap)
+	return snap
+}
+
+// fetchStatus fetches the rating of handle and the next contest
+func fetchStatus(ctx context.Context, client *cfapi.Client, handle string) (*statusSnapshot, error) {
+	users, err := client.GetUserInfo(ctx, []string{handle})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user %s not found", handle)
+	}
+
+	snap := &statusSnapshot{Handle: users[0].Handle, Rating: users[0].Rating, FetchedAt: time.Now()}
+	// The contest is a nice-to-have; the rating alone is worth caching
+	if next, err := client.NextContest(ctx, statusContestWindow); err == nil && next != nil {
+		snap.NextContest = next.Name
+		snap.NextStart = next.StartTime()
+	}
+	return snap, nil
+}
+
+// localStreak returns the current solve streak from the workspace progress,
+// or 0 outside a workspace
+func localStreak() int {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return 0
+	}
+	progress, err := ws.LoadProgress()
+	if err != nil {
+		return 0
+	}
+	current, _ := progress.Streaks(time.Now())
+	return current
+}
+
+// formatStatus builds the status line from whatever is known; without a
+// snapshot it is just the handle and streak
+func formatStatus(handle string, snap *statusSnapshot, streak int, now time.Time) string {
+	var parts []string
+	switch {
+	case snap != nil && snap.Rating > 0:
+		parts = append(parts, fmt.Sprintf("@%s %d (%s)", snap.Handle, snap.Rating, styles.GetRankShort(snap.Rating)))
+	case snap != nil:
+		parts = append(parts, "@"+snap.Handle)
+	case handle != "":
+		parts = append(parts, "@"+handle)
+	}
+	if streak > 0 {
+		parts = append(parts, fmt.Sprintf("streak %d", streak))
+	}
+	if snap != nil && snap.NextContest != "" && snap.NextStart.After(now) {
+		parts = append(parts, fmt.Sprintf("next: %s in %s", shortContestName(snap.NextContest), formatUntil(snap.NextStart.Sub(now))))
+	}
+	if len(parts) == 0 {
+		return "cf"
+	}
+	return strings.Join(parts, " • ")
+}
+
+// shortContestName trims a contest name for the status line, e.g.
+// "Educational Codeforces Round 170 (Rated for Div. 2)" to "Edu Round 170"
+func shortContestName(name string) string {
+	if i := strings.Index(name, " ("); i > 0 {
+		name = name[:i]
+	}
+	name = strings.Replace(name, "Educational Codeforces Round", "Edu Round", 1)
+	return strings.Replace(name, "Codeforces Round", "CF Round", 1)
+}
+
+// formatUntil formats the time to an event in its largest unit: 3d, 2h or 15m
+func formatUntil(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dm", max(int(d.Minutes()), 1))
+	}
+}
+
+// loadStatusSnapshot returns the cached status of handle, or nil
+func loadStatusSnapshot(handle string) *statusSnapshot {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, statusCacheFile))
+	if err != nil {
+		return nil
+	}
+	var snap statusSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil || !strings.EqualFold(snap.Handle, handle) {
+		return nil
+	}
+	return &snap
+}
+
+// saveStatusSnapshot caches snap; failures only cost the cache
+func saveStatusSnapshot(snap *statusSnapshot) {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, statusCacheFile), data, 0644)
+}
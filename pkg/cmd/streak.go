@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+var (
+	streakDays    int
+	streakRebuild bool
+)
+
+var streakCmd = &cobra.Command{
+	Use:   "streak",
+	Short: "Show your solve streak and calendar",
+	Long: `Display your current and longest solve streaks and a calendar of recent
+days with solves.
+
+Progress is read from the workspace. If no progress has been recorded yet it is
+rebuilt from your Codeforces submissions.
+
+Examples:
+  cf streak              # Last 30 days
+  cf streak --days 90    # Last 90 days
+  cf streak --rebuild    # Rebuild progress from the API first`,
+	RunE: runStreak,
+}
+
+func init() {
+	streakCmd.Flags().IntVar(&streakDays, "days", 30, "Number of days shown in the calendar")
+	streakCmd.Flags().BoolVar(&streakRebuild, "rebuild", false, "Rebuild progress from Codeforces submissions")
+}
+
+func runStreak(cmd *cobra.Command, args []string) error {
+	if streakDays < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	progress, err := loadOrRebuildProgress()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	current, longest := progress.Streaks(now)
+
+	fmt.Println()
+	fmt.Printf("🔥 Current streak: %s\n", pluralDays(current))
+	fmt.Printf("🏆 Longest streak: %s\n", pluralDays(longest))
+	fmt.Printf("\n📅 Last %d days:\n\n", streakDays)
+	fmt.Print(renderHeatmap(progress.SolvedByDay(), now, streakDays))
+	fmt.Println()
+	return nil
+}
+
+// loadOrRebuildProgress reads the workspace progress, rebuilding it from the
+// API when it is missing, empty or --rebuild is set
+func loadOrRebuildProgress() (*v1.Progress, error) {
+	ws, wsErr := requireWorkspace()
+	if wsErr == nil && !streakRebuild {
+		progress, err := ws.LoadProgress()
+		if err == nil && len(progress.Daily) > 0 {
+			return progress, nil
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	handle, err := getHandle(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	submissions, err := getAPIClient().GetUserSubmissions(ctx, handle, 1, 10000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+
+	progress := progressFromSubmissions(submissions)
+	if wsErr == nil {
+		if err := ws.SaveProgress(progress); err != nil {
+			return nil, err
+		}
+	}
+
+	return progress, nil
+}
+
+// progressFromSubmissions rebuilds practice progress from accepted submissions
+// Each problem counts once, on the day it was first accepted
+func progressFromSubmissions(submissions []cfapi.Submission) *v1.Progress {
+	progress := v1.NewProgress()
+	addAcceptedSubmissions(progress, submissions, make(map[string]bool))
+	return progress
+}
+
+// addAcceptedSubmissions records the first accepted submission of each problem
+// not already in seen, oldest first, and returns the newly solved problem IDs
+func addAcceptedSubmissions(progress *v1.Progress, submissions []cfapi.Submission, seen map[string]bool) []string {
+	sorted := make([]cfapi.Submission, len(submissions))
+	copy(sorted, submissions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreationTimeSeconds < sorted[j].CreationTimeSeconds
+	})
+
+	var solved []string
+	for i := range sorted {
+		s := &sorted[i]
+		key := s.Problem.ProblemID()
+		if !s.IsAccepted() || seen[key] {
+			continue
+		}
+		seen[key] = true
+		solved = append(solved, key)
+		progress.AddSolvedAt(key, s.Problem.Rating, s.Problem.Tags, 0, s.SubmissionTime())
+	}
+
+	return solved
+}
+
+// renderHeatmap draws the last days up to end as a calendar of weeks, one row
+// per weekday, shading each day by the number of problems solved
+func renderHeatmap(solved map[string]int, end time.Time, days int) string {
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	start := end.AddDate(0, 0, -(days - 1))
+
+	// Columns start on the Monday of the first week
+	first := start.AddDate(0, 0, -weekdayIndex(start))
+	weeks := (weekdayIndex(start) + days + 6) / 7
+
+	labels := []string{"Mon", "", "Wed", "", "Fri", "", "Sun"}
+
+	var sb strings.Builder
+	for row := 0; row < 7; row++ {
+		sb.WriteString(fmt.Sprintf("  %-4s", labels[row]))
+		for week := 0; week < weeks; week++ {
+			day := first.AddDate(0, 0, week*7+row)
+			if day.Before(start) || day.After(end) {
+				sb.WriteString("  ")
+				continue
+			}
+			sb.WriteString(" " + heatmapCell(solved[day.Format("2006-01-02")]))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("\n  Less %s %s %s %s More\n",
+		heatmapCell(0), heatmapCell(1), heatmapCell(2), heatmapCell(4)))
+
+	return sb.String()
+}
+
+func heatmapCell(count int) string {
+	switch {
+	case count == 0:
+		return "\033[90m·\033[0m"
+	case count == 1:
+		return "\033[32m■\033[0m"
+	case count < 4:
+		return "\033[92m■\033[0m"
+	default:
+		return "\033[1;92m■\033[0m"
+	}
+}
+
+// weekdayIndex returns 0 for Monday through 6 for Sunday
+func weekdayIndex(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
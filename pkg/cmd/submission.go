@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+)
+
+var (
+	// submission get flags
+	submissionOut string
+)
+
+var submissionCmd = &cobra.Command{
+	Use:   "submission",
+	Short: "Work with your Codeforces submissions",
+}
+
+var submissionGetCmd = &cobra.Command{
+	Use:   "get <submission_id>",
+	Short: "Download the source of one of your submissions",
+	Long: `Download the source code of one of your submissions into the problem's
+solutions directory, as solutions/<id>.<ext>.
+
+The submission is looked up among your submissions for its problem and
+language. Codeforces only shows sources to a logged-in author, so this needs
+the browser cookie from 'cf setup'.
+
+Examples:
+  cf submission get 123456789
+  cf submission get 123456789 --out accepted.cpp`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSubmissionGet,
+}
+
+func init() {
+	submissionCmd.AddCommand(submissionGetCmd)
+
+	submissionGetCmd.Flags().StringVar(&submissionOut, "out", "", "File name, relative to the solutions directory")
+}
+
+func runSubmissionGet(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || id <= 0 {
+		return fmt.Errorf("invalid submission ID: %s", args[0])
+	}
+
+	handle := config.GetCFHandle()
+	if handle == "" {
+		return fmt.Errorf("no CF handle configured. Set with 'cf config set cf_handle <handle>'")
+	}
+
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	session := newWebSession()
+	if session == nil || !session.IsReadyForSubmission() {
+		return fmt.Errorf("downloading sources needs your browser cookie; run 'cf setup'")
+	}
+	submitter, err := cfweb.NewSubmitter(session)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(fetchTimeout)
+	defer cancel()
+
+	subs, err := getAPIClient().GetUserSubmissions(ctx, handle, 0, 0)
+	if err != nil {
+		return fmt.Errorf("failed to fetch submissions: %w", explainAPIError(err))
+	}
+	sub := findSubmission(subs, id)
+	if sub == nil {
+		return fmt.Errorf("submission %d is not one of %s's submissions", id, handle)
+	}
+
+	source, err := submitter.GetSubmissionSource(id, sub.ContestID)
+	if errors.Is(err, cfweb.ErrSubmissionForbidden) {
+		return fmt.Errorf("%w; check that your cookie is still logged in as %s ('cf setup')", err, handle)
+	}
+	if err != nil {
+		return err
+	}
+
+	name := submissionOut
+	if name == "" {
+		name = fmt.Sprintf("%d%s", id, sourceExtension(sub.ProgrammingLanguage))
+	}
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(ws.ProblemPath("codeforces", sub.ContestID, sub.Problem.Index), "solutions", name)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create solutions dir: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		return fmt.Errorf("failed to save source: %w", err)
+	}
+
+	fmt.Printf("✓ Saved submission %d (%s, %s) to %s\n", id, sub.Problem.ProblemID(), sub.Verdict, path)
+	if !ws.ProblemExists("codeforces", sub.ContestID, sub.Problem.Index) {
+		fmt.Printf("  Fetch the problem with 'cf problem fetch %d %s'\n", sub.ContestID, sub.Problem.Index)
+	}
+	return nil
+}
+
+// newWebSession returns a session with the configured cookie and handle, or
+// nil without a cookie
+func newWebSession() *cfweb.Session {
+	cookie := config.GetCookie()
+	if cookie == "" {
+		return nil
+	}
+	session, err := cfweb.NewSessionWithCookie(cookie, cfweb.WithUserAgent(config.GetCFClearanceUA()))
+	if err != nil {
+		return nil
+	}
+	session.SetHandle(config.GetCFHandle())
+	return session
+}
+
+// findSubmission returns the submission with the given ID, or nil
+func findSubmission(subs []cfapi.Submission, id int64) *cfapi.Submission {
+	for i := range subs {
+		if subs[i].ID == id {
+			return &subs[i]
+		}
+	}
+	return nil
+}
+
+// sourceExtensions maps language families to source file extensions
+var sourceExtensions = map[string]string{
+	"C":          ".c",
+	"C++":        ".cpp",
+	"C#":         ".cs",
+	"D":          ".d",
+	"Go":         ".go",
+	"Haskell":    ".hs",
+	"Java":       ".java",
+	"JavaScript": ".js",
+	"Kotlin":     ".kt",
+	"OCaml":      ".ml",
+	"PHP":        ".php",
+	"Pascal":     ".pas",
+	"Perl":       ".pl",
+	"Python":     ".py",
+	"Ruby":       ".rb",
+	"Rust":       ".rs",
+	"Scala":      ".scala",
+	"TypeScript": ".ts",
+}
+
+// sourceExtension returns the file extension for a Codeforces language name
+func sourceExtension(lang string) string {
+	if ext, ok := sourceExtensions[cfapi.LanguageFamily(lang)]; ok {
+		return ext
+	}
+	return ".txt"
+}
@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/notify"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// submit flags
+	submitFile         string
+	submitLang         int
+	submitRetryPending bool
+	submitNotify       bool
+)
+
+var submitCmd = &cobra.Command{
+	Use:   "submit <problem>",
+	Short: "Submit your solution to Codeforces",
+	Long: `Submit the problem's solution file to Codeforces.
+
+The solution file is the problem's canonical solution (see solutionFiles in
+workspace.yaml) unless --file is given. --lang is the Codeforces language ID
+of the submit form, e.g. 54 for GNU G++17 or 31 for Python 3. Without it,
+the language comes from the file extension; when the extension fits several
+languages (.cpp, .py, .java) or none, default_language from the config is
+used, e.g. 'cf config set default_language "C++20"'. Submitting needs the
+browser cookie from 'cf setup'.
+
+When the submit page can't be loaded because Codeforces is unreachable,
+overloaded or behind a Cloudflare error, the submission is saved to
+pending-submissions.yaml in the workspace. Failures after the source was
+sent are reported, not saved, since it may have gone through. --retry-pending
+sends the saved submissions in order, skipping any whose source matches
+your latest submission to the problem, and removes each one that goes
+through.
+
+With --notify, submit waits for the final verdict, prints it and shows a
+desktop notification, like 'cf watch --notify'.
+
+Examples:
+  cf submit 1325A
+  cf submit 1325A --lang 54
+  cf submit 1325 A --lang 31 --file solutions/main.py
+  cf submit 1325A --notify
+  cf submit --retry-pending`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: runSubmit,
+}
+
+func init() {
+	submitCmd.Flags().StringVar(&submitFile, "file", "", "Solution file, relative to the problem directory")
+	submitCmd.Flags().IntVar(&submitLang, "lang", 0, "Codeforces language ID (default: from the file extension or default_language)")
+	submitCmd.Flags().BoolVar(&submitRetryPending, "retry-pending", false, "Send submissions queued after network failures")
+	submitCmd.Flags().BoolVar(&submitNotify, "notify", false, "Wait for the verdict and show a desktop notification")
+}
+
+func runSubmit(cmd *cobra.Command, args []string) error {
+	if submitRetryPending {
+		if len(args) > 0 {
+			return fmt.Errorf("--retry-pending sends the queued submissions and takes no problem")
+		}
+		return runRetryPending()
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("requires a problem, e.g. 'cf submit 1325A --lang 54'")
+	}
+
+	contestID, index, err := problemArgs(args)
+	if err != nil {
+		return err
+	}
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	solution := ws.SolutionPath("codeforces", contestID, index, "")
+	if submitFile != "" {
+		solution = submitFile
+		if !filepath.IsAbs(solution) {
+			solution = filepath.Join(ws.ProblemPath("codeforces", contestID, index), solution)
+		}
+	}
+	source, err := os.ReadFile(solution)
+	if err != nil {
+		return fmt.Errorf("solution %s not found: write it there or pass --file", solution)
+	}
+	languageID, err := submitLanguage(solution, submitLang, config.GetDefaultLanguage())
+	if err != nil {
+		return err
+	}
+
+	submitter, err := newSubmitter()
+	if err != nil {
+		return err
+	}
+
+	pending := workspace.PendingSubmission{
+		ContestID:  contestID,
+		Index:      index,
+		LanguageID: languageID,
+		Source:     string(source),
+		SourceFile: solution,
+	}
+	result, err := sendSubmission(submitter, pending)
+	if err != nil {
+		return queueOnTransient(ws, pending, err)
+	}
+
+	fmt.Printf("✓ Submitted %s as submission %d\n", pending.ProblemID(), result.SubmissionID)
+	if !submitNotify {
+		fmt.Println("  Follow the verdict with 'cf watch'")
+		return nil
+	}
+
+	timeout := verdictTimeout
+	if commandTimeout > 0 {
+		timeout = commandTimeout
+	}
+	fmt.Println("  Waiting for the verdict...")
+	final, err := submitter.WaitForVerdict(result.SubmissionID, contestID, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to get the verdict, follow it with 'cf watch': %w", err)
+	}
+
+	title, message := submitNotification(pending.ProblemID(), final)
+	fmt.Printf("  %s · %s\n", title, message)
+	if err := notify.Send(title, message); err != nil {
+		fmt.Printf("⚠️  Desktop notification failed, used the terminal bell: %v\n", err)
+	}
+	return nil
+}
+
+// submitNotification returns the title and message notifying the final
+// verdict of a submission to problem
+func submitNotification(problem string, r *cfweb.SubmissionResult) (string, string) {
+	title := fmt.Sprintf("%s: %s", problem, r.Verdict)
+	if r.Outcome == cfweb.VerdictAccepted {
+		return title, fmt.Sprintf("%d ms, %d KB", r.Time.Milliseconds(), r.Memory/1024)
+	}
+	return title, fmt.Sprintf("submission %d", r.SubmissionID)
+}
+
+// runRetryPending sends the queued submissions and reports what is left
+func runRetryPending() error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	pending, err := ws.PendingSubmissions()
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		fmt.Println("No pending submissions")
+		return nil
+	}
+
+	submitter, err := newSubmitter()
+	if err != nil {
+		return err
+	}
+
+	result, err := replayPendingSubmissions(ws, submitter)
+	for _, s := range result.Sent {
+		fmt.Printf("✓ Submitted %d%s as submission %d\n", s.ContestID, s.ProblemIndex, s.SubmissionID)
+	}
+	for _, f := range result.Rejected {
+		fmt.Printf("✗ %s was rejected and removed from the queue: %v\n", f.Submission.ProblemID(), f.Err)
+	}
+	if err != nil {
+		return err
+	}
+	if result.Remaining > 0 {
+		return fmt.Errorf("%d submissions are still pending: %s", result.Remaining, result.LastError)
+	}
+	return nil
+}
+
+// submitLanguage picks the compiler ID to submit solution with: lang when
+// given, else the only language of the file's extension, else the default
+// language if the extension is unknown or the default is among its languages
+func submitLanguage(solution string, lang int, defaultLanguage string) (int, error) {
+	if lang > 0 {
+		return lang, nil
+	}
+
+	var def *cfweb.Language
+	if defaultLanguage != "" {
+		var err error
+		if def, err = cfweb.ResolveLanguage(defaultLanguage); err != nil {
+			return 0, fmt.Errorf("default_language: %w", err)
+		}
+	}
+
+	ext := strings.ToLower(filepath.Ext(solution))
+	candidates := cfweb.LanguagesForExtension(ext)
+	switch {
+	case len(candidates) == 1:
+		return candidates[0].CompilerID, nil
+	case def != nil && (len(candidates) == 0 || def.Extension == ext):
+		return def.CompilerID, nil
+	case len(candidates) == 0:
+		return 0, fmt.Errorf("can't tell the language of %s: pass --lang or set default_language", filepath.Base(solution))
+	}
+
+	names := make([]string, len(candidates))
+	for i, c := range candidates {
+		names[i] = fmt.Sprintf("%s (%d)", c.ID, c.CompilerID)
+	}
+	return 0, fmt.Errorf("%s files can be %s: pass --lang or set default_language", ext, strings.Join(names, ", "))
+}
+
+// newSubmitter returns a submitter for the configured cookie and handle
+func newSubmitter() (*cfweb.Submitter, error) {
+	session := newWebSession()
+	if session == nil || !session.IsReadyForSubmission() {
+		return nil, fmt.Errorf("submitting needs your browser cookie and handle; run 'cf setup'")
+	}
+	return cfweb.NewSubmitter(session)
+}
+
+// sendSubmission submits p to its contest or gym
+func sendSubmission(submitter *cfweb.Submitter, p workspace.PendingSubmission) (*cfweb.SubmissionResult, error) {
+	if p.ContestID >= cfapi.MinGymContestID {
+		return submitter.SubmitToGym(p.ContestID, p.Index, p.LanguageID, p.Source)
+	}
+	return submitter.Submit(p.ContestID, p.Index, p.LanguageID, p.Source)
+}
+
+// queueOnTransient saves p to the pending queue if err is worth retrying,
+// and returns the error to report
+func queueOnTransient(ws *workspace.Workspace, p workspace.PendingSubmission, err error) error {
+	if !cfweb.IsTransient(err) {
+		return err
+	}
+
+	p.QueuedAt = time.Now()
+	p.LastError = err.Error()
+	if qerr := ws.QueueSubmission(p); qerr != nil {
+		return fmt.Errorf("%w (and could not queue it: %v)", err, qerr)
+	}
+	return fmt.Errorf("%w; saved the submission, send it later with 'cf submit --retry-pending'", err)
+}
+
+// pendingFailure is a queued submission Codeforces refused
+type pendingFailure struct {
+	Submission workspace.PendingSubmission
+	Err        error
+}
+
+// replayResult summarizes a run over the pending queue
+type replayResult struct {
+	Sent      []*cfweb.SubmissionResult
+	Rejected  []pendingFailure // Dropped, since sending them again can't succeed
+	Remaining int              // Still queued after a transient failure
+	LastError string
+}
+
+// replayPendingSubmissions sends the queued submissions oldest first,
+// removing those that are sent or rejected for good. The first transient
+// failure stops the run, leaving it and the rest queued. Each one is first
+// compared with the latest submission to its problem, so a source that
+// made it to Codeforces some other way is dropped instead of sent twice.
+func replayPendingSubmissions(ws *workspace.Workspace, submitter *cfweb.Submitter) (replayResult, error) {
+	var result replayResult
+	submitter.SetCheckDuplicates(true)
+
+	pending, err := ws.PendingSubmissions()
+	if err != nil {
+		return result, err
+	}
+
+	for i, p := range pending {
+		sent, err := sendSubmission(submitter, p)
+		if err == nil {
+			result.Sent = append(result.Sent, sent)
+			continue
+		}
+		if !cfweb.IsTransient(err) {
+			result.Rejected = append(result.Rejected, pendingFailure{Submission: p, Err: err})
+			continue
+		}
+
+		left := pending[i:]
+		left[0].LastError = err.Error()
+		result.Remaining = len(left)
+		result.LastError = err.Error()
+		return result, ws.SavePendingSubmissions(left)
+	}
+
+	return result, ws.SavePendingSubmissions(nil)
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	apperrors "github.com/harshit-vibes/cf/pkg/internal/errors"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Refresh problem metadata and submissions from the API",
+	Long: `Update rating and tags of every workspace problem from the Codeforces API,
+then add submissions made since the last sync to your progress.
+
+Ratings are often assigned after a contest ends, so locally saved
+problems can go stale. Statements, samples, notes and practice data
+are left untouched. Problems not found on the API are skipped.
+
+Only submissions newer than the last synced one are fetched; the first sync
+rebuilds progress from all of them.
+
+Examples:
+  cf sync`,
+	Args: cobra.NoArgs,
+	RunE: runSync,
+}
+
+// syncResult summarizes a metadata sync
+type syncResult struct {
+	Total   int
+	Updated int
+	Skipped int
+	Failed  *apperrors.MultiError
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(bulkTimeout)
+	defer cancel()
+
+	fmt.Println("Syncing problem metadata...")
+
+	result, err := syncProblemMetadata(ctx, ws, getAPIClient())
+	if err != nil {
+		reportStopped(ctx, result.Updated, result.Total, "problems")
+		return err
+	}
+
+	fmt.Printf("✓ Updated %d of %d problems", result.Updated, result.Total)
+	if result.Skipped > 0 {
+		fmt.Printf(" (%d not found on API)", result.Skipped)
+	}
+	fmt.Println()
+	if result.Failed.Len() > 0 {
+		fmt.Printf("⚠️  %d problems could not be synced\n", result.Failed.Len())
+	}
+
+	handle := config.GetCFHandle()
+	if handle == "" {
+		fmt.Println("  Set cf_handle to also sync submissions into your progress.")
+		return nil
+	}
+
+	fmt.Println("Syncing submissions...")
+
+	newSubs, solved, err := syncSubmissions(ctx, ws, getAPIClient(), handle)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %d new submissions, %d newly solved problems\n", newSubs, solved)
+
+	return result.Failed.Err()
+}
+
+// syncSubmissions adds the handle's submissions since the last sync to the
+// workspace progress and moves the stored cursor forward. The first sync
+// rebuilds the progress from all submissions.
+func syncSubmissions(ctx context.Context, ws *workspace.Workspace, client *cfapi.Client, handle string) (int, int, error) {
+	state, err := ws.LoadSyncState(handle)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	submissions, cursor, err := client.SyncNewSubmissions(ctx, handle, state.SubmissionCursor)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get submissions: %w", err)
+	}
+
+	progress := v1.NewProgress()
+	if state.SubmissionCursor > 0 {
+		progress, err = ws.LoadProgress()
+		if errors.Is(err, os.ErrNotExist) {
+			progress = v1.NewProgress()
+		} else if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	seen := make(map[string]bool, len(state.Solved))
+	for _, id := range state.Solved {
+		seen[id] = true
+	}
+	solved := addAcceptedSubmissions(progress, submissions, seen)
+	progress.Snapshot()
+
+	if err := ws.SaveProgress(progress); err != nil {
+		return 0, 0, err
+	}
+
+	state.SubmissionCursor = cursor
+	state.Solved = append(state.Solved, solved...)
+	if err := ws.SaveSyncState(state); err != nil {
+		return 0, 0, err
+	}
+
+	return len(submissions), len(solved), nil
+}
+
+// syncProblemMetadata refreshes rating and tags for all workspace problems
+// A problem that fails to sync is collected in result.Failed and the rest
+// carry on; only listing the workspace or an interrupt stops the sync.
+func syncProblemMetadata(ctx context.Context, ws *workspace.Workspace, client *cfapi.Client) (syncResult, error) {
+	var result syncResult
+
+	problems, err := ws.ListProblems()
+	if err != nil {
+		return result, fmt.Errorf("failed to list problems: %w", err)
+	}
+	result.Total = len(problems)
+	result.Failed = apperrors.NewMultiError(len(problems))
+
+	for _, p := range problems {
+		apiProblem, err := client.GetProblem(ctx, p.ContestID, p.Index)
+		if stopped(ctx) {
+			return result, ctx.Err()
+		}
+		if errors.Is(err, cfapi.ErrProblemNotFound) {
+			result.Skipped++
+			continue
+		}
+		if err != nil {
+			fmt.Printf("  ✗ Failed to fetch %s: %v\n", p.ID, err)
+			result.Failed.Add(p.ID, err)
+			continue
+		}
+
+		metadata := p.Metadata
+		metadata.Tags = apiProblem.Tags
+		if apiProblem.Rating > 0 || !p.Metadata.RatingEstimated {
+			// Keep an estimate until the API rates the problem
+			metadata.Rating = apiProblem.Rating
+			metadata.RatingEstimated = false
+		}
+
+		if metadata.Rating == p.Metadata.Rating && metadata.RatingEstimated == p.Metadata.RatingEstimated &&
+			slices.Equal(metadata.Tags, p.Metadata.Tags) {
+			continue
+		}
+		err = ws.UpdateMetadata(p.Platform, p.ContestID, p.Index, &metadata)
+		if errors.Is(err, workspace.ErrProblemNotFound) {
+			// Removed since the index was built
+			result.Skipped++
+			continue
+		}
+		if err != nil {
+			fmt.Printf("  ✗ Failed to update %s: %v\n", p.ID, err)
+			result.Failed.Add(p.ID, err)
+			continue
+		}
+
+		fmt.Printf("  ✓ %s. %s\n", p.ID, p.Name)
+		result.Updated++
+	}
+
+	return result, nil
+}
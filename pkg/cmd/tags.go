@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+)
+
+// tagsCacheFile holds the problemset tags in the cache dir, for completion
+// and tag validation without a request
+const tagsCacheFile = "tags.json"
+
+// loadKnownTags returns the cached problemset tags, or nil if there are none
+func loadKnownTags() []string {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, tagsCacheFile))
+	if err != nil {
+		return nil
+	}
+	var tags []string
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil
+	}
+	return tags
+}
+
+// saveKnownTags caches the problemset tags; failures only cost the cache
+func saveKnownTags(tags []string) {
+	if len(tags) == 0 {
+		return
+	}
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, tagsCacheFile), data, 0644)
+}
+
+// warnUnknownTags warns about resolved tags missing from the cached tag list
+// Warnings are left out of json/csv output so it stays parseable.
+func warnUnknownTags(tags []string) {
+	if !tableOutput() {
+		return
+	}
+	for _, t := range cfapi.UnknownTags(tags, loadKnownTags()) {
+		fmt.Printf("⚠️  Unknown tag %q; 'cf problem list --tag <TAB>' lists the known tags\n", t)
+	}
+}
+
+// completeTags completes --tag with the problemset tags and the aliases
+// The tags are fetched once if they are not cached yet.
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client := getAPIClient()
+
+	known := loadKnownTags()
+	if len(known) == 0 {
+		ctx, cancel := commandContext(completionTimeout)
+		defer cancel()
+		if resp, err := client.GetProblems(ctx, nil); err == nil {
+			known = cfapi.ProblemTags(resp.Problems)
+			saveKnownTags(known)
+		}
+	}
+
+	prefix := strings.ToLower(toComplete)
+	var completions []string
+	for _, t := range known {
+		if strings.HasPrefix(t, prefix) {
+			completions = append(completions, t)
+		}
+	}
+	for alias, tag := range client.TagAliases() {
+		if strings.HasPrefix(alias, prefix) {
+			completions = append(completions, alias+"\t"+tag)
+		}
+	}
+	sort.Strings(completions)
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/runner"
+)
+
+var (
+	// test flags
+	testFile      string
+	testTimeLimit time.Duration
+	testJobs      int
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test <problem>",
+	Short: "Run your solution on the problem samples",
+	Long: `Build your solution and run it on the samples saved with the problem.
+
+Each sample gets a judge-style verdict: OK, WRONG_ANSWER with a diff,
+TIME_LIMIT_EXCEEDED, RUNTIME_ERROR or COMPILATION_ERROR with the error output.
+Samples without an expected output (interactive problems) are MANUAL_REVIEW
+and shown with the solution output. For problems with several correct
+answers, set a checker command in problem.yaml, e.g.
+  checker: python3 check.py {input} {output} {answer}
+It runs in the problem directory and accepts an output by exiting with 0.
+Without a checker, outputs are compared token by token. Set compare in
+problem.yaml to exact for byte-for-byte output, or to float to accept
+numbers within an absolute or relative error of 1e-6.
+The solution file is the problem's canonical solution (see solutionFiles in
+workspace.yaml) unless --file is given, and the time limit is the problem's.
+With --jobs, samples run in parallel; timings then compete for the CPU.
+
+Build and run commands come from the compilers section of the config, e.g.
+  compilers:
+    cpp:
+      compile: clang++ -O2 -std=c++20 -o {bin} {src}
+      run: "{bin}"
+
+Examples:
+  cf test 1325A
+  cf test 1325 A --file solutions/brute.py
+  cf test 1325A --time-limit 5s
+  cf test 1325A --jobs 4`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runTest,
+}
+
+func init() {
+	testCmd.Flags().StringVar(&testFile, "file", "", "Solution file, relative to the problem directory")
+	testCmd.Flags().DurationVar(&testTimeLimit, "time-limit", 0, "Time limit per sample (default: the problem's)")
+	testCmd.Flags().IntVarP(&testJobs, "jobs", "j", 1, "Number of samples to run in parallel")
+}
+
+func runTest(cmd *cobra.Command, args []string) error {
+	contestID, index, err := problemArgs(args)
+	if err != nil {
+		return err
+	}
+
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+	problem, err := ws.LoadProblem("codeforces", contestID, index)
+	if err != nil {
+		return err
+	}
+
+	problemDir := ws.ProblemPath("codeforces", contestID, index)
+	solution := ws.SolutionPath("codeforces", contestID, index, "")
+	if testFile != "" {
+		solution = testFile
+		if !filepath.IsAbs(solution) {
+			solution = filepath.Join(problemDir, solution)
+		}
+	}
+
+	if _, err := os.Stat(solution); err != nil {
+		return fmt.Errorf("solution %s not found: write it there or pass --file", solution)
+	}
+
+	cases, err := runner.LoadTests(filepath.Join(problemDir, "tests"))
+	if err != nil {
+		return err
+	}
+	if len(cases) == 0 {
+		return fmt.Errorf("no samples for %d%s; fetch it again with 'cf problem fetch %d %s'", contestID, index, contestID, index)
+	}
+
+	comparison, err := runner.ParseComparison(problem.Compare)
+	if err != nil {
+		return fmt.Errorf("problem.yaml: %w", err)
+	}
+
+	timeLimit := testTimeLimit
+	if timeLimit == 0 {
+		timeLimit, _ = runner.ParseTimeLimit(problem.Limits.TimeLimit)
+	}
+
+	ctx, cancel := commandContext(runTimeout)
+	defer cancel()
+
+	compilers, err := compilerCommands()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🧪 Testing %s on %d samples\n\n", filepath.Base(solution), len(cases))
+	results, err := runner.RunTests(ctx, solution, cases, runner.WithTimeout(timeLimit),
+		runner.WithConcurrency(testJobs), runner.WithCommands(compilers),
+		runner.WithChecker(problem.Checker, problemDir), runner.WithComparison(comparison))
+	if err != nil {
+		return err
+	}
+
+	passed, review := 0, 0
+	for _, r := range results {
+		printTestResult(r)
+		switch {
+		case r.Passed():
+			passed++
+		case r.Verdict == runner.VerdictManualReview:
+			review++
+		}
+	}
+
+	fmt.Println()
+	if failed := len(results) - passed - review; failed > 0 {
+		return fmt.Errorf("%d of %d samples failed", failed, len(results))
+	}
+	if review > 0 {
+		fmt.Printf("✓ %d samples passed, %d need manual review\n", passed, review)
+		return nil
+	}
+	fmt.Printf("✓ All %d samples passed\n", len(results))
+	return nil
+}
+
+// compilerCommands returns the commands of the compilers config, failing
+// if one is invalid once merged into the defaults
+func compilerCommands() (map[string]runner.Command, error) {
+	commands := make(map[string]runner.Command)
+	for lang, c := range config.GetCompilers() {
+		commands[lang] = runner.Command(c)
+	}
+	if err := runner.ValidateCommands(runner.MergeCommands(commands)); err != nil {
+		return nil, fmt.Errorf("invalid compilers config: %w", err)
+	}
+	return commands, nil
+}
+
+// printTestResult prints the verdict of one sample and, for failures, why
+func printTestResult(r runner.TestResult) {
+	icon := "✗"
+	switch {
+	case r.Passed():
+		icon = "✓"
+	case r.Verdict == runner.VerdictManualReview:
+		icon = "?"
+	}
+	fmt.Printf("  %s %-12s %s%-20s%s %s\n", icon, r.Name, verdictColor(r.Verdict), r.Verdict, "\033[0m",
+		r.Duration.Round(time.Millisecond))
+
+	switch r.Verdict {
+	case runner.VerdictWrongAnswer:
+		if r.Checker != "" {
+			fmt.Println(indent(truncateLines(r.Checker, 20), "      "))
+		}
+		if r.Diff != "" {
+			fmt.Println(indent(r.Diff, "      "))
+		}
+	case runner.VerdictManualReview:
+		fmt.Println(indent(truncateLines(r.Output, 20), "      "))
+	case runner.VerdictRuntimeError:
+		fmt.Printf("      exit code %d\n", r.ExitCode)
+		fallthrough
+	case runner.VerdictCompileError:
+		if r.Stderr != "" {
+			fmt.Println(indent(truncateLines(r.Stderr, 20), "      "))
+		}
+	}
+}
+
+// verdictColor returns the terminal color of a verdict
+func verdictColor(v runner.Verdict) string {
+	switch v {
+	case runner.VerdictAccepted:
+		return "\033[32m"
+	case runner.VerdictCompileError, runner.VerdictManualReview:
+		return "\033[33m"
+	default:
+		return "\033[31m"
+	}
+}
+
+// indent prefixes every line of s
+func indent(s, prefix string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = prefix + l
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncateLines keeps the first n lines of s
+func truncateLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) <= n {
+		return strings.Join(lines, "\n")
+	}
+	return strings.Join(lines[:n], "\n") + fmt.Sprintf("\n... %d more lines", len(lines)-n)
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// today flags
+	todayHistory  bool
+	todayAdaptive bool
+)
+
+var todayCmd = &cobra.Command{
+	Use:   "today",
+	Short: "Show the problem of the day",
+	Long: `Pick a daily problem within your configured difficulty range.
+
+The pick is the same for the whole day and is recorded in the workspace, so
+past picks can be reviewed with --history along with whether you solved them.
+It is seeded by your handle as well as the date, so each user gets their own
+problem of the day.
+
+With --adaptive, the range is suggested from your rating and recent solves
+instead, moving up when you keep solving problems above your rating.
+
+Examples:
+  cf today             # Today's problem
+  cf today --adaptive  # Pick from the suggested difficulty band
+  cf today --history   # Past daily picks`,
+	Args: cobra.NoArgs,
+	RunE: runToday,
+}
+
+func init() {
+	todayCmd.Flags().BoolVar(&todayHistory, "history", false, "List past daily picks and their status")
+	todayCmd.Flags().BoolVar(&todayAdaptive, "adaptive", false, "Use a difficulty band suggested from your recent solves")
+}
+
+func runToday(cmd *cobra.Command, args []string) error {
+	if todayHistory {
+		return runTodayHistory()
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	client := getAPIClient()
+	now := time.Now()
+	date := now.Format("2006-01-02")
+
+	// Reuse today's pick if one was already recorded
+	ws, wsErr := requireWorkspace()
+	if wsErr == nil {
+		pick, ok, err := ws.DailyPickFor(date)
+		if err != nil {
+			return err
+		}
+		if ok {
+			problem, err := lookupProblem(ctx, client, pick.ProblemID)
+			if err != nil {
+				return err
+			}
+			printDailyPick(date, problem)
+			return nil
+		}
+	}
+
+	minRating, maxRating := 0, 0
+	if cfg := config.Get(); cfg != nil {
+		minRating, maxRating = cfg.Difficulty.Min, cfg.Difficulty.Max
+	}
+	handle := config.GetCFHandle()
+
+	if todayAdaptive && handle != "" {
+		min, max, err := client.SuggestDifficultyBand(ctx, handle)
+		if err != nil {
+			fmt.Printf("⚠️  Could not suggest a difficulty band, using %d-%d: %v\n", minRating, maxRating, err)
+		} else {
+			minRating, maxRating = min, max
+			fmt.Printf("Suggested difficulty band: %d-%d\n", minRating, maxRating)
+		}
+	}
+
+	problems, err := client.FilterProblems(ctx, minRating, maxRating, nil, handle != "", handle)
+	if err != nil {
+		return fmt.Errorf("failed to fetch problems: %w", err)
+	}
+
+	problem, ok := pickDailyProblem(problems, handle, now)
+	if !ok {
+		return fmt.Errorf("no unsolved problems rated %d-%d", minRating, maxRating)
+	}
+
+	if wsErr == nil {
+		if err := ws.RecordDailyPick(date, problem.ProblemID()); err != nil {
+			return err
+		}
+	}
+
+	printDailyPick(date, problem)
+	return nil
+}
+
+func runTodayHistory() error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	picks, err := ws.DailyPicks()
+	if err != nil {
+		return err
+	}
+
+	if !tableOutput() {
+		return render(picks, dailyPickColumns())
+	}
+
+	if len(picks) == 0 {
+		fmt.Println("No daily picks recorded yet. Run 'cf today' to get one.")
+		return nil
+	}
+
+	solved := 0
+	for _, p := range picks {
+		if p.Status == v1.StatusSolved {
+			solved++
+		}
+	}
+
+	fmt.Printf("\nDaily picks (%d/%d solved):\n\n", solved, len(picks))
+	if err := render(picks, dailyPickColumns()); err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
+
+// dailyPickColumns describes the columns of the daily pick history
+func dailyPickColumns() []output.Column {
+	return []output.Column{
+		output.Col("Date", 10, func(p workspace.DailyPick) string { return p.Date }),
+		output.Col("Problem", 8, func(p workspace.DailyPick) string { return p.ProblemID }),
+		output.WithColor(output.Col("Status", 0, func(p workspace.DailyPick) string {
+			if p.Status == "" {
+				return "-"
+			}
+			return string(p.Status)
+		}), func(p workspace.DailyPick) string {
+			if p.Status == v1.StatusSolved {
+				return "\033[32m"
+			}
+			return ""
+		}),
+	}
+}
+
+// pickDailyProblem deterministically picks a rated problem for handle's day,
+// so that every run on the same day agrees
+func pickDailyProblem(problems []cfapi.Problem, handle string, day time.Time) (*cfapi.Problem, bool) {
+	var rated []cfapi.Problem
+	for _, p := range problems {
+		if p.Rating > 0 {
+			rated = append(rated, p)
+		}
+	}
+	if len(rated) == 0 {
+		return nil, false
+	}
+
+	pick := cfapi.PickDaily(rated, handle, day)
+	return &pick, true
+}
+
+// lookupProblem fetches a problem by its ID, e.g. 1325A
+func lookupProblem(ctx context.Context, client *cfapi.Client, problemID string) (*cfapi.Problem, error) {
+	contestID, index, err := cfapi.ParseProblemRef(problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	problem, err := client.GetProblem(ctx, contestID, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get problem %s: %w", problemID, err)
+	}
+	return problem, nil
+}
+
+func printDailyPick(date string, p *cfapi.Problem) {
+	fmt.Printf("\n📌 Problem of the day (%s):\n\n", date)
+	fmt.Printf("  %s - %s\n", p.ProblemID(), p.Name)
+	fmt.Printf("  Rating: %d\n", p.Rating)
+	fmt.Printf("  %s\n\n", p.URL())
+}
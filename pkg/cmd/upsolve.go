@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	apperrors "github.com/harshit-vibes/cf/pkg/internal/errors"
+)
+
+var upsolveCmd = &cobra.Command{
+	Use:   "upsolve <contest_id> [handle]",
+	Short: "Fetch the contest problems you did not solve",
+	Long: `Find the problems of a contest you did not solve during it and parse them
+into your workspace.
+
+Problems you submitted but did not get accepted are marked as attempted.
+Uses your configured handle if none is given.
+
+Examples:
+  cf upsolve 1325
+  cf upsolve 1325 tourist`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runUpsolve,
+}
+
+func runUpsolve(cmd *cobra.Command, args []string) error {
+	contestID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid contest ID: %s", args[0])
+	}
+
+	handle, err := getHandle(args[1:])
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := commandContext(queryTimeout)
+	defer cancel()
+
+	client := getAPIClient()
+	upsolve, err := client.GetUpsolveList(ctx, contestID, handle)
+	if err != nil {
+		return explainAPIError(fmt.Errorf("failed to get upsolve problems: %w", err))
+	}
+
+	if len(upsolve) == 0 {
+		fmt.Printf("✓ %s solved every problem of contest %d in-contest\n", handle, contestID)
+		return nil
+	}
+
+	fmt.Printf("\n📝 %d problems to upsolve in contest %d:\n\n", len(upsolve), contestID)
+
+	ws, wsErr := requireWorkspace()
+	if wsErr != nil {
+		for i := range upsolve {
+			fmt.Printf("  %s. %s (%s)\n", upsolve[i].Index, upsolve[i].Name, upsolveStatus(&upsolve[i]))
+		}
+		fmt.Printf("\n  Not saved: %v\n", wsErr)
+		return nil
+	}
+
+	refs := make([]cfapi.ProblemRef, len(upsolve))
+	for i, p := range upsolve {
+		refs[i] = cfapi.ProblemRef{ContestID: contestID, Index: p.Index}
+	}
+	parser := cfweb.NewParserWithClient(nil)
+	problems, errs := parser.ParseProblemsConcurrentContext(ctx, refs, cfweb.DefaultConcurrency)
+
+	failed := apperrors.NewMultiError(len(upsolve))
+	fetched := 0
+	for i := range upsolve {
+		u := &upsolve[i]
+		problem, err := problems[i], errs[i]
+		if err != nil {
+			fmt.Printf("  ✗ Failed to fetch %s: %v\n", u.Index, err)
+			failed.Add(u.Index, err)
+			continue
+		}
+		if _, err := saveParsedProblem(ctx, ws, client, problem, &u.Problem); err != nil {
+			fmt.Printf("  ✗ Skipped %s: %v\n", u.Index, err)
+			failed.Add(u.Index, err)
+			continue
+		}
+
+		fmt.Printf("  ✓ %s. %s (%s)\n", problem.Index, problem.Name, upsolveStatus(u))
+		fetched++
+	}
+
+	fmt.Println()
+	if stopped(ctx) {
+		reportStopped(ctx, fetched, len(upsolve), "problems")
+		return ctx.Err()
+	}
+	return failed.Err()
+}
+
+// upsolveStatus describes how far a problem got during the contest
+func upsolveStatus(u *cfapi.UpsolveProblem) string {
+	switch {
+	case !u.Attempted():
+		return "not attempted"
+	case u.RejectedAttempts == 1:
+		return "attempted, 1 rejected"
+	default:
+		return fmt.Sprintf("attempted, %d rejected", u.RejectedAttempts)
+	}
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,12 +12,16 @@ import (
 
 	"github.com/harshit-vibes/cf/pkg/external/cfapi"
 	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+	"github.com/harshit-vibes/cf/pkg/tui/styles"
 )
 
 var (
 	// user submissions flags
-	submissionsLimit  int
-	submissionsVerdict string
+	submissionsLimit     int
+	submissionsVerdict   string
+	submissionsByProblem bool
+	submissionsSince     string
 )
 
 var userCmd = &cobra.Command{
@@ -49,10 +55,21 @@ var userSubmissionsCmd = &cobra.Command{
 
 If no handle is provided, uses the configured CF handle.
 
+--by-problem collapses the listed submissions into one row per problem,
+ordered by problem index, with the number of attempts up to the first
+accepted one, the final verdict and the time of the first AC.
+
+--since only lists submissions made after a cutoff: a duration back from
+now (24h, 90m, 7d), a date (2024-03-01) or a time (2024-03-01T18:00:00Z).
+As many pages are fetched as the window needs; --verdict filters within it
+and --limit still caps the list.
+
 Examples:
   cf user submissions                 # Your recent submissions
   cf user submissions --limit 50      # Last 50 submissions
-  cf user submissions --verdict AC    # Only accepted submissions`,
+  cf user submissions --verdict AC    # Only accepted submissions
+  cf user submissions --by-problem    # Attempts and first AC per problem
+  cf user submissions --since 24h     # Everything from the last day`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runUserSubmissions,
 }
@@ -76,12 +93,18 @@ func init() {
 	userCmd.AddCommand(userInfoCmd)
 	userCmd.AddCommand(userSubmissionsCmd)
 	userCmd.AddCommand(userRatingCmd)
+	for _, c := range []*cobra.Command{userInfoCmd, userSubmissionsCmd, userRatingCmd} {
+		c.ValidArgsFunction = completeHandles
+	}
 
 	// user submissions flags
 	userSubmissionsCmd.Flags().IntVar(&submissionsLimit, "limit", 10, "Number of submissions to show")
 	userSubmissionsCmd.Flags().StringVar(&submissionsVerdict, "verdict", "", "Filter by verdict (AC, WA, TLE, etc.)")
+	userSubmissionsCmd.Flags().BoolVar(&submissionsByProblem, "by-problem", false, "Group submissions per problem with attempt counts")
+	userSubmissionsCmd.Flags().StringVar(&submissionsSince, "since", "", "Only submissions after a duration ago (24h, 7d) or a date")
 }
 
+// getHandle returns the handle argument or the configured handle
 func getHandle(args []string) (string, error) {
 	if len(args) > 0 {
 		return args[0], nil
@@ -95,67 +118,152 @@ func getHandle(args []string) (string, error) {
 	return handle, nil
 }
 
+// recordHandle adds a handle given as an argument to the recent-handles
+// history; callers use it once the API has confirmed the handle exists
+func recordHandle(args []string, handle string) {
+	if len(args) == 0 {
+		return
+	}
+	if handle, err := cfapi.NormalizeHandle(handle); err == nil {
+		_ = config.RecordHandle(handle)
+	}
+}
+
+// completeHandles completes a handle argument with the recently queried
+// handles and the configured one
+func completeHandles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []string
+	seen := make(map[string]bool)
+	for _, h := range append(config.RecentHandles(), config.GetCFHandle()) {
+		key := strings.ToLower(h)
+		if h == "" || seen[key] || !strings.HasPrefix(key, strings.ToLower(toComplete)) {
+			continue
+		}
+		seen[key] = true
+		completions = append(completions, h)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// newAPIClient constructs every API client the commands use
+// Tests replace it to serve canned responses instead of calling Codeforces.
+var newAPIClient = cfapi.NewClient
+
+// getAPIClient returns an API client for the configured user agent, tag
+// aliases and API key
 func getAPIClient() *cfapi.Client {
-	return cfapi.NewClient()
+	opts := []cfapi.ClientOption{
+		cfapi.WithUserAgent(userAgent()),
+		cfapi.WithTagAliases(config.GetTagAliases()),
+	}
+	if config.HasAPIKey() {
+		opts = append(opts, cfapi.WithAPIKey(config.GetAPICredentials()))
+	}
+	return newAPIClient(opts...)
+}
+
+// userAgent identifies this build to the CF API
+func userAgent() string {
+	return "cf/" + Version
+}
+
+// explainAPIError adds advice for the request parameter the API rejected, or
+// for a maintenance page. Other errors are returned unchanged.
+func explainAPIError(err error) error {
+	if errors.Is(err, cfapi.ErrServerMaintenance) {
+		return fmt.Errorf("%w (Codeforces appears to be under maintenance; try again later)", err)
+	}
+
+	var apiErr *cfapi.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch apiErr.Field {
+	case "handle", "handles":
+		return fmt.Errorf("%w (check the handle spelling)", err)
+	case "contestId":
+		return fmt.Errorf("%w (check the contest ID)", err)
+	case "apiKey", "apiSig", "time":
+		return fmt.Errorf("%w (check your API key with 'cf setup')", err)
+	}
+	return err
 }
 
 func runUserInfo(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
 	handle, err := getHandle(args)
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := commandContext(fetchTimeout)
 	defer cancel()
 
 	client := getAPIClient()
 	users, err := client.GetUserInfo(ctx, []string{handle})
 	if err != nil {
-		return fmt.Errorf("failed to get user info: %w", err)
+		return explainAPIError(fmt.Errorf("failed to get user info: %w", err))
 	}
 
 	if len(users) == 0 {
 		return fmt.Errorf("user %s not found", handle)
 	}
+	recordHandle(args, users[0].Handle)
 
-	u := users[0]
-
-	fmt.Printf("\n%s\n", u.Handle)
-	fmt.Println(strings.Repeat("─", 40))
-
-	// Rank with color indicator
-	rankColor := getRankColor(u.Rating)
-	fmt.Printf("  Rank:         %s%s\033[0m\n", rankColor, u.Rank)
-	fmt.Printf("  Rating:       %s%d\033[0m (max: %d)\n", rankColor, u.Rating, u.MaxRating)
-
-	if u.Country != "" {
-		location := u.Country
-		if u.City != "" {
-			location = u.City + ", " + u.Country
-		}
-		fmt.Printf("  Location:     %s\n", location)
+	if !tableOutput() {
+		return renderTo(out, users[0], userInfoColumns())
 	}
 
-	if u.Organization != "" {
-		fmt.Printf("  Organization: %s\n", u.Organization)
+	fmt.Fprintln(out)
+	if err := renderTo(out, users[0], userInfoColumns()); err != nil {
+		return err
 	}
 
-	fmt.Printf("  Contribution: %d\n", u.Contribution)
-	fmt.Printf("  Friends:      %d\n", u.FriendOfCount)
-	fmt.Printf("  Registered:   %s\n", u.RegistrationTime().Format("Jan 2006"))
-	fmt.Printf("  Last Online:  %s\n", formatTimeAgo(u.LastOnline()))
-
-	fmt.Println()
+	fmt.Fprintln(out)
 	return nil
 }
 
+// userInfoColumns describes the fields shown for a user profile
+func userInfoColumns() []output.Column {
+	rankColor := func(u cfapi.User) string { return getRankColor(u.Rating) }
+
+	return []output.Column{
+		output.Col("Handle", 0, func(u cfapi.User) string { return u.Handle }),
+		output.WithColor(output.Col("Rank", 0, func(u cfapi.User) string { return displayRank(&u) }), rankColor),
+		output.WithColor(output.Col("Rating", 0, func(u cfapi.User) string { return displayRating(&u) }), rankColor),
+		output.Col("Max Rating", 0, func(u cfapi.User) string {
+			if u.IsUnrated() {
+				return ""
+			}
+			return strconv.Itoa(u.MaxRating)
+		}),
+		output.Col("Location", 0, func(u cfapi.User) string {
+			if u.City != "" && u.Country != "" {
+				return u.City + ", " + u.Country
+			}
+			return u.Country
+		}),
+		output.Col("Organization", 0, func(u cfapi.User) string { return u.Organization }),
+		output.Col("Contribution", 0, func(u cfapi.User) string { return strconv.Itoa(u.Contribution) }),
+		output.Col("Friends", 0, func(u cfapi.User) string { return strconv.Itoa(u.FriendOfCount) }),
+		output.Col("Registered", 0, func(u cfapi.User) string { return u.RegistrationTime().Format("Jan 2006") }),
+		output.Col("Last Online", 0, func(u cfapi.User) string { return formatTimeAgo(u.LastOnline()) }),
+	}
+}
+
 func runUserSubmissions(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
 	handle, err := getHandle(args)
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := commandContext(fetchTimeout)
 	defer cancel()
 
 	client := getAPIClient()
@@ -166,10 +274,20 @@ func runUserSubmissions(cmd *cobra.Command, args []string) error {
 		fetchCount = submissionsLimit * 5 // Fetch extra to ensure we get enough after filtering
 	}
 
-	submissions, err := client.GetUserSubmissions(ctx, handle, 1, fetchCount)
+	var submissions []cfapi.Submission
+	if submissionsSince != "" {
+		cutoff, perr := parseSince(submissionsSince, time.Now())
+		if perr != nil {
+			return perr
+		}
+		submissions, err = client.GetSubmissionsSince(ctx, handle, cutoff)
+	} else {
+		submissions, err = client.GetUserSubmissions(ctx, handle, 1, fetchCount)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get submissions: %w", err)
+		return explainAPIError(fmt.Errorf("failed to get submissions: %w", err))
 	}
+	recordHandle(args, handle)
 
 	// Filter by verdict if specified
 	if submissionsVerdict != "" {
@@ -188,93 +306,142 @@ func runUserSubmissions(cmd *cobra.Command, args []string) error {
 		submissions = submissions[:submissionsLimit]
 	}
 
+	if submissionsByProblem {
+		return renderProblemAttempts(out, handle, cfapi.GroupByProblem(submissions))
+	}
+
+	if !tableOutput() {
+		return renderTo(out, submissions, submissionColumns())
+	}
+
 	if len(submissions) == 0 {
-		fmt.Println("No submissions found.")
+		fmt.Fprintln(out, "No submissions found.")
 		return nil
 	}
 
-	fmt.Printf("\nRecent submissions for %s:\n\n", handle)
-	fmt.Printf("%-12s %-10s %-40s %8s  %s\n", "Time", "Problem", "Name", "Verdict", "Language")
-	fmt.Println(strings.Repeat("─", 100))
+	fmt.Fprintf(out, "\nRecent submissions for %s:\n\n", handle)
+	if err := renderTo(out, submissions, submissionColumns()); err != nil {
+		return err
+	}
 
-	for _, s := range submissions {
-		name := s.Problem.Name
-		if len(name) > 38 {
-			name = name[:35] + "..."
+	fmt.Fprintln(out)
+	return nil
+}
+
+// parseSince returns the cutoff of --since: now minus a duration such as 24h
+// or 7d, the local midnight starting a date, or an RFC3339 time
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, -n), nil
 		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since: %s (use e.g. 24h, 7d or 2024-03-01)", value)
+}
 
-		verdictColor := getVerdictColor(s.Verdict)
-		timeStr := s.SubmissionTime().Format("Jan 02 15:04")
+// submissionColumns describes the fields shown for each submission
+func submissionColumns() []output.Column {
+	return []output.Column{
+		output.Col("Time", 12, func(s cfapi.Submission) string { return s.SubmissionTime().Format("Jan 02 15:04") }),
+		output.Col("Problem", 10, func(s cfapi.Submission) string { return s.Problem.ProblemID() }),
+		output.Col("Name", 40, func(s cfapi.Submission) string { return s.Problem.Name }),
+		output.WithColor(output.Col("Verdict", 8, func(s cfapi.Submission) string { return s.Verdict }),
+			func(s cfapi.Submission) string { return getVerdictColor(s.Verdict) }),
+		output.Col("Language", 0, func(s cfapi.Submission) string { return s.ProgrammingLanguage }),
+	}
+}
 
-		fmt.Printf("%-12s %-10s %-40s %s%-8s\033[0m  %s\n",
-			timeStr,
-			s.Problem.ProblemID(),
-			name,
-			verdictColor,
-			s.Verdict,
-			s.ProgrammingLanguage,
-		)
+// renderProblemAttempts shows submissions grouped per problem
+func renderProblemAttempts(out io.Writer, handle string, attempts []cfapi.ProblemAttempts) error {
+	if !tableOutput() {
+		return renderTo(out, attempts, problemAttemptColumns())
 	}
 
-	fmt.Println()
+	if len(attempts) == 0 {
+		fmt.Fprintln(out, "No submissions found.")
+		return nil
+	}
+
+	fmt.Fprintf(out, "\nAttempts per problem for %s:\n\n", handle)
+	if err := renderTo(out, attempts, problemAttemptColumns()); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
 	return nil
 }
 
+// problemAttemptColumns describes the fields shown for each problem in --by-problem
+func problemAttemptColumns() []output.Column {
+	return []output.Column{
+		output.Col("Problem", 10, func(a cfapi.ProblemAttempts) string { return a.Problem.ProblemID() }),
+		output.Col("Name", 40, func(a cfapi.ProblemAttempts) string { return a.Problem.Name }),
+		output.WithColor(output.Col("Result", 20, formatAttempts),
+			func(a cfapi.ProblemAttempts) string { return getVerdictColor(a.Verdict) }),
+		output.Col("First AC", 0, func(a cfapi.ProblemAttempts) string {
+			if !a.Solved() {
+				return "-"
+			}
+			return a.AcceptedTime().Format("Jan 02 15:04")
+		}),
+	}
+}
+
+// formatAttempts summarizes a problem's attempts, e.g. "3 attempts, AC"
+func formatAttempts(a cfapi.ProblemAttempts) string {
+	noun := "attempts"
+	if a.Attempts == 1 {
+		noun = "attempt"
+	}
+	return fmt.Sprintf("%d %s, %s", a.Attempts, noun, styles.GetVerdictShort(a.Verdict))
+}
+
 func runUserRating(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
 	handle, err := getHandle(args)
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := commandContext(fetchTimeout)
 	defer cancel()
 
 	client := getAPIClient()
 	changes, err := client.GetUserRating(ctx, handle)
 	if err != nil {
-		return fmt.Errorf("failed to get rating history: %w", err)
+		return explainAPIError(fmt.Errorf("failed to get rating history: %w", err))
+	}
+	recordHandle(args, handle)
+
+	if !tableOutput() {
+		return renderTo(out, changes, ratingColumns())
 	}
 
 	if len(changes) == 0 {
-		fmt.Printf("%s has not participated in any rated contests.\n", handle)
+		fmt.Fprintf(out, "%s has not participated in any rated contests.\n", handle)
 		return nil
 	}
 
-	fmt.Printf("\nRating history for %s (%d contests):\n\n", handle, len(changes))
-	fmt.Printf("%-12s %-50s %5s → %5s  %s\n", "Date", "Contest", "Old", "New", "Delta")
-	fmt.Println(strings.Repeat("─", 100))
+	fmt.Fprintf(out, "\nRating history for %s (%d contests):\n\n", handle, len(changes))
 
 	// Show last 15 contests (most recent)
 	start := 0
 	if len(changes) > 15 {
 		start = len(changes) - 15
-		fmt.Printf("  ... %d earlier contests ...\n", start)
+		fmt.Fprintf(out, "  ... %d earlier contests ...\n", start)
 	}
 
-	for i := start; i < len(changes); i++ {
-		rc := changes[i]
-		contestName := rc.ContestName
-		if len(contestName) > 48 {
-			contestName = contestName[:45] + "..."
-		}
-
-		delta := rc.RatingDelta()
-		deltaStr := fmt.Sprintf("%+d", delta)
-		deltaColor := "\033[32m" // green
-		if delta < 0 {
-			deltaColor = "\033[31m" // red
-		}
-
-		date := time.Unix(rc.RatingUpdateTimeSeconds, 0).Format("Jan 02 2006")
-
-		fmt.Printf("%-12s %-50s %5d → %5d  %s%s\033[0m\n",
-			date,
-			contestName,
-			rc.OldRating,
-			rc.NewRating,
-			deltaColor,
-			deltaStr,
-		)
+	if err := renderTo(out, changes[start:], ratingColumns()); err != nil {
+		return err
 	}
 
 	// Summary
@@ -282,18 +449,53 @@ func runUserRating(cmd *cobra.Command, args []string) error {
 	last := changes[len(changes)-1]
 	totalDelta := last.NewRating - first.OldRating
 
-	fmt.Println(strings.Repeat("─", 100))
-	deltaColor := "\033[32m"
-	if totalDelta < 0 {
-		deltaColor = "\033[31m"
-	}
-	fmt.Printf("Total change: %s%+d\033[0m over %d contests\n", deltaColor, totalDelta, len(changes))
-	fmt.Println()
+	fmt.Fprintln(out, strings.Repeat("─", 100))
+	fmt.Fprintf(out, "Total change: %s%+d\033[0m over %d contests\n", getDeltaColor(totalDelta), totalDelta, len(changes))
+	fmt.Fprintln(out)
 
 	return nil
 }
 
+// ratingColumns describes the fields shown for each rating change
+func ratingColumns() []output.Column {
+	return []output.Column{
+		output.Col("Date", 12, func(rc cfapi.RatingChange) string {
+			return time.Unix(rc.RatingUpdateTimeSeconds, 0).Format("Jan 02 2006")
+		}),
+		output.Col("Contest", 50, func(rc cfapi.RatingChange) string { return rc.ContestName }),
+		output.Col("Old", 5, func(rc cfapi.RatingChange) string { return strconv.Itoa(rc.OldRating) }).AlignRight(),
+		output.Col("New", 5, func(rc cfapi.RatingChange) string { return strconv.Itoa(rc.NewRating) }).AlignRight(),
+		output.WithColor(output.Col("Delta", 0, func(rc cfapi.RatingChange) string { return fmt.Sprintf("%+d", rc.RatingDelta()) }),
+			func(rc cfapi.RatingChange) string { return getDeltaColor(rc.RatingDelta()) }),
+	}
+}
+
+// getDeltaColor returns ANSI color code for a rating change
+func getDeltaColor(delta int) string {
+	if delta < 0 {
+		return "\033[31m" // red
+	}
+	return "\033[32m" // green
+}
+
+// displayRank returns the user's rank, or "Unrated" if they have none
+func displayRank(u *cfapi.User) string {
+	if u.Rank == "" {
+		return "Unrated"
+	}
+	return u.Rank
+}
+
+// displayRating returns the user's rating, or "Unrated" instead of 0
+func displayRating(u *cfapi.User) string {
+	if u.IsUnrated() {
+		return "Unrated"
+	}
+	return strconv.Itoa(u.Rating)
+}
+
 // getRankColor returns ANSI color code for CF rank
+// Unrated users (rating 0) get the newbie gray
 func getRankColor(rating int) string {
 	switch {
 	case rating >= 3000:
@@ -315,7 +517,7 @@ func getRankColor(rating int) string {
 	case rating >= 1200:
 		return "\033[32m" // green - pupil
 	default:
-		return "\033[90m" // gray - newbie
+		return "\033[90m" // gray - newbie and unrated
 	}
 }
 
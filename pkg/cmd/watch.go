@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/internal/notify"
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+)
+
+var (
+	// watch flags
+	watchInterval time.Duration
+	watchCount    int
+	watchNotify   bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [handle]",
+	Short: "Show verdicts of your submissions live",
+	Long: `Poll your recent submissions and print each one when its verdict changes.
+
+Runs until Ctrl-C. Submissions already judged when the watch starts are not
+shown; ones still in the queue or being tested are.
+Uses your configured handle if none is given.
+With --notify, a desktop notification is shown when a submission gets its
+final verdict (notify-send, osascript or a Windows toast, falling back to
+the terminal bell).
+With -o json each change is printed as one JSON object per line; -o csv
+prints the header once.
+
+Examples:
+  cf watch
+  cf watch tourist --interval 10s
+  cf watch --notify`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.ValidArgsFunction = completeHandles
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Second, "Time between polls")
+	watchCmd.Flags().IntVar(&watchCount, "count", 20, "Number of recent submissions to watch")
+	watchCmd.Flags().BoolVar(&watchNotify, "notify", false, "Show a desktop notification for final verdicts")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	handle, err := getHandle(args)
+	if err != nil {
+		return err
+	}
+
+	// JSON and CSV stream one record per change; messages go to stderr so
+	// stdout stays parseable
+	out, status := cmd.OutOrStdout(), cmd.OutOrStdout()
+	var stream *output.Stream
+	if !tableOutput() {
+		status = cmd.ErrOrStderr()
+		if stream, err = output.NewStream(out, outputFormat, submissionColumns()); err != nil {
+			return err
+		}
+	}
+
+	interval := watchInterval
+	if interval < cfapi.MinRequestInterval {
+		fmt.Fprintf(status, "⚠️  Interval raised to %v, the API rate limit\n", cfapi.MinRequestInterval)
+		interval = cfapi.MinRequestInterval
+	}
+
+	ctx, cancel := watchContext()
+	defer cancel()
+
+	client := getAPIClient()
+	seen := make(map[int64]string)
+	first := true
+	notifyFailed := false
+
+	fmt.Fprintf(status, "👀 Watching submissions of %s every %v (Ctrl-C to stop)\n\n", handle, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		submissions, err := client.GetLatestSubmissions(ctx, handle, watchCount)
+		switch {
+		case stopped(ctx):
+			return nil
+		case err != nil:
+			fmt.Fprintf(status, "  ✗ %v\n", explainAPIError(err))
+		default:
+			changed := changedSubmissions(seen, submissions)
+			if first {
+				changed = slices.DeleteFunc(changed, func(s cfapi.Submission) bool { return !isPending(s.Verdict) })
+				first = false
+				recordHandle(args, handle)
+			}
+			if err := printWatched(out, stream, changed); err != nil {
+				return err
+			}
+			if watchNotify {
+				if err := notifyVerdicts(changed); err != nil && !notifyFailed {
+					fmt.Fprintf(status, "⚠️  Desktop notification failed, using the terminal bell: %v\n", err)
+					notifyFailed = true
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// changedSubmissions returns the submissions that are new or whose verdict or
+// passed test count changed since the last poll, oldest first, and records
+// their state in seen
+func changedSubmissions(seen map[int64]string, submissions []cfapi.Submission) []cfapi.Submission {
+	var changed []cfapi.Submission
+	for i := len(submissions) - 1; i >= 0; i-- {
+		s := submissions[i]
+		state := fmt.Sprintf("%s/%d", s.Verdict, s.PassedTestCount)
+		if seen[s.ID] == state {
+			continue
+		}
+		seen[s.ID] = state
+		changed = append(changed, s)
+	}
+	return changed
+}
+
+// isPending returns true if a submission is still queued or being judged
+func isPending(verdict string) bool {
+	return verdict == "" || verdict == cfapi.VerdictTesting
+}
+
+// notifyVerdicts sends a desktop notification for each submission that got
+// its final verdict and returns the last error of the notifier, if any
+func notifyVerdicts(changed []cfapi.Submission) error {
+	var lastErr error
+	for _, s := range changed {
+		if isPending(s.Verdict) {
+			continue
+		}
+		title, message := verdictNotification(s)
+		if err := notify.Send(title, message); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// verdictNotification returns the title and message notifying a final verdict
+func verdictNotification(s cfapi.Submission) (string, string) {
+	title := fmt.Sprintf("%s: %s", s.Problem.ProblemID(), s.Verdict)
+	switch s.Verdict {
+	case cfapi.VerdictOK:
+		return title, fmt.Sprintf("%s · %d ms, %d KB", s.Problem.Name, s.TimeConsumedMillis, s.MemoryConsumedBytes/1024)
+	case cfapi.VerdictCompilationError:
+		return title, s.Problem.Name
+	default:
+		return title, fmt.Sprintf("%s · on test %d", s.Problem.Name, s.PassedTestCount+1)
+	}
+}
+
+// printWatched prints one line per changed submission, or appends them to
+// stream when the output is JSON or CSV
+func printWatched(w io.Writer, stream *output.Stream, changed []cfapi.Submission) error {
+	if stream != nil {
+		return stream.Write(changed)
+	}
+
+	for _, s := range changed {
+		fmt.Fprintln(w, watchLine(s))
+	}
+	return nil
+}
+
+// watchLine formats a submission as a compact, colored line
+func watchLine(s cfapi.Submission) string {
+	verdict := s.Verdict
+	detail := ""
+	switch {
+	case verdict == "":
+		verdict = "IN QUEUE"
+	case verdict == cfapi.VerdictOK:
+		detail = fmt.Sprintf("%d ms, %d KB", s.TimeConsumedMillis, s.MemoryConsumedBytes/1024)
+	case isPending(verdict):
+		detail = fmt.Sprintf("passed %d", s.PassedTestCount)
+	case verdict == cfapi.VerdictCompilationError:
+	default:
+		detail = fmt.Sprintf("on test %d", s.PassedTestCount+1)
+	}
+
+	return fmt.Sprintf("  %s  %-7s %s%-22s\033[0m %s",
+		s.SubmissionTime().Format("15:04:05"), s.Problem.ProblemID(), getVerdictColor(s.Verdict), verdict, detail)
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/internal/output"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+var workspaceCmd = &cobra.Command{
+	Use:     "workspace",
+	Aliases: []string{"ws"},
+	Short:   "Workspace-wide summaries",
+	Long:    `Commands that look at every problem saved in the workspace.`,
+}
+
+var workspaceStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show totals over the saved problems",
+	Long: `Count the saved problems by status and add up the time spent on them,
+with the rating and tag spread of the solved ones.
+
+Unlike 'cf stats', nothing is fetched: the totals come from each problem's
+problem.yaml, so they work without a handle or a synced progress file.
+
+Examples:
+  cf workspace stats
+  cf workspace stats -o json`,
+	Args: cobra.NoArgs,
+	RunE: runWorkspaceStats,
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceStatsCmd)
+}
+
+func runWorkspaceStats(cmd *cobra.Command, args []string) error {
+	ws, err := requireWorkspace()
+	if err != nil {
+		return err
+	}
+
+	stats, err := ws.Stats()
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if !tableOutput() {
+		return renderTo(out, stats, workspaceStatsColumns())
+	}
+	printWorkspaceStats(out, stats)
+	return nil
+}
+
+// workspaceStatsColumns describes the totals of a workspace
+func workspaceStatsColumns() []output.Column {
+	return []output.Column{
+		output.Col("Total", 0, func(s *v1.WorkspaceStats) string { return strconv.Itoa(s.Total) }),
+		output.Col("Solved", 0, func(s *v1.WorkspaceStats) string { return strconv.Itoa(s.Solved) }),
+		output.Col("Attempted", 0, func(s *v1.WorkspaceStats) string { return strconv.Itoa(s.Attempted) }),
+		output.Col("Todo", 0, func(s *v1.WorkspaceStats) string { return strconv.Itoa(s.Todo) }),
+		output.Col("Unseen", 0, func(s *v1.WorkspaceStats) string { return strconv.Itoa(s.Unseen) }),
+		output.Col("Time Spent", 0, func(s *v1.WorkspaceStats) string { return strconv.Itoa(s.TotalTime) }),
+	}
+}
+
+// printWorkspaceStats prints the summary of a workspace
+func printWorkspaceStats(out io.Writer, stats *v1.WorkspaceStats) {
+	if stats.Total == 0 {
+		fmt.Fprintln(out, "No problems in the workspace yet. Add one with 'cf parse <problem>'.")
+		return
+	}
+
+	fmt.Fprintf(out, "\n📁 Workspace: %d problems\n", stats.Total)
+	fmt.Fprintf(out, "   Solved:     %d\n", stats.Solved)
+	fmt.Fprintf(out, "   Attempted:  %d\n", stats.Attempted)
+	fmt.Fprintf(out, "   Todo:       %d\n", stats.Todo)
+	fmt.Fprintf(out, "   Unseen:     %d\n", stats.Unseen)
+	if stats.TotalTime > 0 {
+		fmt.Fprintf(out, "   Time spent: %s\n", formatDuration(time.Duration(stats.TotalTime)*time.Second))
+	}
+
+	if len(stats.RatingDistribution) > 0 {
+		fmt.Fprintf(out, "\n⭐ Solved by Rating:\n")
+		buckets := make([]string, 0, len(stats.RatingDistribution))
+		for b := range stats.RatingDistribution {
+			buckets = append(buckets, b)
+		}
+		sort.Slice(buckets, func(i, j int) bool { return bucketStart(buckets[i]) < bucketStart(buckets[j]) })
+		for _, b := range buckets {
+			count := stats.RatingDistribution[b]
+			fmt.Fprintf(out, "   %-10s %3d %s\n", b, count, strings.Repeat("█", min(count, 30)))
+		}
+	}
+
+	if len(stats.TagDistribution) > 0 {
+		fmt.Fprintf(out, "\n🏷️  Solved by Tag:\n")
+		tags := make([]string, 0, len(stats.TagDistribution))
+		for t := range stats.TagDistribution {
+			tags = append(tags, t)
+		}
+		sort.Slice(tags, func(i, j int) bool {
+			ci, cj := stats.TagDistribution[tags[i]], stats.TagDistribution[tags[j]]
+			if ci != cj {
+				return ci > cj
+			}
+			return tags[i] < tags[j]
+		})
+		for i, t := range tags {
+			if i >= 10 {
+				break
+			}
+			count := stats.TagDistribution[t]
+			fmt.Fprintf(out, "   %-20s %3d %s\n", t, count, strings.Repeat("█", min(count, 20)))
+		}
+	}
+	fmt.Fprintln(out)
+}
+
+// bucketStart returns the lowest rating of a bucket such as 1200-1399 or 2400+
+func bucketStart(bucket string) int {
+	n, _ := strconv.Atoi(strings.TrimRight(strings.SplitN(bucket, "-", 2)[0], "+"))
+	return n
+}
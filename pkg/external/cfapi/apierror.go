@@ -0,0 +1,53 @@
+package cfapi
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// reCommentField matches the "param: message" form of API failure comments
+var reCommentField = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*):\s*(.*)$`)
+
+// APIError is returned when the API answers a request with status FAILED
+// Field names the request parameter the API rejected, e.g. "handle" or
+// "contestId", and is empty when the comment does not name one.
+type APIError struct {
+	Comment string
+	Field   string
+	Message string
+}
+
+func newAPIError(comment string) *APIError {
+	field, message := parseComment(comment)
+	return &APIError{Comment: comment, Field: field, Message: message}
+}
+
+func (e *APIError) Error() string {
+	return "api error: " + e.Comment
+}
+
+// NotFound returns true if the API could not find the value given for Field
+func (e *APIError) NotFound() bool {
+	return strings.Contains(strings.ToLower(e.Message), "not found")
+}
+
+// parseComment splits an API failure comment like
+// "handle: User with handle X not found" into the parameter and message
+// Comments without a parameter prefix are returned whole as the message.
+func parseComment(comment string) (field, message string) {
+	comment = strings.TrimSpace(comment)
+	if m := reCommentField.FindStringSubmatch(comment); m != nil {
+		return m[1], m[2]
+	}
+	return "", comment
+}
+
+// apiErrorFromBody returns the APIError in a FAILED response body, or nil
+func apiErrorFromBody(body []byte) *APIError {
+	var resp Response[json.RawMessage]
+	if json.Unmarshal(body, &resp) != nil || resp.Status != "FAILED" {
+		return nil
+	}
+	return newAPIError(resp.Comment)
+}
@@ -0,0 +1,74 @@
+package cfapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestParseComment(t *testing.T) {
+	tests := []struct {
+		comment string
+		field   string
+		message string
+	}{
+		{"handle: User with handle nonexistent not found", "handle", "User with handle nonexistent not found"},
+		{"handles: User with handle nobody not found", "handles", "User with handle nobody not found"},
+		{"contestId: Contest with id 999999 not found", "contestId", "Contest with id 999999 not found"},
+		{"apiKey: Time not valid", "apiKey", "Time not valid"},
+		{"Problem not found", "", "Problem not found"},
+		{"API error message", "", "API error message"},
+		{"Call limit exceeded", "", "Call limit exceeded"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.comment, func(t *testing.T) {
+			field, message := parseComment(tt.comment)
+			if field != tt.field || message != tt.message {
+				t.Errorf("parseComment(%q) = %q, %q, want %q, %q", tt.comment, field, message, tt.field, tt.message)
+			}
+		})
+	}
+}
+
+func TestAPIError_FromResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+	}{
+		{"status FAILED", 200},
+		{"bad request", 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &mockTransport{
+				statusCode: tt.statusCode,
+				body:       `{"status":"FAILED","comment":"handles: User with handle nobody not found"}`,
+			}
+			client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+			_, err := client.GetUserInfo(context.Background(), []string{"nobody"})
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetUserInfo() error = %v, want an APIError", err)
+			}
+			if apiErr.Field != "handles" || !apiErr.NotFound() {
+				t.Errorf("APIError = %+v, want a not found handles error", apiErr)
+			}
+		})
+	}
+}
+
+func TestStatusError_UnwrapWithoutAPIError(t *testing.T) {
+	err := &StatusError{StatusCode: 503, Body: "<html>Service Unavailable</html>"}
+	if err.Unwrap() != nil {
+		t.Errorf("Unwrap() = %v, want nil for a non-API body", err.Unwrap())
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		t.Error("errors.As should not find an APIError in an HTML body")
+	}
+}
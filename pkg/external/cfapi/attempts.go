@@ -0,0 +1,69 @@
+package cfapi
+
+import (
+	"sort"
+	"time"
+)
+
+// ProblemAttempts summarizes a user's submissions to one problem
+type ProblemAttempts struct {
+	Problem  Problem `json:"problem"`
+	Attempts int     `json:"attempts"` // Submissions up to the first accepted one, or all if unsolved
+	Verdict  string  `json:"verdict"`  // OK once accepted, otherwise the latest verdict
+	// AcceptedTimeSeconds is when the first accepted submission was made, 0 if unsolved
+	AcceptedTimeSeconds int64 `json:"acceptedTimeSeconds,omitempty"`
+}
+
+// Solved returns true if the problem has an accepted submission
+func (a *ProblemAttempts) Solved() bool {
+	return a.AcceptedTimeSeconds > 0
+}
+
+// AcceptedTime returns the time of the first accepted submission
+func (a *ProblemAttempts) AcceptedTime() time.Time {
+	return time.Unix(a.AcceptedTimeSeconds, 0)
+}
+
+// GroupByProblem collapses submissions into one entry per problem, ordered
+// by contest and then problem index. Submissions made after the first
+// accepted one don't count as attempts.
+func GroupByProblem(subs []Submission) []ProblemAttempts {
+	ordered := make([]Submission, len(subs))
+	copy(ordered, subs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].CreationTimeSeconds < ordered[j].CreationTimeSeconds
+	})
+
+	byProblem := make(map[string]*ProblemAttempts)
+	var groups []*ProblemAttempts
+	for _, s := range ordered {
+		id := s.Problem.ProblemID()
+		a, ok := byProblem[id]
+		if !ok {
+			a = &ProblemAttempts{Problem: s.Problem}
+			byProblem[id] = a
+			groups = append(groups, a)
+		}
+		if a.Solved() {
+			continue
+		}
+		a.Attempts++
+		a.Verdict = s.Verdict
+		if s.IsAccepted() {
+			a.AcceptedTimeSeconds = s.CreationTimeSeconds
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Problem.ContestID != groups[j].Problem.ContestID {
+			return groups[i].Problem.ContestID < groups[j].Problem.ContestID
+		}
+		return groups[i].Problem.Index < groups[j].Problem.Index
+	})
+
+	attempts := make([]ProblemAttempts, len(groups))
+	for i, a := range groups {
+		attempts[i] = *a
+	}
+	return attempts
+}
@@ -0,0 +1,114 @@
+package cfapi
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithAPIKey signs every request with the given Codeforces API key and secret
+func WithAPIKey(key, secret string) ClientOption {
+	return func(c *Client) {
+		c.apiKey = key
+		c.apiSecret = secret
+	}
+}
+
+// ClockOffset returns the detected difference between the Codeforces server
+// clock and the local clock, or 0 if no skew has been detected
+func (c *Client) ClockOffset() time.Duration {
+	return time.Duration(c.clockOffset.Load())
+}
+
+// signed returns true if requests are authenticated with an API key
+func (c *Client) signed() bool {
+	return c.apiKey != "" && c.apiSecret != ""
+}
+
+// signRequest returns a copy of params with apiKey, time and apiSig set
+// The time is shifted by the detected clock offset
+func (c *Client) signRequest(method string, params url.Values) url.Values {
+	signed := url.Values{}
+	for k, v := range params {
+		signed[k] = append([]string(nil), v...)
+	}
+
+	now := time.Now().Add(c.ClockOffset())
+	signed.Set("apiKey", c.apiKey)
+	signed.Set("time", strconv.FormatInt(now.Unix(), 10))
+
+	prefix := fmt.Sprintf("%06d", rand.IntN(1000000))
+	signed.Set("apiSig", prefix+apiSignature(prefix, method, signed, c.apiSecret))
+
+	return signed
+}
+
+// apiSignature computes sha512hex("<rand>/<method>?<sorted params>#<secret>")
+func apiSignature(prefix, method string, params url.Values, secret string) string {
+	type pair struct{ key, value string }
+
+	var pairs []pair
+	for k, values := range params {
+		if k == "apiSig" {
+			continue
+		}
+		for _, v := range values {
+			pairs = append(pairs, pair{k, v})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].key != pairs[j].key {
+			return pairs[i].key < pairs[j].key
+		}
+		return pairs[i].value < pairs[j].value
+	})
+
+	parts := make([]string, len(pairs))
+	for i, p := range pairs {
+		parts[i] = p.key + "=" + p.value
+	}
+
+	sum := sha512.Sum512([]byte(prefix + "/" + method + "?" + strings.Join(parts, "&") + "#" + secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// clockSkewError is returned when a signed request is rejected for its time
+// and the server clock could be read from the response
+type clockSkewError struct {
+	offset time.Duration
+	err    error
+}
+
+func (e *clockSkewError) Error() string { return e.err.Error() }
+func (e *clockSkewError) Unwrap() error { return e.err }
+
+// detectClockSkew checks a failed response for a signature time error and
+// computes the server clock offset from its Date header
+func detectClockSkew(header http.Header, body []byte, sentAt time.Time, err error) error {
+	var resp Response[json.RawMessage]
+	if json.Unmarshal(body, &resp) != nil || !isTimeError(resp.Comment) {
+		return err
+	}
+
+	serverTime, parseErr := http.ParseTime(header.Get("Date"))
+	if parseErr != nil {
+		return err
+	}
+
+	return &clockSkewError{offset: serverTime.Sub(sentAt).Round(time.Second), err: err}
+}
+
+// isTimeError returns true if an API comment rejects the request time
+func isTimeError(comment string) bool {
+	comment = strings.ToLower(comment)
+	return strings.Contains(comment, "time") &&
+		(strings.Contains(comment, "not valid") || strings.Contains(comment, "invalid"))
+}
@@ -0,0 +1,96 @@
+package cfapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+const (
+	// RecentSolveWindow is how many of the latest rated solves SuggestDifficultyBand looks at
+	RecentSolveWindow = 20
+
+	// MinRecentSolves is how many rated solves are needed before the band is
+	// moved away from the user's rating
+	MinRecentSolves = 5
+
+	// Band edges relative to its base rating
+	bandBelow = 100
+	bandAbove = 300
+
+	minBandRating = 800
+	maxBandRating = 3500
+)
+
+// SuggestDifficultyBand suggests a practice band for handle from their current
+// rating and the ratings of their latest solves. The band starts around the
+// rating and moves up when the user is consistently solving harder problems.
+// Unrated users get a band around their recent solves.
+func (c *Client) SuggestDifficultyBand(ctx context.Context, handle string) (min, max int, err error) {
+	users, err := c.GetUserInfo(ctx, []string{handle})
+	if err != nil {
+		return 0, 0, fmt.Errorf("get user info: %w", err)
+	}
+	if len(users) == 0 {
+		return 0, 0, fmt.Errorf("user %s: %w", handle, ErrInvalidHandle)
+	}
+
+	solved, err := c.GetSolvedProblems(ctx, handle)
+	if err != nil {
+		return 0, 0, fmt.Errorf("get solved problems: %w", err)
+	}
+
+	// Solved problems come newest first
+	var recent []int
+	for _, p := range solved {
+		if p.Rating > 0 {
+			recent = append(recent, p.Rating)
+		}
+		if len(recent) == RecentSolveWindow {
+			break
+		}
+	}
+
+	min, max = suggestBand(users[0].Rating, recent)
+	return min, max, nil
+}
+
+// suggestBand returns the band for a user rating and recent solve ratings
+// The base is the rating rounded down to 100, raised to the median recent
+// solve when that is higher and there are at least MinRecentSolves of them.
+func suggestBand(rating int, recent []int) (min, max int) {
+	base := rating / 100 * 100
+	if len(recent) >= MinRecentSolves || (rating == 0 && len(recent) > 0) {
+		if m := medianRating(recent) / 100 * 100; m > base {
+			base = m
+		}
+	}
+	if base == 0 {
+		base = minBandRating
+	}
+
+	min = clampRating(base - bandBelow)
+	max = clampRating(base + bandAbove)
+	return min, max
+}
+
+// medianRating returns the median of ratings, rounding down between two values
+func medianRating(ratings []int) int {
+	sorted := append([]int(nil), ratings...)
+	sort.Ints(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+func clampRating(r int) int {
+	if r < minBandRating {
+		return minBandRating
+	}
+	if r > maxBandRating {
+		return maxBandRating
+	}
+	return r
+}
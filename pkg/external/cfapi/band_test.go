@@ -0,0 +1,27 @@
+package cfapi
+
+import "testing"
+
+func TestSuggestBand(t *testing.T) {
+	tests := []struct {
+		name     string
+		rating   int
+		recent   []int
+		min, max int
+	}{
+		{"around rating", 1432, []int{1200, 1300, 1400, 1300, 1200}, 1300, 1700},
+		{"raised by harder solves", 1400, []int{1800, 1900, 1700, 1800, 1800}, 1700, 2100},
+		{"too few solves to move", 1400, []int{2400, 2400}, 1300, 1700},
+		{"unrated uses recent solves", 0, []int{1000, 1100}, 900, 1300},
+		{"unrated newcomer", 0, nil, 800, 1100},
+		{"top of the scale", 3600, nil, 3500, 3500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, max := suggestBand(tt.rating, tt.recent)
+			if min != tt.min || max != tt.max {
+				t.Errorf("suggestBand(%d, %v) = %d-%d, want %d-%d", tt.rating, tt.recent, min, max, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package cfapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// UserInfoChunkSize is how many handles GetUsersBulk asks for per request
+const UserInfoChunkSize = 100
+
+// reMissingHandle matches the handle in "User with handle X not found"
+var reMissingHandle = regexp.MustCompile(`(?i)handle (\S+) not found`)
+
+// MissingHandlesError lists the handles GetUsersBulk could not find
+type MissingHandlesError struct {
+	Handles []string
+}
+
+func (e *MissingHandlesError) Error() string {
+	return fmt.Sprintf("%d handles not found: %s", len(e.Handles), strings.Join(e.Handles, ", "))
+}
+
+// GetUsersBulk fetches users in chunks of UserInfoChunkSize and returns them
+// sorted by rating, highest first, for a leaderboard. Handles that are
+// malformed or don't exist are left out and listed in a *MissingHandlesError
+// returned along with the users found, so one bad handle doesn't drop the
+// whole roster.
+func (c *Client) GetUsersBulk(ctx context.Context, handles []string) ([]User, error) {
+	var valid, missing []string
+	seen := make(map[string]bool, len(handles))
+	for _, h := range handles {
+		handle, err := NormalizeHandle(h)
+		if err != nil {
+			missing = append(missing, h)
+			continue
+		}
+		if key := strings.ToLower(handle); !seen[key] {
+			seen[key] = true
+			valid = append(valid, handle)
+		}
+	}
+
+	var users []User
+	for start := 0; start < len(valid); start += UserInfoChunkSize {
+		chunk := valid[start:min(start+UserInfoChunkSize, len(valid))]
+		found, notFound, err := c.getUserChunk(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, found...)
+		missing = append(missing, notFound...)
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		if users[i].Rating != users[j].Rating {
+			return users[i].Rating > users[j].Rating
+		}
+		return strings.ToLower(users[i].Handle) < strings.ToLower(users[j].Handle)
+	})
+
+	if len(missing) > 0 {
+		return users, &MissingHandlesError{Handles: missing}
+	}
+	return users, nil
+}
+
+// getUserChunk fetches one chunk of handles. The API fails the whole request
+// on the first unknown handle, so that handle is dropped and the rest retried.
+func (c *Client) getUserChunk(ctx context.Context, handles []string) (users []User, missing []string, err error) {
+	for len(handles) > 0 {
+		users, err = c.GetUserInfo(ctx, handles)
+		if err == nil {
+			return users, missing, nil
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || !apiErr.NotFound() {
+			return nil, nil, err
+		}
+		m := reMissingHandle.FindStringSubmatch(apiErr.Message)
+		if m == nil {
+			return nil, nil, err
+		}
+		rest := removeHandle(handles, m[1])
+		if len(rest) == len(handles) {
+			return nil, nil, err
+		}
+		missing = append(missing, m[1])
+		handles = rest
+	}
+	return nil, missing, nil
+}
+
+// removeHandle returns handles without handle, compared case-insensitively
+func removeHandle(handles []string, handle string) []string {
+	rest := make([]string, 0, len(handles))
+	for _, h := range handles {
+		if !strings.EqualFold(h, handle) {
+			rest = append(rest, h)
+		}
+	}
+	return rest
+}
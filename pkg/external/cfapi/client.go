@@ -1,14 +1,18 @@
 package cfapi
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -19,14 +23,57 @@ const (
 	DefaultTimeout     = 30 * time.Second
 	DefaultTTL         = 5 * time.Minute
 	RateLimit          = 5  // requests per second
+	MinRequestInterval = time.Second / RateLimit
 	MaxResponseSize    = 10 * 1024 * 1024 // 10MB max response size to prevent OOM
+	PingRetryDelay     = 300 * time.Millisecond
+	DefaultUserAgent   = "cf/1.0"
 )
 
+// ErrResponseTooLarge is returned when a response body exceeds the configured max size
+var ErrResponseTooLarge = errors.New("response exceeded max size")
+
+// ErrProblemNotFound is returned when a problem is not in the problemset
+var ErrProblemNotFound = errors.New("not found")
+
+// ErrServerMaintenance is returned when the API answers with an HTML page
+// instead of JSON, which Codeforces serves while it is under maintenance
+var ErrServerMaintenance = errors.New("server under maintenance")
+
+// StatusError is returned when the API responds with a non-200 status
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("api error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// Unwrap returns the APIError carried in the body of a FAILED response, so
+// errors.As finds it whatever status code the API answered with
+func (e *StatusError) Unwrap() error {
+	if apiErr := apiErrorFromBody([]byte(e.Body)); apiErr != nil {
+		return apiErr
+	}
+	return nil
+}
+
 // Client is the Codeforces API client
 type Client struct {
 	httpClient *http.Client
 	limiter    *rate.Limiter
 	cache      *Cache
+	userAgent  string
+	maxSize    int64
+
+	difficultyBands []DifficultyBand
+	tagAliases      map[string]string
+	snapshots       *standingsSnapshots
+
+	// Authentication
+	apiKey      string
+	apiSecret   string
+	clockOffset atomic.Int64 // server minus local clock, in nanoseconds
 }
 
 // ClientOption configures the client
@@ -46,12 +93,39 @@ func WithCacheTTL(ttl time.Duration) ClientOption {
 	}
 }
 
+// WithUserAgent sets the User-Agent header sent with API requests
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) {
+		if ua != "" {
+			c.userAgent = ua
+		}
+	}
+}
+
+// WithMaxResponseSize sets the maximum response body size in bytes
+func WithMaxResponseSize(n int64) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.maxSize = n
+		}
+	}
+}
+
 // NewClient creates a new Codeforces API client
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
 		httpClient: &http.Client{Timeout: DefaultTimeout},
 		limiter:    rate.NewLimiter(rate.Limit(RateLimit), 1),
 		cache:      NewCache(DefaultTTL),
+		userAgent:  DefaultUserAgent,
+		maxSize:    MaxResponseSize,
+
+		difficultyBands: DefaultDifficultyBands,
+		tagAliases:      make(map[string]string, len(DefaultTagAliases)),
+		snapshots:       newStandingsSnapshots(),
+	}
+	for alias, tag := range DefaultTagAliases {
+		c.tagAliases[alias] = tag
 	}
 
 	for _, opt := range opts {
@@ -62,7 +136,21 @@ func NewClient(opts ...ClientOption) *Client {
 }
 
 // request makes an API request with rate limiting
+// A signed request rejected for clock skew is retried once with the server's time
 func (c *Client) request(ctx context.Context, method string, params url.Values) ([]byte, error) {
+	body, err := c.send(ctx, method, params)
+
+	var skew *clockSkewError
+	if errors.As(err, &skew) {
+		c.clockOffset.Store(int64(skew.offset))
+		body, err = c.send(ctx, method, params)
+	}
+
+	return body, err
+}
+
+// send performs a single API request
+func (c *Client) send(ctx context.Context, method string, params url.Values) ([]byte, error) {
 	// Wait for rate limiter
 	if err := c.limiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit: %w", err)
@@ -74,6 +162,9 @@ func (c *Client) request(ctx context.Context, method string, params url.Values)
 	if params == nil {
 		params = url.Values{}
 	}
+	if c.signed() {
+		params = c.signRequest(method, params)
+	}
 
 	fullURL := u + "?" + params.Encode()
 
@@ -82,8 +173,9 @@ func (c *Client) request(ctx context.Context, method string, params url.Values)
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "cf/1.0")
+	req.Header.Set("User-Agent", c.userAgent)
 
+	sentAt := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("http request: %w", err)
@@ -91,20 +183,36 @@ func (c *Client) request(ctx context.Context, method string, params url.Values)
 	defer resp.Body.Close()
 
 	// Use bounded reader to prevent OOM from large responses
-	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxResponseSize))
+	// Read one extra byte so an oversized body is reported instead of truncated
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxSize+1))
 	if err != nil {
 		return nil, fmt.Errorf("read response: %w", err)
 	}
+	if int64(len(body)) > c.maxSize {
+		return nil, fmt.Errorf("%w (%d bytes)", ErrResponseTooLarge, c.maxSize)
+	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("api error (status %d): %s", resp.StatusCode, string(body))
+		err := &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+		if c.signed() {
+			return nil, detectClockSkew(resp.Header, body, sentAt, err)
+		}
+		return nil, err
+	}
+
+	// The maintenance page comes with a 200, so decoding it would only
+	// report a JSON syntax error
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("<")) {
+		return nil, fmt.Errorf("%s: %w", method, ErrServerMaintenance)
 	}
 
 	return body, nil
 }
 
 // GetProblems retrieves all problems from the problemset
+// Tags may be aliases, see ResolveTags.
 func (c *Client) GetProblems(ctx context.Context, tags []string) (*ProblemsResponse, error) {
+	tags = c.ResolveTags(tags)
 	cacheKey := "problems:" + strings.Join(tags, ",")
 
 	// Check cache
@@ -128,7 +236,7 @@ func (c *Client) GetProblems(ctx context.Context, tags []string) (*ProblemsRespo
 	}
 
 	if resp.Status != "OK" {
-		return nil, fmt.Errorf("api error: %s", resp.Comment)
+		return nil, newAPIError(resp.Comment)
 	}
 
 	c.cache.Set(cacheKey, &resp.Result)
@@ -141,14 +249,23 @@ func (c *Client) GetUserInfo(ctx context.Context, handles []string) ([]User, err
 		return nil, fmt.Errorf("no handles provided")
 	}
 
-	cacheKey := "users:" + strings.Join(handles, ",")
+	normalized := make([]string, len(handles))
+	for i, h := range handles {
+		handle, err := NormalizeHandle(h)
+		if err != nil {
+			return nil, err
+		}
+		normalized[i] = handle
+	}
+
+	cacheKey := "users:" + strings.Join(normalized, ",")
 
 	if cached, ok := c.cache.Get(cacheKey); ok {
 		return cached.([]User), nil
 	}
 
 	params := url.Values{}
-	params.Set("handles", strings.Join(handles, ";"))
+	params.Set("handles", strings.Join(normalized, ";"))
 
 	body, err := c.request(ctx, "user.info", params)
 	if err != nil {
@@ -161,7 +278,7 @@ func (c *Client) GetUserInfo(ctx context.Context, handles []string) ([]User, err
 	}
 
 	if resp.Status != "OK" {
-		return nil, fmt.Errorf("api error: %s", resp.Comment)
+		return nil, newAPIError(resp.Comment)
 	}
 
 	c.cache.Set(cacheKey, resp.Result)
@@ -170,14 +287,42 @@ func (c *Client) GetUserInfo(ctx context.Context, handles []string) ([]User, err
 
 // GetUserSubmissions retrieves submissions for a user
 func (c *Client) GetUserSubmissions(ctx context.Context, handle string, from, count int) ([]Submission, error) {
-	cacheKey := fmt.Sprintf("submissions:%s:%d:%d", handle, from, count)
+	normalized, err := NormalizeHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := fmt.Sprintf("submissions:%s:%d:%d", normalized, from, count)
 
 	if cached, ok := c.cache.Get(cacheKey); ok {
 		return cached.([]Submission), nil
 	}
 
+	submissions, err := c.fetchSubmissions(ctx, normalized, from, count)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.Set(cacheKey, submissions)
+	return submissions, nil
+}
+
+// GetLatestSubmissions returns the count most recent submissions of handle,
+// bypassing the cache so that polling sees verdicts as they change
+func (c *Client) GetLatestSubmissions(ctx context.Context, handle string, count int) ([]Submission, error) {
+	return c.fetchSubmissions(ctx, handle, 1, count)
+}
+
+// fetchSubmissions requests a page of user.status without using the cache
+// The handle is validated first, so every submissions call rejects a
+// malformed handle without a request
+func (c *Client) fetchSubmissions(ctx context.Context, handle string, from, count int) ([]Submission, error) {
+	normalized, err := NormalizeHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+
 	params := url.Values{}
-	params.Set("handle", handle)
+	params.Set("handle", normalized)
 	if from > 0 {
 		params.Set("from", strconv.Itoa(from))
 	}
@@ -196,23 +341,100 @@ func (c *Client) GetUserSubmissions(ctx context.Context, handle string, from, co
 	}
 
 	if resp.Status != "OK" {
-		return nil, fmt.Errorf("api error: %s", resp.Comment)
+		return nil, newAPIError(resp.Comment)
 	}
 
-	c.cache.Set(cacheKey, resp.Result)
 	return resp.Result, nil
 }
 
+// SyncPageSize is the number of submissions requested per page by SyncNewSubmissions
+const SyncPageSize = 100
+
+// SyncNewSubmissions returns the submissions of handle with an ID above
+// sinceID, newest first, and the new cursor: the highest ID seen, or sinceID
+// when there is nothing new. A submission still being judged holds the
+// cursor just below it, so the next sync reads it again with its verdict.
+// Pages are fetched from the newest submission and paging stops at the first
+// ID at or below sinceID, so a sinceID of 0 fetches everything.
+func (c *Client) SyncNewSubmissions(ctx context.Context, handle string, sinceID int) ([]Submission, int, error) {
+	newSubs := []Submission{}
+
+	for from := 1; ; from += SyncPageSize {
+		page, err := c.fetchSubmissions(ctx, handle, from, SyncPageSize)
+		if err != nil {
+			return nil, sinceID, err
+		}
+
+		done := len(page) < SyncPageSize
+		for _, s := range page {
+			if int(s.ID) <= sinceID {
+				done = true
+				break
+			}
+			newSubs = append(newSubs, s)
+		}
+
+		if done {
+			return newSubs, syncCursor(newSubs, sinceID), nil
+		}
+	}
+}
+
+// syncCursor returns the highest ID of subs below which every submission
+// has its final verdict, or sinceID when there is none
+func syncCursor(subs []Submission, sinceID int) int {
+	cursor := sinceID
+	for _, s := range subs {
+		cursor = max(cursor, int(s.ID))
+	}
+	for _, s := range subs {
+		if s.IsPending() {
+			cursor = min(cursor, int(s.ID)-1)
+		}
+	}
+	return max(cursor, sinceID)
+}
+
+// GetSubmissionsSince returns the submissions of handle made after since,
+// newest first. Pages of SyncPageSize are fetched until one reaches a
+// submission made at or before since, so the window is covered however many
+// submissions it holds.
+func (c *Client) GetSubmissionsSince(ctx context.Context, handle string, since time.Time) ([]Submission, error) {
+	subs := []Submission{}
+
+	for from := 1; ; from += SyncPageSize {
+		page, err := c.fetchSubmissions(ctx, handle, from, SyncPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range page {
+			if !s.SubmissionTime().After(since) {
+				return subs, nil
+			}
+			subs = append(subs, s)
+		}
+
+		if len(page) < SyncPageSize {
+			return subs, nil
+		}
+	}
+}
+
 // GetUserRating retrieves rating history for a user
 func (c *Client) GetUserRating(ctx context.Context, handle string) ([]RatingChange, error) {
-	cacheKey := "rating:" + handle
+	normalized, err := NormalizeHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := "rating:" + normalized
 
 	if cached, ok := c.cache.Get(cacheKey); ok {
 		return cached.([]RatingChange), nil
 	}
 
 	params := url.Values{}
-	params.Set("handle", handle)
+	params.Set("handle", normalized)
 
 	body, err := c.request(ctx, "user.rating", params)
 	if err != nil {
@@ -225,7 +447,7 @@ func (c *Client) GetUserRating(ctx context.Context, handle string) ([]RatingChan
 	}
 
 	if resp.Status != "OK" {
-		return nil, fmt.Errorf("api error: %s", resp.Comment)
+		return nil, newAPIError(resp.Comment)
 	}
 
 	c.cache.Set(cacheKey, resp.Result)
@@ -278,13 +500,88 @@ func (c *Client) GetContests(ctx context.Context, gym bool) ([]Contest, error) {
 	}
 
 	if resp.Status != "OK" {
-		return nil, fmt.Errorf("api error: %s", resp.Comment)
+		return nil, newAPIError(resp.Comment)
 	}
 
 	c.cache.Set(cacheKey, resp.Result)
 	return resp.Result, nil
 }
 
+// ContestFilter narrows down a contest list
+type ContestFilter struct {
+	Name     string // Case-insensitive substring of the contest name
+	Division string // Division number, e.g. "2" matches "Div. 2"
+	Type     string // Exact contest type (CF, IOI, ICPC)
+}
+
+// Matches returns true if the contest satisfies every set criterion
+func (f ContestFilter) Matches(c *Contest) bool {
+	return f.matcher()(c)
+}
+
+// matcher returns Matches with the division pattern compiled once, for
+// filtering many contests
+func (f ContestFilter) matcher() func(c *Contest) bool {
+	var reDiv *regexp.Regexp
+	if f.Division != "" {
+		reDiv = regexp.MustCompile(`(?i)div\.?\s*` + regexp.QuoteMeta(f.Division) + `\b`)
+	}
+	name := strings.ToLower(f.Name)
+
+	return func(c *Contest) bool {
+		if f.Type != "" && !strings.EqualFold(c.Type, f.Type) {
+			return false
+		}
+		if name != "" && !strings.Contains(strings.ToLower(c.Name), name) {
+			return false
+		}
+		return reDiv == nil || reDiv.MatchString(c.Name)
+	}
+}
+
+// GetContestsFiltered retrieves contests matching the filter
+// The unfiltered list is cached, filtering happens in memory
+func (c *Client) GetContestsFiltered(ctx context.Context, gym bool, opts ContestFilter) ([]Contest, error) {
+	contests, err := c.GetContests(ctx, gym)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := opts.matcher()
+	var filtered []Contest
+	for i := range contests {
+		if matches(&contests[i]) {
+			filtered = append(filtered, contests[i])
+		}
+	}
+
+	return filtered, nil
+}
+
+// NextContest returns the earliest contest that has not started and starts
+// within the given window, or nil if there is none. It uses the cached
+// contest list, so it is cheap to call periodically.
+func (c *Client) NextContest(ctx context.Context, within time.Duration) (*Contest, error) {
+	contests, err := c.GetContests(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var next *Contest
+	for i := range contests {
+		ct := &contests[i]
+		start := ct.StartTime()
+		if ct.Phase != PhaseBefore || !start.After(now) || start.Sub(now) > within {
+			continue
+		}
+		if next == nil || start.Before(next.StartTime()) {
+			next = ct
+		}
+	}
+	return next, nil
+}
+
 // GetContestStandings retrieves contest standings
 func (c *Client) GetContestStandings(ctx context.Context, contestID int, from, count int, handles []string, showUnofficial bool) (*ContestStandings, error) {
 	params := url.Values{}
@@ -311,12 +608,42 @@ func (c *Client) GetContestStandings(ctx context.Context, contestID int, from, c
 	}
 
 	if resp.Status != "OK" {
-		return nil, fmt.Errorf("api error: %s", resp.Comment)
+		return nil, newAPIError(resp.Comment)
 	}
 
 	return &resp.Result, nil
 }
 
+// GetContestRatingChanges retrieves the rating changes of a rated contest
+// The list is empty for unrated contests and before ratings are applied.
+func (c *Client) GetContestRatingChanges(ctx context.Context, contestID int) ([]RatingChange, error) {
+	cacheKey := fmt.Sprintf("ratingChanges:%d", contestID)
+
+	if cached, ok := c.cache.Get(cacheKey); ok {
+		return cached.([]RatingChange), nil
+	}
+
+	params := url.Values{}
+	params.Set("contestId", strconv.Itoa(contestID))
+
+	body, err := c.request(ctx, "contest.ratingChanges", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Response[[]RatingChange]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+
+	if resp.Status != "OK" {
+		return nil, newAPIError(resp.Comment)
+	}
+
+	c.cache.Set(cacheKey, resp.Result)
+	return resp.Result, nil
+}
+
 // GetProblem retrieves a single problem by contest ID and index
 func (c *Client) GetProblem(ctx context.Context, contestID int, index string) (*Problem, error) {
 	cacheKey := fmt.Sprintf("problem:%d:%s", contestID, index)
@@ -338,12 +665,56 @@ func (c *Client) GetProblem(ctx context.Context, contestID int, index string) (*
 		}
 	}
 
-	return nil, fmt.Errorf("problem %d%s not found", contestID, index)
+	return nil, fmt.Errorf("problem %d%s %w", contestID, index, ErrProblemNotFound)
 }
 
-// GetSolvedProblems returns all problems solved by a user
+// GetProblemsByRefs retrieves several problems from a single problemset fetch
+// Problems are returned in the order of refs. Refs missing from the problemset
+// are left out and reported together in an error wrapping ErrProblemNotFound,
+// alongside the problems that were found.
+func (c *Client) GetProblemsByRefs(ctx context.Context, refs []ProblemRef) ([]Problem, error) {
+	problems, err := c.GetProblems(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byRef := make(map[ProblemRef]*Problem, len(problems.Problems))
+	for i := range problems.Problems {
+		p := &problems.Problems[i]
+		byRef[ProblemRef{ContestID: p.ContestID, Index: p.Index}] = p
+	}
+
+	found := make([]Problem, 0, len(refs))
+	var missing []string
+	for _, ref := range refs {
+		p, ok := byRef[ref]
+		if !ok {
+			missing = append(missing, ref.String())
+			continue
+		}
+		found = append(found, *p)
+	}
+
+	if len(missing) > 0 {
+		return found, fmt.Errorf("problems %s %w", strings.Join(missing, ", "), ErrProblemNotFound)
+	}
+	return found, nil
+}
+
+// GetSolvedProblems returns all problems solved by a user, each once. The
+// set is cached under solved:<handle> until the cache expires or is cleared.
 func (c *Client) GetSolvedProblems(ctx context.Context, handle string) ([]Problem, error) {
-	submissions, err := c.GetUserSubmissions(ctx, handle, 1, 10000)
+	normalized, err := NormalizeHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := "solved:" + normalized
+
+	if cached, ok := c.cache.Get(cacheKey); ok {
+		return cached.([]Problem), nil
+	}
+
+	submissions, err := c.GetUserSubmissions(ctx, normalized, 1, 10000)
 	if err != nil {
 		return nil, err
 	}
@@ -361,6 +732,7 @@ func (c *Client) GetSolvedProblems(ctx context.Context, handle string) ([]Proble
 		}
 	}
 
+	c.cache.Set(cacheKey, solved)
 	return solved, nil
 }
 
@@ -414,6 +786,48 @@ func (c *Client) Ping(ctx context.Context) error {
 	return err
 }
 
+// PingWithRetries pings the API, retrying on network and 5xx errors
+// Returns the last error once all attempts are exhausted
+func (c *Client) PingWithRetries(ctx context.Context, attempts int) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = c.Ping(ctx); err == nil || !isRetryable(err) {
+			return err
+		}
+
+		if i < attempts-1 {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(PingRetryDelay):
+			}
+		}
+	}
+
+	return err
+}
+
+// isRetryable returns true for transient network and server errors
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500
+	}
+
+	return IsNetworkError(err)
+}
+
+// IsNetworkError returns true if err comes from the transport rather than the API
+// Such errors mean codeforces.com could not be reached at all
+func IsNetworkError(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
 // ClearCache clears the API cache
 func (c *Client) ClearCache() {
 	c.cache.Clear()
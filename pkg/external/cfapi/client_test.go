@@ -423,3 +423,34 @@ func TestClient_Request_RateLimiting(t *testing.T) {
 		t.Logf("Rate limiting may not be effective, elapsed: %v", elapsed)
 	}
 }
+
+func TestContestFilter_Matches(t *testing.T) {
+	div2 := Contest{Name: "Codeforces Round 900 (Div. 2)", Type: ContestTypeCF}
+	edu := Contest{Name: "Educational Codeforces Round 160 (Rated for Div. 2)", Type: ContestTypeICPC}
+	div1 := Contest{Name: "Codeforces Round 901 (Div. 1)", Type: ContestTypeCF}
+
+	tests := []struct {
+		name    string
+		filter  ContestFilter
+		contest Contest
+		want    bool
+	}{
+		{"empty filter", ContestFilter{}, div2, true},
+		{"division match", ContestFilter{Division: "2"}, div2, true},
+		{"division mismatch", ContestFilter{Division: "2"}, div1, false},
+		{"rated for division", ContestFilter{Division: "2"}, edu, true},
+		{"name case-insensitive", ContestFilter{Name: "educational"}, edu, true},
+		{"name mismatch", ContestFilter{Name: "educational"}, div2, false},
+		{"type match", ContestFilter{Type: "icpc"}, edu, true},
+		{"type mismatch", ContestFilter{Type: ContestTypeIOI}, div2, false},
+		{"combined", ContestFilter{Division: "1", Type: ContestTypeCF}, div1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(&tt.contest); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
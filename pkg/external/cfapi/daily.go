@@ -0,0 +1,28 @@
+package cfapi
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// PickDaily picks the problem of the day for handle from problems. The pick
+// is seeded by the handle, the date of day and the band of ratings the
+// problems span, so it is stable for a user's day while different users, or
+// a new difficulty band, get a different problem. An empty list picks the
+// zero Problem.
+func PickDaily(problems []Problem, handle string, day time.Time) Problem {
+	if len(problems) == 0 {
+		return Problem{}
+	}
+
+	low, high := problems[0].Rating, problems[0].Rating
+	for _, p := range problems[1:] {
+		low, high = min(low, p.Rating), max(high, p.Rating)
+	}
+
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s|%s|%d-%d", strings.ToLower(handle), day.Format("2006-01-02"), low, high)
+	return problems[int(h.Sum32()%uint32(len(problems)))]
+}
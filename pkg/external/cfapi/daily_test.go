@@ -0,0 +1,53 @@
+package cfapi
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// dailyProblems returns n problems rated 800 to 1600
+func dailyProblems(n int) []Problem {
+	problems := make([]Problem, n)
+	for i := range problems {
+		problems[i] = Problem{ContestID: 1000 + i, Index: "A", Rating: 800 + 100*(i%9)}
+	}
+	return problems
+}
+
+func TestPickDaily_Stable(t *testing.T) {
+	problems := dailyProblems(50)
+	day := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	first := PickDaily(problems, "tourist", day)
+	if first.ContestID == 0 {
+		t.Fatal("PickDaily() picked no problem")
+	}
+	if again := PickDaily(problems, "tourist", day.Add(10*time.Hour)); again.ProblemID() != first.ProblemID() {
+		t.Errorf("PickDaily() not stable for a day: %s then %s", first.ProblemID(), again.ProblemID())
+	}
+	if again := PickDaily(problems, "Tourist", day); again.ProblemID() != first.ProblemID() {
+		t.Errorf("PickDaily() should ignore handle case: %s then %s", first.ProblemID(), again.ProblemID())
+	}
+}
+
+func TestPickDaily_PerHandle(t *testing.T) {
+	problems := dailyProblems(50)
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	picks := make(map[string]bool)
+	for i := 0; i < 10; i++ {
+		pick := PickDaily(problems, fmt.Sprintf("user%d", i), day)
+		picks[pick.ProblemID()] = true
+	}
+	// Ten handles over fifty problems should rarely collide much
+	if len(picks) < 5 {
+		t.Errorf("PickDaily() gave 10 handles only %d distinct problems", len(picks))
+	}
+}
+
+func TestPickDaily_Empty(t *testing.T) {
+	if p := PickDaily(nil, "tourist", time.Now()); p.ContestID != 0 || p.Index != "" {
+		t.Errorf("PickDaily(nil) = %+v, want the zero Problem", p)
+	}
+}
@@ -0,0 +1,71 @@
+package cfapi
+
+import (
+	"context"
+	"math"
+	"sync"
+)
+
+// standingsSnapshots keeps the last polled points per handle of each contest
+type standingsSnapshots struct {
+	mu     sync.Mutex
+	points map[int]map[string]float64 // contest ID -> handle -> points
+}
+
+func newStandingsSnapshots() *standingsSnapshots {
+	return &standingsSnapshots{points: make(map[int]map[string]float64)}
+}
+
+// swap stores current as the snapshot of contestID and returns the previous one
+func (s *standingsSnapshots) swap(contestID int, current map[string]float64) (map[string]float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.points[contestID]
+	s.points[contestID] = current
+	return previous, ok
+}
+
+// get returns a copy of the snapshot of contestID
+func (s *standingsSnapshots) get(contestID int) map[string]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	points := make(map[string]float64, len(s.points[contestID]))
+	for handle, p := range s.points[contestID] {
+		points[handle] = p
+	}
+	return points
+}
+
+// StandingsDelta polls the standings of a live contest for handles and
+// returns the points each gained since the previous call for the same
+// contest. The first call only takes the snapshot and returns an empty map.
+// Handles not taking part are left out; keys are the handles as given.
+func (c *Client) StandingsDelta(ctx context.Context, contestID int, handles []string) (map[string]int, error) {
+	standings, err := c.GetContestStandings(ctx, contestID, 0, 0, handles, true)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]float64, len(handles))
+	for _, handle := range handles {
+		if row := participantRow(standings, handle); row != nil {
+			current[handle] = row.Points
+		}
+	}
+
+	previous, ok := c.snapshots.swap(contestID, current)
+	delta := make(map[string]int, len(current))
+	if !ok {
+		return delta, nil
+	}
+	for handle, points := range current {
+		delta[handle] = int(math.Round(points - previous[handle]))
+	}
+	return delta, nil
+}
+
+// StandingsPoints returns the points per handle from the latest
+// StandingsDelta poll of a contest
+func (c *Client) StandingsPoints(contestID int) map[string]float64 {
+	return c.snapshots.get(contestID)
+}
@@ -0,0 +1,110 @@
+package cfapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// DifficultyBand maps a solve rate to a rating: a problem solved by at least
+// MinSolveRate (0 to 1) of the official participants is estimated at Rating
+type DifficultyBand struct {
+	MinSolveRate float64
+	Rating       int
+}
+
+// DefaultDifficultyBands is the heuristic used by EstimateDifficulty
+// Each halving of the solve rate adds roughly 200 rating points, which
+// matches rated Div. 2 rounds reasonably well: a problem almost everyone
+// solves is an 800, one solved by 1 in 100 participants is around 2600.
+var DefaultDifficultyBands = []DifficultyBand{
+	{0.80, 800},
+	{0.60, 1000},
+	{0.45, 1200},
+	{0.30, 1400},
+	{0.20, 1600},
+	{0.12, 1800},
+	{0.07, 2000},
+	{0.04, 2200},
+	{0.02, 2400},
+	{0.01, 2600},
+	{0.005, 2800},
+	{0.001, 3000},
+	{0, 3500},
+}
+
+// WithDifficultyBands replaces the bands used by EstimateDifficulty
+// Bands must be sorted by MinSolveRate, highest first.
+func WithDifficultyBands(bands []DifficultyBand) ClientOption {
+	return func(c *Client) {
+		if len(bands) > 0 {
+			c.difficultyBands = bands
+		}
+	}
+}
+
+// EstimateDifficulty estimates the rating of a contest problem from the share
+// of official participants who solved it, for problems the API has not rated
+// such as gym problems. The standings are cached, so estimating every
+// problem of a contest makes a single request.
+func (c *Client) EstimateDifficulty(ctx context.Context, contestID int, index string) (int, error) {
+	cacheKey := fmt.Sprintf("estimate:standings:%d", contestID)
+
+	standings, ok := c.cache.Get(cacheKey)
+	if !ok {
+		s, err := c.GetContestStandings(ctx, contestID, 1, 0, nil, false)
+		if err != nil {
+			if isContestNotFound(err) {
+				return 0, fmt.Errorf("contest %d: %w", contestID, ErrContestNotFound)
+			}
+			return 0, err
+		}
+		c.cache.Set(cacheKey, s)
+		standings = s
+	}
+
+	rate, err := solveRate(standings.(*ContestStandings), index)
+	if err != nil {
+		return 0, fmt.Errorf("contest %d: %w", contestID, err)
+	}
+	return ratingForSolveRate(rate, c.difficultyBands), nil
+}
+
+// solveRate returns the fraction of official participants who solved index
+func solveRate(standings *ContestStandings, index string) (float64, error) {
+	pos := -1
+	for i, p := range standings.Problems {
+		if p.Index == index {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return 0, fmt.Errorf("no problem %s", index)
+	}
+
+	participants, solved := 0, 0
+	for _, row := range standings.Rows {
+		if row.Party.ParticipantType != ParticipantContestant {
+			continue
+		}
+		participants++
+		if pos < len(row.ProblemResults) && row.ProblemResults[pos].Points > 0 {
+			solved++
+		}
+	}
+	if participants == 0 {
+		return 0, fmt.Errorf("no official participants to estimate from")
+	}
+
+	return float64(solved) / float64(participants), nil
+}
+
+// ratingForSolveRate returns the rating of the first band rate reaches
+func ratingForSolveRate(rate float64, bands []DifficultyBand) int {
+	for _, b := range bands {
+		if rate >= b.MinSolveRate {
+			return b.Rating
+		}
+	}
+	return bands[len(bands)-1].Rating
+}
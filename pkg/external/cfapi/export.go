@@ -0,0 +1,92 @@
+package cfapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// MinGymContestID is the lowest contest ID used by gym contests
+const MinGymContestID = 100000
+
+// IsGym returns true if the problem belongs to a gym contest
+func (p *Problem) IsGym() bool {
+	return p.ContestID >= MinGymContestID
+}
+
+// ExportID returns the portable ID of a problem: contestId/index for
+// problemset problems, gym/contestId/index for gym problems, as in the
+// Codeforces URLs, and problemsetName/index for problems without a contest
+func (p *Problem) ExportID() string {
+	switch {
+	case p.IsGym():
+		return fmt.Sprintf("gym/%d/%s", p.ContestID, p.Index)
+	case p.ContestID > 0:
+		return fmt.Sprintf("%d/%s", p.ContestID, p.Index)
+	case p.ProblemsetName != "":
+		return p.ProblemsetName + "/" + p.Index
+	default:
+		return p.Index
+	}
+}
+
+// ExportedProblem is a solved problem in the JSON export
+type ExportedProblem struct {
+	ID        string   `json:"id"`
+	ContestID int      `json:"contestId,omitempty"`
+	Index     string   `json:"index"`
+	Name      string   `json:"name"`
+	Rating    int      `json:"rating,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Gym       bool     `json:"gym"`
+}
+
+// ExportSolved writes the IDs of the problems handle solved, one per line,
+// newest first; see Problem.ExportID for the format
+func (c *Client) ExportSolved(ctx context.Context, handle string, w io.Writer) error {
+	solved, err := c.GetSolvedProblems(ctx, handle)
+	if err != nil {
+		return fmt.Errorf("get solved problems: %w", err)
+	}
+
+	bw := bufio.NewWriter(w)
+	for i := range solved {
+		fmt.Fprintln(bw, solved[i].ExportID())
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+	return nil
+}
+
+// ExportSolvedJSON writes the problems handle solved as a JSON array of
+// ExportedProblem, newest first
+func (c *Client) ExportSolvedJSON(ctx context.Context, handle string, w io.Writer) error {
+	solved, err := c.GetSolvedProblems(ctx, handle)
+	if err != nil {
+		return fmt.Errorf("get solved problems: %w", err)
+	}
+
+	exported := make([]ExportedProblem, len(solved))
+	for i := range solved {
+		p := &solved[i]
+		exported[i] = ExportedProblem{
+			ID:        p.ExportID(),
+			ContestID: p.ContestID,
+			Index:     p.Index,
+			Name:      p.Name,
+			Rating:    p.Rating,
+			Tags:      p.Tags,
+			Gym:       p.IsGym(),
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(exported); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+	return nil
+}
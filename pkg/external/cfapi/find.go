@@ -0,0 +1,116 @@
+package cfapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Name match quality, best first
+const (
+	matchExact = iota
+	matchPrefix
+	matchSubstring
+	matchWords
+	matchFuzzy
+	noMatch
+)
+
+// FindProblemsByName searches the problemset for problems whose name
+// matches query, ignoring case and punctuation. Results are ranked: exact
+// names first, then names starting with the query, containing it, containing
+// all its words in any order, and finally containing its letters in order.
+// Within a rank, shorter names come first and the problemset order (newest
+// contests first) is kept. No match returns an empty result.
+func (c *Client) FindProblemsByName(ctx context.Context, query string) ([]Problem, error) {
+	q := normalizeName(query)
+	if q == "" {
+		return nil, fmt.Errorf("empty problem name")
+	}
+
+	problems, err := c.GetProblems(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		problem Problem
+		rank    int
+		length  int
+	}
+	var matches []match
+	for _, p := range problems.Problems {
+		name := normalizeName(p.Name)
+		if rank := nameMatch(name, q); rank != noMatch {
+			matches = append(matches, match{problem: p, rank: rank, length: len(name)})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].length < matches[j].length
+	})
+
+	found := make([]Problem, len(matches))
+	for i, m := range matches {
+		found[i] = m.problem
+	}
+	return found, nil
+}
+
+// nameMatch ranks how well the normalized name matches the normalized query
+func nameMatch(name, query string) int {
+	switch {
+	case name == query:
+		return matchExact
+	case strings.HasPrefix(name, query):
+		return matchPrefix
+	case strings.Contains(name, query):
+		return matchSubstring
+	}
+
+	words := strings.Fields(query)
+	if len(words) > 1 {
+		all := true
+		for _, w := range words {
+			if !strings.Contains(name, w) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return matchWords
+		}
+	}
+
+	// Letters in order, for typos that drop characters ("theatr sqare")
+	letters := strings.ReplaceAll(query, " ", "")
+	if len(letters) >= 4 && isSubsequence(letters, name) {
+		return matchFuzzy
+	}
+	return noMatch
+}
+
+// isSubsequence returns true if the characters of sub appear in s in order
+func isSubsequence(sub, s string) bool {
+	i := 0
+	for j := 0; j < len(s) && i < len(sub); j++ {
+		if s[j] == sub[i] {
+			i++
+		}
+	}
+	return i == len(sub)
+}
+
+// normalizeName lowercases a name and turns runs of punctuation and spaces
+// into single spaces
+func normalizeName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
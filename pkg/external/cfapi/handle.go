@@ -0,0 +1,34 @@
+package cfapi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Codeforces handle length limits
+const (
+	MinHandleLength = 3
+	MaxHandleLength = 24
+)
+
+// ErrInvalidHandle is returned for a handle Codeforces would never accept
+var ErrInvalidHandle = errors.New("invalid handle")
+
+// reHandle matches the characters allowed in a Codeforces handle
+var reHandle = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+
+// NormalizeHandle validates a handle and returns its lowercase form
+// Handles are case-insensitive, so the lowercase form is used for cache
+// keys; display the handle returned by the API instead.
+func NormalizeHandle(s string) (string, error) {
+	handle := strings.TrimSpace(s)
+	if len(handle) < MinHandleLength || len(handle) > MaxHandleLength {
+		return "", fmt.Errorf("%w %q: must be %d to %d characters", ErrInvalidHandle, s, MinHandleLength, MaxHandleLength)
+	}
+	if !reHandle.MatchString(handle) {
+		return "", fmt.Errorf("%w %q: only letters, digits, '_', '-' and '.' are allowed", ErrInvalidHandle, s)
+	}
+	return strings.ToLower(handle), nil
+}
@@ -0,0 +1,84 @@
+package cfapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestNormalizeHandle(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"tourist", "tourist", false},
+		{"Tourist", "tourist", false},
+		{"  Petr ", "petr", false},
+		{"jiangly_fan-1.0", "jiangly_fan-1.0", false},
+		{"tou rist", "", true},
+		{"ab", "", true},
+		{"abcdefghijklmnopqrstuvwxy", "", true}, // 25 characters
+		{"user@cf", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeHandle(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeHandle(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidHandle) {
+				t.Errorf("NormalizeHandle(%q) error = %v, want ErrInvalidHandle", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeHandle(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_InvalidHandle_NoRequest(t *testing.T) {
+	transport := &mockTransport{statusCode: 200, body: `{"status":"OK","result":[]}`}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+	ctx := context.Background()
+
+	if _, err := client.GetUserInfo(ctx, []string{"tourist", "tou rist"}); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("GetUserInfo() error = %v, want ErrInvalidHandle", err)
+	}
+	if _, err := client.GetUserSubmissions(ctx, "tou rist", 1, 10); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("GetUserSubmissions() error = %v, want ErrInvalidHandle", err)
+	}
+	if _, err := client.GetUserRating(ctx, "x"); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("GetUserRating() error = %v, want ErrInvalidHandle", err)
+	}
+	if _, err := client.GetLatestSubmissions(ctx, "tou rist", 10); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("GetLatestSubmissions() error = %v, want ErrInvalidHandle", err)
+	}
+	if _, _, err := client.SyncNewSubmissions(ctx, "", 0); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("SyncNewSubmissions() error = %v, want ErrInvalidHandle", err)
+	}
+	if transport.calls != 0 {
+		t.Errorf("%d requests sent for invalid handles, want 0", transport.calls)
+	}
+}
+
+func TestClient_HandleCacheKeyIgnoresCase(t *testing.T) {
+	transport := &mockTransport{statusCode: 200, body: `{"status":"OK","result":[{"handle":"tourist","rating":3800}]}`}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	for _, handle := range []string{"tourist", "Tourist", "TOURIST"} {
+		users, err := client.GetUserInfo(context.Background(), []string{handle})
+		if err != nil {
+			t.Fatalf("GetUserInfo(%s) error = %v", handle, err)
+		}
+		if users[0].Handle != "tourist" {
+			t.Errorf("Handle = %q, want the API's spelling", users[0].Handle)
+		}
+	}
+	if transport.calls != 1 {
+		t.Errorf("%d requests sent, want 1 shared cache entry", transport.calls)
+	}
+}
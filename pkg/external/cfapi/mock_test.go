@@ -2,9 +2,15 @@ package cfapi
 
 import (
 	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -16,9 +22,13 @@ type mockTransport struct {
 	statusCode int
 	body       string
 	err        error
+	calls      int
+	lastQuery  url.Values
 }
 
 func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.calls++
+	m.lastQuery = req.URL.Query()
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -237,6 +247,34 @@ func TestClient_GetContests_APIFailed(t *testing.T) {
 	}
 }
 
+func TestClient_NextContest(t *testing.T) {
+	now := time.Now().Unix()
+	body := fmt.Sprintf(`{"status":"OK","result":[
+		{"id":3,"name":"Far Away","phase":"BEFORE","startTimeSeconds":%d},
+		{"id":2,"name":"Later","phase":"BEFORE","startTimeSeconds":%d},
+		{"id":1,"name":"Soon","phase":"BEFORE","startTimeSeconds":%d},
+		{"id":0,"name":"Running","phase":"CODING","startTimeSeconds":%d}
+	]}`, now+30*86400, now+7200, now+3600, now-600)
+	transport := &mockTransport{statusCode: 200, body: body}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	next, err := client.NextContest(context.Background(), 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("NextContest() error = %v", err)
+	}
+	if next == nil || next.Name != "Soon" {
+		t.Errorf("NextContest() = %+v, want Soon", next)
+	}
+
+	next, err = client.NextContest(context.Background(), 30*time.Minute)
+	if err != nil || next != nil {
+		t.Errorf("NextContest(30m) = %+v, %v, want nil", next, err)
+	}
+	if transport.calls != 1 {
+		t.Errorf("calls = %d, want 1 (contest list is cached)", transport.calls)
+	}
+}
+
 func TestClient_GetContests_InvalidJSON(t *testing.T) {
 	transport := &mockTransport{
 		statusCode: 200,
@@ -424,6 +462,47 @@ func TestClient_GetProblems_Success(t *testing.T) {
 	}
 }
 
+func TestClient_GetProblems_ResolvesTagAliases(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":{"problems":[],"problemStatistics":[]}}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	if _, err := client.GetProblems(context.Background(), []string{"NT", "dsu"}); err != nil {
+		t.Fatalf("GetProblems() error = %v", err)
+	}
+	if got := transport.lastQuery.Get("tags"); got != "number theory;dsu" {
+		t.Errorf("tags = %q, want %q", got, "number theory;dsu")
+	}
+
+	// An alias and its tag share the cache entry
+	if _, err := client.GetProblems(context.Background(), []string{"number theory", "DSU"}); err != nil {
+		t.Fatalf("GetProblems() error = %v", err)
+	}
+	if transport.calls != 1 {
+		t.Errorf("calls = %d, want the resolved tags served from cache", transport.calls)
+	}
+}
+
+func TestClient_CachedTags(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":{"problems":[{"contestId":1,"index":"A","tags":["math","dp"]},{"contestId":2,"index":"B","tags":["dp","dsu"]}],"problemStatistics":[]}}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	if tags := client.CachedTags(); tags != nil {
+		t.Errorf("CachedTags() = %v before any request, want nil", tags)
+	}
+	if _, err := client.GetProblems(context.Background(), nil); err != nil {
+		t.Fatalf("GetProblems() error = %v", err)
+	}
+	if got := strings.Join(client.CachedTags(), ","); got != "dp,dsu,math" {
+		t.Errorf("CachedTags() = %s, want dp,dsu,math", got)
+	}
+}
+
 func TestClient_GetUserInfo_Success(t *testing.T) {
 	transport := &mockTransport{
 		statusCode: 200,
@@ -456,6 +535,22 @@ func TestClient_GetUserRating_Success(t *testing.T) {
 	}
 }
 
+func TestClient_GetUserRating_Empty(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":[]}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	ratings, err := client.GetUserRating(context.Background(), "newbie")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ratings == nil || len(ratings) != 0 {
+		t.Errorf("Expected an empty, non-nil slice, got %v", ratings)
+	}
+}
+
 func TestClient_GetContests_Success(t *testing.T) {
 	transport := &mockTransport{
 		statusCode: 200,
@@ -740,6 +835,9 @@ func TestClient_GetProblem_NotFound_Mock(t *testing.T) {
 	if !strings.Contains(err.Error(), "problem 999999Z not found") {
 		t.Errorf("Expected 'problem 999999Z not found' error, got: %v", err)
 	}
+	if !errors.Is(err, ErrProblemNotFound) {
+		t.Errorf("Expected ErrProblemNotFound, got: %v", err)
+	}
 }
 
 func TestClient_GetSolvedProblems_Success(t *testing.T) {
@@ -779,6 +877,42 @@ func TestClient_GetSolvedProblems_Deduplication(t *testing.T) {
 	}
 }
 
+func TestClient_GetSolvedProblems_Cached(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `{"status":"OK","result":[
+				{"id":1,"verdict":"OK","problem":{"contestId":1,"index":"A","name":"Test"}},
+				{"id":2,"verdict":"OK","problem":{"contestId":1,"index":"A","name":"Test"}}
+			]}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	if _, err := client.GetSolvedProblems(context.Background(), "tourist"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	solved, err := client.GetSolvedProblems(context.Background(), "Tourist")
+	if err != nil {
+		t.Fatalf("Unexpected error on second call: %v", err)
+	}
+	if callCount != 1 {
+		t.Errorf("Expected the second call to use the cache, got %d requests", callCount)
+	}
+	if len(solved) != 1 {
+		t.Errorf("Expected the cached set to be deduplicated, got %d problems", len(solved))
+	}
+	if _, ok := client.cache.Get("solved:tourist"); !ok {
+		t.Error("Expected the solved set under solved:tourist")
+	}
+
+	client.ClearCache()
+	if _, ok := client.cache.Get("solved:tourist"); ok {
+		t.Error("Expected ClearCache to drop the solved set")
+	}
+}
+
 func TestClient_FilterProblems_ExcludeSolved_Mock(t *testing.T) {
 	// Sequential mock for multiple calls
 	callCount := 0
@@ -883,3 +1017,1285 @@ func (s *sequentialTransport) RoundTrip(req *http.Request) (*http.Response, erro
 		Header:     make(http.Header),
 	}, nil
 }
+
+func TestClient_GetContestsFiltered_Success(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":[{"id":1,"name":"Codeforces Round 1 (Div. 2)","type":"CF"},{"id":2,"name":"Codeforces Round 2 (Div. 1)","type":"CF"}]}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	contests, err := client.GetContestsFiltered(context.Background(), false, ContestFilter{Division: "2"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(contests) != 1 || contests[0].ID != 1 {
+		t.Errorf("Expected only contest 1, got %+v", contests)
+	}
+
+	// The unfiltered list should be cached
+	if _, ok := client.cache.Get("contests:false"); !ok {
+		t.Error("Unfiltered contest list should be cached")
+	}
+}
+
+func TestClient_GetContestsFiltered_APIFailed(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"FAILED","comment":"error"}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.GetContestsFiltered(context.Background(), false, ContestFilter{Type: ContestTypeCF})
+	if err == nil {
+		t.Error("Expected error for API FAILED")
+	}
+}
+
+// ============ PingWithRetries Tests ============
+
+// flakyTransport fails the first n requests with the given status, then succeeds
+type flakyTransport struct {
+	failures   int
+	failStatus int
+	calls      int
+}
+
+func (f *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return &http.Response{
+			StatusCode: f.failStatus,
+			Body:       io.NopCloser(strings.NewReader("error")),
+			Header:     make(http.Header),
+		}, nil
+	}
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(`{"status":"OK","result":[]}`)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestClient_PingWithRetries_RecoversFrom5xx(t *testing.T) {
+	transport := &flakyTransport{failures: 1, failStatus: 503}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	if err := client.PingWithRetries(context.Background(), 3); err != nil {
+		t.Errorf("Expected ping to recover, got: %v", err)
+	}
+	if transport.calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", transport.calls)
+	}
+}
+
+func TestClient_PingWithRetries_Exhausted(t *testing.T) {
+	transport := &mockTransport{err: fmt.Errorf("connection refused")}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	err := client.PingWithRetries(context.Background(), 2)
+	if err == nil {
+		t.Fatal("Expected error after exhausting attempts")
+	}
+	if !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Expected last error to be returned, got: %v", err)
+	}
+}
+
+func TestClient_PingWithRetries_NoRetryOn4xx(t *testing.T) {
+	transport := &flakyTransport{failures: 5, failStatus: 400}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	if err := client.PingWithRetries(context.Background(), 3); err == nil {
+		t.Error("Expected error for 400 status")
+	}
+	if transport.calls != 1 {
+		t.Errorf("Expected no retries for 4xx, got %d calls", transport.calls)
+	}
+}
+
+func TestClient_Request_StatusError(t *testing.T) {
+	transport := &mockTransport{statusCode: 502, body: "Bad Gateway"}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.GetContests(context.Background(), false)
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("Expected *StatusError, got %T", err)
+	}
+	if statusErr.StatusCode != 502 {
+		t.Errorf("StatusCode = %d, want 502", statusErr.StatusCode)
+	}
+}
+
+// ============ User-Agent Tests ============
+
+// headerTransport captures the headers of the last request
+type headerTransport struct {
+	header http.Header
+}
+
+func (h *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	h.header = req.Header.Clone()
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(`{"status":"OK","result":{"problems":[],"problemStatistics":[]}}`)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestClient_UserAgent_Default(t *testing.T) {
+	transport := &headerTransport{}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	if _, err := client.GetProblems(context.Background(), nil); err != nil {
+		t.Fatalf("GetProblems() error = %v", err)
+	}
+	if got := transport.header.Get("User-Agent"); got != DefaultUserAgent {
+		t.Errorf("User-Agent = %q, want %q", got, DefaultUserAgent)
+	}
+}
+
+func TestClient_WithUserAgent(t *testing.T) {
+	transport := &headerTransport{}
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithUserAgent("mytool/2.3"),
+	)
+
+	if _, err := client.GetProblems(context.Background(), nil); err != nil {
+		t.Fatalf("GetProblems() error = %v", err)
+	}
+	if got := transport.header.Get("User-Agent"); got != "mytool/2.3" {
+		t.Errorf("User-Agent = %q, want mytool/2.3", got)
+	}
+}
+
+func TestClient_WithUserAgent_EmptyKeepsDefault(t *testing.T) {
+	client := NewClient(WithUserAgent(""))
+	if client.userAgent != DefaultUserAgent {
+		t.Errorf("userAgent = %q, want %q", client.userAgent, DefaultUserAgent)
+	}
+}
+
+// ============ Maintenance Tests ============
+
+func TestClient_MaintenancePage(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       "\n<!DOCTYPE html><html><body>Codeforces is temporarily unavailable</body></html>",
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.GetUserInfo(context.Background(), []string{"tourist"})
+	if !errors.Is(err, ErrServerMaintenance) {
+		t.Fatalf("Expected ErrServerMaintenance, got %v", err)
+	}
+	if strings.Contains(err.Error(), "parse response") {
+		t.Errorf("Maintenance page should not be reported as a parse error: %v", err)
+	}
+
+	if _, err := client.GetContests(context.Background(), false); !errors.Is(err, ErrServerMaintenance) {
+		t.Errorf("Expected ErrServerMaintenance from GetContests, got %v", err)
+	}
+}
+
+// ============ Response Size Tests ============
+
+func TestClient_WithMaxResponseSize_Exceeded(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":{"problems":[],"problemStatistics":[]}}`,
+	}
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithMaxResponseSize(16),
+	)
+
+	_, err := client.GetProblems(context.Background(), nil)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("Expected ErrResponseTooLarge, got %v", err)
+	}
+	if strings.Contains(err.Error(), "parse response") {
+		t.Errorf("Oversized response should not be reported as a parse error: %v", err)
+	}
+}
+
+func TestClient_WithMaxResponseSize_ExactFit(t *testing.T) {
+	body := `{"status":"OK","result":{"problems":[],"problemStatistics":[]}}`
+	transport := &mockTransport{statusCode: 200, body: body}
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithMaxResponseSize(int64(len(body))),
+	)
+
+	if _, err := client.GetProblems(context.Background(), nil); err != nil {
+		t.Errorf("Body equal to the cap should be accepted, got %v", err)
+	}
+}
+
+func TestClient_WithMaxResponseSize_IgnoresNonPositive(t *testing.T) {
+	client := NewClient(WithMaxResponseSize(0))
+	if client.maxSize != MaxResponseSize {
+		t.Errorf("maxSize = %d, want %d", client.maxSize, MaxResponseSize)
+	}
+}
+
+// ============ Network Error Tests ============
+
+func TestIsNetworkError(t *testing.T) {
+	unreachable := NewClient(WithHTTPClient(&http.Client{Transport: &mockTransport{err: fmt.Errorf("dial tcp: no route to host")}}))
+	_, err := unreachable.GetProblems(context.Background(), nil)
+	if !IsNetworkError(err) {
+		t.Errorf("IsNetworkError(%v) = false, want true", err)
+	}
+
+	failed := NewClient(WithHTTPClient(&http.Client{Transport: &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"FAILED","comment":"handles: User with handle nobody not found"}`,
+	}}))
+	_, err = failed.GetUserInfo(context.Background(), []string{"nobody"})
+	if err == nil || IsNetworkError(err) {
+		t.Errorf("IsNetworkError(%v) = true, want false for API errors", err)
+	}
+
+	if IsNetworkError(&StatusError{StatusCode: 503}) {
+		t.Error("IsNetworkError() should be false for HTTP status errors")
+	}
+	if IsNetworkError(nil) {
+		t.Error("IsNetworkError(nil) should be false")
+	}
+}
+
+// ============ Signed Request Tests ============
+
+// skewTransport rejects signed requests whose time is off from serverTime
+// and records the query of every request
+type skewTransport struct {
+	serverTime   time.Time
+	alwaysReject bool
+	queries      []url.Values
+}
+
+func (s *skewTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	q := req.URL.Query()
+	s.queries = append(s.queries, q)
+
+	header := make(http.Header)
+	header.Set("Date", s.serverTime.UTC().Format(http.TimeFormat))
+
+	sent, _ := strconv.ParseInt(q.Get("time"), 10, 64)
+	if d := s.serverTime.Unix() - sent; s.alwaysReject || d > 60 || d < -60 {
+		return &http.Response{
+			StatusCode: 400,
+			Body:       io.NopCloser(strings.NewReader(`{"status":"FAILED","comment":"apiKey: Time not valid"}`)),
+			Header:     header,
+		}, nil
+	}
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(`{"status":"OK","result":[{"handle":"tourist"}]}`)),
+		Header:     header,
+	}, nil
+}
+
+func TestClient_SignRequest(t *testing.T) {
+	client := NewClient(WithAPIKey("key", "secret"))
+
+	params := url.Values{}
+	params.Set("handles", "tourist")
+	signed := client.signRequest("user.info", params)
+
+	if params.Get("apiKey") != "" {
+		t.Error("signRequest() should not modify the caller's params")
+	}
+	if signed.Get("apiKey") != "key" || signed.Get("time") == "" {
+		t.Errorf("signRequest() params = %v, want apiKey and time", signed)
+	}
+
+	sig := signed.Get("apiSig")
+	if len(sig) != 6+128 {
+		t.Fatalf("apiSig length = %d, want %d", len(sig), 6+128)
+	}
+	prefix := sig[:6]
+	want := "/user.info?apiKey=key&handles=tourist&time=" + signed.Get("time") + "#secret"
+	sum := sha512.Sum512([]byte(prefix + want))
+	if sig[6:] != hex.EncodeToString(sum[:]) {
+		t.Errorf("apiSig does not match sha512(%q)", prefix+want)
+	}
+}
+
+func TestClient_Unsigned_NoAPIParams(t *testing.T) {
+	transport := &skewTransport{serverTime: time.Now()}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	client.GetUserInfo(context.Background(), []string{"tourist"})
+
+	if len(transport.queries) != 1 || transport.queries[0].Get("apiSig") != "" {
+		t.Errorf("unsigned client should not send apiSig, got %v", transport.queries)
+	}
+}
+
+func TestClient_ClockSkew_RetriesWithServerTime(t *testing.T) {
+	transport := &skewTransport{serverTime: time.Now().Add(-2 * time.Hour)}
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithAPIKey("key", "secret"),
+	)
+
+	users, err := client.GetUserInfo(context.Background(), []string{"tourist"})
+	if err != nil {
+		t.Fatalf("GetUserInfo() error = %v", err)
+	}
+	if len(users) != 1 || users[0].Handle != "tourist" {
+		t.Errorf("GetUserInfo() = %v", users)
+	}
+	if len(transport.queries) != 2 {
+		t.Fatalf("expected 2 requests (rejected + retry), got %d", len(transport.queries))
+	}
+
+	offset := client.ClockOffset()
+	if offset > -2*time.Hour+5*time.Second || offset < -2*time.Hour-5*time.Second {
+		t.Errorf("ClockOffset() = %v, want about -2h", offset)
+	}
+}
+
+func TestClient_ClockSkew_CorrectsStaleOffset(t *testing.T) {
+	transport := &skewTransport{serverTime: time.Now().Add(time.Hour)}
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithAPIKey("key", "secret"),
+	)
+	client.clockOffset.Store(int64(-time.Hour))
+
+	if _, err := client.GetUserInfo(context.Background(), []string{"tourist"}); err != nil {
+		t.Fatalf("GetUserInfo() error = %v", err)
+	}
+	if len(transport.queries) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(transport.queries))
+	}
+	if offset := client.ClockOffset(); offset < 55*time.Minute {
+		t.Errorf("ClockOffset() = %v, want about +1h", offset)
+	}
+}
+
+func TestClient_ClockSkew_RetriesOnce(t *testing.T) {
+	transport := &skewTransport{serverTime: time.Now().Add(time.Hour), alwaysReject: true}
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithAPIKey("key", "secret"),
+	)
+
+	_, err := client.GetUserInfo(context.Background(), []string{"tourist"})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != 400 {
+		t.Errorf("GetUserInfo() error = %v, want StatusError 400", err)
+	}
+	if len(transport.queries) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(transport.queries))
+	}
+}
+
+func TestClient_ClockSkew_NotTimeError(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 400,
+		body:       `{"status":"FAILED","comment":"handles: User with handle nobody not found"}`,
+	}
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithAPIKey("key", "secret"),
+	)
+
+	_, err := client.GetUserInfo(context.Background(), []string{"nobody"})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != 400 {
+		t.Errorf("GetUserInfo() error = %v, want StatusError 400", err)
+	}
+	if client.ClockOffset() != 0 {
+		t.Errorf("ClockOffset() = %v, want 0", client.ClockOffset())
+	}
+}
+
+func TestIsTimeError(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    bool
+	}{
+		{"apiKey: Time not valid", true},
+		{"time: Invalid time", true},
+		{"handles: User not found", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isTimeError(tt.comment); got != tt.want {
+			t.Errorf("isTimeError(%q) = %v, want %v", tt.comment, got, tt.want)
+		}
+	}
+}
+
+// ============ Registration Tests ============
+
+func TestClient_IsRegistered(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `{"status":"OK","result":{"contest":{"id":1},"problems":[],"rows":[{"party":{"members":[{"handle":"Tourist"}]}}]}}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	registered, err := client.IsRegistered(context.Background(), 1, "tourist")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !registered {
+		t.Error("Expected handle to be registered")
+	}
+
+	// The second check must come from the cache
+	registered, err = client.IsRegistered(context.Background(), 1, "TOURIST")
+	if err != nil || !registered {
+		t.Errorf("Expected cached registration, got %v, %v", registered, err)
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 request, got %d", callCount)
+	}
+}
+
+func TestClient_IsRegistered_NotParticipant(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":{"contest":{"id":1},"problems":[],"rows":[]}}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	registered, err := client.IsRegistered(context.Background(), 1, "tourist")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if registered {
+		t.Error("Expected handle not to be registered")
+	}
+}
+
+func TestClient_IsRegistered_ContestNotFound(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 400,
+		body:       `{"status":"FAILED","comment":"contestId: Contest with id 999999 not found"}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.IsRegistered(context.Background(), 999999, "tourist")
+	if !errors.Is(err, ErrContestNotFound) {
+		t.Errorf("Expected ErrContestNotFound, got: %v", err)
+	}
+}
+
+func TestClient_IsRegistered_APIFailed(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 400,
+		body:       `{"status":"FAILED","comment":"handles: User with handle nobody not found"}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.IsRegistered(context.Background(), 1, "nobody")
+	if err == nil {
+		t.Fatal("Expected error for unknown handle")
+	}
+	if errors.Is(err, ErrContestNotFound) {
+		t.Errorf("Unknown handle should not be reported as a missing contest: %v", err)
+	}
+}
+
+func TestClient_GetUpsolveList(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body: `{"status":"OK","result":{"contest":{"id":1325},
+			"problems":[{"index":"A","name":"Solved"},{"index":"B","name":"Failed"},{"index":"C","name":"Untouched"}],
+			"rows":[
+				{"party":{"members":[{"handle":"tourist"}],"participantType":"PRACTICE"},"problemResults":[{"points":1},{"points":1},{"points":1}]},
+				{"party":{"members":[{"handle":"Tourist"}],"participantType":"CONTESTANT"},"problemResults":[{"points":500},{"points":0,"rejectedAttemptCount":2},{"points":0}]}
+			]}}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	upsolve, err := client.GetUpsolveList(context.Background(), 1325, "tourist")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(upsolve) != 2 {
+		t.Fatalf("Expected 2 unsolved problems, got %d", len(upsolve))
+	}
+	if upsolve[0].Index != "B" || !upsolve[0].Attempted() || upsolve[0].RejectedAttempts != 2 {
+		t.Errorf("Expected B attempted twice, got %+v", upsolve[0])
+	}
+	if upsolve[1].Index != "C" || upsolve[1].Attempted() {
+		t.Errorf("Expected C not attempted, got %+v", upsolve[1])
+	}
+	if upsolve[1].ContestID != 1325 {
+		t.Errorf("Expected contest ID filled in, got %d", upsolve[1].ContestID)
+	}
+}
+
+func TestClient_GetUpsolveProblems_NotParticipant(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":{"contest":{"id":1},"problems":[{"index":"A"},{"index":"B"}],"rows":[]}}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	problems, err := client.GetUpsolveProblems(context.Background(), 1, "tourist")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Errorf("Expected every problem without participation, got %d", len(problems))
+	}
+}
+
+func TestClient_GetUpsolveProblems_ContestNotFound(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 400,
+		body:       `{"status":"FAILED","comment":"contestId: Contest with id 999999 not found"}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.GetUpsolveProblems(context.Background(), 999999, "tourist")
+	if !errors.Is(err, ErrContestNotFound) {
+		t.Errorf("Expected ErrContestNotFound, got: %v", err)
+	}
+}
+
+func TestClient_GetProblemsByRefs(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `{"status":"OK","result":{"problems":[{"contestId":1,"index":"A","name":"First"},{"contestId":2,"index":"B","name":"Second"},{"contestId":3,"index":"C","name":"Third"}],"problemStatistics":[]}}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	refs := []ProblemRef{{ContestID: 3, Index: "C"}, {ContestID: 1, Index: "A"}}
+	problems, err := client.GetProblemsByRefs(context.Background(), refs)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(problems) != 2 || problems[0].Name != "Third" || problems[1].Name != "First" {
+		t.Errorf("Expected problems in ref order, got %+v", problems)
+	}
+
+	// The problemset is fetched once and then served from the cache
+	if _, err := client.GetProblemsByRefs(context.Background(), refs); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if callCount != 1 {
+		t.Errorf("Expected 1 request, got %d", callCount)
+	}
+}
+
+func TestClient_GetProblemsByRefs_PartialMiss(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":{"problems":[{"contestId":1,"index":"A","name":"Test"}],"problemStatistics":[]}}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	refs := []ProblemRef{{ContestID: 999, Index: "Z"}, {ContestID: 1, Index: "A"}, {ContestID: 998, Index: "Y"}}
+	problems, err := client.GetProblemsByRefs(context.Background(), refs)
+	if !errors.Is(err, ErrProblemNotFound) {
+		t.Fatalf("Expected ErrProblemNotFound, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "999Z, 998Y") {
+		t.Errorf("Expected both misses in the error, got: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Index != "A" {
+		t.Errorf("Expected the found problem alongside the error, got %+v", problems)
+	}
+}
+
+func TestClient_FindProblemsByName(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `{"status":"OK","result":{"problems":[` +
+				`{"contestId":2000,"index":"C","name":"Square of the Theatre"},` +
+				`{"contestId":1500,"index":"D","name":"Theatre Square Revisited"},` +
+				`{"contestId":4,"index":"A","name":"Watermelon"},` +
+				`{"contestId":1,"index":"A","name":"Theatre Square"}` +
+				`],"problemStatistics":[]}}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+	ctx := context.Background()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"theatre square", []string{"1A", "1500D", "2000C"}},
+		{"THEATRE-SQUARE", []string{"1A", "1500D", "2000C"}},
+		{"revisited", []string{"1500D"}},
+		{"watermeln", []string{"4A"}},
+		{"dynamic programming", nil},
+	}
+	for _, tt := range tests {
+		problems, err := client.FindProblemsByName(ctx, tt.query)
+		if err != nil {
+			t.Fatalf("FindProblemsByName(%q) error = %v", tt.query, err)
+		}
+		var got []string
+		for _, p := range problems {
+			got = append(got, p.ProblemID())
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("FindProblemsByName(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	// All searches share one cached problemset fetch
+	if callCount != 1 {
+		t.Errorf("Expected 1 request, got %d", callCount)
+	}
+
+	if _, err := client.FindProblemsByName(ctx, " ?! "); err == nil {
+		t.Error("Expected an error for an empty query")
+	}
+}
+
+// standingsRows builds a contest.standings response with a contestant row per handle and points
+func standingsRows(points map[string]float64) string {
+	var rows []string
+	for handle, p := range points {
+		rows = append(rows, fmt.Sprintf(`{"party":{"members":[{"handle":"%s"}],"participantType":"CONTESTANT"},"points":%g}`, handle, p))
+	}
+	return `{"status":"OK","result":{"contest":{"id":1},"problems":[],"rows":[` + strings.Join(rows, ",") + `]}}`
+}
+
+func TestClient_StandingsDelta(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: standingsRows(map[string]float64{"tourist": 500, "Petr": 0})},
+			{statusCode: 200, body: standingsRows(map[string]float64{"tourist": 1500, "Petr": 0, "Benq": 750})},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+	handles := []string{"tourist", "petr", "Benq", "nobody"}
+
+	// The first poll only takes the snapshot
+	delta, err := client.StandingsDelta(context.Background(), 1, handles)
+	if err != nil {
+		t.Fatalf("StandingsDelta() error = %v", err)
+	}
+	if len(delta) != 0 {
+		t.Errorf("first poll delta = %v, want none", delta)
+	}
+
+	delta, err = client.StandingsDelta(context.Background(), 1, handles)
+	if err != nil {
+		t.Fatalf("StandingsDelta() error = %v", err)
+	}
+	want := map[string]int{"tourist": 1000, "petr": 0, "Benq": 750}
+	if len(delta) != len(want) {
+		t.Errorf("delta = %v, want %v", delta, want)
+	}
+	for handle, gained := range want {
+		if delta[handle] != gained {
+			t.Errorf("delta[%s] = %d, want %d", handle, delta[handle], gained)
+		}
+	}
+
+	if points := client.StandingsPoints(1); points["tourist"] != 1500 {
+		t.Errorf("StandingsPoints() = %v, want tourist at 1500", points)
+	}
+	if points := client.StandingsPoints(2); len(points) != 0 {
+		t.Errorf("StandingsPoints() of an unpolled contest = %v, want empty", points)
+	}
+}
+
+// submissionsPage builds a user.status response with submission IDs from high down to low
+func submissionsPage(high, low int) string {
+	var items []string
+	for id := high; id >= low; id-- {
+		items = append(items, fmt.Sprintf(`{"id":%d,"problem":{"contestId":1,"index":"A"},"verdict":"OK"}`, id))
+	}
+	return `{"status":"OK","result":[` + strings.Join(items, ",") + `]}`
+}
+
+func TestClient_SyncNewSubmissions(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: submissionsPage(250, 151)},
+			{statusCode: 200, body: submissionsPage(150, 51)},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	subs, cursor, err := client.SyncNewSubmissions(context.Background(), "tourist", 120)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(subs) != 130 || subs[0].ID != 250 || subs[len(subs)-1].ID != 121 {
+		t.Errorf("Expected submissions 250..121, got %d from %d", len(subs), subs[0].ID)
+	}
+	if cursor != 250 {
+		t.Errorf("Expected cursor 250, got %d", cursor)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected paging to stop after 2 requests, got %d", callCount)
+	}
+}
+
+func TestClient_SyncNewSubmissions_NoneNew(t *testing.T) {
+	transport := &mockTransport{statusCode: 200, body: submissionsPage(42, 1)}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	subs, cursor, err := client.SyncNewSubmissions(context.Background(), "tourist", 42)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if subs == nil || len(subs) != 0 {
+		t.Errorf("Expected an empty slice, got %v", subs)
+	}
+	if cursor != 42 {
+		t.Errorf("Expected cursor to stay 42, got %d", cursor)
+	}
+}
+
+func TestClient_SyncNewSubmissions_FromStart(t *testing.T) {
+	transport := &mockTransport{statusCode: 200, body: submissionsPage(3, 1)}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	subs, cursor, err := client.SyncNewSubmissions(context.Background(), "tourist", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(subs) != 3 || cursor != 3 {
+		t.Errorf("Expected all 3 submissions and cursor 3, got %d and %d", len(subs), cursor)
+	}
+}
+
+func TestClient_SyncNewSubmissions_PendingVerdict(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `{"status":"OK","result":[
+				{"id":13,"problem":{"contestId":1,"index":"C"},"verdict":"OK"},
+				{"id":12,"problem":{"contestId":1,"index":"B"},"verdict":"TESTING"},
+				{"id":11,"problem":{"contestId":1,"index":"A"},"verdict":"OK"},
+				{"id":10,"problem":{"contestId":1,"index":"A"},"verdict":"WRONG_ANSWER"}]}`},
+			{statusCode: 200, body: `{"status":"OK","result":[
+				{"id":14,"problem":{"contestId":1,"index":"D"},"verdict":"WRONG_ANSWER"},
+				{"id":13,"problem":{"contestId":1,"index":"C"},"verdict":"OK"},
+				{"id":12,"problem":{"contestId":1,"index":"B"},"verdict":"OK"},
+				{"id":11,"problem":{"contestId":1,"index":"A"},"verdict":"OK"}]}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	subs, cursor, err := client.SyncNewSubmissions(context.Background(), "tourist", 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(subs) != 3 {
+		t.Errorf("Expected 3 new submissions, got %d", len(subs))
+	}
+	if cursor != 11 {
+		t.Fatalf("Expected the cursor held below the judging submission at 11, got %d", cursor)
+	}
+
+	// The next sync reads 12 again, now accepted
+	subs, cursor, err = client.SyncNewSubmissions(context.Background(), "tourist", cursor)
+	if err != nil {
+		t.Fatalf("Unexpected error on the next sync: %v", err)
+	}
+	accepted := false
+	for _, s := range subs {
+		if s.ID == 12 && s.IsAccepted() {
+			accepted = true
+		}
+	}
+	if !accepted {
+		t.Errorf("Expected submission 12 to be re-read as accepted, got %+v", subs)
+	}
+	if cursor != 14 {
+		t.Errorf("Expected cursor 14 once every verdict is final, got %d", cursor)
+	}
+}
+
+// timedSubmissionsPage returns a user.status page of submissions high down
+// to low, submission n made at second n
+func timedSubmissionsPage(high, low int) string {
+	var items []string
+	for id := high; id >= low; id-- {
+		items = append(items, fmt.Sprintf(`{"id":%d,"creationTimeSeconds":%d,"problem":{"contestId":1,"index":"A"},"verdict":"OK"}`, id, id))
+	}
+	return `{"status":"OK","result":[` + strings.Join(items, ",") + `]}`
+}
+
+func TestClient_GetSubmissionsSince(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: timedSubmissionsPage(250, 151)},
+			{statusCode: 200, body: timedSubmissionsPage(150, 51)},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	subs, err := client.GetSubmissionsSince(context.Background(), "tourist", time.Unix(120, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(subs) != 130 || subs[0].ID != 250 || subs[len(subs)-1].ID != 121 {
+		t.Errorf("Expected submissions 250..121, got %d from %d", len(subs), subs[0].ID)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected paging to stop after 2 requests, got %d", callCount)
+	}
+}
+
+func TestClient_GetSubmissionsSince_ShortHistory(t *testing.T) {
+	transport := &mockTransport{statusCode: 200, body: timedSubmissionsPage(3, 1)}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	subs, err := client.GetSubmissionsSince(context.Background(), "tourist", time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(subs) != 3 {
+		t.Errorf("Expected all 3 submissions, got %d", len(subs))
+	}
+}
+
+// ============ EstimateDifficulty Tests ============
+
+// estimateStandings returns standings of 10 contestants where A is solved by
+// 9, B by 3 and C by none, plus a practice row that solved everything
+func estimateStandings() string {
+	var rows []string
+	for i := 0; i < 10; i++ {
+		a, b := 0, 0
+		if i < 9 {
+			a = 1
+		}
+		if i < 3 {
+			b = 1
+		}
+		rows = append(rows, fmt.Sprintf(`{"party":{"participantType":"CONTESTANT"},"problemResults":[{"points":%d},{"points":%d},{"points":0}]}`, a, b))
+	}
+	rows = append(rows, `{"party":{"participantType":"PRACTICE"},"problemResults":[{"points":1},{"points":1},{"points":1}]}`)
+
+	return `{"status":"OK","result":{"contest":{"id":100001},` +
+		`"problems":[{"index":"A"},{"index":"B"},{"index":"C"}],` +
+		`"rows":[` + strings.Join(rows, ",") + `]}}`
+}
+
+func TestClient_EstimateDifficulty(t *testing.T) {
+	transport := &mockTransport{statusCode: 200, body: estimateStandings()}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	tests := []struct {
+		index string
+		want  int
+	}{
+		{"A", 800},  // 90%
+		{"B", 1400}, // 30%
+		{"C", 3500}, // nobody
+	}
+	for _, tt := range tests {
+		got, err := client.EstimateDifficulty(context.Background(), 100001, tt.index)
+		if err != nil {
+			t.Fatalf("EstimateDifficulty(%s) error = %v", tt.index, err)
+		}
+		if got != tt.want {
+			t.Errorf("EstimateDifficulty(%s) = %d, want %d", tt.index, got, tt.want)
+		}
+	}
+
+	if transport.calls != 1 {
+		t.Errorf("standings requested %d times, want 1", transport.calls)
+	}
+
+	if _, err := client.EstimateDifficulty(context.Background(), 100001, "Z"); err == nil {
+		t.Error("EstimateDifficulty() should fail for a problem not in the contest")
+	}
+}
+
+func TestClient_EstimateDifficulty_CustomBands(t *testing.T) {
+	transport := &mockTransport{statusCode: 200, body: estimateStandings()}
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithDifficultyBands([]DifficultyBand{{0.5, 1000}, {0, 2000}}),
+	)
+
+	got, err := client.EstimateDifficulty(context.Background(), 100001, "B")
+	if err != nil {
+		t.Fatalf("EstimateDifficulty() error = %v", err)
+	}
+	if got != 2000 {
+		t.Errorf("EstimateDifficulty() = %d, want 2000 from the custom bands", got)
+	}
+}
+
+func TestClient_EstimateDifficulty_ContestNotFound(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 400,
+		body:       `{"status":"FAILED","comment":"contestId: Contest with id 999999 not found"}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.EstimateDifficulty(context.Background(), 999999, "A")
+	if !errors.Is(err, ErrContestNotFound) {
+		t.Errorf("EstimateDifficulty() error = %v, want ErrContestNotFound", err)
+	}
+}
+
+// acceptedSubmissions builds a user.status response with one accepted
+// submission per rating, newest first
+func acceptedSubmissions(ratings ...int) string {
+	var subs []string
+	for i, r := range ratings {
+		subs = append(subs, fmt.Sprintf(`{"id":%d,"contestId":%d,"problem":{"contestId":%d,"index":"A","rating":%d},"verdict":"OK"}`,
+			len(ratings)-i, 1000+i, 1000+i, r))
+	}
+	return `{"status":"OK","result":[` + strings.Join(subs, ",") + `]}`
+}
+
+func TestClient_SuggestDifficultyBand_RaisedByRecentSolves(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `{"status":"OK","result":[{"handle":"tourist","rating":1400}]}`},
+			{statusCode: 200, body: acceptedSubmissions(1900, 1800, 1800, 1900, 1700, 1800, 1800, 2000)},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	min, max, err := client.SuggestDifficultyBand(context.Background(), "tourist")
+	if err != nil {
+		t.Fatalf("SuggestDifficultyBand() error = %v", err)
+	}
+	if min != 1700 || max != 2100 {
+		t.Errorf("SuggestDifficultyBand() = %d-%d, want 1700-2100", min, max)
+	}
+}
+
+func TestClient_RecommendForTag(t *testing.T) {
+	tagged := `{"status":"OK","result":{"problems":[` +
+		`{"contestId":1000,"index":"A","rating":1400,"tags":["dp"]},` +
+		`{"contestId":2000,"index":"B","rating":1500,"tags":["dp"]},` +
+		`{"contestId":3000,"index":"C","rating":1600,"tags":["dp","greedy"]},` +
+		`{"contestId":4000,"index":"D","rating":2500,"tags":["dp"]},` +
+		`{"contestId":5000,"index":"E","tags":["dp"]}` +
+		`],"problemStatistics":[` +
+		`{"contestId":1000,"index":"A","solvedCount":5000},` +
+		`{"contestId":2000,"index":"B","solvedCount":50},` +
+		`{"contestId":3000,"index":"C","solvedCount":900}]}}`
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `{"status":"OK","result":{"problems":[{"contestId":1,"index":"A","tags":["dp","graphs"]}],"problemStatistics":[]}}`},
+			{statusCode: 200, body: `{"status":"OK","result":[{"handle":"tourist","rating":1400}]}`},
+			{statusCode: 200, body: acceptedSubmissions(1400)}, // 1000A
+			{statusCode: 200, body: tagged},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	problems, err := client.RecommendForTag(context.Background(), "tourist", "DP", 5)
+	if err != nil {
+		t.Fatalf("RecommendForTag() error = %v", err)
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.ProblemID())
+	}
+	// 1000A is solved, 4000D is above the 1300-1700 band and 5000E is unrated
+	if strings.Join(got, ",") != "3000C,2000B" {
+		t.Errorf("RecommendForTag() = %v, want [3000C 2000B] by solve count", got)
+	}
+	if callCount != 4 {
+		t.Errorf("Expected 4 requests, got %d", callCount)
+	}
+}
+
+func TestClient_RecommendForTag_UnknownTag(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":{"problems":[{"contestId":1,"index":"A","tags":["dp","graphs","greedy"]}],"problemStatistics":[]}}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.RecommendForTag(context.Background(), "tourist", "grpahs", 5)
+	if !errors.Is(err, ErrUnknownTag) {
+		t.Fatalf("RecommendForTag() error = %v, want ErrUnknownTag", err)
+	}
+	if !strings.Contains(err.Error(), "did you mean graphs?") {
+		t.Errorf("Expected a correction in the error, got: %v", err)
+	}
+}
+
+func TestSuggestTags(t *testing.T) {
+	known := []string{"dp", "graphs", "greedy", "number theory", "shortest paths", "trees"}
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"dpp", "dp"},
+		{"tree", "trees"},
+		{"numbr theory", "number theory"},
+		{"short", "shortest paths"},
+		{"xyzzy", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(SuggestTags(tt.tag, known), ","); got != tt.want {
+			t.Errorf("SuggestTags(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestClient_SuggestDifficultyBand_NoUser(t *testing.T) {
+	transport := &mockTransport{statusCode: 200, body: `{"status":"OK","result":[]}`}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	if _, _, err := client.SuggestDifficultyBand(context.Background(), "tourist"); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("SuggestDifficultyBand() error = %v, want ErrInvalidHandle", err)
+	}
+}
+
+func TestClient_ExportSolved(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body: `{"status":"OK","result":[
+			{"id":4,"problem":{"contestId":100001,"index":"B","name":"Gym B"},"verdict":"OK"},
+			{"id":3,"problem":{"contestId":1325,"index":"A","name":"EhAb AnD gCd","rating":800},"verdict":"OK"},
+			{"id":2,"problem":{"contestId":1325,"index":"A","name":"EhAb AnD gCd","rating":800},"verdict":"OK"},
+			{"id":1,"problem":{"contestId":1325,"index":"B","name":"CopyCopyCopyCopyCopy"},"verdict":"WRONG_ANSWER"}
+		]}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	var buf strings.Builder
+	if err := client.ExportSolved(context.Background(), "tourist", &buf); err != nil {
+		t.Fatalf("ExportSolved() error = %v", err)
+	}
+	if want := "gym/100001/B\n1325/A\n"; buf.String() != want {
+		t.Errorf("ExportSolved() = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := client.ExportSolvedJSON(context.Background(), "tourist", &buf); err != nil {
+		t.Fatalf("ExportSolvedJSON() error = %v", err)
+	}
+	var exported []ExportedProblem
+	if err := json.Unmarshal([]byte(buf.String()), &exported); err != nil {
+		t.Fatalf("ExportSolvedJSON() wrote invalid JSON: %v", err)
+	}
+	if len(exported) != 2 || !exported[0].Gym || exported[1].Gym || exported[1].Rating != 800 {
+		t.Errorf("ExportSolvedJSON() = %+v, want gym 100001B then 1325A", exported)
+	}
+}
+
+func TestClient_NextUnsolved(t *testing.T) {
+	standings := `{"status":"OK","result":{"contest":{"id":1325},
+		"problems":[{"index":"B","name":"Second"},{"index":"A","name":"First"},{"index":"C","name":"Third"}],"rows":[]}}`
+	solvedA := `{"status":"OK","result":[{"id":1,"problem":{"contestId":1325,"index":"A"},"verdict":"OK"}]}`
+
+	callCount := 0
+	client := NewClient(WithHTTPClient(&http.Client{Transport: &sequentialTransport{
+		responses: []mockResponse{{statusCode: 200, body: standings}, {statusCode: 200, body: solvedA}},
+		callCount: &callCount,
+	}}))
+
+	next, err := client.NextUnsolved(context.Background(), 1325, "tourist")
+	if err != nil {
+		t.Fatalf("NextUnsolved() error = %v", err)
+	}
+	if next.Index != "B" || next.ContestID != 1325 {
+		t.Errorf("NextUnsolved() = %s, want 1325B", next.ProblemID())
+	}
+}
+
+func TestClient_NextUnsolved_AllSolved(t *testing.T) {
+	standings := `{"status":"OK","result":{"contest":{"id":1325},"problems":[{"index":"A"}],"rows":[]}}`
+	solved := `{"status":"OK","result":[{"id":1,"problem":{"contestId":1325,"index":"A"},"verdict":"OK"}]}`
+
+	callCount := 0
+	client := NewClient(WithHTTPClient(&http.Client{Transport: &sequentialTransport{
+		responses: []mockResponse{{statusCode: 200, body: standings}, {statusCode: 200, body: solved}},
+		callCount: &callCount,
+	}}))
+
+	if _, err := client.NextUnsolved(context.Background(), 1325, "tourist"); !errors.Is(err, ErrAllSolved) {
+		t.Errorf("NextUnsolved() error = %v, want ErrAllSolved", err)
+	}
+}
+
+func TestClient_GetUsersBulk(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 400, body: `{"status":"FAILED","comment":"handles: User with handle nobody_here not found"}`},
+			{statusCode: 200, body: `{"status":"OK","result":[{"handle":"petr","rating":3100},{"handle":"tourist","rating":3800},{"handle":"newbie","rating":0}]}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	users, err := client.GetUsersBulk(context.Background(), []string{"petr", "nobody_here", "tourist", "bad handle!", "newbie", "Petr"})
+
+	var missing *MissingHandlesError
+	if !errors.As(err, &missing) {
+		t.Fatalf("GetUsersBulk() error = %v, want *MissingHandlesError", err)
+	}
+	if got := strings.Join(missing.Handles, ","); got != "bad handle!,nobody_here" {
+		t.Errorf("missing = %s, want the malformed and the unknown handle", got)
+	}
+
+	var handles []string
+	for _, u := range users {
+		handles = append(handles, u.Handle)
+	}
+	if got := strings.Join(handles, ","); got != "tourist,petr,newbie" {
+		t.Errorf("users = %s, want tourist,petr,newbie (by rating)", got)
+	}
+	if callCount != 2 {
+		t.Errorf("calls = %d, want 2 (retry without the unknown handle)", callCount)
+	}
+}
+
+func TestClient_GetUsersBulk_Chunks(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `{"status":"OK","result":[{"handle":"a","rating":1500}]}`},
+			{statusCode: 200, body: `{"status":"OK","result":[{"handle":"b","rating":1600}]}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	handles := make([]string, UserInfoChunkSize+1)
+	for i := range handles {
+		handles[i] = fmt.Sprintf("user%d", i)
+	}
+	users, err := client.GetUsersBulk(context.Background(), handles)
+	if err != nil {
+		t.Fatalf("GetUsersBulk() error = %v", err)
+	}
+	if callCount != 2 || len(users) != 2 || users[0].Handle != "b" {
+		t.Errorf("calls = %d, users = %+v, want 2 chunks merged by rating", callCount, users)
+	}
+}
+
+func TestClient_GetUsersBulk_OtherErrorsFail(t *testing.T) {
+	transport := &mockTransport{statusCode: 503, body: "Service Unavailable"}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	users, err := client.GetUsersBulk(context.Background(), []string{"tourist"})
+	var missing *MissingHandlesError
+	if err == nil || errors.As(err, &missing) || users != nil {
+		t.Errorf("GetUsersBulk() = %v, %v, want a plain error", users, err)
+	}
+}
+
+// ============ ContestReport Tests ============
+
+const reportStandingsBody = `{"status":"OK","result":{
+	"contest":{"id":1325,"name":"Codeforces Round 628","phase":"FINISHED"},
+	"problems":[{"index":"A","name":"EhAb AnD gCd"},{"index":"B","name":"CopyCopyCopy"},{"index":"C","name":"Ehab and Path-etic MEXs"}],
+	"rows":[
+		{"party":{"members":[{"handle":"tourist"}],"participantType":"PRACTICE"},"rank":0,"problemResults":[]},
+		{"party":{"members":[{"handle":"tourist"}],"participantType":"CONTESTANT"},"rank":3,"points":2,"penalty":30,
+		 "problemResults":[
+			{"points":1,"rejectedAttemptCount":0,"bestSubmissionTimeSeconds":300},
+			{"points":1,"rejectedAttemptCount":1,"bestSubmissionTimeSeconds":900},
+			{"points":0,"rejectedAttemptCount":2}]}
+	]}}`
+
+func TestClient_ContestReport(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `{"status":"OK","result":[{"id":1325,"name":"Codeforces Round 628 (Div. 2)","phase":"FINISHED"}]}`},
+			{statusCode: 200, body: reportStandingsBody},
+			{statusCode: 200, body: `{"status":"OK","result":[{"contestId":1325,"handle":"petr","oldRating":3000,"newRating":3010},{"contestId":1325,"handle":"Tourist","rank":3,"oldRating":3500,"newRating":3550}]}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	report, err := client.ContestReport(context.Background(), 1325, "tourist")
+	if err != nil {
+		t.Fatalf("ContestReport() error = %v", err)
+	}
+	if report.Contest == nil || report.Contest.Name != "Codeforces Round 628 (Div. 2)" {
+		t.Errorf("Contest = %+v, want the contest list entry", report.Contest)
+	}
+	if report.Standing == nil || report.Standing.Rank != 3 {
+		t.Errorf("Standing = %+v, want the contestant row", report.Standing)
+	}
+	if report.RatingChange == nil || report.RatingChange.RatingDelta() != 50 {
+		t.Errorf("RatingChange = %+v, want +50", report.RatingChange)
+	}
+	if len(report.Problems) != 3 || report.SolvedCount() != 2 {
+		t.Fatalf("Problems = %+v, want 3 with 2 solved", report.Problems)
+	}
+	if b := report.Problems[1]; b.SolveTime() != 15*time.Minute || b.RejectedAttempts != 1 || b.ContestID != 1325 {
+		t.Errorf("Problems[1] = %+v, want solved at 15m after 1 rejected attempt", b)
+	}
+	if c := report.Problems[2]; c.Solved || c.RejectedAttempts != 2 {
+		t.Errorf("Problems[2] = %+v, want unsolved with 2 attempts", c)
+	}
+}
+
+func TestClient_ContestReport_Partial(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 503, body: "Service Unavailable"},
+			{statusCode: 200, body: reportStandingsBody},
+			{statusCode: 200, body: `{"status":"FAILED","comment":"contestId: Rating changes are unavailable for this contest"}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	report, err := client.ContestReport(context.Background(), 1325, "tourist")
+	var reportErr *ReportError
+	if !errors.As(err, &reportErr) {
+		t.Fatalf("ContestReport() error = %v, want a *ReportError", err)
+	}
+	if _, ok := reportErr.Failed["contest"]; !ok || len(reportErr.Failed) != 2 {
+		t.Errorf("Failed = %v, want contest and ratings", reportErr.Failed)
+	}
+	if report == nil || report.Contest == nil || report.Contest.Name != "Codeforces Round 628" {
+		t.Fatalf("report = %+v, want the contest from the standings", report)
+	}
+	if report.RatingChange != nil || report.SolvedCount() != 2 {
+		t.Errorf("report = %+v, want standings data without a rating change", report)
+	}
+}
+
+func TestClient_ContestReport_AllFailed(t *testing.T) {
+	transport := &mockTransport{statusCode: 503, body: "Service Unavailable"}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	report, err := client.ContestReport(context.Background(), 1325, "tourist")
+	if err == nil || report != nil {
+		t.Errorf("ContestReport() = %+v, %v, want nil and an error", report, err)
+	}
+}
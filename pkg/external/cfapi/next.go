@@ -0,0 +1,48 @@
+package cfapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrAllSolved is returned by NextUnsolved when every problem of the contest is solved
+var ErrAllSolved = errors.New("all problems solved")
+
+// NextUnsolved returns the first problem of a contest, in index order, that
+// handle has not solved at any time. Returns ErrAllSolved if there is none.
+func (c *Client) NextUnsolved(ctx context.Context, contestID int, handle string) (*Problem, error) {
+	standings, err := c.GetContestStandings(ctx, contestID, 1, 1, nil, false)
+	if err != nil {
+		if isContestNotFound(err) {
+			return nil, fmt.Errorf("contest %d: %w", contestID, ErrContestNotFound)
+		}
+		return nil, err
+	}
+
+	solved, err := c.GetSolvedProblems(ctx, handle)
+	if err != nil {
+		return nil, fmt.Errorf("get solved problems: %w", err)
+	}
+	solvedSet := make(map[string]bool, len(solved))
+	for i := range solved {
+		solvedSet[solved[i].ProblemID()] = true
+	}
+
+	problems := append([]Problem(nil), standings.Problems...)
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Index < problems[j].Index
+	})
+
+	for i := range problems {
+		p := &problems[i]
+		if p.ContestID == 0 {
+			p.ContestID = contestID
+		}
+		if !solvedSet[p.ProblemID()] {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("contest %d: %w", contestID, ErrAllSolved)
+}
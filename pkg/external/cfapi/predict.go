@@ -0,0 +1,201 @@
+package cfapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ErrNotContestant is returned when predicting the rating change of a handle
+// that is not an official contestant of the contest
+var ErrNotContestant = errors.New("not an official contestant")
+
+// InitialRating is the rating an unrated contestant is predicted from
+const InitialRating = 1400
+
+// maxPredictedRating bounds the rating searched for in ratingForRank
+const maxPredictedRating = 8000
+
+// RatedContestant is an official contestant with their pre-contest rating
+type RatedContestant struct {
+	Handle string
+	Rank   int // Standings rank; tied contestants share it
+	Rating int
+}
+
+// PredictRatingChange predicts handle's rating change in a contest from its
+// current standings and the participants' current ratings, which stand in
+// for their pre-contest ratings until the contest is rated. Only official
+// contestants are rated; out of competition, virtual and team rows are left
+// out. Ratings are fetched in chunks of UserInfoChunkSize, so large contests
+// take many requests.
+func (c *Client) PredictRatingChange(ctx context.Context, contestID int, handle string) (int, error) {
+	standings, err := c.GetContestStandings(ctx, contestID, 0, 0, nil, false)
+	if err != nil {
+		if isContestNotFound(err) {
+			return 0, fmt.Errorf("contest %d: %w", contestID, ErrContestNotFound)
+		}
+		return 0, err
+	}
+
+	var contestants []RatedContestant
+	var handles []string
+	for _, row := range standings.Rows {
+		if row.Party.ParticipantType != ParticipantContestant || len(row.Party.Members) != 1 {
+			continue
+		}
+		h := row.Party.Members[0].Handle
+		contestants = append(contestants, RatedContestant{Handle: h, Rank: row.Rank})
+		handles = append(handles, h)
+	}
+
+	target := -1
+	for i, ct := range contestants {
+		if strings.EqualFold(ct.Handle, handle) {
+			target = i
+		}
+	}
+	if target < 0 {
+		return 0, fmt.Errorf("%s in contest %d: %w", handle, contestID, ErrNotContestant)
+	}
+
+	users, err := c.GetUsersBulk(ctx, handles)
+	var missing *MissingHandlesError
+	if err != nil && !errors.As(err, &missing) {
+		return 0, err
+	}
+	ratings := make(map[string]int, len(users))
+	for _, u := range users {
+		ratings[strings.ToLower(u.Handle)] = u.Rating
+	}
+	for i := range contestants {
+		contestants[i].Rating = ratings[strings.ToLower(contestants[i].Handle)]
+	}
+
+	return RatingDeltas(contestants)[target], nil
+}
+
+// RatingDeltas applies the Codeforces rating formula to contestants, given
+// in standings order, and returns each one's rating change in that order.
+// Unrated contestants (rating 0) start from InitialRating.
+//
+// The probability that a contestant rated ra finishes above one rated rb is
+// 1 / (1 + 10^((rb-ra)/400)). A contestant's seed, their expected rank, is
+// 1 plus the probabilities of every other contestant finishing above them.
+// Tied contestants all take the lowest place of the tie. Each contestant
+// aims for the geometric mean of their seed and place, finds the rating
+// whose seed would be that rank, and moves half way to it. The changes are
+// then shifted so they sum to slightly below zero, and once more so the top
+// 4*sqrt(n) rated contestants don't gain on average, by at most 10 points.
+func RatingDeltas(contestants []RatedContestant) []int {
+	n := len(contestants)
+	if n == 0 {
+		return nil
+	}
+
+	ratings := make([]int, n)
+	for i, ct := range contestants {
+		ratings[i] = ct.Rating
+		if ratings[i] == 0 {
+			ratings[i] = InitialRating
+		}
+	}
+
+	// Seeds of every whole rating, counting all contestants as opponents;
+	// contestants are tallied by rating as many share one
+	tally := make(map[int]int)
+	var distinct []int
+	for _, r := range ratings {
+		if tally[r] == 0 {
+			distinct = append(distinct, r)
+		}
+		tally[r]++
+	}
+	seeds := make([]float64, maxPredictedRating)
+	for r := range seeds {
+		seeds[r] = 1
+		for _, other := range distinct {
+			seeds[r] += float64(tally[other]) * winProbability(other, r)
+		}
+	}
+	seedOf := func(r int) float64 {
+		return seeds[min(max(r, 0), maxPredictedRating-1)]
+	}
+
+	places := tiedPlaces(contestants)
+	deltas := make([]int, n)
+	for i, r := range ratings {
+		// A contestant is not their own opponent
+		seed := seedOf(r) - 0.5
+		need := ratingForRank(seeds, math.Sqrt(float64(places[i])*seed))
+		deltas[i] = (need - r) / 2
+	}
+
+	sum := 0
+	for _, d := range deltas {
+		sum += d
+	}
+	inc := -sum/n - 1
+	for i := range deltas {
+		deltas[i] += inc
+	}
+
+	byRating := make([]int, n)
+	for i := range byRating {
+		byRating[i] = i
+	}
+	sort.SliceStable(byRating, func(a, b int) bool {
+		return ratings[byRating[a]] > ratings[byRating[b]]
+	})
+	top := min(4*int(math.Round(math.Sqrt(float64(n)))), n)
+	sum = 0
+	for _, i := range byRating[:top] {
+		sum += deltas[i]
+	}
+	inc = min(max(-sum/top, -10), 0)
+	for i := range deltas {
+		deltas[i] += inc
+	}
+	return deltas
+}
+
+// winProbability returns the probability that a contestant rated ra
+// finishes above one rated rb
+func winProbability(ra, rb int) float64 {
+	return 1 / (1 + math.Pow(10, float64(rb-ra)/400))
+}
+
+// ratingForRank returns the highest rating whose seed is at least rank;
+// seeds fall as the rating rises
+func ratingForRank(seeds []float64, rank float64) int {
+	left, right := 1, len(seeds)
+	for right-left > 1 {
+		mid := (left + right) / 2
+		if seeds[mid] < rank {
+			right = mid
+		} else {
+			left = mid
+		}
+	}
+	return left
+}
+
+// tiedPlaces returns the place of each contestant, with a tie taking the
+// lowest place the tied contestants occupy
+func tiedPlaces(contestants []RatedContestant) []int {
+	places := make([]int, len(contestants))
+	for first := 0; first < len(contestants); {
+		last := first + 1
+		for last < len(contestants) && contestants[last].Rank == contestants[first].Rank {
+			last++
+		}
+		for i := first; i < last; i++ {
+			places[i] = last
+		}
+		first = last
+	}
+	return places
+}
@@ -0,0 +1,82 @@
+package cfapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+// Six official contestants with a tie for third and an unrated newcomer
+var predictContestants = []RatedContestant{
+	{Handle: "alice", Rank: 1, Rating: 1500},
+	{Handle: "bob", Rank: 2, Rating: 1600},
+	{Handle: "carol", Rank: 3, Rating: 1400},
+	{Handle: "dave", Rank: 3, Rating: 0},
+	{Handle: "erin", Rank: 5, Rating: 2100},
+	{Handle: "frank", Rank: 6, Rating: 1200},
+}
+
+func TestRatingDeltas(t *testing.T) {
+	tests := []struct {
+		name        string
+		contestants []RatedContestant
+		want        []int
+	}{
+		{"synthetic standings", predictContestants, []int{194, 54, -10, -10, -192, -39}},
+		{"equal ratings", []RatedContestant{{Rank: 1, Rating: 1500}, {Rank: 2, Rating: 1500}}, []int{65, -67}},
+		{"empty", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RatingDeltas(tt.contestants); !slices.Equal(got, tt.want) {
+				t.Errorf("RatingDeltas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTiedPlaces(t *testing.T) {
+	got := tiedPlaces(predictContestants)
+	if want := []int{1, 2, 4, 4, 5, 6}; !slices.Equal(got, want) {
+		t.Errorf("tiedPlaces() = %v, want %v", got, want)
+	}
+}
+
+func TestClient_PredictRatingChange(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `{"status":"OK","result":{"contest":{"id":1},"problems":[],"rows":[
+ {"party":{"members":[{"handle":"alice"}],"participantType":"CONTESTANT"},"rank":1},
+ {"party":{"members":[{"handle":"ghost"}],"participantType":"OUT_OF_COMPETITION"},"rank":0},
+ {"party":{"members":[{"handle":"bob"}],"participantType":"CONTESTANT"},"rank":2}]}}`},
+			{statusCode: 200, body: `{"status":"OK","result":[{"handle":"bob","rating":1500},{"handle":"alice","rating":1500}]}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	delta, err := client.PredictRatingChange(context.Background(), 1, "Alice")
+	if err != nil {
+		t.Fatalf("PredictRatingChange() error = %v", err)
+	}
+	if delta != 65 {
+		t.Errorf("PredictRatingChange() = %d, want 65", delta)
+	}
+}
+
+func TestClient_PredictRatingChange_NotContestant(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":{"contest":{"id":1},"problems":[],"rows":[{"party":{"members":[{"handle":"tourist"}],"participantType":"OUT_OF_COMPETITION"},"rank":0}]}}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.PredictRatingChange(context.Background(), 1, "tourist")
+	if !errors.Is(err, ErrNotContestant) {
+		t.Errorf("PredictRatingChange() error = %v, want ErrNotContestant", err)
+	}
+}
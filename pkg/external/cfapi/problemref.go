@@ -0,0 +1,39 @@
+package cfapi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// reProblemRef matches "1325A", "1325/A", "1325-A" and subtask indexes like "1325F2"
+var reProblemRef = regexp.MustCompile(`^(\d+)[/-]?([A-Za-z][0-9]?)$`)
+
+// ParseProblemRef splits a problem reference such as "1325A", "1325/A",
+// "1325-A" or a gym problem like "100001A" into contest ID and index
+// The index is returned upper-cased
+func ParseProblemRef(s string) (contestID int, index string, err error) {
+	matches := reProblemRef.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return 0, "", fmt.Errorf("invalid problem reference %q (expected e.g. 1325A or 1325/A)", s)
+	}
+
+	contestID, err = strconv.Atoi(matches[1])
+	if err != nil || contestID <= 0 {
+		return 0, "", fmt.Errorf("invalid contest ID in problem reference %q", s)
+	}
+
+	return contestID, strings.ToUpper(matches[2]), nil
+}
+
+// ProblemRef identifies a problem by contest ID and index
+type ProblemRef struct {
+	ContestID int    `yaml:"contestId" json:"contestId"`
+	Index     string `yaml:"index" json:"index"`
+}
+
+// String returns the reference in the form used by ProblemID, e.g. 1325A
+func (r ProblemRef) String() string {
+	return fmt.Sprintf("%d%s", r.ContestID, r.Index)
+}
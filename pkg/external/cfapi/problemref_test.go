@@ -0,0 +1,42 @@
+package cfapi
+
+import "testing"
+
+func TestParseProblemRef(t *testing.T) {
+	tests := []struct {
+		input     string
+		contestID int
+		index     string
+		wantErr   bool
+	}{
+		{"1325A", 1325, "A", false},
+		{"1325/A", 1325, "A", false},
+		{"1325-A", 1325, "A", false},
+		{"1325a", 1325, "A", false},
+		{" 1325B ", 1325, "B", false},
+		{"1A", 1, "A", false},
+		{"1520F2", 1520, "F2", false},
+		{"1520/f1", 1520, "F1", false},
+		{"100001A", 100001, "A", false}, // gym
+		{"abcA", 0, "", true},
+		{"1325", 0, "", true},
+		{"A", 0, "", true},
+		{"1325/", 0, "", true},
+		{"1325AB", 0, "", true},
+		{"0A", 0, "", true},
+		{"", 0, "", true},
+		{"1325 A", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			contestID, index, err := ParseProblemRef(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseProblemRef(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if contestID != tt.contestID || index != tt.index {
+				t.Errorf("ParseProblemRef(%q) = (%d, %q), want (%d, %q)", tt.input, contestID, index, tt.contestID, tt.index)
+			}
+		})
+	}
+}
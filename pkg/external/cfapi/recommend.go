@@ -0,0 +1,139 @@
+package cfapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownTag is returned for a tag that no problem in the problemset has
+var ErrUnknownTag = errors.New("unknown tag")
+
+// maxTagSuggestions is how many corrections are offered for an unknown tag
+const maxTagSuggestions = 3
+
+// RecommendForTag recommends up to count unsolved, rated problems with tag
+// in the practice band SuggestDifficultyBand picks for handle, most solved
+// first. The tag may be an alias; an unknown tag fails with ErrUnknownTag
+// and the closest known tags in the message.
+func (c *Client) RecommendForTag(ctx context.Context, handle, tag string, count int) ([]Problem, error) {
+	tags := c.ResolveTags([]string{tag})
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("empty tag")
+	}
+
+	all, err := c.GetProblems(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	known := ProblemTags(all.Problems)
+	if len(UnknownTags(tags, known)) > 0 {
+		if suggestions := SuggestTags(tags[0], known); len(suggestions) > 0 {
+			return nil, fmt.Errorf("%w %q; did you mean %s?", ErrUnknownTag, tag, strings.Join(suggestions, ", "))
+		}
+		return nil, fmt.Errorf("%w %q", ErrUnknownTag, tag)
+	}
+
+	min, max, err := c.SuggestDifficultyBand(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := c.FilterProblems(ctx, min, max, tags, true, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same request as FilterProblems, served from the cache
+	tagged, err := c.GetProblems(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+	solvedBy := make(map[string]int, len(tagged.ProblemStatistics))
+	for _, s := range tagged.ProblemStatistics {
+		solvedBy[fmt.Sprintf("%d%s", s.ContestID, s.Index)] = s.SolvedCount
+	}
+
+	var rated []Problem
+	for _, p := range candidates {
+		if p.Rating > 0 {
+			rated = append(rated, p)
+		}
+	}
+	sort.SliceStable(rated, func(i, j int) bool {
+		return solvedBy[rated[i].ProblemID()] > solvedBy[rated[j].ProblemID()]
+	})
+
+	if count > 0 && len(rated) > count {
+		rated = rated[:count]
+	}
+	return rated, nil
+}
+
+// SuggestTags returns the known tags closest to a mistyped tag, best first:
+// those within a few edits of it and those containing it as a word
+func SuggestTags(tag string, known []string) []string {
+	tag = normalizeTag(tag)
+	maxEdits := 2
+	if len(tag) >= 12 {
+		maxEdits = 3
+	}
+
+	type suggestion struct {
+		tag  string
+		cost int
+	}
+	var found []suggestion
+	for _, k := range known {
+		k = normalizeTag(k)
+		if d := editDistance(tag, k); d <= maxEdits {
+			found = append(found, suggestion{k, d})
+			continue
+		}
+		for _, word := range strings.Fields(k) {
+			if len(tag) >= 3 && strings.HasPrefix(word, tag) {
+				found = append(found, suggestion{k, maxEdits + 1})
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].cost != found[j].cost {
+			return found[i].cost < found[j].cost
+		}
+		return found[i].tag < found[j].tag
+	})
+
+	var suggestions []string
+	for _, s := range found {
+		if len(suggestions) == maxTagSuggestions {
+			break
+		}
+		suggestions = append(suggestions, s.tag)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,54 @@
+package cfapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RegistrationCacheTTL is how long registration checks are cached
+// Short so that registering mid-session shows up quickly
+const RegistrationCacheTTL = time.Minute
+
+// ErrContestNotFound is returned when a contest ID does not exist
+var ErrContestNotFound = errors.New("contest not found")
+
+// IsRegistered returns true if handle is a participant of the contest
+// Participants are read from the contest standings, including unofficial
+// and virtual participation. The API has no standings for a contest that
+// has not started, so this only answers once it is running or over.
+func (c *Client) IsRegistered(ctx context.Context, contestID int, handle string) (bool, error) {
+	cacheKey := fmt.Sprintf("registered:%d:%s", contestID, strings.ToLower(handle))
+
+	if cached, ok := c.cache.Get(cacheKey); ok {
+		return cached.(bool), nil
+	}
+
+	standings, err := c.GetContestStandings(ctx, contestID, 1, 0, []string{handle}, true)
+	if err != nil {
+		if isContestNotFound(err) {
+			return false, fmt.Errorf("contest %d: %w", contestID, ErrContestNotFound)
+		}
+		return false, err
+	}
+
+	registered := false
+	for _, row := range standings.Rows {
+		for _, m := range row.Party.Members {
+			if strings.EqualFold(m.Handle, handle) {
+				registered = true
+			}
+		}
+	}
+
+	c.cache.SetWithTTL(cacheKey, registered, RegistrationCacheTTL)
+	return registered, nil
+}
+
+// isContestNotFound returns true if the API rejected a request for an unknown contest
+func isContestNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && strings.EqualFold(apiErr.Field, "contestId") && apiErr.NotFound()
+}
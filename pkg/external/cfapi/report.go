@@ -0,0 +1,158 @@
+package cfapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ContestReport gathers a participant's results in one contest for a
+// post-contest review
+type ContestReport struct {
+	Contest *Contest `json:"contest,omitempty"`
+	Handle  string   `json:"handle"`
+
+	// Standing is the handle's row in the official standings, nil if they
+	// did not take part
+	Standing *RanklistRow `json:"standing,omitempty"`
+
+	// RatingChange is nil for unrated contests, before ratings are applied
+	// and for participants out of competition
+	RatingChange *RatingChange `json:"ratingChange,omitempty"`
+
+	// Problems of the contest in order with the handle's results
+	Problems []ReportProblem `json:"problems"`
+}
+
+// ReportProblem is a contest problem with the participant's result on it
+type ReportProblem struct {
+	Problem
+	Solved           bool    `json:"solved"`
+	Points           float64 `json:"points"`
+	SolveSeconds     int64   `json:"solveSeconds,omitempty"` // Since the contest start, if solved
+	RejectedAttempts int     `json:"rejectedAttempts"`
+}
+
+// SolveTime returns how long after the contest start the problem was solved
+func (p *ReportProblem) SolveTime() time.Duration {
+	return time.Duration(p.SolveSeconds) * time.Second
+}
+
+// ReportError lists the parts of a ContestReport that could not be fetched
+// The report is still returned with the parts that were.
+type ReportError struct {
+	Failed map[string]error // Keyed by part: contest, standings or ratings
+}
+
+func (e *ReportError) Error() string {
+	parts := make([]string, 0, len(e.Failed))
+	for part := range e.Failed {
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+
+	msgs := make([]string, len(parts))
+	for i, part := range parts {
+		msgs[i] = fmt.Sprintf("%s: %v", part, e.Failed[part])
+	}
+	return "incomplete contest report (" + strings.Join(msgs, "; ") + ")"
+}
+
+// Unwrap returns the errors of the failed parts
+func (e *ReportError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// ContestReport combines the contest info, handle's standing and problem
+// results and their rating change. The sub-calls go through the client's
+// rate limiter one after another. If some of them fail, the report holds
+// what could be fetched and the error is a *ReportError; only when nothing
+// could be fetched is the report nil.
+func (c *Client) ContestReport(ctx context.Context, contestID int, handle string) (*ContestReport, error) {
+	report := &ContestReport{Handle: handle}
+	failed := make(map[string]error)
+
+	contest, err := c.GetContest(ctx, contestID)
+	if err != nil {
+		failed["contest"] = err
+	} else {
+		report.Contest = contest
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	standings, err := c.GetContestStandings(ctx, contestID, 1, 0, []string{handle}, true)
+	if err != nil {
+		failed["standings"] = err
+	} else {
+		if report.Contest == nil {
+			report.Contest = &standings.Contest
+		}
+		report.Standing = participantRow(standings, handle)
+		report.Problems = reportProblems(standings, report.Standing, contestID)
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	changes, err := c.GetContestRatingChanges(ctx, contestID)
+	if err != nil {
+		failed["ratings"] = err
+	} else {
+		for i := range changes {
+			if strings.EqualFold(changes[i].Handle, handle) {
+				report.RatingChange = &changes[i]
+				break
+			}
+		}
+	}
+
+	if len(failed) == 3 {
+		return nil, &ReportError{Failed: failed}
+	}
+	if len(failed) > 0 {
+		return report, &ReportError{Failed: failed}
+	}
+	return report, nil
+}
+
+// reportProblems pairs the contest problems with the results of row
+func reportProblems(standings *ContestStandings, row *RanklistRow, contestID int) []ReportProblem {
+	problems := make([]ReportProblem, len(standings.Problems))
+	for i, p := range standings.Problems {
+		if p.ContestID == 0 {
+			p.ContestID = contestID
+		}
+		problems[i] = ReportProblem{Problem: p}
+		if row == nil || i >= len(row.ProblemResults) {
+			continue
+		}
+
+		r := row.ProblemResults[i]
+		problems[i].Points = r.Points
+		problems[i].RejectedAttempts = r.RejectedAttemptCount
+		if r.Points > 0 {
+			problems[i].Solved = true
+			problems[i].SolveSeconds = r.BestSubmissionTimeSeconds
+		}
+	}
+	return problems
+}
+
+// SolvedCount returns how many problems were solved
+func (r *ContestReport) SolvedCount() int {
+	n := 0
+	for _, p := range r.Problems {
+		if p.Solved {
+			n++
+		}
+	}
+	return n
+}
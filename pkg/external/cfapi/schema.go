@@ -0,0 +1,24 @@
+package cfapi
+
+import (
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+// ToSchema converts an API problem to a schema v1 Problem that can be saved
+// to the workspace. The API has no statements, samples or limits, so those
+// are left empty; fetch them with cfweb when needed.
+func (p *Problem) ToSchema() *v1.Problem {
+	problem := v1.NewProblem(p.ContestID, p.Index, p.Name)
+	problem.ID = p.ProblemID()
+	problem.URL = p.URL()
+	if p.IsGym() {
+		problem.URL = p.GymURL()
+	}
+	problem.Metadata = v1.ProblemMetadata{
+		Rating: p.Rating,
+		Tags:   append([]string{}, p.Tags...),
+	}
+	problem.Samples = []v1.Sample{}
+	problem.FetchMethod = "api"
+	return problem
+}
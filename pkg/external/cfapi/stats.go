@@ -0,0 +1,167 @@
+package cfapi
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// VerdictStats counts submissions by verdict
+// Submissions still in the queue are counted as TESTING
+func VerdictStats(subs []Submission) map[string]int {
+	stats := make(map[string]int)
+	for _, s := range subs {
+		verdict := s.Verdict
+		if verdict == "" {
+			verdict = VerdictTesting
+		}
+		stats[verdict]++
+	}
+	return stats
+}
+
+// AcceptanceRate returns the fraction of attempted problems that were solved
+// Problems are counted once regardless of how many submissions they received
+func AcceptanceRate(subs []Submission) float64 {
+	attempted := make(map[string]bool)
+	solved := make(map[string]bool)
+
+	for _, s := range subs {
+		key := s.Problem.ProblemID()
+		attempted[key] = true
+		if s.IsAccepted() {
+			solved[key] = true
+		}
+	}
+
+	if len(attempted) == 0 {
+		return 0
+	}
+	return float64(len(solved)) / float64(len(attempted))
+}
+
+// ParticipatedContests returns the IDs of the contests with at least one
+// submission made during the contest, in ascending order. Practice
+// submissions after the contest don't count; virtual participations do.
+func ParticipatedContests(subs []Submission) []int {
+	seen := make(map[int]bool)
+	var contests []int
+
+	for _, s := range subs {
+		if s.ContestID == 0 || seen[s.ContestID] {
+			continue
+		}
+		if _, ok := s.RelativeTime(); !ok {
+			continue
+		}
+		seen[s.ContestID] = true
+		contests = append(contests, s.ContestID)
+	}
+
+	sort.Ints(contests)
+	return contests
+}
+
+// SolveTime returns how long after the contest start the problem was first accepted
+// Returns false if there is no in-contest accepted submission for the problem
+func SolveTime(subs []Submission, contestID int, index string) (time.Duration, bool) {
+	var best time.Duration
+	found := false
+
+	for _, s := range subs {
+		if s.ContestID != contestID || s.Problem.Index != index || !s.IsAccepted() {
+			continue
+		}
+		rel, ok := s.RelativeTime()
+		if !ok {
+			continue
+		}
+		if !found || rel < best {
+			best = rel
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// ContestSolveTimes returns the first-AC time for every problem solved in the contest
+// Keys are problem indexes
+func ContestSolveTimes(subs []Submission, contestID int) map[string]time.Duration {
+	times := make(map[string]time.Duration)
+
+	for _, s := range subs {
+		if s.ContestID != contestID || !s.IsAccepted() {
+			continue
+		}
+		rel, ok := s.RelativeTime()
+		if !ok {
+			continue
+		}
+		if best, seen := times[s.Problem.Index]; !seen || rel < best {
+			times[s.Problem.Index] = rel
+		}
+	}
+
+	return times
+}
+
+// LanguageStats counts submissions by programming language
+// Languages are grouped into families such as "C++" unless raw is set, in
+// which case compiler names like "GNU C++17" are kept as-is
+func LanguageStats(subs []Submission, raw bool) map[string]int {
+	stats := make(map[string]int)
+	for _, s := range subs {
+		lang := s.ProgrammingLanguage
+		if !raw {
+			lang = LanguageFamily(lang)
+		}
+		stats[lang]++
+	}
+	return stats
+}
+
+// languageFamilies maps compiler name fragments to a language family
+// Order matters: more specific fragments must come first, and padded
+// fragments only match whole words
+var languageFamilies = []struct {
+	fragment string
+	family   string
+}{
+	{"c++", "C++"},
+	{"clang", "C++"},
+	{"c#", "C#"},
+	{"javascript", "JavaScript"},
+	{"node.js", "JavaScript"},
+	{"typescript", "TypeScript"},
+	{"java", "Java"},
+	{"kotlin", "Kotlin"},
+	{"pypy", "Python"},
+	{"python", "Python"},
+	{"rust", "Rust"},
+	{" go ", "Go"},
+	{"haskell", "Haskell"},
+	{"pascal", "Pascal"},
+	{"delphi", "Pascal"},
+	{"ruby", "Ruby"},
+	{"scala", "Scala"},
+	{"php", "PHP"},
+	{"perl", "Perl"},
+	{"ocaml", "OCaml"},
+	{" d ", "D"},
+	{"gnu c", "C"},
+}
+
+// LanguageFamily returns the language a Codeforces compiler name belongs to,
+// e.g. "GNU C++20 (64)" is "C++" and "PyPy 3-64" is "Python"
+// Unknown compilers are returned unchanged
+func LanguageFamily(lang string) string {
+	// Pad with spaces so short names only match whole words
+	lower := " " + strings.ToLower(strings.TrimSpace(lang)) + " "
+	for _, f := range languageFamilies {
+		if strings.Contains(lower, f.fragment) {
+			return f.family
+		}
+	}
+	return strings.TrimSpace(lang)
+}
@@ -0,0 +1,264 @@
+package cfapi
+
+import (
+	"testing"
+	"time"
+)
+
+func testSubmission(contestID int, index, verdict string) Submission {
+	return Submission{
+		Problem: Problem{ContestID: contestID, Index: index},
+		Verdict: verdict,
+	}
+}
+
+func TestVerdictStats(t *testing.T) {
+	subs := []Submission{
+		testSubmission(1, "A", VerdictOK),
+		testSubmission(1, "B", VerdictWrongAnswer),
+		testSubmission(1, "B", VerdictWrongAnswer),
+		testSubmission(1, "B", VerdictOK),
+		testSubmission(1, "C", ""),
+	}
+
+	stats := VerdictStats(subs)
+
+	want := map[string]int{
+		VerdictOK:          2,
+		VerdictWrongAnswer: 2,
+		VerdictTesting:     1,
+	}
+	if len(stats) != len(want) {
+		t.Errorf("VerdictStats() has %d verdicts, want %d", len(stats), len(want))
+	}
+	for verdict, count := range want {
+		if stats[verdict] != count {
+			t.Errorf("VerdictStats()[%s] = %d, want %d", verdict, stats[verdict], count)
+		}
+	}
+}
+
+func TestVerdictStats_Empty(t *testing.T) {
+	if stats := VerdictStats(nil); len(stats) != 0 {
+		t.Errorf("VerdictStats(nil) = %v, want empty", stats)
+	}
+}
+
+func TestAcceptanceRate(t *testing.T) {
+	tests := []struct {
+		name string
+		subs []Submission
+		want float64
+	}{
+		{
+			name: "no submissions",
+			subs: nil,
+			want: 0,
+		},
+		{
+			name: "repeated attempts count once",
+			subs: []Submission{
+				testSubmission(1, "A", VerdictWrongAnswer),
+				testSubmission(1, "A", VerdictWrongAnswer),
+				testSubmission(1, "A", VerdictOK),
+				testSubmission(1, "B", VerdictTimeLimitExceeded),
+			},
+			want: 0.5,
+		},
+		{
+			name: "all solved",
+			subs: []Submission{
+				testSubmission(1, "A", VerdictOK),
+				testSubmission(1, "A", VerdictOK),
+				testSubmission(2, "A", VerdictOK),
+			},
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AcceptanceRate(tt.subs); got != tt.want {
+				t.Errorf("AcceptanceRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func contestSubmission(index, verdict, participant string, relative int64) Submission {
+	return Submission{
+		ContestID:           10,
+		Problem:             Problem{ContestID: 10, Index: index},
+		Verdict:             verdict,
+		RelativeTimeSeconds: relative,
+		Author:              Party{ParticipantType: participant},
+	}
+}
+
+func TestSubmission_RelativeTime(t *testing.T) {
+	s := contestSubmission("A", VerdictOK, ParticipantContestant, 90)
+	if rel, ok := s.RelativeTime(); !ok || rel != 90*time.Second {
+		t.Errorf("RelativeTime() = %v, %v; want 90s, true", rel, ok)
+	}
+
+	practice := contestSubmission("A", VerdictOK, ParticipantPractice, practiceRelativeTime)
+	if _, ok := practice.RelativeTime(); ok {
+		t.Error("RelativeTime() should be false for practice submissions")
+	}
+}
+
+func TestSolveTime(t *testing.T) {
+	subs := []Submission{
+		contestSubmission("A", VerdictWrongAnswer, ParticipantContestant, 300),
+		contestSubmission("A", VerdictOK, ParticipantContestant, 600),
+		contestSubmission("A", VerdictOK, ParticipantContestant, 900),
+		contestSubmission("B", VerdictOK, ParticipantPractice, practiceRelativeTime),
+	}
+
+	if d, ok := SolveTime(subs, 10, "A"); !ok || d != 10*time.Minute {
+		t.Errorf("SolveTime(A) = %v, %v; want 10m, true", d, ok)
+	}
+	if _, ok := SolveTime(subs, 10, "B"); ok {
+		t.Error("SolveTime(B) should ignore practice submissions")
+	}
+	if _, ok := SolveTime(subs, 11, "A"); ok {
+		t.Error("SolveTime() should ignore other contests")
+	}
+}
+
+func TestContestSolveTimes(t *testing.T) {
+	subs := []Submission{
+		contestSubmission("A", VerdictOK, ParticipantContestant, 600),
+		contestSubmission("B", VerdictOK, ParticipantVirtual, 1800),
+		contestSubmission("B", VerdictOK, ParticipantVirtual, 1200),
+		contestSubmission("C", VerdictWrongAnswer, ParticipantContestant, 2400),
+	}
+
+	times := ContestSolveTimes(subs, 10)
+
+	if len(times) != 2 {
+		t.Fatalf("ContestSolveTimes() returned %d problems, want 2", len(times))
+	}
+	if times["A"] != 10*time.Minute {
+		t.Errorf("times[A] = %v, want 10m", times["A"])
+	}
+	if times["B"] != 20*time.Minute {
+		t.Errorf("times[B] = %v, want 20m", times["B"])
+	}
+}
+
+func TestLanguageFamily(t *testing.T) {
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"GNU C++17", "C++"},
+		{"GNU C++20 (64)", "C++"},
+		{"MS C++ 2017", "C++"},
+		{"Clang++17 Diagnostics", "C++"},
+		{"GNU C11", "C"},
+		{"C# 10", "C#"},
+		{"Java 21", "Java"},
+		{"JavaScript V8 4.8.0", "JavaScript"},
+		{"Node.js 15.8.0 (64bit)", "JavaScript"},
+		{"Kotlin 1.9", "Kotlin"},
+		{"Python 3", "Python"},
+		{"PyPy 3-64", "Python"},
+		{"Rust 2021", "Rust"},
+		{"Go 1.22.2", "Go"},
+		{"D DMD32 v2.105.0", "D"},
+		{"Delphi 7", "Pascal"},
+		{"Free Pascal 3.2.2", "Pascal"},
+		{"Befunge", "Befunge"},
+	}
+
+	for _, tt := range tests {
+		if got := LanguageFamily(tt.lang); got != tt.want {
+			t.Errorf("LanguageFamily(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestLanguageStats(t *testing.T) {
+	subs := []Submission{
+		{ProgrammingLanguage: "GNU C++17"},
+		{ProgrammingLanguage: "GNU C++20 (64)"},
+		{ProgrammingLanguage: "GNU C++20 (64)"},
+		{ProgrammingLanguage: "PyPy 3"},
+	}
+
+	families := LanguageStats(subs, false)
+	if families["C++"] != 3 || families["Python"] != 1 || len(families) != 2 {
+		t.Errorf("LanguageStats(raw=false) = %v, want C++:3 Python:1", families)
+	}
+
+	raw := LanguageStats(subs, true)
+	if raw["GNU C++17"] != 1 || raw["GNU C++20 (64)"] != 2 || len(raw) != 3 {
+		t.Errorf("LanguageStats(raw=true) = %v, want compiler names kept", raw)
+	}
+}
+
+func TestGroupByProblem(t *testing.T) {
+	at := func(s Submission, seconds int64) Submission {
+		s.CreationTimeSeconds = seconds
+		return s
+	}
+	// Newest first, as the API returns them
+	subs := []Submission{
+		at(testSubmission(2, "A", VerdictWrongAnswer), 700),
+		at(testSubmission(1, "B", VerdictOK), 600),
+		at(testSubmission(1, "A", VerdictOK), 500),
+		at(testSubmission(1, "B", VerdictWrongAnswer), 400),
+		at(testSubmission(1, "B", VerdictWrongAnswer), 300),
+		at(testSubmission(1, "A", VerdictWrongAnswer), 200),
+		at(testSubmission(1, "A", VerdictOK), 100),
+	}
+
+	got := GroupByProblem(subs)
+
+	want := []struct {
+		id         string
+		attempts   int
+		verdict    string
+		acceptedAt int64
+	}{
+		{"1A", 1, VerdictOK, 100},
+		{"1B", 3, VerdictOK, 600},
+		{"2A", 1, VerdictWrongAnswer, 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("GroupByProblem() returned %d problems, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Problem.ProblemID() != w.id || g.Attempts != w.attempts || g.Verdict != w.verdict || g.AcceptedTimeSeconds != w.acceptedAt {
+			t.Errorf("GroupByProblem()[%d] = %s %d %s %d, want %s %d %s %d", i,
+				g.Problem.ProblemID(), g.Attempts, g.Verdict, g.AcceptedTimeSeconds,
+				w.id, w.attempts, w.verdict, w.acceptedAt)
+		}
+	}
+	if got[2].Solved() {
+		t.Error("unsolved problem reported as solved")
+	}
+}
+
+func TestParticipatedContests(t *testing.T) {
+	inContest := contestSubmission("A", VerdictWrongAnswer, ParticipantContestant, 600)
+	afterContest := contestSubmission("B", VerdictOK, ParticipantPractice, practiceRelativeTime)
+	afterContest.ContestID, afterContest.Problem.ContestID = 20, 20
+	virtual := contestSubmission("C", VerdictOK, ParticipantVirtual, 1200)
+	virtual.ContestID, virtual.Problem.ContestID = 5, 5
+
+	got := ParticipatedContests([]Submission{afterContest, inContest, virtual, inContest})
+
+	if len(got) != 2 || got[0] != 5 || got[1] != 10 {
+		t.Errorf("ParticipatedContests() = %v, want [5 10]", got)
+	}
+}
+
+func TestParticipatedContests_OnlyPractice(t *testing.T) {
+	practice := contestSubmission("A", VerdictOK, ParticipantPractice, practiceRelativeTime)
+	if got := ParticipatedContests([]Submission{practice}); len(got) != 0 {
+		t.Errorf("ParticipatedContests() = %v, want none for practice submissions", got)
+	}
+}
@@ -0,0 +1,124 @@
+package cfapi
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultTagAliases maps common shorthands to Codeforces tag names
+// Tags given by their full name are passed through unchanged.
+var DefaultTagAliases = map[string]string{
+	"2p":     "two pointers",
+	"bf":     "brute force",
+	"bm":     "bitmasks",
+	"bs":     "binary search",
+	"comb":   "combinatorics",
+	"constr": "constructive algorithms",
+	"crt":    "chinese remainder theorem",
+	"dfs":    "dfs and similar",
+	"dnc":    "divide and conquer",
+	"ds":     "data structures",
+	"game":   "games",
+	"geo":    "geometry",
+	"graph":  "graphs",
+	"hash":   "hashing",
+	"impl":   "implementation",
+	"mitm":   "meet-in-the-middle",
+	"nt":     "number theory",
+	"prob":   "probabilities",
+	"sp":     "shortest paths",
+	"sort":   "sortings",
+	"string": "strings",
+	"suffix": "string suffix structures",
+	"tree":   "trees",
+	"ts":     "ternary search",
+}
+
+// WithTagAliases adds tag shorthands on top of DefaultTagAliases, replacing
+// defaults with the same name
+func WithTagAliases(aliases map[string]string) ClientOption {
+	return func(c *Client) {
+		for alias, tag := range aliases {
+			c.tagAliases[normalizeTag(alias)] = normalizeTag(tag)
+		}
+	}
+}
+
+// ResolveTags returns tags with aliases replaced by the tag names they stand
+// for, lower-cased and without duplicates. Matching is case-insensitive.
+func (c *Client) ResolveTags(tags []string) []string {
+	resolved := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		t = normalizeTag(t)
+		if tag, ok := c.tagAliases[t]; ok {
+			t = tag
+		}
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		resolved = append(resolved, t)
+	}
+	return resolved
+}
+
+// TagAliases returns the tag shorthands of the client and their tags
+func (c *Client) TagAliases() map[string]string {
+	aliases := make(map[string]string, len(c.tagAliases))
+	for alias, tag := range c.tagAliases {
+		aliases[alias] = tag
+	}
+	return aliases
+}
+
+// CachedTags returns the tags of the whole problemset if it is in the
+// client cache, or nil. It never makes a request.
+func (c *Client) CachedTags() []string {
+	cached, ok := c.cache.Get("problems:")
+	if !ok {
+		return nil
+	}
+	return ProblemTags(cached.(*ProblemsResponse).Problems)
+}
+
+// ProblemTags returns the distinct tags of problems, sorted
+func ProblemTags(problems []Problem) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, p := range problems {
+		for _, t := range p.Tags {
+			if !seen[t] {
+				seen[t] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// UnknownTags returns the tags that are not in known. Without known tags
+// nothing can be checked, so it returns nil.
+func UnknownTags(tags, known []string) []string {
+	if len(known) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(known))
+	for _, t := range known {
+		set[normalizeTag(t)] = true
+	}
+
+	var unknown []string
+	for _, t := range tags {
+		if !set[normalizeTag(t)] {
+			unknown = append(unknown, t)
+		}
+	}
+	return unknown
+}
+
+// normalizeTag lower-cases a tag and collapses its whitespace
+func normalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+}
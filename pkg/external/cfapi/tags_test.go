@@ -0,0 +1,54 @@
+package cfapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClient_ResolveTags(t *testing.T) {
+	client := NewClient()
+
+	tests := []struct {
+		name string
+		in   []string
+		want []string
+	}{
+		{"alias", []string{"nt"}, []string{"number theory"}},
+		{"case-insensitive alias", []string{"DFS"}, []string{"dfs and similar"}},
+		{"exact tag passes through", []string{"dsu", "Two Pointers"}, []string{"dsu", "two pointers"}},
+		{"alias and its tag deduplicated", []string{"bs", "binary search"}, []string{"binary search"}},
+		{"unknown passes through", []string{"  Fancy   Stuff "}, []string{"fancy stuff"}},
+		{"empty dropped", []string{"", " "}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.ResolveTags(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveTags(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTagAliases(t *testing.T) {
+	client := NewClient(WithTagAliases(map[string]string{"NumT": "Number Theory", "bs": "bitmasks"}))
+
+	got := client.ResolveTags([]string{"numt", "bs", "nt"})
+	want := []string{"number theory", "bitmasks"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveTags() = %q, want %q", got, want)
+	}
+	if DefaultTagAliases["bs"] != "binary search" {
+		t.Error("WithTagAliases() should not modify DefaultTagAliases")
+	}
+}
+
+func TestUnknownTags(t *testing.T) {
+	known := []string{"dp", "number theory"}
+
+	if got := UnknownTags([]string{"number theory", "dpp"}, known); !reflect.DeepEqual(got, []string{"dpp"}) {
+		t.Errorf("UnknownTags() = %q, want [dpp]", got)
+	}
+	if got := UnknownTags([]string{"anything"}, nil); got != nil {
+		t.Errorf("UnknownTags() without known tags = %q, want nil", got)
+	}
+}
@@ -174,6 +174,18 @@ const (
 	VerdictRejected            = "REJECTED"
 )
 
+// Participant types
+const (
+	ParticipantContestant       = "CONTESTANT"
+	ParticipantPractice         = "PRACTICE"
+	ParticipantVirtual          = "VIRTUAL"
+	ParticipantManager          = "MANAGER"
+	ParticipantOutOfCompetition = "OUT_OF_COMPETITION"
+)
+
+// practiceRelativeTime is the relativeTimeSeconds CF reports outside a contest
+const practiceRelativeTime = 2147483647
+
 // ContestPhase constants
 const (
 	PhaseBefore        = "BEFORE"
@@ -183,6 +195,13 @@ const (
 	PhaseFinished      = "FINISHED"
 )
 
+// ContestType constants
+const (
+	ContestTypeCF   = "CF"
+	ContestTypeIOI  = "IOI"
+	ContestTypeICPC = "ICPC"
+)
+
 // Rank thresholds
 var RankThresholds = map[string]int{
 	"newbie":                 0,
@@ -223,16 +242,38 @@ func (p *Problem) ContestURL() string {
 	return ""
 }
 
+// GymURL returns the gym URL for this problem
+func (p *Problem) GymURL() string {
+	if p.ContestID > 0 {
+		return fmt.Sprintf("https://codeforces.com/gym/%d/problem/%s", p.ContestID, p.Index)
+	}
+	return ""
+}
+
 // IsAccepted returns true if the submission was accepted
 func (s *Submission) IsAccepted() bool {
 	return s.Verdict == VerdictOK
 }
 
+// IsPending returns true if the submission is still queued or being judged
+func (s *Submission) IsPending() bool {
+	return s.Verdict == "" || s.Verdict == VerdictTesting
+}
+
 // SubmissionTime returns the submission time as time.Time
 func (s *Submission) SubmissionTime() time.Time {
 	return time.Unix(s.CreationTimeSeconds, 0)
 }
 
+// RelativeTime returns the time since the author's contest start
+// Returns false for practice submissions, which have no contest-relative time
+func (s *Submission) RelativeTime() (time.Duration, bool) {
+	if s.Author.ParticipantType == ParticipantPractice || s.RelativeTimeSeconds >= practiceRelativeTime {
+		return 0, false
+	}
+	return time.Duration(s.RelativeTimeSeconds) * time.Second, true
+}
+
 // StartTime returns the contest start time
 func (c *Contest) StartTime() time.Time {
 	return time.Unix(c.StartTimeSeconds, 0)
@@ -253,6 +294,11 @@ func (c *Contest) IsFinished() bool {
 	return c.Phase == PhaseFinished
 }
 
+// IsUnrated returns true if the user has never taken part in a rated contest
+func (u *User) IsUnrated() bool {
+	return u.Rating == 0 && u.Rank == ""
+}
+
 // LastOnline returns when the user was last online
 func (u *User) LastOnline() time.Time {
 	return time.Unix(u.LastOnlineTimeSeconds, 0)
@@ -21,6 +21,11 @@ func TestProblem_ProblemID(t *testing.T) {
 			problem: Problem{ContestID: 1500, Index: "B2"},
 			wantID:  "1500B2",
 		},
+		{
+			name:    "subtask index",
+			problem: Problem{ContestID: 1851, Index: "F2"},
+			wantID:  "1851F2",
+		},
 		{
 			name:    "without contest ID",
 			problem: Problem{Index: "A"},
@@ -48,6 +53,11 @@ func TestProblem_URL(t *testing.T) {
 			problem: Problem{ContestID: 1325, Index: "A"},
 			wantURL: "https://codeforces.com/problemset/problem/1325/A",
 		},
+		{
+			name:    "subtask index",
+			problem: Problem{ContestID: 1520, Index: "A1"},
+			wantURL: "https://codeforces.com/problemset/problem/1520/A1",
+		},
 		{
 			name:    "without contest ID",
 			problem: Problem{Index: "A"},
@@ -75,6 +85,11 @@ func TestProblem_ContestURL(t *testing.T) {
 			problem: Problem{ContestID: 1325, Index: "A"},
 			wantURL: "https://codeforces.com/contest/1325/problem/A",
 		},
+		{
+			name:    "subtask index",
+			problem: Problem{ContestID: 1851, Index: "F2"},
+			wantURL: "https://codeforces.com/contest/1851/problem/F2",
+		},
 		{
 			name:    "without contest ID",
 			problem: Problem{Index: "A"},
@@ -231,6 +246,26 @@ func TestUser_RegistrationTime(t *testing.T) {
 	}
 }
 
+func TestUser_IsUnrated(t *testing.T) {
+	tests := []struct {
+		name string
+		user User
+		want bool
+	}{
+		{"new user", User{}, true},
+		{"rated", User{Rating: 1500, Rank: "specialist"}, false},
+		{"rated below zero rank", User{Rating: 0, Rank: "newbie"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.IsUnrated(); got != tt.want {
+				t.Errorf("IsUnrated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRatingChange_RatingDelta(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -355,3 +390,47 @@ func TestUserFields(t *testing.T) {
 		t.Errorf("Rating = %v, want %v", user.Rating, 3800)
 	}
 }
+
+func TestProblem_ToSchema(t *testing.T) {
+	p := Problem{ContestID: 1325, Index: "A", Name: "EhAb AnD gCd", Rating: 800, Tags: []string{"greedy", "number theory"}}
+
+	got := p.ToSchema()
+
+	if got.ID != "1325A" || got.Platform != "codeforces" || got.ContestID != 1325 || got.Index != "A" || got.Name != p.Name {
+		t.Errorf("identity = %s %s %d %s %q", got.ID, got.Platform, got.ContestID, got.Index, got.Name)
+	}
+	if got.URL != "https://codeforces.com/problemset/problem/1325/A" {
+		t.Errorf("URL = %q", got.URL)
+	}
+	if got.Metadata.Rating != 800 || len(got.Metadata.Tags) != 2 || got.Metadata.Tags[1] != "number theory" {
+		t.Errorf("Metadata = %+v", got.Metadata)
+	}
+	if got.Samples == nil || len(got.Samples) != 0 {
+		t.Errorf("Samples = %v, want empty", got.Samples)
+	}
+	if got.FetchMethod != "api" || got.Schema.Type == "" {
+		t.Errorf("FetchMethod = %q, Schema = %+v", got.FetchMethod, got.Schema)
+	}
+
+	// The schema copy must not share the API problem's tags
+	got.Metadata.Tags[0] = "changed"
+	if p.Tags[0] != "greedy" {
+		t.Error("ToSchema() shares the tags slice")
+	}
+}
+
+func TestProblem_ToSchema_UnratedWithoutTags(t *testing.T) {
+	p := Problem{ContestID: 100001, Index: "B", Name: "Gym Problem"}
+
+	got := p.ToSchema()
+
+	if got.Metadata.Rating != 0 {
+		t.Errorf("Rating = %d, want 0", got.Metadata.Rating)
+	}
+	if got.Metadata.Tags == nil || len(got.Metadata.Tags) != 0 {
+		t.Errorf("Tags = %v, want empty", got.Metadata.Tags)
+	}
+	if got.URL != "https://codeforces.com/gym/100001/problem/B" {
+		t.Errorf("URL = %q, want the gym URL", got.URL)
+	}
+}
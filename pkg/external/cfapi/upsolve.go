@@ -0,0 +1,88 @@
+package cfapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// UpsolveProblem is a contest problem a participant did not solve in-contest
+type UpsolveProblem struct {
+	Problem
+	// RejectedAttempts is the number of rejected in-contest submissions;
+	// zero means the problem was not attempted
+	RejectedAttempts int
+}
+
+// Attempted returns true if the problem was submitted during the contest
+func (u *UpsolveProblem) Attempted() bool {
+	return u.RejectedAttempts > 0
+}
+
+// GetUpsolveProblems returns the problems of a contest that handle did not
+// solve while taking part, in contest order
+func (c *Client) GetUpsolveProblems(ctx context.Context, contestID int, handle string) ([]Problem, error) {
+	upsolve, err := c.GetUpsolveList(ctx, contestID, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := make([]Problem, len(upsolve))
+	for i := range upsolve {
+		problems[i] = upsolve[i].Problem
+	}
+	return problems, nil
+}
+
+// GetUpsolveList is GetUpsolveProblems with the in-contest attempts of each
+// problem. Only contestant and out of competition participation counts; if
+// handle did not take part, every problem is returned as not attempted.
+func (c *Client) GetUpsolveList(ctx context.Context, contestID int, handle string) ([]UpsolveProblem, error) {
+	standings, err := c.GetContestStandings(ctx, contestID, 1, 0, []string{handle}, true)
+	if err != nil {
+		if isContestNotFound(err) {
+			return nil, fmt.Errorf("contest %d: %w", contestID, ErrContestNotFound)
+		}
+		return nil, err
+	}
+
+	var results []ProblemResult
+	if row := participantRow(standings, handle); row != nil {
+		results = row.ProblemResults
+	}
+
+	var upsolve []UpsolveProblem
+	for i, p := range standings.Problems {
+		if p.ContestID == 0 {
+			p.ContestID = contestID
+		}
+
+		rejected := 0
+		if i < len(results) {
+			if results[i].Points > 0 {
+				continue
+			}
+			rejected = results[i].RejectedAttemptCount
+		}
+		upsolve = append(upsolve, UpsolveProblem{Problem: p, RejectedAttempts: rejected})
+	}
+
+	return upsolve, nil
+}
+
+// participantRow returns the standings row of handle's contestant or out of
+// competition participation, or nil if handle did not take part
+func participantRow(standings *ContestStandings, handle string) *RanklistRow {
+	var found *RanklistRow
+	for i, row := range standings.Rows {
+		if row.Party.ParticipantType != ParticipantContestant && row.Party.ParticipantType != ParticipantOutOfCompetition {
+			continue
+		}
+		for _, m := range row.Party.Members {
+			if strings.EqualFold(m.Handle, handle) {
+				found = &standings.Rows[i]
+			}
+		}
+	}
+	return found
+}
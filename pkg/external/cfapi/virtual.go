@@ -0,0 +1,94 @@
+package cfapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ICPCPenaltyMinutes is the penalty for each rejected attempt on a solved problem
+const ICPCPenaltyMinutes = 20
+
+// icpcScore is a standings position under ICPC scoring
+type icpcScore struct {
+	solved  int
+	penalty int // minutes
+}
+
+// beats returns true if a ranks strictly above b
+func (a icpcScore) beats(b icpcScore) bool {
+	if a.solved != b.solved {
+		return a.solved > b.solved
+	}
+	return a.penalty < b.penalty
+}
+
+// rowScore computes the ICPC score of a standings row from its problem results
+// so that rows of Codeforces-scored contests can be compared the same way
+func rowScore(row RanklistRow) icpcScore {
+	var score icpcScore
+	for _, r := range row.ProblemResults {
+		if r.Points <= 0 {
+			continue
+		}
+		score.solved++
+		score.penalty += int(r.BestSubmissionTimeSeconds/60) + ICPCPenaltyMinutes*r.RejectedAttemptCount
+	}
+	return score
+}
+
+// VirtualRank returns the rank a participant would have had in the official
+// standings of a contest, scored ICPC-style: most problems solved, then least
+// penalty time. solveTimes maps problem indexes to the time of the accepted
+// submission since the start; penalties is the total number of rejected
+// attempts on solved problems. Ties share the rank.
+func (c *Client) VirtualRank(ctx context.Context, contestID int, solveTimes map[string]time.Duration, penalties int) (int, error) {
+	standings, err := c.GetContestStandings(ctx, contestID, 1, 0, nil, false)
+	if err != nil {
+		return 0, err
+	}
+
+	indexes := make(map[string]bool, len(standings.Problems))
+	for _, p := range standings.Problems {
+		indexes[p.Index] = true
+	}
+
+	score := icpcScore{penalty: ICPCPenaltyMinutes * penalties}
+	for index, d := range solveTimes {
+		if !indexes[index] {
+			return 0, fmt.Errorf("contest %d has no problem %s", contestID, index)
+		}
+		score.solved++
+		score.penalty += int(d / time.Minute)
+	}
+
+	rank := 1
+	for _, row := range standings.Rows {
+		if rowScore(row).beats(score) {
+			rank++
+		}
+	}
+
+	return rank, nil
+}
+
+// RejectedAttempts counts rejected submissions made before the first accepted
+// one on each solved problem of a contest, the attempts that add ICPC penalty
+// Compilation errors and practice submissions are not counted
+func RejectedAttempts(subs []Submission, contestID int) int {
+	solveTimes := ContestSolveTimes(subs, contestID)
+
+	rejected := 0
+	for _, s := range subs {
+		if s.ContestID != contestID || s.IsAccepted() || s.Verdict == VerdictCompilationError {
+			continue
+		}
+		solvedAt, solved := solveTimes[s.Problem.Index]
+		rel, ok := s.RelativeTime()
+		if solved && ok && rel < solvedAt {
+			rejected++
+		}
+	}
+
+	return rejected
+}
@@ -0,0 +1,71 @@
+package cfapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Three official rows: 2 solved/30min, 2 solved/90min, 1 solved/5min
+const virtualStandingsBody = `{"status":"OK","result":{"contest":{"id":10},
+"problems":[{"index":"A"},{"index":"B"}],
+"rows":[
+ {"rank":1,"problemResults":[{"points":1,"bestSubmissionTimeSeconds":600},{"points":1,"bestSubmissionTimeSeconds":1200}]},
+ {"rank":2,"problemResults":[{"points":1,"bestSubmissionTimeSeconds":1800,"rejectedAttemptCount":1},{"points":1,"bestSubmissionTimeSeconds":2400}]},
+ {"rank":3,"problemResults":[{"points":1,"bestSubmissionTimeSeconds":300},{"points":0,"rejectedAttemptCount":4}]}
+]}}`
+
+func TestClient_VirtualRank(t *testing.T) {
+	tests := []struct {
+		name       string
+		solveTimes map[string]time.Duration
+		penalties  int
+		want       int
+	}{
+		{"best", map[string]time.Duration{"A": 5 * time.Minute, "B": 10 * time.Minute}, 0, 1},
+		{"between", map[string]time.Duration{"A": 20 * time.Minute, "B": 40 * time.Minute}, 0, 2},
+		{"penalties push down", map[string]time.Duration{"A": 20 * time.Minute, "B": 40 * time.Minute}, 2, 3},
+		{"one solve", map[string]time.Duration{"A": 10 * time.Minute}, 0, 4},
+		{"nothing solved", nil, 0, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &mockTransport{statusCode: 200, body: virtualStandingsBody}
+			client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+			rank, err := client.VirtualRank(context.Background(), 10, tt.solveTimes, tt.penalties)
+			if err != nil {
+				t.Fatalf("VirtualRank() error = %v", err)
+			}
+			if rank != tt.want {
+				t.Errorf("VirtualRank() = %d, want %d", rank, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_VirtualRank_UnknownProblem(t *testing.T) {
+	transport := &mockTransport{statusCode: 200, body: virtualStandingsBody}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := client.VirtualRank(context.Background(), 10, map[string]time.Duration{"Z": time.Minute}, 0)
+	if err == nil {
+		t.Error("VirtualRank() should reject problems not in the contest")
+	}
+}
+
+func TestRejectedAttempts(t *testing.T) {
+	subs := []Submission{
+		contestSubmission("A", VerdictWrongAnswer, ParticipantVirtual, 60),
+		contestSubmission("A", VerdictCompilationError, ParticipantVirtual, 90),
+		contestSubmission("A", VerdictOK, ParticipantVirtual, 120),
+		contestSubmission("A", VerdictWrongAnswer, ParticipantVirtual, 180), // After AC
+		contestSubmission("B", VerdictWrongAnswer, ParticipantVirtual, 200), // Never solved
+	}
+
+	if got := RejectedAttempts(subs, 10); got != 1 {
+		t.Errorf("RejectedAttempts() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,116 @@
+package cfweb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"golang.org/x/time/rate"
+)
+
+const (
+	DefaultConcurrency = 2                      // Conservative default to avoid Cloudflare bans
+	MinRequestInterval = 500 * time.Millisecond // Minimum gap between page requests across workers
+)
+
+// ParseProblemsConcurrent fetches several problem pages with a bounded worker pool
+// Results and errors are aligned with refs by index
+func (p *Parser) ParseProblemsConcurrent(refs []cfapi.ProblemRef, concurrency int) ([]*ParsedProblem, []error) {
+	return p.parseProblemsConcurrent(context.Background(), refs, concurrency, MinRequestInterval)
+}
+
+// ParseProblemsConcurrentContext is ParseProblemsConcurrent bound to ctx
+// Problems not yet fetched when ctx is done report ctx's error
+func (p *Parser) ParseProblemsConcurrentContext(ctx context.Context, refs []cfapi.ProblemRef, concurrency int) ([]*ParsedProblem, []error) {
+	return p.parseProblemsConcurrent(ctx, refs, concurrency, MinRequestInterval)
+}
+
+func (p *Parser) parseProblemsConcurrent(ctx context.Context, refs []cfapi.ProblemRef, concurrency int, interval time.Duration) ([]*ParsedProblem, []error) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	if concurrency > len(refs) {
+		concurrency = len(refs)
+	}
+
+	problems := make([]*ParsedProblem, len(refs))
+	errs := make([]error, len(refs))
+
+	// Shared limiter spaces out requests from all workers
+	limiter := rate.NewLimiter(rate.Every(interval), 1)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// Cached problems don't hit the network, so don't wait for them
+				key := problemCacheKey("contest", refs[i].ContestID, refs[i].Index)
+				if problem, ok := p.cachedProblem(key); ok {
+					problems[i] = problem
+					continue
+				}
+
+				if err := limiter.Wait(ctx); err != nil {
+					errs[i] = err
+					continue
+				}
+				problems[i], errs[i] = p.ParseProblemContext(ctx, refs[i].ContestID, refs[i].Index)
+			}
+		}()
+	}
+
+	for i := range refs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return problems, errs
+}
+
+// ContestParse is the outcome of parsing every problem of a contest
+// Problems and Errs are aligned with Refs; a problem that was not parsed,
+// for example because the deadline passed first, is nil and has its error.
+type ContestParse struct {
+	Refs     []cfapi.ProblemRef
+	Problems []*ParsedProblem
+	Errs     []error
+}
+
+// Parsed returns the problems that were parsed, in contest order
+func (r *ContestParse) Parsed() []*ParsedProblem {
+	var parsed []*ParsedProblem
+	for _, p := range r.Problems {
+		if p != nil {
+			parsed = append(parsed, p)
+		}
+	}
+	return parsed
+}
+
+// ParseContestContext lists the problems on the contest page and parses each
+// of them with ParseProblemsConcurrentContext. Every fetch is bound to ctx,
+// so a single deadline spans the whole chain. The error is only set when the
+// contest page could not be read; problems parsed before ctx is done are kept.
+func (p *Parser) ParseContestContext(ctx context.Context, contestID int, concurrency int) (*ContestParse, error) {
+	return p.parseContest(ctx, contestID, concurrency, MinRequestInterval)
+}
+
+func (p *Parser) parseContest(ctx context.Context, contestID int, concurrency int, interval time.Duration) (*ContestParse, error) {
+	listed, err := p.ParseContestProblemsContext(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ContestParse{Refs: make([]cfapi.ProblemRef, len(listed))}
+	for i, l := range listed {
+		result.Refs[i] = cfapi.ProblemRef{ContestID: contestID, Index: l.Index}
+	}
+	result.Problems, result.Errs = p.parseProblemsConcurrent(ctx, result.Refs, concurrency, interval)
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package cfweb
+
+import "strings"
+
+// cookieAttributes are Set-Cookie directives that come along when a cookie
+// is copied from the browser with its attributes; they are not cookies
+var cookieAttributes = map[string]bool{
+	"path":        true,
+	"domain":      true,
+	"expires":     true,
+	"max-age":     true,
+	"secure":      true,
+	"httponly":    true,
+	"samesite":    true,
+	"priority":    true,
+	"partitioned": true,
+}
+
+// SanitizeCookie cleans up a cookie string pasted from a browser into the
+// "a=1; b=2" form SetCookie expects. "Cookie:" and "Set-Cookie:" prefixes,
+// attribute directives such as Path=/, Secure or HttpOnly, quotes around
+// values and cookies of other domains are dropped. Each line is read as a
+// cookie header or a row of the browser's cookie table (name, value, domain
+// separated by tabs), and a repeated name keeps its last value.
+func SanitizeCookie(raw string) string {
+	var names []string
+	values := make(map[string]string)
+
+	for _, line := range strings.Split(strings.ReplaceAll(raw, "\r", "\n"), "\n") {
+		for _, pair := range lineCookies(line) {
+			if _, ok := values[pair[0]]; !ok {
+				names = append(names, pair[0])
+			}
+			values[pair[0]] = pair[1]
+		}
+	}
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + values[name]
+	}
+	return strings.Join(parts, "; ")
+}
+
+// lineCookies returns the name/value pairs of one pasted line, or none if
+// the line sets cookies for a domain other than codeforces.com
+func lineCookies(line string) [][2]string {
+	line = strings.TrimSpace(line)
+	for _, prefix := range []string{"set-cookie:", "cookie:"} {
+		if strings.HasPrefix(strings.ToLower(line), prefix) {
+			line = strings.TrimSpace(line[len(prefix):])
+		}
+	}
+
+	// A row copied from the browser's cookie table
+	if strings.Contains(line, "\t") && !strings.Contains(line, "=") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 || (len(fields) > 2 && !isCodeforcesDomain(fields[2])) {
+			return nil
+		}
+		name, value := strings.TrimSpace(fields[0]), unquote(fields[1])
+		if name == "" || value == "" {
+			return nil
+		}
+		return [][2]string{{name, value}}
+	}
+
+	var pairs [][2]string
+	domain := ""
+	for _, part := range strings.Split(line, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if cookieAttributes[strings.ToLower(name)] {
+			if strings.EqualFold(name, "domain") {
+				domain = value
+			}
+			continue
+		}
+		value = unquote(value)
+		if !ok || name == "" || value == "" {
+			continue
+		}
+		pairs = append(pairs, [2]string{name, value})
+	}
+
+	if domain != "" && !isCodeforcesDomain(domain) {
+		return nil
+	}
+	return pairs
+}
+
+// isCodeforcesDomain returns true for codeforces.com and its subdomains
+func isCodeforcesDomain(domain string) bool {
+	domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	return domain == "codeforces.com" || strings.HasSuffix(domain, ".codeforces.com")
+}
+
+// unquote trims whitespace and the double quotes a cookie value may have
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		value = value[1 : len(value)-1]
+	}
+	return value
+}
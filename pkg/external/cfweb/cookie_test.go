@@ -0,0 +1,70 @@
+package cfweb
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestSanitizeCookie(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"empty", "", ""},
+		{"clean", "JSESSIONID=abc; 39ce7=def", "JSESSIONID=abc; 39ce7=def"},
+		{
+			name: "attributes",
+			raw:  "JSESSIONID=abc; Path=/; Secure; HttpOnly; SameSite=Lax; cf_clearance=xyz; Max-Age=3600",
+			want: "JSESSIONID=abc; cf_clearance=xyz",
+		},
+		{
+			name: "set-cookie lines from another domain",
+			raw:  "Set-Cookie: JSESSIONID=abc; Domain=codeforces.com\nSet-Cookie: _ga=GA1; Domain=.google.com\n",
+			want: "JSESSIONID=abc",
+		},
+		{
+			name: "cookie table rows",
+			raw:  "JSESSIONID\tabc\tcodeforces.com\t/\tSession\ncf_clearance\txyz\t.codeforces.com\t/\n_ym_d\t1\tmc.yandex.ru\t/",
+			want: "JSESSIONID=abc; cf_clearance=xyz",
+		},
+		{"quotes and repeats", `Cookie: a="1"; b=2; a=3`, "a=3; b=2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeCookie(tt.raw); got != tt.want {
+				t.Errorf("SanitizeCookie(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeCookie_BrowserPaste(t *testing.T) {
+	// Copied from the browser with attributes, stray whitespace and CRLF
+	raw := "  JSESSIONID=24FF903C9002F539DCDE4C869C77C1DD; Path=/; HttpOnly\r\n" +
+		"39ce7=CFtzSSKd ; Domain=.codeforces.com; Secure;\r\n" +
+		"cf_clearance=Abc.123-456; Expires=Wed, 21 Oct 2026 07:28:00 GMT; Secure; HttpOnly; SameSite=None  \r\n"
+
+	got := SanitizeCookie(raw)
+	want := "JSESSIONID=24FF903C9002F539DCDE4C869C77C1DD; 39ce7=CFtzSSKd; cf_clearance=Abc.123-456"
+	if got != want {
+		t.Errorf("SanitizeCookie() = %q, want %q", got, want)
+	}
+
+	session, err := NewSessionWithCookie(raw)
+	if err != nil {
+		t.Fatalf("NewSessionWithCookie() error = %v", err)
+	}
+	cfURL, _ := url.Parse(BaseURL)
+	values := make(map[string]string)
+	for _, c := range session.jar.Cookies(cfURL) {
+		values[c.Name] = c.Value
+	}
+	if values["JSESSIONID"] != "24FF903C9002F539DCDE4C869C77C1DD" || values["cf_clearance"] != "Abc.123-456" {
+		t.Errorf("session cookies = %v, want JSESSIONID and cf_clearance", values)
+	}
+	if _, ok := values["Path"]; ok {
+		t.Errorf("session cookies = %v, attributes should not become cookies", values)
+	}
+}
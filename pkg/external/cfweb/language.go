@@ -0,0 +1,56 @@
+package cfweb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResolveLanguage finds a supported language by ID (cpp20), short name
+// (C++20, Python 3), full compiler name or compiler ID (89), ignoring case
+// and spaces
+func ResolveLanguage(name string) (*Language, error) {
+	key := languageKey(name)
+	if key == "" {
+		return nil, fmt.Errorf("empty language")
+	}
+
+	if id, err := strconv.Atoi(key); err == nil {
+		if lang := GetLanguageByCompilerID(id); lang != nil {
+			return lang, nil
+		}
+	}
+	for i := range SupportedLanguages {
+		lang := &SupportedLanguages[i]
+		if languageKey(lang.ID) == key || languageKey(lang.Name) == key {
+			return lang, nil
+		}
+	}
+
+	ids := make([]string, len(SupportedLanguages))
+	for i, lang := range SupportedLanguages {
+		ids[i] = lang.ID
+	}
+	return nil, fmt.Errorf("unknown language %q (supported: %s)", name, strings.Join(ids, ", "))
+}
+
+// LanguagesForExtension returns the supported languages of files with ext,
+// e.g. every C++ standard for .cpp
+func LanguagesForExtension(ext string) []Language {
+	ext = strings.ToLower(ext)
+	var langs []Language
+	for _, lang := range SupportedLanguages {
+		if lang.Extension == ext {
+			langs = append(langs, lang)
+		}
+	}
+	return langs
+}
+
+// languageKey normalizes a language name for matching: "GNU C++20" and
+// "c++ 20" both become "cpp20"
+func languageKey(name string) string {
+	key := strings.ToLower(strings.Join(strings.Fields(name), ""))
+	key = strings.ReplaceAll(key, "++", "pp")
+	return strings.TrimPrefix(key, "gnu")
+}
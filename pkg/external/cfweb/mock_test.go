@@ -1,14 +1,20 @@
 package cfweb
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
 )
 
 // mockTransport implements http.RoundTripper for testing
@@ -713,6 +719,83 @@ func TestSubmitter_Submit_PostFailed(t *testing.T) {
 	}
 }
 
+func TestSubmitter_Submit_TransientErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []mockResponse
+		transient bool
+	}{
+		{"network error", []mockResponse{{err: fmt.Errorf("connection reset")}}, true},
+		{"submit page unavailable", []mockResponse{{statusCode: 503, body: `Service Unavailable`}}, true},
+		// Once the form is posted, Codeforces may have the submission
+		{"rate limited post", []mockResponse{
+			{statusCode: 200, body: `<html><meta name="X-Csrf-Token" content="test-csrf"></html>`},
+			{statusCode: 429, body: ``},
+		}, false},
+		{"post network error", []mockResponse{
+			{statusCode: 200, body: `<html><meta name="X-Csrf-Token" content="test-csrf"></html>`},
+			{err: fmt.Errorf("connection reset")},
+		}, false},
+		{"lookup after redirect fails", []mockResponse{
+			{statusCode: 200, body: `<html><meta name="X-Csrf-Token" content="test-csrf"></html>`},
+			{statusCode: 302, headers: map[string]string{"Location": "/contest/1/my"}},
+			{err: fmt.Errorf("connection reset")},
+		}, false},
+		{"contest over", []mockResponse{
+			{statusCode: 200, body: `<html><meta name="X-Csrf-Token" content="test-csrf"></html>`},
+			{statusCode: 200, body: `Contest is over`},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			session := createMockSession(&mockTransport{})
+			session.client.Transport = &sequentialMockTransport{responses: tt.responses, callCount: &callCount}
+			submitter := &Submitter{session: session}
+
+			_, err := submitter.Submit(1, "A", 54, "int main(){}")
+			if err == nil {
+				t.Fatal("Expected submit error")
+			}
+			if got := IsTransient(err); got != tt.transient {
+				t.Errorf("IsTransient(%v) = %v, want %v", err, got, tt.transient)
+			}
+		})
+	}
+}
+
+func TestSubmitter_SubmitToGym_Unavailable(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []mockResponse
+		transient bool
+	}{
+		{"submit page unavailable", []mockResponse{{statusCode: 503, body: `Service Unavailable`}}, true},
+		{"rate limited post", []mockResponse{
+			{statusCode: 200, body: `<html><meta name="X-Csrf-Token" content="test-csrf"></html>`},
+			{statusCode: 429, body: ``},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callCount := 0
+			session := createMockSession(&mockTransport{})
+			session.client.Transport = &sequentialMockTransport{responses: tt.responses, callCount: &callCount}
+			submitter := &Submitter{session: session}
+
+			_, err := submitter.SubmitToGym(100001, "A", 54, "int main(){}")
+			if !errors.Is(err, ErrTemporarilyUnavailable) {
+				t.Fatalf("SubmitToGym() error = %v, want ErrTemporarilyUnavailable", err)
+			}
+			if got := IsTransient(err); got != tt.transient {
+				t.Errorf("IsTransient(%v) = %v, want %v", err, got, tt.transient)
+			}
+		})
+	}
+}
+
 func TestSubmitter_Submit_RedirectSuccess(t *testing.T) {
 	callCount := 0
 	session := createMockSession(&mockTransport{})
@@ -830,6 +913,12 @@ func TestSubmitter_GetSubmission_VerdictInQueue(t *testing.T) {
 	if result.Status != "In queue" {
 		t.Errorf("Expected status 'In queue', got '%s'", result.Status)
 	}
+	if result.Outcome != VerdictInQueue {
+		t.Errorf("Expected outcome %v, got %v", VerdictInQueue, result.Outcome)
+	}
+	if result.IsFinal() {
+		t.Error("Expected queued submission not to be final")
+	}
 }
 
 func TestSubmitter_GetSubmission_VerdictRunning(t *testing.T) {
@@ -847,6 +936,12 @@ func TestSubmitter_GetSubmission_VerdictRunning(t *testing.T) {
 	if result.Status != "Running" {
 		t.Errorf("Expected status 'Running', got '%s'", result.Status)
 	}
+	if result.Outcome != VerdictRunning {
+		t.Errorf("Expected outcome %v, got %v", VerdictRunning, result.Outcome)
+	}
+	if result.IsFinal() {
+		t.Error("Expected running submission not to be final")
+	}
 }
 
 func TestSubmitter_GetSubmission_VerdictAccepted(t *testing.T) {
@@ -867,6 +962,12 @@ func TestSubmitter_GetSubmission_VerdictAccepted(t *testing.T) {
 	if result.Verdict != "OK" {
 		t.Errorf("Expected verdict 'OK', got '%s'", result.Verdict)
 	}
+	if result.Outcome != VerdictAccepted {
+		t.Errorf("Expected outcome %v, got %v", VerdictAccepted, result.Outcome)
+	}
+	if !result.IsFinal() {
+		t.Error("Expected accepted submission to be final")
+	}
 	if result.Time != 46*time.Millisecond {
 		t.Errorf("Expected time 46ms, got %v", result.Time)
 	}
@@ -890,6 +991,12 @@ func TestSubmitter_GetSubmission_VerdictRejected(t *testing.T) {
 	if result.Status != "Judged" {
 		t.Errorf("Expected status 'Judged', got '%s'", result.Status)
 	}
+	if result.Outcome != VerdictWrongAnswer {
+		t.Errorf("Expected outcome %v, got %v", VerdictWrongAnswer, result.Outcome)
+	}
+	if !result.IsFinal() {
+		t.Error("Expected rejected submission to be final")
+	}
 }
 
 func TestSubmitter_WaitForVerdict_Success(t *testing.T) {
@@ -1004,6 +1111,7 @@ func (m *sequentialMockTransport) RoundTrip(req *http.Request) (*http.Response,
 		StatusCode: resp.statusCode,
 		Body:       io.NopCloser(strings.NewReader(resp.body)),
 		Header:     make(http.Header),
+		Request:    req,
 	}
 
 	for k, v := range resp.headers {
@@ -1381,3 +1489,796 @@ func (e *errorReadCloser) Read(p []byte) (n int, err error) {
 func (e *errorReadCloser) Close() error {
 	return nil
 }
+
+// ============ EnrichFromAPI Tests ============
+
+func newMockAPIClient(body string) *cfapi.Client {
+	transport := &mockTransport{statusCode: 200, body: body}
+	return cfapi.NewClient(cfapi.WithHTTPClient(&http.Client{Transport: transport}))
+}
+
+func TestParsedProblem_EnrichFromAPI_FillsMissing(t *testing.T) {
+	client := newMockAPIClient(`{"status":"OK","result":{"problems":[{"contestId":1,"index":"A","name":"Theatre Square","rating":1000,"tags":["math"]}],"problemStatistics":[]}}`)
+
+	problem := &ParsedProblem{ContestID: 1, Index: "A", Name: "Theatre Square"}
+	if err := problem.EnrichFromAPI(context.Background(), client); err != nil {
+		t.Fatalf("EnrichFromAPI() error = %v", err)
+	}
+
+	if problem.Rating != 1000 {
+		t.Errorf("Rating = %d, want 1000", problem.Rating)
+	}
+	if len(problem.Tags) != 1 || problem.Tags[0] != "math" {
+		t.Errorf("Tags = %v, want [math]", problem.Tags)
+	}
+}
+
+func TestParsedProblem_EnrichFromAPI_KeepsScraped(t *testing.T) {
+	client := newMockAPIClient(`{"status":"OK","result":{"problems":[{"contestId":1,"index":"A","rating":1000,"tags":["math"]}],"problemStatistics":[]}}`)
+
+	problem := &ParsedProblem{ContestID: 1, Index: "A", Rating: 800}
+	if err := problem.EnrichFromAPI(context.Background(), client); err != nil {
+		t.Fatalf("EnrichFromAPI() error = %v", err)
+	}
+
+	if problem.Rating != 800 {
+		t.Errorf("Rating = %d, want scraped value 800", problem.Rating)
+	}
+	if len(problem.Tags) != 1 {
+		t.Errorf("Tags should be filled from API, got %v", problem.Tags)
+	}
+}
+
+func TestParsedProblem_EnrichFromAPI_NotFound(t *testing.T) {
+	client := newMockAPIClient(`{"status":"OK","result":{"problems":[],"problemStatistics":[]}}`)
+
+	problem := &ParsedProblem{ContestID: 1, Index: "A"}
+	if err := problem.EnrichFromAPI(context.Background(), client); err == nil {
+		t.Error("Expected error when problem is missing from API")
+	}
+}
+
+// ============ Concurrent Parse Tests ============
+
+// concurrencyTransport serves problem pages and records peak in-flight requests
+type concurrencyTransport struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	calls    int
+}
+
+func (c *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.calls++
+	c.inFlight++
+	if c.inFlight > c.peak {
+		c.peak = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+
+	index := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+	if index == "X" {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader("Not Found")),
+			Header:     make(http.Header),
+		}, nil
+	}
+
+	body := fmt.Sprintf(`<div class="problem-statement"><div class="title">%s. Problem %s</div></div>`, index, index)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestParser_ParseProblemsConcurrent_AlignedResults(t *testing.T) {
+	transport := &concurrencyTransport{}
+	parser := NewParserWithClient(&http.Client{Transport: transport})
+
+	refs := []cfapi.ProblemRef{{ContestID: 1, Index: "A"}, {ContestID: 1, Index: "X"}, {ContestID: 1, Index: "C"}, {ContestID: 1, Index: "D"}}
+	problems, errs := parser.parseProblemsConcurrent(context.Background(), refs, 2, time.Millisecond)
+
+	if len(problems) != len(refs) || len(errs) != len(refs) {
+		t.Fatalf("got %d problems and %d errors, want %d each", len(problems), len(errs), len(refs))
+	}
+
+	for i, ref := range refs {
+		if ref.Index == "X" {
+			if errs[i] == nil || problems[i] != nil {
+				t.Errorf("ref %d: expected error for missing problem", i)
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Errorf("ref %d: unexpected error %v", i, errs[i])
+			continue
+		}
+		if problems[i].Index != ref.Index {
+			t.Errorf("ref %d: got problem %s, want %s", i, problems[i].Index, ref.Index)
+		}
+	}
+
+	if transport.peak > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", transport.peak)
+	}
+}
+
+func TestParser_ParseProblemsConcurrent_EnforcesInterval(t *testing.T) {
+	parser := NewParserWithClient(&http.Client{Transport: &concurrencyTransport{}})
+
+	refs := []cfapi.ProblemRef{{ContestID: 1, Index: "A"}, {ContestID: 1, Index: "B"}, {ContestID: 1, Index: "C"}}
+	interval := 30 * time.Millisecond
+
+	start := time.Now()
+	parser.parseProblemsConcurrent(context.Background(), refs, 3, interval)
+	elapsed := time.Since(start)
+
+	// First request is immediate, the remaining two wait one interval each
+	if elapsed < 2*interval {
+		t.Errorf("elapsed = %v, want at least %v", elapsed, 2*interval)
+	}
+}
+
+func TestParser_ParseProblemsConcurrent_Empty(t *testing.T) {
+	parser := NewParserWithClient(&http.Client{Transport: &concurrencyTransport{}})
+
+	problems, errs := parser.ParseProblemsConcurrent(nil, 0)
+	if len(problems) != 0 || len(errs) != 0 {
+		t.Errorf("expected empty results, got %d problems and %d errors", len(problems), len(errs))
+	}
+}
+
+// ============ Parse Cache Tests ============
+
+func TestParser_ParseProblem_NoCacheByDefault(t *testing.T) {
+	transport := &concurrencyTransport{}
+	parser := NewParserWithClient(&http.Client{Transport: transport})
+
+	parser.ParseProblem(1, "A")
+	parser.ParseProblem(1, "A")
+
+	if transport.calls != 2 {
+		t.Errorf("calls = %d, want 2 without a parse cache", transport.calls)
+	}
+}
+
+func TestParser_WithParseCache(t *testing.T) {
+	transport := &concurrencyTransport{}
+	parser := NewParserWithClient(&http.Client{Transport: transport}, WithParseCache(time.Minute))
+
+	first, err := parser.ParseProblem(1, "A")
+	if err != nil {
+		t.Fatalf("ParseProblem() error = %v", err)
+	}
+	first.Name = "modified by caller"
+	first.Tags = append(first.Tags, "dp")
+
+	second, err := parser.ParseProblem(1, "A")
+	if err != nil {
+		t.Fatalf("ParseProblem() error = %v", err)
+	}
+	if transport.calls != 1 {
+		t.Errorf("calls = %d, want 1 with a parse cache", transport.calls)
+	}
+	if second.Name != "Problem A" || len(second.Tags) != 0 {
+		t.Errorf("cached problem was changed by the caller: %+v", second)
+	}
+
+	// Problemset pages are cached separately
+	parser.ParseProblemset(1, "A")
+	if transport.calls != 2 {
+		t.Errorf("calls = %d, want 2 after parsing the problemset page", transport.calls)
+	}
+
+	parser.ClearCache()
+	parser.ParseProblem(1, "A")
+	if transport.calls != 3 {
+		t.Errorf("calls = %d, want 3 after ClearCache()", transport.calls)
+	}
+}
+
+func TestParser_WithParseCache_SkipsErrors(t *testing.T) {
+	transport := &concurrencyTransport{}
+	parser := NewParserWithClient(&http.Client{Transport: transport}, WithParseCache(time.Minute))
+
+	parser.ParseProblem(1, "X")
+	if _, err := parser.ParseProblem(1, "X"); err == nil {
+		t.Error("ParseProblem() should keep failing for a missing problem")
+	}
+	if transport.calls != 2 {
+		t.Errorf("calls = %d, want 2 since failures aren't cached", transport.calls)
+	}
+}
+
+func TestParser_ParseProblemsConcurrent_UsesCache(t *testing.T) {
+	transport := &concurrencyTransport{}
+	parser := NewParserWithClient(&http.Client{Transport: transport}, WithParseCache(time.Minute))
+
+	parser.ParseProblem(1, "A")
+
+	refs := []cfapi.ProblemRef{{ContestID: 1, Index: "A"}, {ContestID: 1, Index: "B"}}
+	problems, errs := parser.parseProblemsConcurrent(context.Background(), refs, 2, time.Millisecond)
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if problems[0].Index != "A" || problems[1].Index != "B" {
+		t.Errorf("got problems %s, %s", problems[0].Index, problems[1].Index)
+	}
+	if transport.calls != 2 {
+		t.Errorf("calls = %d, want 2 (one cached)", transport.calls)
+	}
+}
+
+func TestParser_ClearCache_NoCache(t *testing.T) {
+	parser := NewParserWithClient(nil)
+	parser.ClearCache() // must not panic
+}
+
+// ============ Context Tests ============
+
+// blockingTransport blocks until the request context is cancelled
+type blockingTransport struct {
+	started chan struct{}
+}
+
+func (b *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	close(b.started)
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestParser_ParseProblemContext_CancelMidFetch(t *testing.T) {
+	transport := &blockingTransport{started: make(chan struct{})}
+	parser := NewParserWithClient(&http.Client{Transport: transport})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-transport.started
+		cancel()
+	}()
+
+	_, err := parser.ParseProblemContext(ctx, 1, "A")
+	if err == nil {
+		t.Fatal("Expected error when context is cancelled")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestParser_ParseContestProblemsContext_Cancelled(t *testing.T) {
+	transport := &blockingTransport{started: make(chan struct{})}
+	parser := NewParserWithClient(&http.Client{Transport: transport})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := parser.ParseContestProblemsContext(ctx, 1); err == nil {
+		t.Error("Expected error with cancelled context")
+	}
+}
+
+func TestParser_ParseProblemsConcurrentContext_Cancelled(t *testing.T) {
+	parser := NewParserWithClient(&http.Client{Transport: &concurrencyTransport{}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	refs := []cfapi.ProblemRef{{ContestID: 1, Index: "A"}, {ContestID: 1, Index: "B"}}
+	_, errs := parser.ParseProblemsConcurrentContext(ctx, refs, 2)
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("ref %d: expected error with cancelled context", i)
+		}
+	}
+}
+
+// contestTransport serves a contest page listing problems A to D and the
+// problem pages, each taking delay
+type contestTransport struct {
+	problems concurrencyTransport
+	delay    time.Duration
+}
+
+func (c *contestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.Contains(req.URL.Path, "/problem/") {
+		var rows strings.Builder
+		rows.WriteString("<tr><th>Problem</th></tr>")
+		for _, index := range []string{"A", "B", "C", "D"} {
+			fmt.Fprintf(&rows, `<tr><td class="id"><a href="/contest/1/problem/%s">%s</a></td><td><a href="/contest/1/problem/%s">Problem %s</a></td></tr>`,
+				index, index, index, index)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`<html><table class="problems">` + rows.String() + `</table></html>`)),
+			Header:     make(http.Header),
+		}, nil
+	}
+
+	select {
+	case <-time.After(c.delay):
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	return c.problems.RoundTrip(req)
+}
+
+func TestParser_ParseContest(t *testing.T) {
+	parser := NewParserWithClient(&http.Client{Transport: &contestTransport{}})
+
+	result, err := parser.parseContest(context.Background(), 1, 2, time.Millisecond)
+	if err != nil {
+		t.Fatalf("parseContest() error = %v", err)
+	}
+	if len(result.Refs) != 4 || len(result.Parsed()) != 4 {
+		t.Fatalf("got %d refs and %d parsed problems, want 4 each", len(result.Refs), len(result.Parsed()))
+	}
+	for i, ref := range result.Refs {
+		if result.Errs[i] != nil || result.Problems[i].Index != ref.Index {
+			t.Errorf("ref %s: problem %+v, error %v", ref.Index, result.Problems[i], result.Errs[i])
+		}
+	}
+}
+
+func TestParser_ParseContest_DeadlineSpansChain(t *testing.T) {
+	// One worker and a request gap of 40ms: A and B are parsed by 60ms,
+	// the rest would only start after the deadline
+	parser := NewParserWithClient(&http.Client{Transport: &contestTransport{delay: 5 * time.Millisecond}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 70*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result, err := parser.parseContest(ctx, 1, 1, 40*time.Millisecond)
+	if err != nil {
+		t.Fatalf("parseContest() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("parseContest() took %v, should stop at the deadline", elapsed)
+	}
+
+	parsed := result.Parsed()
+	if len(parsed) == 0 || len(parsed) == len(result.Refs) {
+		t.Fatalf("parsed %d of %d problems, want a partial result", len(parsed), len(result.Refs))
+	}
+	for i, p := range result.Problems {
+		if p == nil && result.Errs[i] == nil {
+			t.Errorf("ref %s: missing problem without an error", result.Refs[i].Index)
+		}
+	}
+	if result.Problems[0] == nil || result.Problems[0].Index != "A" {
+		t.Errorf("first problem = %+v, want A parsed before the deadline", result.Problems[0])
+	}
+}
+
+func TestParser_ParseContestContext_PageError(t *testing.T) {
+	parser := NewParserWithClient(&http.Client{Transport: &mockTransport{statusCode: 503, body: "busy"}})
+
+	if _, err := parser.ParseContestContext(context.Background(), 1, 2); err == nil {
+		t.Error("Expected error when the contest page fails")
+	}
+}
+
+// ============ Duplicate Source Tests ============
+
+const mySubmissionsPage = `<html><table class="status-frame-datatable">` +
+	`<tr data-submission-id="222"><td class="id-cell">B</td><td class="status-cell">Accepted</td></tr>` +
+	`<tr data-submission-id="111"><td class="id-cell">A</td><td class="status-cell">Wrong answer</td></tr>` +
+	`</table></html>`
+
+func TestSubmitter_Submit_DuplicateSourceDetected(t *testing.T) {
+	callCount := 0
+	session := createMockSession(&mockTransport{})
+	session.client.Transport = &sequentialMockTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: mySubmissionsPage},
+			{statusCode: 200, body: `<html><pre id="program-source-text">int main(){}  ` + "\r\n" + `</pre></html>`},
+		},
+		callCount: &callCount,
+	}
+	submitter := &Submitter{session: session}
+	submitter.SetCheckDuplicates(true)
+
+	_, err := submitter.Submit(1, "A", 54, "int main(){}\n")
+	if !errors.Is(err, ErrDuplicateSource) {
+		t.Fatalf("Expected ErrDuplicateSource, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "111") {
+		t.Errorf("Error should name the matching submission, got %v", err)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected no submit request after duplicate check, got %d requests", callCount)
+	}
+}
+
+func TestSubmitter_Submit_DuplicateCheckDifferentSource(t *testing.T) {
+	callCount := 0
+	session := createMockSession(&mockTransport{})
+	session.client.Transport = &sequentialMockTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: mySubmissionsPage},
+			{statusCode: 200, body: `<html><pre id="program-source-text">int main(){return 1;}</pre></html>`},
+			{statusCode: 200, body: `<html><meta name="X-Csrf-Token" content="test-csrf"></html>`},
+			{statusCode: 200, body: `Source code is too long`},
+		},
+		callCount: &callCount,
+	}
+	submitter := &Submitter{session: session}
+	submitter.SetCheckDuplicates(true)
+
+	_, err := submitter.Submit(1, "A", 54, "int main(){}")
+	if errors.Is(err, ErrDuplicateSource) {
+		t.Fatal("Different source should not be reported as duplicate")
+	}
+	if callCount != 4 {
+		t.Errorf("Expected submission to proceed, got %d requests", callCount)
+	}
+}
+
+func TestSubmitter_Submit_DuplicateCheckNoPreviousSubmission(t *testing.T) {
+	callCount := 0
+	session := createMockSession(&mockTransport{})
+	session.client.Transport = &sequentialMockTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: mySubmissionsPage},
+			{statusCode: 200, body: `<html><meta name="X-Csrf-Token" content="test-csrf"></html>`},
+			{statusCode: 200, body: `Contest is over`},
+		},
+		callCount: &callCount,
+	}
+	submitter := &Submitter{session: session}
+	submitter.SetCheckDuplicates(true)
+
+	// No submission to problem C exists, so the check is skipped
+	_, err := submitter.Submit(1, "C", 54, "int main(){}")
+	if err == nil || !strings.Contains(err.Error(), "contest is over") {
+		t.Errorf("Expected submission to proceed to contest-over error, got %v", err)
+	}
+}
+
+func TestSourceHash_IgnoresWhitespaceDifferences(t *testing.T) {
+	if sourceHash("a\r\nb  \n\n") != sourceHash("a\nb") {
+		t.Error("sourceHash should ignore line endings and trailing whitespace")
+	}
+	if sourceHash("a\nb") == sourceHash("a\nc") {
+		t.Error("sourceHash should differ for different sources")
+	}
+}
+
+// ============ Contest Registration Tests ============
+
+const registrationPage = `<html><form><input type="hidden" name="csrf_token" value="reg-csrf">` +
+	`<input name="ftaa" value="ftaa123"><select name="teamId"><option value="77">Team A</option></select></form></html>`
+
+// formRecorder records the forms posted through it
+type formRecorder struct {
+	next  http.RoundTripper
+	forms []url.Values
+}
+
+func (f *formRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		f.forms = append(f.forms, form)
+	}
+	return f.next.RoundTrip(req)
+}
+
+func registrationSubmitter(responses ...mockResponse) (*Submitter, *formRecorder, *int) {
+	callCount := 0
+	session := createMockSession(&mockTransport{})
+	recorder := &formRecorder{next: &sequentialMockTransport{responses: responses, callCount: &callCount}}
+	session.client.Transport = recorder
+	return &Submitter{session: session}, recorder, &callCount
+}
+
+func TestSubmitter_RegisterForContest(t *testing.T) {
+	submitter, recorder, calls := registrationSubmitter(
+		mockResponse{statusCode: 200, body: registrationPage},
+		mockResponse{statusCode: 302, headers: map[string]string{"Location": "/contests"}},
+		mockResponse{statusCode: 200, body: "<html>contests</html>"},
+	)
+
+	if err := submitter.RegisterForContest(2000, ""); err != nil {
+		t.Fatalf("RegisterForContest() error = %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("requests = %d, want the page, the form and the redirect", *calls)
+	}
+	form := recorder.forms[0]
+	if form.Get("csrf_token") != "reg-csrf" || form.Get("ftaa") != "ftaa123" || form.Get("takePartAs") != "personal" {
+		t.Errorf("posted form = %v", form)
+	}
+}
+
+func TestSubmitter_RegisterForContest_Team(t *testing.T) {
+	submitter, recorder, _ := registrationSubmitter(
+		mockResponse{statusCode: 200, body: registrationPage},
+		mockResponse{statusCode: 302, headers: map[string]string{"Location": "/contests"}},
+		mockResponse{statusCode: 200, body: "<html>contests</html>"},
+	)
+
+	if err := submitter.RegisterForContest(2000, "team a"); err != nil {
+		t.Fatalf("RegisterForContest() error = %v", err)
+	}
+	if form := recorder.forms[0]; form.Get("takePartAs") != "team" || form.Get("teamId") != "77" {
+		t.Errorf("posted form = %v, want team 77", form)
+	}
+}
+
+func TestSubmitter_RegisterForContest_UnknownTeam(t *testing.T) {
+	page := `<input name="csrf_token" value="reg-csrf"><select name="teamId">` +
+		`<option value="77">Team A</option><option value="78">Team B</option></select>`
+	submitter, recorder, _ := registrationSubmitter(
+		mockResponse{statusCode: 200, body: page},
+	)
+
+	err := submitter.RegisterForContest(2000, "Team C")
+	if err == nil || !strings.Contains(err.Error(), "Team A (77), Team B (78)") {
+		t.Errorf("RegisterForContest() error = %v, want the offered teams listed", err)
+	}
+	if len(recorder.forms) != 0 {
+		t.Error("RegisterForContest() should not post with an unknown team")
+	}
+}
+
+func TestSubmitter_RegisterForContest_NotLoggedIn(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []mockResponse
+	}{
+		{"before posting", []mockResponse{
+			{statusCode: 302, headers: map[string]string{"Location": "/enter?back=%2FcontestRegistration%2F2000"}},
+			{statusCode: 200, body: `<html><form id="enterForm"></form></html>`},
+		}},
+		{"after posting", []mockResponse{
+			{statusCode: 200, body: registrationPage},
+			{statusCode: 302, headers: map[string]string{"Location": "/enter"}},
+			{statusCode: 200, body: `<html><form id="enterForm"></form></html>`},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submitter, _, _ := registrationSubmitter(tt.responses...)
+			if err := submitter.RegisterForContest(2000, ""); !errors.Is(err, ErrNotLoggedIn) {
+				t.Errorf("RegisterForContest() error = %v, want ErrNotLoggedIn", err)
+			}
+		})
+	}
+}
+
+func TestSubmitter_RegisterForContest_NoCSRFToken(t *testing.T) {
+	submitter, recorder, _ := registrationSubmitter(
+		mockResponse{statusCode: 200, body: "<html>no csrf</html>"},
+	)
+
+	err := submitter.RegisterForContest(2000, "")
+	if err == nil || !strings.Contains(err.Error(), "csrf token not found") {
+		t.Errorf("RegisterForContest() error = %v, want 'csrf token not found'", err)
+	}
+	if len(recorder.forms) != 0 {
+		t.Error("RegisterForContest() should not post without a CSRF token")
+	}
+}
+
+func TestSubmitter_RegisterForContest_AlreadyRegistered(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []mockResponse
+	}{
+		{"on the page", []mockResponse{
+			{statusCode: 200, body: `<html><div>You have already registered for the contest</div></html>`},
+		}},
+		{"after posting", []mockResponse{
+			{statusCode: 200, body: registrationPage},
+			{statusCode: 200, body: `<html>You are registered for the contest</html>`},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submitter, _, _ := registrationSubmitter(tt.responses...)
+			if err := submitter.RegisterForContest(2000, ""); !errors.Is(err, ErrAlreadyRegistered) {
+				t.Errorf("RegisterForContest() error = %v, want ErrAlreadyRegistered", err)
+			}
+		})
+	}
+}
+
+func TestSubmitter_RegisterForContest_Closed(t *testing.T) {
+	submitter, _, _ := registrationSubmitter(
+		mockResponse{statusCode: 200, body: `<html>Registration for the contest is closed</html>`},
+	)
+
+	if err := submitter.RegisterForContest(2000, ""); !errors.Is(err, ErrRegistrationClosed) {
+		t.Errorf("RegisterForContest() error = %v, want ErrRegistrationClosed", err)
+	}
+}
+
+func TestSubmitter_RegisterForContest_NoTeam(t *testing.T) {
+	submitter, recorder, _ := registrationSubmitter(
+		mockResponse{statusCode: 200, body: `<input name="csrf_token" value="reg-csrf">`},
+	)
+
+	if err := submitter.RegisterForContest(2000, "Team A"); err == nil || !strings.Contains(err.Error(), "no team") {
+		t.Errorf("RegisterForContest() error = %v, want a missing team error", err)
+	}
+	if len(recorder.forms) != 0 {
+		t.Error("RegisterForContest() should not post without a team")
+	}
+}
+
+func TestSubmitter_IsRegistered(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want bool
+	}{
+		{"registered", `<html><div>You have already registered for the contest</div></html>`, true},
+		{"open", registrationPage, false},
+		{"closed", `<html>Registration for the contest is closed</html>`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submitter, recorder, _ := registrationSubmitter(mockResponse{statusCode: 200, body: tt.page})
+			got, err := submitter.IsRegistered(2000)
+			if err != nil {
+				t.Fatalf("IsRegistered() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsRegistered() = %v, want %v", got, tt.want)
+			}
+			if len(recorder.forms) != 0 {
+				t.Error("IsRegistered() should not post the form")
+			}
+		})
+	}
+}
+
+func TestSubmitter_IsRegistered_NotLoggedIn(t *testing.T) {
+	submitter, _, _ := registrationSubmitter(
+		mockResponse{statusCode: 302, headers: map[string]string{"Location": "/enter"}},
+		mockResponse{statusCode: 200, body: `<html><form id="enterForm"></form></html>`},
+	)
+
+	if _, err := submitter.IsRegistered(2000); !errors.Is(err, ErrNotLoggedIn) {
+		t.Errorf("IsRegistered() error = %v, want ErrNotLoggedIn", err)
+	}
+}
+
+func TestSubmitter_RegisterForContest_Rejected(t *testing.T) {
+	submitter, _, _ := registrationSubmitter(
+		mockResponse{statusCode: 200, body: registrationPage},
+		mockResponse{statusCode: 200, body: registrationPage},
+	)
+
+	err := submitter.RegisterForContest(2000, "")
+	if err == nil || errors.Is(err, ErrAlreadyRegistered) {
+		t.Errorf("RegisterForContest() error = %v, want a failure when the form comes back", err)
+	}
+}
+
+// ============ Gym Routing Tests ============
+
+// pathTransport records the path of each request and serves a problem page
+type pathTransport struct {
+	paths []string
+}
+
+func (p *pathTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	p.paths = append(p.paths, req.URL.Path)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`<div class="problem-statement"><div class="title">A. Gym Problem</div></div>`)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestParser_ParseGymProblem(t *testing.T) {
+	transport := &pathTransport{}
+	parser := NewParserWithClient(&http.Client{Transport: transport})
+
+	problem, err := parser.ParseGymProblem(100001, "A")
+	if err != nil {
+		t.Fatalf("ParseGymProblem() error = %v", err)
+	}
+	if problem.URL != "https://codeforces.com/gym/100001/problem/A" || problem.ContestID != 100001 {
+		t.Errorf("ParseGymProblem() = %+v, want the gym problem", problem)
+	}
+
+	// Gym IDs passed to ParseProblem take the same route
+	if _, err := parser.ParseProblem(100001, "B"); err != nil {
+		t.Fatalf("ParseProblem() error = %v", err)
+	}
+	if _, err := parser.ParseProblem(1325, "A"); err != nil {
+		t.Fatalf("ParseProblem() error = %v", err)
+	}
+	want := []string{"/gym/100001/problem/A", "/gym/100001/problem/B", "/contest/1325/problem/A"}
+	if !slices.Equal(transport.paths, want) {
+		t.Errorf("requested %v, want %v", transport.paths, want)
+	}
+}
+
+func TestParser_ParseGymProblem_NotGym(t *testing.T) {
+	transport := &pathTransport{}
+	parser := NewParserWithClient(&http.Client{Transport: transport})
+
+	if _, err := parser.ParseGymProblem(1325, "A"); err == nil {
+		t.Error("ParseGymProblem() should reject a regular contest ID")
+	}
+	if len(transport.paths) != 0 {
+		t.Errorf("requested %v, want no requests", transport.paths)
+	}
+}
+
+// ============ User-Agent Tests ============
+
+// uaTransport records the User-Agent of each request
+type uaTransport struct {
+	agents []string
+}
+
+func (u *uaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	u.agents = append(u.agents, req.Header.Get("User-Agent"))
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader("<html></html>")),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestSession_UserAgent_MatchesClearance(t *testing.T) {
+	const clearanceUA = "Mozilla/5.0 (X11; Linux x86_64) Firefox/128.0"
+
+	session, err := NewSessionWithCookie("JSESSIONID=a; 39ce7=b; cf_clearance=c", WithUserAgent(clearanceUA))
+	if err != nil {
+		t.Fatalf("NewSessionWithCookie() error = %v", err)
+	}
+	transport := &uaTransport{}
+	session.client = &http.Client{Transport: transport}
+	session.SetHandle("testuser")
+
+	if _, err := session.get(BaseURL); err != nil {
+		t.Fatalf("session.get() error = %v", err)
+	}
+	if _, err := (&Submitter{session: session}).get(BaseURL + "/submissions"); err != nil {
+		t.Fatalf("submitter.get() error = %v", err)
+	}
+	if _, err := NewParser(session).fetch(BaseURL + "/problemset/problem/1/A"); err != nil {
+		t.Fatalf("parser.fetch() error = %v", err)
+	}
+
+	for i, got := range transport.agents {
+		if got != clearanceUA {
+			t.Errorf("request %d User-Agent = %q, want %q", i, got, clearanceUA)
+		}
+	}
+}
+
+func TestSession_UserAgent_Default(t *testing.T) {
+	session, err := NewSessionWithCookie("cf_clearance=c", WithUserAgent(""))
+	if err != nil {
+		t.Fatalf("NewSessionWithCookie() error = %v", err)
+	}
+	if session.UserAgent() != UserAgent {
+		t.Errorf("UserAgent() = %q, want default", session.UserAgent())
+	}
+}
+
+func TestMinGymContestID_MatchesAPI(t *testing.T) {
+	if minGymContestID != cfapi.MinGymContestID {
+		t.Errorf("minGymContestID = %d, cfapi.MinGymContestID = %d", minGymContestID, cfapi.MinGymContestID)
+	}
+}
@@ -1,14 +1,18 @@
 package cfweb
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
 	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
 )
 
@@ -24,26 +28,68 @@ var (
 type Parser struct {
 	session   *Session
 	selectors Selectors
+	cache     *cfapi.Cache // nil unless WithParseCache is set
+}
+
+// ParserOption configures the parser
+type ParserOption func(*Parser)
+
+// WithParseCache keeps parsed problems in memory for ttl so repeated
+// parses of the same problem skip the network
+func WithParseCache(ttl time.Duration) ParserOption {
+	return func(p *Parser) {
+		if ttl > 0 {
+			p.cache = cfapi.NewCache(ttl)
+		}
+	}
 }
 
 // NewParser creates a new parser
-func NewParser(session *Session) *Parser {
-	return &Parser{
+func NewParser(session *Session, opts ...ParserOption) *Parser {
+	p := &Parser{
 		session:   session,
 		selectors: CurrentSelectors,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // NewParserWithClient creates a parser with a custom HTTP client
-func NewParserWithClient(client *http.Client) *Parser {
-	return &Parser{
-		session: &Session{
-			client: client,
-		},
-		selectors: CurrentSelectors,
+func NewParserWithClient(client *http.Client, opts ...ParserOption) *Parser {
+	return NewParser(&Session{client: client}, opts...)
+}
+
+// ClearCache drops all cached problems
+func (p *Parser) ClearCache() {
+	if p.cache != nil {
+		p.cache.Clear()
+	}
+}
+
+// cachedProblem returns a copy of a cached problem, so callers can modify it freely
+func (p *Parser) cachedProblem(key string) (*ParsedProblem, bool) {
+	if p.cache == nil {
+		return nil, false
+	}
+	v, ok := p.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return v.(*ParsedProblem).clone(), true
+}
+
+func (p *Parser) cacheProblem(key string, problem *ParsedProblem) {
+	if p.cache != nil {
+		p.cache.Set(key, problem.clone())
 	}
 }
 
+func problemCacheKey(page string, contestID int, index string) string {
+	return fmt.Sprintf("%s:%d%s", page, contestID, index)
+}
+
 // ParsedProblem contains parsed problem data
 type ParsedProblem struct {
 	ContestID   int
@@ -59,8 +105,15 @@ type ParsedProblem struct {
 	Tags        []string
 	Rating      int
 	URL         string
+
+	// hasSampleTests is set when the page had a sample tests container
+	hasSampleTests bool
 }
 
+// ErrSamplesNotParsed is returned by Validate when a page had sample tests
+// that none of the parsing paths understood, usually because CF markup changed
+var ErrSamplesNotParsed = errors.New("sample tests found but none could be parsed")
+
 // Sample represents a test case
 type Sample struct {
 	Index  int
@@ -70,10 +123,25 @@ type Sample struct {
 
 // ParseProblem parses a problem page
 func (p *Parser) ParseProblem(contestID int, index string) (*ParsedProblem, error) {
+	return p.ParseProblemContext(context.Background(), contestID, index)
+}
+
+// ParseProblemContext parses a problem page, aborting when ctx is done
+// Gym contest IDs are parsed from the gym page, as with ParseGymProblem.
+func (p *Parser) ParseProblemContext(ctx context.Context, contestID int, index string) (*ParsedProblem, error) {
+	if contestID >= minGymContestID {
+		return p.ParseGymProblemContext(ctx, contestID, index)
+	}
+
+	key := problemCacheKey("contest", contestID, index)
+	if problem, ok := p.cachedProblem(key); ok {
+		return problem, nil
+	}
+
 	// Construct problem URL
-	url := fmt.Sprintf("%s/contest/%d/problem/%s", BaseURL, contestID, index)
+	url := problemURL(contestID, index)
 
-	resp, err := p.fetch(url)
+	resp, err := p.fetchContext(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("fetch problem page: %w", err)
 	}
@@ -83,14 +151,78 @@ func (p *Parser) ParseProblem(contestID int, index string) (*ParsedProblem, erro
 		return nil, fmt.Errorf("problem page returned status %d", resp.StatusCode)
 	}
 
-	return p.parseProblemHTML(resp.Body, contestID, index, url)
+	problem, err := p.parseProblemHTML(resp.Body, contestID, index, url)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cacheProblem(key, problem)
+	return problem, nil
+}
+
+// ParseGymProblem parses a gym problem page
+func (p *Parser) ParseGymProblem(gymID int, index string) (*ParsedProblem, error) {
+	return p.ParseGymProblemContext(context.Background(), gymID, index)
+}
+
+// ParseGymProblemContext parses a gym problem page, aborting when ctx is done
+// Gym pages of private gyms need a logged in session.
+func (p *Parser) ParseGymProblemContext(ctx context.Context, gymID int, index string) (*ParsedProblem, error) {
+	if gymID < minGymContestID {
+		return nil, fmt.Errorf("contest %d is not a gym, gym IDs start at %d", gymID, minGymContestID)
+	}
+
+	key := problemCacheKey("gym", gymID, index)
+	if problem, ok := p.cachedProblem(key); ok {
+		return problem, nil
+	}
+
+	url := problemURL(gymID, index)
+
+	resp, err := p.fetchContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("fetch gym problem page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gym problem page returned status %d", resp.StatusCode)
+	}
+
+	problem, err := p.parseProblemHTML(resp.Body, gymID, index, url)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cacheProblem(key, problem)
+	return problem, nil
+}
+
+// problemURL returns the page of a problem: /gym/<id>/problem/<index> for
+// gym contest IDs and /contest/<id>/problem/<index> otherwise
+func problemURL(contestID int, index string) string {
+	problem := &cfapi.Problem{ContestID: contestID, Index: index}
+	if problem.IsGym() {
+		return problem.GymURL()
+	}
+	return problem.ContestURL()
 }
 
 // ParseProblemset parses a problem from the problemset
 func (p *Parser) ParseProblemset(contestID int, index string) (*ParsedProblem, error) {
-	url := fmt.Sprintf("%s/problemset/problem/%d/%s", BaseURL, contestID, index)
+	return p.ParseProblemsetContext(context.Background(), contestID, index)
+}
 
-	resp, err := p.fetch(url)
+// ParseProblemsetContext parses a problem from the problemset, aborting when ctx is done
+func (p *Parser) ParseProblemsetContext(ctx context.Context, contestID int, index string) (*ParsedProblem, error) {
+	key := problemCacheKey("problemset", contestID, index)
+	if problem, ok := p.cachedProblem(key); ok {
+		return problem, nil
+	}
+
+	url := (&cfapi.Problem{ContestID: contestID, Index: index}).URL()
+
+	resp, err := p.fetchContext(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("fetch problemset page: %w", err)
 	}
@@ -100,7 +232,13 @@ func (p *Parser) ParseProblemset(contestID int, index string) (*ParsedProblem, e
 		return nil, fmt.Errorf("problemset page returned status %d", resp.StatusCode)
 	}
 
-	return p.parseProblemHTML(resp.Body, contestID, index, url)
+	problem, err := p.parseProblemHTML(resp.Body, contestID, index, url)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cacheProblem(key, problem)
+	return problem, nil
 }
 
 // parseProblemHTML parses the problem HTML
@@ -151,38 +289,63 @@ func (p *Parser) parseProblemHTML(r io.Reader, contestID int, index, url string)
 	// Parse samples
 	sampleTests := doc.Find(sel.SampleTests)
 	if sampleTests.Length() > 0 {
+		problem.hasSampleTests = true
 		problem.Samples = parseSamples(sampleTests, sel)
 	}
 
-	// Parse tags
+	problem.Tags, problem.Rating = parseTags(doc, sel)
+
+	return problem, nil
+}
+
+// parseTags reads the tags and rating from the problem's tag boxes. The
+// boxes are matched wherever they are, so tags collapsed into a spoiler for
+// users hiding them are read too. The rating is the *NNNN box, preferring
+// the one titled Difficulty.
+func parseTags(doc *goquery.Document, sel ProblemSelectors) ([]string, int) {
+	var tags []string
+	seen := make(map[string]bool)
+	rating := 0
+
 	doc.Find(sel.Tags).Each(func(i int, s *goquery.Selection) {
-		tag := strings.TrimSpace(s.Text())
-		// Skip rating tag and empty tags
-		if tag != "" && !strings.HasPrefix(tag, "*") {
-			problem.Tags = append(problem.Tags, tag)
+		// Boxes pad their text with the page's indentation
+		tag := strings.Join(strings.Fields(s.Text()), " ")
+		switch {
+		case tag == "":
+		case strings.HasPrefix(tag, "*"):
+			if rating == 0 {
+				rating = parseRating(tag)
+			}
+		case !seen[tag]:
+			seen[tag] = true
+			tags = append(tags, tag)
 		}
 	})
 
-	// Parse rating
-	ratingText := ""
-	doc.Find(sel.Rating).Each(func(i int, s *goquery.Selection) {
-		text := s.Text()
-		if strings.HasPrefix(text, "*") {
-			ratingText = text
+	doc.Find(sel.Rating).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if r := parseRating(strings.TrimSpace(s.Text())); r > 0 {
+			rating = r
+			return false
 		}
+		return true
 	})
-	if ratingText != "" {
-		problem.Rating = parseRating(ratingText)
-	}
 
-	return problem, nil
+	return tags, rating
 }
 
 // ParseContestProblems parses all problems from a contest
 func (p *Parser) ParseContestProblems(contestID int) ([]ParsedProblem, error) {
+	return p.ParseContestProblemsContext(context.Background(), contestID)
+}
+
+// ParseContestProblemsContext parses all problems from a contest, aborting when ctx is done
+func (p *Parser) ParseContestProblemsContext(ctx context.Context, contestID int) ([]ParsedProblem, error) {
 	url := fmt.Sprintf("%s/contest/%d", BaseURL, contestID)
+	if contestID >= minGymContestID {
+		url = fmt.Sprintf("%s/gym/%d", BaseURL, contestID)
+	}
 
-	resp, err := p.fetch(url)
+	resp, err := p.fetchContext(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("fetch contest page: %w", err)
 	}
@@ -235,23 +398,50 @@ func (p *Parser) ParseContestProblems(contestID int) ([]ParsedProblem, error) {
 
 // fetch makes an HTTP GET request
 func (p *Parser) fetch(url string) (*http.Response, error) {
-	if p.session != nil && p.session.client != nil {
-		req, err := http.NewRequest(http.MethodGet, url, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("User-Agent", UserAgent)
-		return p.session.client.Do(req)
-	}
+	return p.fetchContext(context.Background(), url)
+}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// fetchContext makes an HTTP GET request bound to ctx
+func (p *Parser) fetchContext(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", UserAgent)
+	if p.session != nil {
+		req.Header.Set("User-Agent", p.session.UserAgent())
+	} else {
+		req.Header.Set("User-Agent", UserAgent)
+	}
+
+	if p.session != nil && p.session.client != nil {
+		return p.session.client.Do(req)
+	}
 	return http.DefaultClient.Do(req)
 }
 
+// clone returns a copy of the problem that shares no slices with it
+func (p *ParsedProblem) clone() *ParsedProblem {
+	cp := *p
+	cp.Samples = append([]Sample(nil), p.Samples...)
+	cp.Tags = append([]string(nil), p.Tags...)
+	return &cp
+}
+
+// Validate checks a parsed problem for signs that the selectors no longer
+// match the page, such as a sample tests container that yielded no samples
+func (p *ParsedProblem) Validate() error {
+	if p.hasSampleTests && len(p.Samples) == 0 {
+		return fmt.Errorf("problem %s: %w (selector version: %s)",
+			p.ProblemID(), ErrSamplesNotParsed, CurrentVersion.Version)
+	}
+	return nil
+}
+
+// ProblemID returns the canonical problem identifier, e.g. "1325A"
+func (p *ParsedProblem) ProblemID() string {
+	return (&cfapi.Problem{ContestID: p.ContestID, Index: p.Index}).ProblemID()
+}
+
 // ToSchemaProblem converts ParsedProblem to schema v1 Problem
 func (p *ParsedProblem) ToSchemaProblem() *v1.Problem {
 	samples := make([]v1.Sample, len(p.Samples))
@@ -264,7 +454,7 @@ func (p *ParsedProblem) ToSchemaProblem() *v1.Problem {
 	}
 
 	return &v1.Problem{
-		ID:        fmt.Sprintf("%d%s", p.ContestID, p.Index),
+		ID:        p.ProblemID(),
 		Platform:  "codeforces",
 		ContestID: p.ContestID,
 		Index:     p.Index,
@@ -283,6 +473,29 @@ func (p *ParsedProblem) ToSchemaProblem() *v1.Problem {
 	}
 }
 
+// EnrichFromAPI fills in missing rating and tags from the CF API
+// Scraping can miss metadata hidden behind spoilers, the API has it reliably.
+// Only empty fields are overwritten.
+func (p *ParsedProblem) EnrichFromAPI(ctx context.Context, client *cfapi.Client) error {
+	if p.Rating > 0 && len(p.Tags) > 0 {
+		return nil
+	}
+
+	apiProblem, err := client.GetProblem(ctx, p.ContestID, p.Index)
+	if err != nil {
+		return fmt.Errorf("enrich from api: %w", err)
+	}
+
+	if p.Rating == 0 {
+		p.Rating = apiProblem.Rating
+	}
+	if len(p.Tags) == 0 {
+		p.Tags = apiProblem.Tags
+	}
+
+	return nil
+}
+
 // Helper functions
 
 func cleanTitle(title string) string {
@@ -305,25 +518,136 @@ func cleanHTML(text string) string {
 	return text
 }
 
+// statementSkipClasses mark the parts of the statement div that are parsed
+// into their own fields and left out of the statement text
+var statementSkipClasses = []string{"header", "input-specification", "output-specification", "sample-tests", "note"}
+
+// buildStatement converts the statement div to markdown, in page order:
+// paragraphs and divs become paragraphs, lists become markdown lists and
+// bold and italic text is marked with ** and *. Whitespace within a
+// paragraph is collapsed.
 func buildStatement(statement *goquery.Selection) string {
 	if statement == nil {
 		return ""
 	}
 
-	var sb strings.Builder
+	var md markdownBuilder
+	md.walk(statement)
+	return strings.Join(md.finish(), "\n\n")
+}
+
+// markdownBuilder collects the markdown blocks of an element
+type markdownBuilder struct {
+	blocks []string
+	inline strings.Builder
+}
+
+// walk converts the children of s, starting a new block for block elements
+func (m *markdownBuilder) walk(s *goquery.Selection) {
+	s.Contents().Each(func(i int, c *goquery.Selection) {
+		for _, class := range statementSkipClasses {
+			if c.HasClass(class) {
+				return
+			}
+		}
 
-	// Get direct text content, excluding child elements we handle separately
-	statement.Contents().Each(func(i int, s *goquery.Selection) {
-		if goquery.NodeName(s) == "#text" {
-			text := strings.TrimSpace(s.Text())
-			if text != "" {
-				sb.WriteString(text)
-				sb.WriteString(" ")
+		switch name := goquery.NodeName(c); name {
+		case "#text":
+			m.inline.WriteString(c.Text())
+		case "p", "div", "center", "h1", "h2", "h3", "h4", "h5", "h6":
+			m.flush()
+			m.walk(c)
+			m.flush()
+		case "br":
+			m.flush()
+		case "ul", "ol":
+			m.flush()
+			if list := markdownList(c, name == "ol"); list != "" {
+				m.blocks = append(m.blocks, list)
 			}
+		case "pre":
+			m.flush()
+			m.blocks = append(m.blocks, "```\n"+extractPreContent(c)+"\n```")
+		case "b", "strong":
+			m.inline.WriteString(emphasize(c, "**"))
+		case "i", "em":
+			m.inline.WriteString(emphasize(c, "*"))
+		case "script", "style":
+			// Not part of the statement text
+		default:
+			m.walk(c)
 		}
 	})
+}
+
+// flush ends the current paragraph
+func (m *markdownBuilder) flush() {
+	if text := cleanHTML(m.inline.String()); text != "" {
+		m.blocks = append(m.blocks, text)
+	}
+	m.inline.Reset()
+}
 
-	return strings.TrimSpace(sb.String())
+// finish ends the last paragraph and returns the blocks
+func (m *markdownBuilder) finish() []string {
+	m.flush()
+	return m.blocks
+}
+
+// emphasize wraps the text of s in marker, keeping the spaces around it
+// outside the marker so the markdown stays valid
+func emphasize(s *goquery.Selection, marker string) string {
+	var inner markdownBuilder
+	inner.walk(s)
+	text := strings.Join(inner.finish(), " ")
+	if text == "" {
+		return s.Text()
+	}
+
+	raw := s.Text()
+	var lead, trail string
+	if strings.TrimLeft(raw, " \t\n") != raw {
+		lead = " "
+	}
+	if strings.TrimRight(raw, " \t\n") != raw {
+		trail = " "
+	}
+	return lead + marker + text + marker + trail
+}
+
+// markdownList converts a ul or ol to markdown list items, indenting the
+// further blocks of an item, such as nested lists, under it
+func markdownList(list *goquery.Selection, ordered bool) string {
+	var lines []string
+	n := 0
+	list.ChildrenFiltered("li").Each(func(i int, li *goquery.Selection) {
+		var item markdownBuilder
+		item.walk(li)
+		blocks := item.finish()
+		if len(blocks) == 0 {
+			return
+		}
+
+		n++
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", n)
+		}
+		indent := strings.Repeat(" ", len(marker))
+		for j, block := range blocks {
+			for k, line := range strings.Split(block, "\n") {
+				switch {
+				case j == 0 && k == 0:
+					lines = append(lines, marker+line)
+				case line == "":
+					lines = append(lines, "")
+				default:
+					lines = append(lines, indent+line)
+				}
+			}
+		}
+	})
+	return strings.Join(lines, "\n")
 }
 
 func parseSamples(sampleTests *goquery.Selection, sel ProblemSelectors) []Sample {
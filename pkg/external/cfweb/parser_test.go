@@ -1,7 +1,9 @@
 package cfweb
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 	"strings"
 	"testing"
 
@@ -170,6 +172,7 @@ func TestExtractProblemIndex(t *testing.T) {
 		{"/contest/1/problem/B", "B"},
 		{"/contest/999/problem/C1", "C1"},
 		{"/contest/1325/problem/B2", "B2"},
+		{"/gym/100001/problem/A", "A"},
 		{"/contest/1/problems", ""},
 		{"/problemset/problem/1/A", ""},
 		{"", ""},
@@ -265,6 +268,55 @@ func TestBuildStatement(t *testing.T) {
 	}
 }
 
+func TestBuildStatement_Markdown(t *testing.T) {
+	html := `<div class="problem-statement">
+		<div class="header"><div class="title">A. Arrays</div></div>
+		<div>
+			<p>You are given an array of   <b>n</b> integers.
+			Find the <strong>maximum</strong> sum.</p>
+			<p>The constraints are:</p>
+			<ul>
+				<li>$$$1 \le n \le 10^5$$$;</li>
+				<li>all values are <i>distinct</i>;
+					<ul><li>even nested ones</li></ul>
+				</li>
+			</ul>
+			<p>Output the answer in <b> one </b>line.</p>
+		</div>
+		<div class="input-specification"><div class="section-title">Input</div><p>One line</p></div>
+		<div class="sample-tests"><pre>1</pre></div>
+	</div>`
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	want := "You are given an array of **n** integers. Find the **maximum** sum.\n\n" +
+		"The constraints are:\n\n" +
+		"- $$$1 \\le n \\le 10^5$$$;\n" +
+		"- all values are *distinct*;\n" +
+		"  - even nested ones\n\n" +
+		"Output the answer in **one** line."
+	if got := buildStatement(doc.Find(".problem-statement").First()); got != want {
+		t.Errorf("buildStatement() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBuildStatement_OrderedList(t *testing.T) {
+	html := `<div class="problem-statement"><p>Steps:</p><ol><li>read</li><li><p>solve</p></li></ol></div>`
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	want := "Steps:\n\n1. read\n2. solve"
+	if got := buildStatement(doc.Find(".problem-statement").First()); got != want {
+		t.Errorf("buildStatement() = %q, want %q", got, want)
+	}
+}
+
 func TestBuildStatement_Nil(t *testing.T) {
 	got := buildStatement(nil)
 	if got != "" {
@@ -329,6 +381,17 @@ func TestParser_Selectors(t *testing.T) {
 	}
 }
 
+func TestParsedProblem_ProblemID_SubtaskIndex(t *testing.T) {
+	parsed := &ParsedProblem{ContestID: 1520, Index: "A1"}
+
+	if got := parsed.ProblemID(); got != "1520A1" {
+		t.Errorf("ProblemID() = %v, want 1520A1", got)
+	}
+	if got := parsed.ToSchemaProblem().ID; got != "1520A1" {
+		t.Errorf("ToSchemaProblem().ID = %v, want 1520A1", got)
+	}
+}
+
 func TestParsedProblem_ToSchemaProblem_EmptySamples(t *testing.T) {
 	parsed := &ParsedProblem{
 		ContestID: 1,
@@ -444,3 +507,140 @@ func TestParser_ParseProblemHTML(t *testing.T) {
 		t.Errorf("Rating = %v, want 1200", problem.Rating)
 	}
 }
+
+func TestParseProblemHTML_TagsInSpoiler(t *testing.T) {
+	// Tags as served to a user who hides them: padded boxes in the problem
+	// tags sidebox, collapsed into a spoiler
+	html := `<html><body>
+<div class="problem-statement">
+	<div class="header"><div class="title">C. Hidden Tags</div></div>
+</div>
+<div class="roundbox sidebox borderTopRound">
+	<div class="caption titled">&rarr; Problem tags</div>
+	<div style="padding: 0.5em;">
+		<div class="spoiler">
+			<b class="spoiler-title">Show tags</b>
+			<div class="spoiler-content" style="display: none;">
+				<div class="roundbox borderTopRound borderBottomRound" style="margin:2px; padding:0 3px 2px 3px; background-color:#f0f0f0;float:left;">
+					<span class="tag-box" style="font-size:1.2rem;" title="Dynamic programming">
+						dp
+					</span>
+				</div>
+				<div class="roundbox borderTopRound borderBottomRound" style="margin:2px; padding:0 3px 2px 3px; background-color:#f0f0f0;float:left;">
+					<span class="tag-box" style="font-size:1.2rem;" title="Binary search">
+						binary search
+					</span>
+				</div>
+				<div class="roundbox borderTopRound borderBottomRound" style="margin:2px; padding:0 3px 2px 3px; background-color:#f0f0f0;float:left;">
+					<span class="tag-box" style="font-size:1.2rem;" title="Difficulty">
+						*1900
+					</span>
+				</div>
+			</div>
+		</div>
+	</div>
+</div>
+</body></html>`
+
+	parser := NewParser(nil)
+	problem, err := parser.parseProblemHTML(strings.NewReader(html), 1000, "C", "https://codeforces.com/contest/1000/problem/C")
+	if err != nil {
+		t.Fatalf("parseProblemHTML() error = %v", err)
+	}
+
+	want := []string{"dp", "binary search"}
+	if !slices.Equal(problem.Tags, want) {
+		t.Errorf("Tags = %q, want %q", problem.Tags, want)
+	}
+	if problem.Rating != 1900 {
+		t.Errorf("Rating = %v, want 1900", problem.Rating)
+	}
+}
+
+func TestParseProblemHTML_RatingWithoutTitle(t *testing.T) {
+	html := `<html><body>
+<div class="problem-statement"><div class="header"><div class="title">A. Untitled Rating</div></div></div>
+<span class="tag-box">math</span>
+<span class="tag-box">
+	*800
+</span>
+<span class="tag-box">math</span>
+</body></html>`
+
+	parser := NewParser(nil)
+	problem, err := parser.parseProblemHTML(strings.NewReader(html), 1, "A", "https://codeforces.com/contest/1/problem/A")
+	if err != nil {
+		t.Fatalf("parseProblemHTML() error = %v", err)
+	}
+	if problem.Rating != 800 {
+		t.Errorf("Rating = %v, want 800 from the *NNNN box", problem.Rating)
+	}
+	if len(problem.Tags) != 1 || problem.Tags[0] != "math" {
+		t.Errorf("Tags = %q, want [math] once", problem.Tags)
+	}
+}
+
+func TestParseProblemHTML_UnparsedSamples(t *testing.T) {
+	// A sample-tests container whose inner markup matches neither parsing path
+	html := `<html><body>
+<div class="problem-statement">
+	<div class="header"><div class="title">A. Changed Markup</div></div>
+	<div class="sample-tests">
+		<div class="sample-case">
+			<div class="sample-in"><code>1 2</code></div>
+			<div class="sample-out"><code>3</code></div>
+		</div>
+	</div>
+</div>
+</body></html>`
+
+	parser := NewParser(nil)
+	problem, err := parser.parseProblemHTML(strings.NewReader(html), 1325, "A", "https://codeforces.com/contest/1325/problem/A")
+	if err != nil {
+		t.Fatalf("parseProblemHTML() error = %v", err)
+	}
+	if len(problem.Samples) != 0 {
+		t.Fatalf("Samples = %d, want 0", len(problem.Samples))
+	}
+
+	err = problem.Validate()
+	if !errors.Is(err, ErrSamplesNotParsed) {
+		t.Fatalf("Validate() error = %v, want ErrSamplesNotParsed", err)
+	}
+	if !strings.Contains(err.Error(), "1325A") || !strings.Contains(err.Error(), CurrentVersion.Version) {
+		t.Errorf("Validate() error = %v, want problem ID and selector version", err)
+	}
+}
+
+func TestParsedProblem_Validate(t *testing.T) {
+	// No sample tests container at all, e.g. an interactive problem
+	if err := (&ParsedProblem{ContestID: 1, Index: "A"}).Validate(); err != nil {
+		t.Errorf("Validate() without samples container = %v, want nil", err)
+	}
+
+	parsed := &ParsedProblem{ContestID: 1, Index: "A", hasSampleTests: true, Samples: []Sample{{Index: 1}}}
+	if err := parsed.Validate(); err != nil {
+		t.Errorf("Validate() with samples = %v, want nil", err)
+	}
+}
+
+func TestProblemURL(t *testing.T) {
+	tests := []struct {
+		contestID int
+		index     string
+		want      string
+	}{
+		{1325, "A", "https://codeforces.com/contest/1325/problem/A"},
+		{99999, "B", "https://codeforces.com/contest/99999/problem/B"},
+		{100000, "A", "https://codeforces.com/gym/100000/problem/A"},
+		{102345, "C1", "https://codeforces.com/gym/102345/problem/C1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := problemURL(tt.contestID, tt.index); got != tt.want {
+				t.Errorf("problemURL(%d, %q) = %q, want %q", tt.contestID, tt.index, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package cfweb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ErrAlreadyRegistered is returned when registering for a contest the
+// session's handle is already registered for
+var ErrAlreadyRegistered = errors.New("already registered for the contest")
+
+// ErrRegistrationClosed is returned when a contest does not take
+// registrations, because it has not opened them yet or they are over
+var ErrRegistrationClosed = errors.New("registration for the contest is closed")
+
+// Page texts of the registration outcomes
+var (
+	alreadyRegisteredTexts  = []string{"You have already registered", "You are registered for the contest"}
+	registrationClosedTexts = []string{"Registration for the contest is closed", "registration is not opened", "Registration is closed"}
+)
+
+// Team is a team offered on a contest's registration page
+type Team struct {
+	ID   string
+	Name string
+}
+
+// RegisterForContest registers the session's handle for a contest, on its
+// own when team is empty and otherwise with the team of that ID or name
+func (s *Submitter) RegisterForContest(contestID int, team string) error {
+	registerURL := registrationURL(contestID)
+
+	page, err := s.registrationPage(registerURL)
+	if err != nil {
+		return err
+	}
+	if err := registrationOutcome(page); err != nil {
+		return err
+	}
+
+	csrfToken := extractCSRFToken(page)
+	if csrfToken == "" {
+		return fmt.Errorf("csrf token not found")
+	}
+
+	form := url.Values{}
+	form.Set("csrf_token", csrfToken)
+	form.Set("action", "formSubmitted")
+	form.Set("backUrl", "/contests")
+	form.Set("takePartAs", "personal")
+	if team != "" {
+		teamID, err := selectTeam(page, team)
+		if err != nil {
+			return err
+		}
+		form.Set("takePartAs", "team")
+		form.Set("teamId", teamID)
+	}
+	if ftaa := extractHiddenInput(page, "ftaa"); ftaa != "" {
+		form.Set("ftaa", ftaa)
+	}
+	if bfaa := extractHiddenInput(page, "bfaa"); bfaa != "" {
+		form.Set("bfaa", bfaa)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, registerURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("create registration request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", s.session.UserAgent())
+	req.Header.Set("Referer", registerURL)
+	req.Header.Set("Origin", BaseURL)
+
+	resp, err := s.session.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("register for contest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Registering redirects back to the contest list or the contest; a
+	// failed one shows the registration page again and an expired cookie
+	// lands on the login page
+	if onLoginPage(resp) {
+		return ErrNotLoggedIn
+	}
+	if resp.StatusCode == http.StatusOK && registeredPage(resp, contestID) {
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, MaxPageSize))
+	if err := registrationOutcome(string(respBody)); err != nil {
+		return err
+	}
+	if unavailableStatus(resp.StatusCode) {
+		return fmt.Errorf("registration failed (status %d): %w", resp.StatusCode, ErrTemporarilyUnavailable)
+	}
+	return fmt.Errorf("registration failed (status %d)", resp.StatusCode)
+}
+
+// IsRegistered reports whether the session's handle is registered for a
+// contest, read from its registration page. Unlike the API's standings,
+// the page answers for contests that have not started yet.
+func (s *Submitter) IsRegistered(contestID int) (bool, error) {
+	page, err := s.registrationPage(registrationURL(contestID))
+	if err != nil {
+		return false, err
+	}
+	return errors.Is(registrationOutcome(page), ErrAlreadyRegistered), nil
+}
+
+// registrationURL returns the registration page of a contest
+func registrationURL(contestID int) string {
+	return fmt.Sprintf("%s/contestRegistration/%d", BaseURL, contestID)
+}
+
+// registrationPage fetches a contest's registration page
+func (s *Submitter) registrationPage(registerURL string) (string, error) {
+	resp, err := s.get(registerURL)
+	if err != nil {
+		return "", fmt.Errorf("get registration page: %w", err)
+	}
+	defer resp.Body.Close()
+	if unavailableStatus(resp.StatusCode) {
+		return "", fmt.Errorf("get registration page: status %d: %w", resp.StatusCode, ErrTemporarilyUnavailable)
+	}
+	if onLoginPage(resp) {
+		return "", ErrNotLoggedIn
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxPageSize))
+	if err != nil {
+		return "", fmt.Errorf("read registration page: %w", err)
+	}
+	return string(body), nil
+}
+
+// registrationOutcome returns the typed error a registration page reports
+func registrationOutcome(page string) error {
+	for _, text := range alreadyRegisteredTexts {
+		if strings.Contains(page, text) {
+			return ErrAlreadyRegistered
+		}
+	}
+	for _, text := range registrationClosedTexts {
+		if strings.Contains(page, text) {
+			return ErrRegistrationClosed
+		}
+	}
+	return nil
+}
+
+// registeredPage reports whether a response landed where a successful
+// registration redirects: the contest list or the contest itself
+func registeredPage(resp *http.Response, contestID int) bool {
+	if resp.Request == nil {
+		return false
+	}
+	path := resp.Request.URL.Path
+	if path == "/contests" || strings.HasPrefix(path, "/contests/") {
+		return true
+	}
+	contestPath := fmt.Sprintf("/contest/%d", contestID)
+	return path == contestPath || strings.HasPrefix(path, contestPath+"/")
+}
+
+// parseTeams returns the teams of the registration form's team list
+func parseTeams(page string) ([]Team, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("parse registration page: %w", err)
+	}
+	var teams []Team
+	doc.Find("select[name='teamId'] option[value]").Each(func(_ int, opt *goquery.Selection) {
+		if id, _ := opt.Attr("value"); id != "" {
+			teams = append(teams, Team{ID: id, Name: strings.TrimSpace(opt.Text())})
+		}
+	})
+	return teams, nil
+}
+
+// selectTeam returns the ID of the offered team whose ID or name matches
+// want, listing the offered teams when none does
+func selectTeam(page, want string) (string, error) {
+	teams, err := parseTeams(page)
+	if err != nil {
+		return "", err
+	}
+	if len(teams) == 0 {
+		return "", fmt.Errorf("no team to register with; create one on Codeforces or register individually")
+	}
+	for _, t := range teams {
+		if t.ID == want || strings.EqualFold(t.Name, want) {
+			return t.ID, nil
+		}
+	}
+	names := make([]string, len(teams))
+	for i, t := range teams {
+		names[i] = fmt.Sprintf("%s (%s)", t.Name, t.ID)
+	}
+	return "", fmt.Errorf("no team %q on the registration page; choose one of: %s", want, strings.Join(names, ", "))
+}
@@ -284,3 +284,48 @@ func TestPythonLanguagesExist(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantID string
+	}{
+		{"cpp20", "cpp20"},
+		{"C++20", "cpp20"},
+		{"c++ 17", "cpp17"},
+		{"Python 3", "python3"},
+		{"PyPy3", "pypy3"},
+		{"GNU G++20 11.2.0 (64 bit)", "cpp20"},
+		{"89", "cpp20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, err := ResolveLanguage(tt.name)
+			if err != nil {
+				t.Fatalf("ResolveLanguage(%q) error = %v", tt.name, err)
+			}
+			if lang.ID != tt.wantID {
+				t.Errorf("ResolveLanguage(%q).ID = %s, want %s", tt.name, lang.ID, tt.wantID)
+			}
+		})
+	}
+
+	for _, name := range []string{"", "C++98", "9999"} {
+		if _, err := ResolveLanguage(name); err == nil {
+			t.Errorf("ResolveLanguage(%q) should fail", name)
+		}
+	}
+}
+
+func TestLanguagesForExtension(t *testing.T) {
+	if got := LanguagesForExtension(".cpp"); len(got) != 3 {
+		t.Errorf("LanguagesForExtension(.cpp) = %d languages, want 3", len(got))
+	}
+	if got := LanguagesForExtension(".GO"); len(got) != 1 || got[0].ID != "go" {
+		t.Errorf("LanguagesForExtension(.GO) = %v, want go", got)
+	}
+	if got := LanguagesForExtension(".txt"); len(got) != 0 {
+		t.Errorf("LanguagesForExtension(.txt) = %v, want none", got)
+	}
+}
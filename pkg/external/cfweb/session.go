@@ -1,6 +1,7 @@
 package cfweb
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -28,16 +29,45 @@ const (
 	MaxPageSize = 5 * 1024 * 1024 // 5MB max page size to prevent OOM
 )
 
+// minGymContestID is the lowest contest ID used by gym contests, the same
+// threshold as cfapi.MinGymContestID
+const minGymContestID = 100000
+
+// ErrNotLoggedIn is returned when Codeforces sends a request to its login
+// page, because the session's cookie is missing or has expired
+var ErrNotLoggedIn = errors.New("not logged in to codeforces; refresh your browser cookie with 'cf setup'")
+
+// loginPath is the path of the Codeforces login page
+const loginPath = "/enter"
+
+// onLoginPage reports whether a response ended on the login page
+func onLoginPage(resp *http.Response) bool {
+	return resp.Request != nil && resp.Request.URL.Path == loginPath
+}
+
 // Session manages CF web authentication using browser cookies
 type Session struct {
 	client    *http.Client
 	jar       *cookiejar.Jar
 	csrfToken string
 	handle    string
+	userAgent string // Empty uses the default UserAgent
+}
+
+// SessionOption configures a session
+type SessionOption func(*Session)
+
+// WithUserAgent sets the User-Agent sent with every request
+// Cloudflare ties cf_clearance to the browser it was issued to, so this
+// should be that browser's User-Agent. An empty ua keeps the default.
+func WithUserAgent(ua string) SessionOption {
+	return func(s *Session) {
+		s.userAgent = ua
+	}
 }
 
 // NewSession creates a new CF session
-func NewSession() (*Session, error) {
+func NewSession(opts ...SessionOption) (*Session, error) {
 	jar, err := cookiejar.New(&cookiejar.Options{
 		PublicSuffixList: publicsuffix.List,
 	})
@@ -50,22 +80,27 @@ func NewSession() (*Session, error) {
 		Timeout: 30 * time.Second,
 	}
 
-	return &Session{
+	s := &Session{
 		client: client,
 		jar:    jar,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 // NewSessionWithCookie creates a session with the provided cookie string
 // Cookie format: "JSESSIONID=xxx; 39ce7=xxx; cf_clearance=xxx; ..."
-func NewSessionWithCookie(cookieStr string) (*Session, error) {
-	session, err := NewSession()
+// The string is cleaned up with SanitizeCookie first.
+func NewSessionWithCookie(cookieStr string, opts ...SessionOption) (*Session, error) {
+	session, err := NewSession(opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	if cookieStr != "" {
-		session.SetCookie(cookieStr)
+	if cookie := SanitizeCookie(cookieStr); cookie != "" {
+		session.SetCookie(cookie)
 	}
 
 	return session, nil
@@ -118,6 +153,14 @@ func (s *Session) SetCookie(cookieStr string) {
 	}
 }
 
+// UserAgent returns the User-Agent sent with requests
+func (s *Session) UserAgent() string {
+	if s.userAgent != "" {
+		return s.userAgent
+	}
+	return UserAgent
+}
+
 // SetHandle sets the user handle
 func (s *Session) SetHandle(handle string) {
 	s.handle = handle
@@ -151,7 +194,7 @@ func (s *Session) get(urlStr string) (*http.Response, error) {
 		return nil, err
 	}
 
-	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("User-Agent", s.UserAgent())
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
 	req.Header.Set("Accept-Language", "en-US,en;q=0.5")
 
@@ -1,8 +1,11 @@
 package cfweb
 
 import (
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -20,9 +23,52 @@ var (
 	reMemoryMB = regexp.MustCompile(`(\d+)\s*MB`)
 )
 
+// ErrSubmissionForbidden is returned when the source of a submission is not
+// visible to the session, e.g. someone else's submission during a contest
+var ErrSubmissionForbidden = errors.New("submission source not available to your account")
+
+// ErrDuplicateSource is returned when the source matches the latest submission to the problem
+var ErrDuplicateSource = errors.New("duplicate submission: source is identical to your latest submission")
+
+// ErrTemporarilyUnavailable is returned when Codeforces or Cloudflare in
+// front of it answers with a server error or rate limit; trying again later
+// may succeed
+var ErrTemporarilyUnavailable = errors.New("codeforces is temporarily unavailable")
+
+// notSentError marks a failure raised before the submission was posted,
+// so Codeforces has certainly not received it
+type notSentError struct {
+	err error
+}
+
+func (e *notSentError) Error() string { return e.err.Error() }
+func (e *notSentError) Unwrap() error { return e.err }
+
+// IsTransient returns true if err is a network failure or a temporary
+// server error raised before the submission was posted, so it can be
+// retried unchanged later. Failures of the post itself or of reading the
+// result back are not: the submission may have gone through.
+func IsTransient(err error) bool {
+	var notSent *notSentError
+	if !errors.As(err, &notSent) {
+		return false
+	}
+	if errors.Is(err, ErrTemporarilyUnavailable) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// unavailableStatus returns true for statuses worth retrying later
+func unavailableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
 // Submitter handles solution submission to CF
 type Submitter struct {
-	session *Session
+	session         *Session
+	checkDuplicates bool
 }
 
 // NewSubmitter creates a new submitter with an authenticated session
@@ -34,6 +80,12 @@ func NewSubmitter(session *Session) (*Submitter, error) {
 	return &Submitter{session: session}, nil
 }
 
+// SetCheckDuplicates enables comparing the source with the latest submission before posting
+// Disabled by default since users sometimes resubmit on purpose
+func (s *Submitter) SetCheckDuplicates(enabled bool) {
+	s.checkDuplicates = enabled
+}
+
 // SubmissionResult contains the result of a submission
 type SubmissionResult struct {
 	SubmissionID int64
@@ -45,24 +97,43 @@ type SubmissionResult struct {
 	PassedTests  int
 	SubmittedAt  time.Time
 	Status       string
+	Outcome      Verdict // parsed verdict; Verdict and Status keep the raw text for display
+}
+
+// IsFinal returns true if the submission has finished judging
+func (r *SubmissionResult) IsFinal() bool {
+	return r.Outcome.IsFinal()
 }
 
 // Submit submits a solution to a problem
+// Gym contest IDs are submitted with SubmitToGym.
 func (s *Submitter) Submit(contestID int, problemIndex string, langID int, sourceCode string) (*SubmissionResult, error) {
+	if contestID >= minGymContestID {
+		return s.SubmitToGym(contestID, problemIndex, langID, sourceCode)
+	}
+	if s.checkDuplicates {
+		if err := s.checkDuplicateSource(contestID, problemIndex, sourceCode); err != nil {
+			return nil, err
+		}
+	}
+
 	// Construct submit URL
 	submitURL := fmt.Sprintf("%s/contest/%d/submit", BaseURL, contestID)
 
 	// Get the submit page first to extract CSRF token
 	resp, err := s.get(submitURL)
 	if err != nil {
-		return nil, fmt.Errorf("get submit page: %w", err)
+		return nil, &notSentError{fmt.Errorf("get submit page: %w", err)}
 	}
 	defer resp.Body.Close()
+	if unavailableStatus(resp.StatusCode) {
+		return nil, &notSentError{fmt.Errorf("get submit page: status %d: %w", resp.StatusCode, ErrTemporarilyUnavailable)}
+	}
 
 	// Use bounded reader to prevent OOM from large responses
 	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxPageSize))
 	if err != nil {
-		return nil, fmt.Errorf("read submit page: %w", err)
+		return nil, &notSentError{fmt.Errorf("read submit page: %w", err)}
 	}
 
 	// Extract CSRF token
@@ -98,7 +169,7 @@ func (s *Submitter) Submit(contestID int, problemIndex string, langID int, sourc
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("User-Agent", s.session.UserAgent())
 	req.Header.Set("Referer", submitURL)
 	req.Header.Set("Origin", BaseURL)
 
@@ -140,24 +211,36 @@ func (s *Submitter) Submit(contestID int, problemIndex string, langID int, sourc
 		return s.getLatestSubmission(contestID, problemIndex)
 	}
 
+	if unavailableStatus(resp.StatusCode) {
+		return nil, fmt.Errorf("submission failed (status %d): %w", resp.StatusCode, ErrTemporarilyUnavailable)
+	}
 	return nil, fmt.Errorf("submission failed (status %d)", resp.StatusCode)
 }
 
 // SubmitToGym submits a solution to a gym problem
 func (s *Submitter) SubmitToGym(gymID int, problemIndex string, langID int, sourceCode string) (*SubmissionResult, error) {
+	if s.checkDuplicates {
+		if err := s.checkDuplicateSource(gymID, problemIndex, sourceCode); err != nil {
+			return nil, err
+		}
+	}
+
 	submitURL := fmt.Sprintf("%s/gym/%d/submit", BaseURL, gymID)
 
 	// Similar logic to Submit, but for gym
 	resp, err := s.get(submitURL)
 	if err != nil {
-		return nil, fmt.Errorf("get gym submit page: %w", err)
+		return nil, &notSentError{fmt.Errorf("get gym submit page: %w", err)}
 	}
 	defer resp.Body.Close()
+	if unavailableStatus(resp.StatusCode) {
+		return nil, &notSentError{fmt.Errorf("get gym submit page: status %d: %w", resp.StatusCode, ErrTemporarilyUnavailable)}
+	}
 
 	// Use bounded reader to prevent OOM from large responses
 	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxPageSize))
 	if err != nil {
-		return nil, fmt.Errorf("read gym submit page: %w", err)
+		return nil, &notSentError{fmt.Errorf("read gym submit page: %w", err)}
 	}
 
 	csrfToken := extractCSRFToken(string(body))
@@ -188,7 +271,7 @@ func (s *Submitter) SubmitToGym(gymID int, problemIndex string, langID int, sour
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("User-Agent", s.session.UserAgent())
 	req.Header.Set("Referer", submitURL)
 	req.Header.Set("Origin", BaseURL)
 
@@ -202,6 +285,9 @@ func (s *Submitter) SubmitToGym(gymID int, problemIndex string, langID int, sour
 		return s.getLatestGymSubmission(gymID, problemIndex)
 	}
 
+	if unavailableStatus(resp.StatusCode) {
+		return nil, fmt.Errorf("gym submission failed (status %d): %w", resp.StatusCode, ErrTemporarilyUnavailable)
+	}
 	return nil, fmt.Errorf("gym submission failed (status %d)", resp.StatusCode)
 }
 
@@ -229,6 +315,128 @@ func (s *Submitter) getLatestSubmission(contestID int, problemIndex string) (*Su
 	return parseSubmissionRow(row, contestID)
 }
 
+// getLatestProblemSubmission fetches the latest submission to a specific problem
+func (s *Submitter) getLatestProblemSubmission(contestID int, problemIndex string) (*SubmissionResult, error) {
+	myURL := fmt.Sprintf("%s/%s/%d/my", BaseURL, contestKind(contestID), contestID)
+
+	resp, err := s.get(myURL)
+	if err != nil {
+		return nil, fmt.Errorf("get my submissions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse submissions page: %w", err)
+	}
+
+	var latest *SubmissionResult
+	doc.Find("table.status-frame-datatable tr[data-submission-id]").EachWithBreak(func(i int, row *goquery.Selection) bool {
+		result, err := parseSubmissionRow(row, contestID)
+		if err == nil && result.ProblemIndex == problemIndex {
+			latest = result
+			return false
+		}
+		return true
+	})
+
+	if latest == nil {
+		return nil, fmt.Errorf("no submissions found for problem %s", problemIndex)
+	}
+	return latest, nil
+}
+
+// contestKind returns the URL segment of a contest ID: "contest" or "gym"
+func contestKind(contestID int) string {
+	if contestID >= minGymContestID {
+		return "gym"
+	}
+	return "contest"
+}
+
+// submissionURL returns the page of a submission in its contest or gym
+func submissionURL(contestID int, submissionID int64) string {
+	return fmt.Sprintf("%s/%s/%d/submission/%d", BaseURL, contestKind(contestID), contestID, submissionID)
+}
+
+// GetSubmissionSource fetches the source code of a submission
+// Codeforces only shows sources to their author, and to everyone once the
+// contest is over; other pages come back as ErrSubmissionForbidden.
+func (s *Submitter) GetSubmissionSource(submissionID int64, contestID int) (string, error) {
+	sourceURL := submissionURL(contestID, submissionID)
+
+	resp, err := s.get(sourceURL)
+	if err != nil {
+		return "", fmt.Errorf("get submission source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden, http.StatusNotFound:
+		return "", fmt.Errorf("submission %d: %w", submissionID, ErrSubmissionForbidden)
+	default:
+		return "", fmt.Errorf("get submission source: status %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, MaxPageSize))
+	if err != nil {
+		return "", fmt.Errorf("parse submission page: %w", err)
+	}
+
+	// Hidden sources redirect to the contest page instead of failing
+	source := doc.Find("#program-source-text").First()
+	if source.Length() == 0 {
+		return "", fmt.Errorf("submission %d: %w", submissionID, ErrSubmissionForbidden)
+	}
+	return extractSourceText(source), nil
+}
+
+// extractSourceText returns the code of a source <pre>, with <br> tags as
+// newlines like extractPreContent but keeping all indentation
+func extractSourceText(sel *goquery.Selection) string {
+	html, _ := sel.Html()
+	html = strings.ReplaceAll(html, "<br/>", "\n")
+	html = strings.ReplaceAll(html, "<br>", "\n")
+
+	text := sel.Text()
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(html)); err == nil {
+		text = doc.Text()
+	}
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.TrimRight(text, "\n") + "\n"
+}
+
+// checkDuplicateSource returns ErrDuplicateSource if the latest submission has the same source
+// Lookup failures are ignored so that they never block a submission
+func (s *Submitter) checkDuplicateSource(contestID int, problemIndex, sourceCode string) error {
+	latest, err := s.getLatestProblemSubmission(contestID, problemIndex)
+	if err != nil {
+		return nil
+	}
+
+	previous, err := s.GetSubmissionSource(latest.SubmissionID, contestID)
+	if err != nil {
+		return nil
+	}
+
+	if sourceHash(previous) == sourceHash(sourceCode) {
+		return fmt.Errorf("%w (submission %d)", ErrDuplicateSource, latest.SubmissionID)
+	}
+	return nil
+}
+
+// sourceHash hashes source code ignoring line endings and trailing whitespace
+func sourceHash(source string) [sha256.Size]byte {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	normalized := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	return sha256.Sum256([]byte(normalized))
+}
+
 // getLatestGymSubmission fetches the latest gym submission
 func (s *Submitter) getLatestGymSubmission(gymID int, problemIndex string) (*SubmissionResult, error) {
 	myURL := fmt.Sprintf("%s/gym/%d/my", BaseURL, gymID)
@@ -262,8 +470,7 @@ func (s *Submitter) WaitForVerdict(submissionID int64, contestID int, timeout ti
 			return nil, err
 		}
 
-		// Check if judging is complete
-		if result.Status != "In queue" && result.Status != "Running" {
+		if result.IsFinal() {
 			return result, nil
 		}
 
@@ -275,7 +482,7 @@ func (s *Submitter) WaitForVerdict(submissionID int64, contestID int, timeout ti
 
 // GetSubmission gets a specific submission's status
 func (s *Submitter) GetSubmission(submissionID int64, contestID int) (*SubmissionResult, error) {
-	statusURL := fmt.Sprintf("%s/contest/%d/submission/%d", BaseURL, contestID, submissionID)
+	statusURL := submissionURL(contestID, submissionID)
 
 	resp, err := s.get(statusURL)
 	if err != nil {
@@ -297,6 +504,7 @@ func (s *Submitter) GetSubmission(submissionID int64, contestID int) (*Submissio
 		ContestID:    contestID,
 		Verdict:      verdict,
 		SubmittedAt:  time.Now(),
+		Outcome:      ParseVerdict(verdict),
 	}
 
 	// Parse time and memory from the info table
@@ -332,7 +540,7 @@ func (s *Submitter) get(url string) (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("User-Agent", s.session.UserAgent())
 	return s.session.client.Do(req)
 }
 
@@ -375,6 +583,7 @@ func parseSubmissionRow(row *goquery.Selection, contestID int) (*SubmissionResul
 		Time:         parseTime(timeText),
 		Memory:       parseMemory(memoryText),
 		SubmittedAt:  time.Now(),
+		Outcome:      ParseVerdict(verdict),
 	}
 
 	// Determine status
@@ -1,6 +1,7 @@
 package cfweb
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -322,3 +323,52 @@ func TestParseSubmissionRow_Running(t *testing.T) {
 	}
 }
 
+
+func TestSubmitter_GetSubmissionSource(t *testing.T) {
+	page := `<html><body><pre id="program-source-text" class="prettyprint">#include &lt;bits/stdc++.h&gt;
+int main() {
+    if (1 &amp;&amp; 2) puts(&quot;ok&quot;);
+}
+</pre></body></html>`
+	session := createMockSession(&mockTransport{statusCode: 200, body: page})
+	submitter := &Submitter{session: session}
+
+	source, err := submitter.GetSubmissionSource(123456, 1325)
+	if err != nil {
+		t.Fatalf("GetSubmissionSource() error = %v", err)
+	}
+	want := "#include <bits/stdc++.h>\nint main() {\n    if (1 && 2) puts(\"ok\");\n}\n"
+	if source != want {
+		t.Errorf("GetSubmissionSource() = %q, want %q", source, want)
+	}
+}
+
+func TestSubmitter_GetSubmissionSource_Forbidden(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+	}{
+		{"redirected to contest page", 200, `<html><body><div class="datatable">Problems</div></body></html>`},
+		{"forbidden status", 403, "Forbidden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := createMockSession(&mockTransport{statusCode: tt.statusCode, body: tt.body})
+			_, err := (&Submitter{session: session}).GetSubmissionSource(123456, 1325)
+			if !errors.Is(err, ErrSubmissionForbidden) {
+				t.Errorf("GetSubmissionSource() error = %v, want ErrSubmissionForbidden", err)
+			}
+		})
+	}
+}
+
+func TestSubmissionURL(t *testing.T) {
+	if got, want := submissionURL(1325, 42), "https://codeforces.com/contest/1325/submission/42"; got != want {
+		t.Errorf("submissionURL() = %q, want %q", got, want)
+	}
+	if got, want := submissionURL(100001, 42), "https://codeforces.com/gym/100001/submission/42"; got != want {
+		t.Errorf("submissionURL() gym = %q, want %q", got, want)
+	}
+}
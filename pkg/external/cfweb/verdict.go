@@ -0,0 +1,88 @@
+package cfweb
+
+import "strings"
+
+// Verdict is the judging state of a submission as shown on Codeforces
+type Verdict int
+
+// Verdict values
+const (
+	VerdictUnknown Verdict = iota
+	VerdictInQueue
+	VerdictRunning
+	VerdictAccepted
+	VerdictWrongAnswer
+	VerdictTimeLimitExceeded
+	VerdictMemoryLimitExceeded
+	VerdictRuntimeError
+	VerdictCompilationError
+	VerdictPresentationError
+	VerdictIdlenessLimitExceeded
+	VerdictChallenged
+	VerdictSkipped
+)
+
+var verdictNames = map[Verdict]string{
+	VerdictUnknown:               "UNKNOWN",
+	VerdictInQueue:               "IN_QUEUE",
+	VerdictRunning:               "TESTING",
+	VerdictAccepted:              "OK",
+	VerdictWrongAnswer:           "WRONG_ANSWER",
+	VerdictTimeLimitExceeded:     "TIME_LIMIT_EXCEEDED",
+	VerdictMemoryLimitExceeded:   "MEMORY_LIMIT_EXCEEDED",
+	VerdictRuntimeError:          "RUNTIME_ERROR",
+	VerdictCompilationError:      "COMPILATION_ERROR",
+	VerdictPresentationError:     "PRESENTATION_ERROR",
+	VerdictIdlenessLimitExceeded: "IDLENESS_LIMIT_EXCEEDED",
+	VerdictChallenged:            "CHALLENGED",
+	VerdictSkipped:               "SKIPPED",
+}
+
+// String returns the API-style name of the verdict (e.g. "WRONG_ANSWER")
+func (v Verdict) String() string {
+	if name, ok := verdictNames[v]; ok {
+		return name
+	}
+	return verdictNames[VerdictUnknown]
+}
+
+// IsFinal returns true if judging has finished
+// Unrecognized verdicts are treated as final so callers don't poll forever
+func (v Verdict) IsFinal() bool {
+	return v != VerdictInQueue && v != VerdictRunning
+}
+
+// ParseVerdict converts the verdict text from a Codeforces page
+// (e.g. "Wrong answer on test 3") into a Verdict
+func ParseVerdict(text string) Verdict {
+	text = strings.TrimSpace(text)
+
+	switch {
+	case text == "" || strings.Contains(text, "queue"):
+		return VerdictInQueue
+	case strings.Contains(text, "Running"), strings.Contains(text, "Pending"):
+		return VerdictRunning
+	case strings.Contains(text, "Accepted"):
+		return VerdictAccepted
+	case strings.Contains(text, "Wrong answer"):
+		return VerdictWrongAnswer
+	case strings.Contains(text, "Time limit"):
+		return VerdictTimeLimitExceeded
+	case strings.Contains(text, "Memory limit"):
+		return VerdictMemoryLimitExceeded
+	case strings.Contains(text, "Runtime error"):
+		return VerdictRuntimeError
+	case strings.Contains(text, "Compilation error"):
+		return VerdictCompilationError
+	case strings.Contains(text, "Presentation error"):
+		return VerdictPresentationError
+	case strings.Contains(text, "Idleness"):
+		return VerdictIdlenessLimitExceeded
+	case strings.Contains(text, "Hacked"):
+		return VerdictChallenged
+	case strings.Contains(text, "Skipped"):
+		return VerdictSkipped
+	default:
+		return VerdictUnknown
+	}
+}
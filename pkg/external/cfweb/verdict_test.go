@@ -0,0 +1,69 @@
+package cfweb
+
+import "testing"
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Verdict
+	}{
+		{"empty", "", VerdictInQueue},
+		{"in queue", "In queue", VerdictInQueue},
+		{"running", "Running on test 5", VerdictRunning},
+		{"pending", "Pending judgement", VerdictRunning},
+		{"accepted", "  Accepted  ", VerdictAccepted},
+		{"wrong answer", "Wrong answer on test 3", VerdictWrongAnswer},
+		{"time limit", "Time limit exceeded on test 2", VerdictTimeLimitExceeded},
+		{"memory limit", "Memory limit exceeded on test 1", VerdictMemoryLimitExceeded},
+		{"runtime error", "Runtime error on test 4", VerdictRuntimeError},
+		{"compilation error", "Compilation error", VerdictCompilationError},
+		{"presentation error", "Presentation error on test 1", VerdictPresentationError},
+		{"idleness", "Idleness limit exceeded on test 7", VerdictIdlenessLimitExceeded},
+		{"hacked", "Hacked", VerdictChallenged},
+		{"skipped", "Skipped", VerdictSkipped},
+		{"unknown", "Denial of judgement", VerdictUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseVerdict(tt.input); got != tt.want {
+				t.Errorf("ParseVerdict(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerdict_IsFinal(t *testing.T) {
+	tests := []struct {
+		verdict Verdict
+		want    bool
+	}{
+		{VerdictInQueue, false},
+		{VerdictRunning, false},
+		{VerdictAccepted, true},
+		{VerdictWrongAnswer, true},
+		{VerdictCompilationError, true},
+		{VerdictUnknown, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.verdict.String(), func(t *testing.T) {
+			if got := tt.verdict.IsFinal(); got != tt.want {
+				t.Errorf("%v.IsFinal() = %v, want %v", tt.verdict, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerdict_String(t *testing.T) {
+	if got := VerdictAccepted.String(); got != "OK" {
+		t.Errorf("VerdictAccepted.String() = %q, want %q", got, "OK")
+	}
+	if got := VerdictWrongAnswer.String(); got != "WRONG_ANSWER" {
+		t.Errorf("VerdictWrongAnswer.String() = %q, want %q", got, "WRONG_ANSWER")
+	}
+	if got := Verdict(99).String(); got != "UNKNOWN" {
+		t.Errorf("Verdict(99).String() = %q, want %q", got, "UNKNOWN")
+	}
+}
@@ -3,6 +3,7 @@ package health
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,14 +13,18 @@ import (
 	"github.com/harshit-vibes/cf/pkg/internal/health"
 )
 
+// DefaultPingAttempts is how many times the API check pings before giving up
+const DefaultPingAttempts = 3
+
 // CFAPICheck checks the Codeforces API availability
 type CFAPICheck struct {
-	client *cfapi.Client
+	client   *cfapi.Client
+	attempts int
 }
 
 // NewCFAPICheck creates a new CF API check
 func NewCFAPICheck(client *cfapi.Client) *CFAPICheck {
-	return &CFAPICheck{client: client}
+	return &CFAPICheck{client: client, attempts: DefaultPingAttempts}
 }
 
 func (c *CFAPICheck) Name() string     { return "CF API" }
@@ -38,8 +43,19 @@ func (c *CFAPICheck) Check(ctx context.Context) health.Result {
 		}
 	}
 
-	// Try to ping the API
-	err := c.client.Ping(ctx)
+	// Try to ping the API, tolerating one-off network hiccups
+	err := c.client.PingWithRetries(ctx, c.attempts)
+	if errors.Is(err, cfapi.ErrServerMaintenance) {
+		return health.Result{
+			Name:     c.Name(),
+			Category: c.Category(),
+			Status:   health.StatusDegraded,
+			Message:  "Codeforces appears to be under maintenance",
+			Details:  err.Error(),
+			Action:   health.ActionRetry,
+			Duration: time.Since(start),
+		}
+	}
 	if err != nil {
 		return health.Result{
 			Name:     c.Name(),
@@ -2,7 +2,9 @@ package health
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -207,3 +209,26 @@ func TestFormatRating_Negative(t *testing.T) {
 		t.Errorf("formatRating(-100) = %v, want '-100'", result)
 	}
 }
+
+func TestCFAPICheck_Check_Maintenance(t *testing.T) {
+	httpClient := &http.Client{
+		Transport: &mockTransport{
+			response: &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("<html><body>Codeforces is temporarily unavailable</body></html>")),
+				Header:     make(http.Header),
+			},
+		},
+	}
+	client := cfapi.NewClient(cfapi.WithHTTPClient(httpClient))
+	check := NewCFAPICheck(client)
+
+	result := check.Check(context.Background())
+
+	if result.Status != health.StatusDegraded {
+		t.Errorf("Status = %v, want %v", result.Status, health.StatusDegraded)
+	}
+	if result.Message != "Codeforces appears to be under maintenance" {
+		t.Errorf("Message = %v, want the maintenance message", result.Message)
+	}
+}
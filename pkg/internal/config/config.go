@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -16,12 +17,36 @@ type Config struct {
 	CFHandle string `mapstructure:"cf_handle"`
 	Cookie   string `mapstructure:"cookie"` // Browser cookie string for CF session
 
+	// User-Agent of the browser the cookie came from; Cloudflare only
+	// accepts cf_clearance from the User-Agent it was issued to
+	CFClearanceUA string `mapstructure:"cf_clearance_ua"`
+
+	// When cf_clearance expires, as RFC 3339; empty if unknown
+	CFClearanceExpires string `mapstructure:"cf_clearance_expires"`
+
+	// API key from codeforces.com/settings/api, used to sign API requests
+	APIKey    string `mapstructure:"api_key"`
+	APISecret string `mapstructure:"api_secret"`
+
 	// Practice settings
 	Difficulty DifficultyRange `mapstructure:"difficulty"`
 	DailyGoal  int             `mapstructure:"daily_goal"`
 
+	// Language ID from cfweb.SupportedLanguages that cf submit uses when the
+	// solution's extension doesn't settle the language, e.g. cpp20
+	DefaultLanguage string `mapstructure:"default_language"`
+
+	// Build and run commands for cf test per language (c, cpp, go, rust,
+	// py, ...), merged field by field into runner.DefaultCommands
+	Compilers map[string]Compiler `mapstructure:"compilers"`
+
+	// Tag shorthands for --tag, e.g. nt: number theory, on top of the
+	// built-in ones
+	TagAliases map[string]string `mapstructure:"tag_aliases"`
+
 	// Paths
 	WorkspacePath string `mapstructure:"workspace_path"`
+	CacheDir      string `mapstructure:"cache_dir"`
 }
 
 // DifficultyRange represents min/max difficulty
@@ -53,6 +78,15 @@ func configFilePath() (string, error) {
 	return filepath.Join(dir, "config.yaml"), nil
 }
 
+// KeysFilePath returns the path of the TUI key bindings override file
+func KeysFilePath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "keys.yaml"), nil
+}
+
 // Init initializes the configuration
 func Init(workspacePath string) error {
 	configMu.Lock()
@@ -72,14 +106,18 @@ func Init(workspacePath string) error {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath(dir)
+	viper.SetConfigPermissions(configFileMode)
 
 	// Set defaults
 	viper.SetDefault("cf_handle", "")
 	viper.SetDefault("cookie", "")
+	viper.SetDefault("api_key", "")
+	viper.SetDefault("api_secret", "")
 	viper.SetDefault("difficulty.min", 800)
 	viper.SetDefault("difficulty.max", 1400)
 	viper.SetDefault("daily_goal", 3)
 	viper.SetDefault("workspace_path", "")
+	viper.SetDefault("cache_dir", "")
 
 	// Try to read existing config
 	if err := viper.ReadInConfig(); err != nil {
@@ -123,13 +161,30 @@ func GetCFHandle() string {
 	return cfg.CFHandle
 }
 
+// configFileMode keeps the config file private to its owner, since it
+// holds the API secret and the browser cookie
+const configFileMode = 0600
+
+// writeConfig saves the config, first making a config file created with
+// looser permissions private
+func writeConfig() error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(path, configFileMode); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return viper.WriteConfig()
+}
+
 // Set updates a configuration value
 func Set(key string, value interface{}) error {
 	configMu.Lock()
 	defer configMu.Unlock()
 
 	viper.Set(key, value)
-	if err := viper.WriteConfig(); err != nil {
+	if err := writeConfig(); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
@@ -141,13 +196,143 @@ func Set(key string, value interface{}) error {
 	return nil
 }
 
+// Credentials are the account settings written by SaveCredentials
+type Credentials struct {
+	Handle        string
+	APIKey        string
+	APISecret     string
+	Cookie        string
+	CFClearanceUA string
+
+	// CFClearanceExpires is when the cookie's cf_clearance expires, zero if unknown
+	CFClearanceExpires time.Time
+}
+
+// SaveCredentials writes the non-empty credentials in a single config update
+// The cookie is stored as given, so callers clean it up first. A known
+// cf_clearance expiry is saved with it; a new cf_clearance without one
+// clears the expiry of the old.
+func SaveCredentials(creds Credentials) error {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if !creds.CFClearanceExpires.IsZero() {
+		viper.Set("cf_clearance_expires", creds.CFClearanceExpires.UTC().Format(time.RFC3339))
+	} else if creds.Cookie != "" && globalConfig != nil &&
+		CookieValue(creds.Cookie, CookieCFClearance) != CookieValue(globalConfig.Cookie, CookieCFClearance) {
+		viper.Set("cf_clearance_expires", "")
+	}
+
+	for key, value := range map[string]string{
+		"cf_handle":       creds.Handle,
+		"api_key":         creds.APIKey,
+		"api_secret":      creds.APISecret,
+		"cookie":          creds.Cookie,
+		"cf_clearance_ua": creds.CFClearanceUA,
+	} {
+		if value != "" {
+			viper.Set(key, value)
+		}
+	}
+
+	if err := writeConfig(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if globalConfig == nil {
+		globalConfig = &Config{}
+	}
+	if err := viper.Unmarshal(globalConfig); err != nil {
+		return fmt.Errorf("failed to reload config: %w", err)
+	}
+
+	return nil
+}
+
+// GetAPICredentials returns the configured API key and secret
+func GetAPICredentials() (key, secret string) {
+	cfg := Get()
+	if cfg == nil {
+		return "", ""
+	}
+	return cfg.APIKey, cfg.APISecret
+}
+
+// HasAPIKey returns true if both an API key and secret are configured
+func HasAPIKey() bool {
+	key, secret := GetAPICredentials()
+	return key != "" && secret != ""
+}
+
+// Compiler is the configured build and run command templates of a
+// language, as runner.Command takes them; either may be left empty
+type Compiler struct {
+	Compile string `mapstructure:"compile"`
+	Run     string `mapstructure:"run"`
+}
+
+// GetCompilers returns the configured build and run commands per language
+// They are not validated here; cf test and the health checks do that.
+func GetCompilers() map[string]Compiler {
+	cfg := Get()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Compilers
+}
+
+// GetTagAliases returns the configured tag shorthands
+func GetTagAliases() map[string]string {
+	cfg := Get()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.TagAliases
+}
+
+// GetDefaultLanguage returns the configured submission language ID, or ""
+func GetDefaultLanguage() string {
+	cfg := Get()
+	if cfg == nil {
+		return ""
+	}
+	return cfg.DefaultLanguage
+}
+
+// SetDefaultLanguage sets the submission language ID, as resolved by
+// cfweb.ResolveLanguage
+func SetDefaultLanguage(id string) error {
+	return Set("default_language", id)
+}
+
 // SetCFHandle sets the CF handle
 func SetCFHandle(handle string) error {
 	return Set("cf_handle", handle)
 }
 
+// Bounds of the difficulty range, the span of Codeforces problem ratings
+const (
+	MinDifficulty = 800
+	MaxDifficulty = 3500
+)
+
+// ValidateDifficulty checks that min <= max and both are within
+// MinDifficulty and MaxDifficulty
+func ValidateDifficulty(min, max int) error {
+	if min < MinDifficulty || max > MaxDifficulty {
+		return fmt.Errorf("difficulty must be between %d and %d, got %d-%d", MinDifficulty, MaxDifficulty, min, max)
+	}
+	if min > max {
+		return fmt.Errorf("difficulty min %d is above max %d", min, max)
+	}
+	return nil
+}
+
 // SetDifficulty sets the difficulty range
 func SetDifficulty(min, max int) error {
+	if err := ValidateDifficulty(min, max); err != nil {
+		return err
+	}
 	if err := Set("difficulty.min", min); err != nil {
 		return err
 	}
@@ -179,6 +364,33 @@ func GetWorkspacePath() string {
 	return cfg.WorkspacePath
 }
 
+// SetCacheDir sets the cache directory
+func SetCacheDir(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+	return Set("cache_dir", absPath)
+}
+
+// GetCacheDir returns the directory for cached API responses and parsed problems
+// Defaults to $XDG_CACHE_HOME/cf, or ~/.cache/cf if XDG_CACHE_HOME is unset
+func GetCacheDir() (string, error) {
+	if cfg := Get(); cfg != nil && cfg.CacheDir != "" {
+		return cfg.CacheDir, nil
+	}
+
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "cf"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home dir: %w", err)
+	}
+	return filepath.Join(home, ".cache", "cf"), nil
+}
+
 // GetCookie returns the configured cookie
 func GetCookie() string {
 	cfg := Get()
@@ -188,9 +400,18 @@ func GetCookie() string {
 	return cfg.Cookie
 }
 
-// SetCookie sets the browser cookie
+// SetCookie sets the browser cookie, as cleaned up by cfweb.SanitizeCookie
+// A recorded cf_clearance expiry is dropped when cf_clearance changes, since
+// it belonged to the old one.
 func SetCookie(cookie string) error {
-	return Set("cookie", cookie)
+	changed := CookieValue(cookie, CookieCFClearance) != CookieValue(GetCookie(), CookieCFClearance)
+	if err := Set("cookie", cookie); err != nil {
+		return err
+	}
+	if changed {
+		return Set("cf_clearance_expires", "")
+	}
+	return nil
 }
 
 // HasCookie returns true if a cookie is configured
@@ -1,9 +1,15 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 func TestInit(t *testing.T) {
@@ -216,6 +222,20 @@ func TestSetDailyGoal(t *testing.T) {
 	}
 }
 
+func TestSetDefaultLanguage(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := Init(filepath.Join(tmpDir, "config.yaml")); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := SetDefaultLanguage("cpp20"); err != nil {
+		t.Fatalf("SetDefaultLanguage() error = %v", err)
+	}
+	if got := GetDefaultLanguage(); got != "cpp20" {
+		t.Errorf("GetDefaultLanguage() = %q, want cpp20", got)
+	}
+}
+
 func TestSetWorkspacePath(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.yaml")
@@ -279,3 +299,264 @@ func TestConfigDir(t *testing.T) {
 		t.Errorf("configDir() should end with .cf, got %v", filepath.Base(dir))
 	}
 }
+
+func TestGetCacheDir(t *testing.T) {
+	globalConfig = &Config{}
+
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg")
+	dir, err := GetCacheDir()
+	if err != nil {
+		t.Fatalf("GetCacheDir() error = %v", err)
+	}
+	if dir != filepath.Join("/tmp/xdg", "cf") {
+		t.Errorf("GetCacheDir() = %v, want XDG_CACHE_HOME/cf", dir)
+	}
+
+	t.Setenv("XDG_CACHE_HOME", "")
+	home, _ := os.UserHomeDir()
+	if dir, _ := GetCacheDir(); dir != filepath.Join(home, ".cache", "cf") {
+		t.Errorf("GetCacheDir() = %v, want ~/.cache/cf", dir)
+	}
+
+	globalConfig = &Config{CacheDir: "/srv/cf-cache"}
+	if dir, _ := GetCacheDir(); dir != "/srv/cf-cache" {
+		t.Errorf("GetCacheDir() = %v, want configured cache_dir", dir)
+	}
+}
+
+func TestSaveCredentials(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	if err := Init(""); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := SetCookie("JSESSIONID=old"); err != nil {
+		t.Fatalf("SetCookie() error = %v", err)
+	}
+
+	err := SaveCredentials(Credentials{Handle: "tourist", APIKey: "key", APISecret: "secret"})
+	if err != nil {
+		t.Fatalf("SaveCredentials() error = %v", err)
+	}
+
+	cfg := Get()
+	if cfg.CFHandle != "tourist" || cfg.APIKey != "key" || cfg.APISecret != "secret" {
+		t.Errorf("SaveCredentials() config = %+v", cfg)
+	}
+	if cfg.Cookie != "JSESSIONID=old" {
+		t.Errorf("Cookie = %q, empty credentials should not overwrite", cfg.Cookie)
+	}
+	if !HasAPIKey() {
+		t.Error("HasAPIKey() = false after saving a key and secret")
+	}
+}
+
+func TestCFClearanceNeedsRefresh(t *testing.T) {
+	soon := time.Now().Add(5 * time.Minute).UTC().Format(time.RFC3339)
+	later := time.Now().Add(3 * time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		cookie  string
+		expires string
+		want    bool
+		status  string
+	}{
+		{"expiring soon", "JSESSIONID=a; cf_clearance=b", soon, true, "cf_clearance expires in 5m"},
+		{"expired", "JSESSIONID=a; cf_clearance=b", past, true, "cf_clearance expired 2h 0m ago"},
+		{"healthy", "JSESSIONID=a; cf_clearance=b", later, false, "cf_clearance expires in 3h 0m"},
+		{"expiry unknown", "JSESSIONID=a; cf_clearance=b", "", false, "cf_clearance set, expiry unknown"},
+		{"no clearance", "JSESSIONID=a", soon, false, "cf_clearance not set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetGlobalConfig(&Config{Cookie: tt.cookie, CFClearanceExpires: tt.expires})
+			defer SetGlobalConfig(nil)
+
+			if got := CFClearanceNeedsRefresh(15 * time.Minute); got != tt.want {
+				t.Errorf("CFClearanceNeedsRefresh() = %v, want %v", got, tt.want)
+			}
+			if got := GetCFClearanceStatus(); got != tt.status {
+				t.Errorf("GetCFClearanceStatus() = %q, want %q", got, tt.status)
+			}
+		})
+	}
+}
+
+func TestSetCookie_ClearsStaleClearanceExpiry(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := Init(""); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := SetCookie("JSESSIONID=a; cf_clearance=old"); err != nil {
+		t.Fatalf("SetCookie() error = %v", err)
+	}
+	if err := SetCFClearanceExpires(time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("SetCFClearanceExpires() error = %v", err)
+	}
+
+	// The same clearance keeps its expiry
+	if err := SetCookie("JSESSIONID=b; cf_clearance=old"); err != nil {
+		t.Fatalf("SetCookie() error = %v", err)
+	}
+	if _, ok := GetCFClearanceExpires(); !ok {
+		t.Error("expiry dropped although cf_clearance did not change")
+	}
+
+	if err := SetCookie("JSESSIONID=b; cf_clearance=new"); err != nil {
+		t.Fatalf("SetCookie() error = %v", err)
+	}
+	if _, ok := GetCFClearanceExpires(); ok {
+		t.Error("expiry of the old cf_clearance kept for the new one")
+	}
+}
+
+func TestSetDifficulty_PersistsToFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	if err := Init(""); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := SetDifficulty(1200, 1900); err != nil {
+		t.Fatalf("SetDifficulty() error = %v", err)
+	}
+
+	data, err := os.ReadFile(viper.ConfigFileUsed())
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var saved struct {
+		Difficulty struct {
+			Min int `yaml:"min"`
+			Max int `yaml:"max"`
+		} `yaml:"difficulty"`
+	}
+	if err := yaml.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if saved.Difficulty.Min != 1200 || saved.Difficulty.Max != 1900 {
+		t.Errorf("saved difficulty = %+v, want 1200-1900", saved.Difficulty)
+	}
+}
+
+func TestValidateDifficulty(t *testing.T) {
+	tests := []struct {
+		min, max int
+		wantErr  bool
+	}{
+		{800, 3500, false},
+		{1200, 1200, false},
+		{1600, 1200, true},
+		{700, 1200, true},
+		{1200, 3600, true},
+	}
+	for _, tt := range tests {
+		err := ValidateDifficulty(tt.min, tt.max)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateDifficulty(%d, %d) error = %v, wantErr %v", tt.min, tt.max, err, tt.wantErr)
+		}
+	}
+}
+
+func TestGetCompilers(t *testing.T) {
+	defer SetGlobalConfig(nil)
+
+	SetGlobalConfig(&Config{Compilers: map[string]Compiler{
+		"cpp": {Compile: "clang++ -O2 -std=c++20 -o {bin} {src}", Run: "{bin}"},
+	}})
+	if got := GetCompilers()["cpp"]; got.Compile != "clang++ -O2 -std=c++20 -o {bin} {src}" {
+		t.Errorf("GetCompilers()[cpp] = %+v", got)
+	}
+
+}
+
+func TestRecordHandle_Dedup(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, h := range []string{"tourist", "petr", "Tourist", "benq"} {
+		if err := RecordHandle(h); err != nil {
+			t.Fatalf("RecordHandle(%q) error = %v", h, err)
+		}
+	}
+
+	if got := strings.Join(RecentHandles(), ","); got != "benq,Tourist,petr" {
+		t.Errorf("RecentHandles() = %s, want benq,Tourist,petr", got)
+	}
+}
+
+func TestRecordHandle_Cap(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if got := RecentHandles(); len(got) != 0 {
+		t.Errorf("RecentHandles() = %v without history, want none", got)
+	}
+	for i := 0; i < MaxRecentHandles+5; i++ {
+		if err := RecordHandle(fmt.Sprintf("user%d", i)); err != nil {
+			t.Fatalf("RecordHandle() error = %v", err)
+		}
+	}
+
+	got := RecentHandles()
+	if len(got) != MaxRecentHandles {
+		t.Fatalf("len(RecentHandles()) = %d, want %d", len(got), MaxRecentHandles)
+	}
+	if last := fmt.Sprintf("user%d", MaxRecentHandles+4); got[0] != last {
+		t.Errorf("RecentHandles()[0] = %s, want the latest %s", got[0], last)
+	}
+	if got[MaxRecentHandles-1] != "user5" {
+		t.Errorf("oldest kept = %s, want user5", got[MaxRecentHandles-1])
+	}
+}
+
+func TestSaveCredentials_ClearanceExpiry(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := Init(""); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	expires := time.Date(2026, 10, 21, 7, 28, 0, 0, time.UTC)
+	if err := SaveCredentials(Credentials{Cookie: "cf_clearance=first", CFClearanceExpires: expires}); err != nil {
+		t.Fatalf("SaveCredentials() error = %v", err)
+	}
+	if got, ok := GetCFClearanceExpires(); !ok || !got.Equal(expires) {
+		t.Errorf("GetCFClearanceExpires() = %v, %v, want %v", got, ok, expires)
+	}
+
+	// A new cf_clearance without a known expiry drops the old one
+	if err := SaveCredentials(Credentials{Cookie: "cf_clearance=second"}); err != nil {
+		t.Fatalf("SaveCredentials() error = %v", err)
+	}
+	if _, ok := GetCFClearanceExpires(); ok {
+		t.Error("GetCFClearanceExpires() kept the expiry of the replaced cf_clearance")
+	}
+}
+
+func TestConfigFile_Private(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".cf", "config.yaml")
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	if err := Init(""); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("new config mode = %v, %v, want 0600", info.Mode().Perm(), err)
+	}
+
+	// A config written before cf kept it private is tightened on the next save
+	if err := os.Chmod(path, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveCredentials(Credentials{APIKey: "key", APISecret: "secret"}); err != nil {
+		t.Fatalf("SaveCredentials() error = %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("config mode after saving secrets = %v, %v, want 0600", info.Mode().Perm(), err)
+	}
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cookie names used by Codeforces sessions
+const (
+	CookieSession     = "JSESSIONID"
+	CookieCE7         = "39ce7"
+	CookieCFClearance = "cf_clearance"
+)
+
+// CookiePair is a single name=value entry from a cookie string
+type CookiePair struct {
+	Name  string
+	Value string
+}
+
+// ParseCookie splits a browser cookie string into name/value pairs
+// Whitespace around names and values is trimmed and malformed pairs are dropped
+func ParseCookie(cookie string) []CookiePair {
+	var pairs []CookiePair
+
+	for _, part := range strings.Split(cookie, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		name := strings.TrimSpace(kv[0])
+		value := strings.TrimSpace(kv[1])
+		if name == "" || value == "" {
+			continue
+		}
+
+		pairs = append(pairs, CookiePair{Name: name, Value: value})
+	}
+
+	return pairs
+}
+
+// NormalizeCookie rewrites a cookie string in canonical "a=1; b=2" form
+func NormalizeCookie(cookie string) string {
+	pairs := ParseCookie(cookie)
+	parts := make([]string, len(pairs))
+	for i, p := range pairs {
+		parts[i] = p.Name + "=" + p.Value
+	}
+	return strings.Join(parts, "; ")
+}
+
+// CookieValue returns the value of the named cookie, or "" if absent
+func CookieValue(cookie, name string) string {
+	for _, p := range ParseCookie(cookie) {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// HasSessionCookies returns true if the configured cookie has the CF session cookies
+func HasSessionCookies() bool {
+	cookie := GetCookie()
+	return CookieValue(cookie, CookieSession) != "" && CookieValue(cookie, CookieCE7) != ""
+}
+
+// HasCFClearance returns true if the configured cookie has a cf_clearance value
+func HasCFClearance() bool {
+	return CookieValue(GetCookie(), CookieCFClearance) != ""
+}
+
+// GetCFClearanceUA returns the User-Agent the cf_clearance cookie was issued to
+func GetCFClearanceUA() string {
+	cfg := Get()
+	if cfg == nil {
+		return ""
+	}
+	return cfg.CFClearanceUA
+}
+
+// GetCFClearanceExpires returns when cf_clearance expires, or false if that
+// is not known
+func GetCFClearanceExpires() (time.Time, bool) {
+	cfg := Get()
+	if cfg == nil || cfg.CFClearanceExpires == "" {
+		return time.Time{}, false
+	}
+	expires, err := time.Parse(time.RFC3339, cfg.CFClearanceExpires)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expires, true
+}
+
+// SetCFClearanceExpires records when cf_clearance expires; a zero time clears it
+func SetCFClearanceExpires(expires time.Time) error {
+	if expires.IsZero() {
+		return Set("cf_clearance_expires", "")
+	}
+	return Set("cf_clearance_expires", expires.UTC().Format(time.RFC3339))
+}
+
+// CFClearanceNeedsRefresh returns true if cf_clearance is set and expires
+// within the given time, or already has. Without cf_clearance or a known
+// expiry there is nothing to refresh.
+func CFClearanceNeedsRefresh(within time.Duration) bool {
+	if !HasCFClearance() {
+		return false
+	}
+	expires, ok := GetCFClearanceExpires()
+	return ok && time.Until(expires) < within
+}
+
+// GetCFClearanceStatus describes the cf_clearance cookie and its expiry,
+// e.g. "cf_clearance expires in 5m"
+func GetCFClearanceStatus() string {
+	if !HasCFClearance() {
+		return "cf_clearance not set"
+	}
+	expires, ok := GetCFClearanceExpires()
+	if !ok {
+		return "cf_clearance set, expiry unknown"
+	}
+
+	left := time.Until(expires)
+	if left <= 0 {
+		return fmt.Sprintf("cf_clearance expired %s ago", roundExpiry(-left))
+	}
+	return fmt.Sprintf("cf_clearance expires in %s", roundExpiry(left))
+}
+
+// roundExpiry formats d coarsely, e.g. "2d 5h", "3h 20m" or "45m"
+func roundExpiry(d time.Duration) string {
+	d = d.Round(time.Minute)
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return "under a minute"
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxRecentHandles is how many handles the history keeps
+const MaxRecentHandles = 20
+
+// history is the content of history.yaml
+type history struct {
+	Handles []string `yaml:"handles"`
+}
+
+var historyMu sync.Mutex
+
+// historyFilePath returns the path of the recent-handles history
+func historyFilePath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.yaml"), nil
+}
+
+// RecordHandle puts handle first in the recent-handles history, dropping an
+// older entry for the same handle (ignoring case) and the oldest past
+// MaxRecentHandles
+func RecordHandle(handle string) error {
+	handle = strings.TrimSpace(handle)
+	if handle == "" {
+		return nil
+	}
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	path, err := historyFilePath()
+	if err != nil {
+		return fmt.Errorf("failed to get config dir: %w", err)
+	}
+	h := readHistory(path)
+
+	handles := []string{handle}
+	for _, old := range h.Handles {
+		if !strings.EqualFold(old, handle) && len(handles) < MaxRecentHandles {
+			handles = append(handles, old)
+		}
+	}
+	h.Handles = handles
+
+	data, err := yaml.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config dir: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// RecentHandles returns the recently queried handles, most recent first
+func RecentHandles() []string {
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	path, err := historyFilePath()
+	if err != nil {
+		return nil
+	}
+	return readHistory(path).Handles
+}
+
+// readHistory reads the history file; a missing or broken file is an empty
+// history, since it only helps completion
+func readHistory(path string) history {
+	var h history
+	if data, err := os.ReadFile(path); err == nil {
+		_ = yaml.Unmarshal(data, &h)
+	}
+	return h
+}
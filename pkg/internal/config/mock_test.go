@@ -161,3 +161,70 @@ func TestGetCFHandle_NilConfig_MockTest(t *testing.T) {
 		t.Errorf("GetCFHandle() with nil config = %v, want empty string", got)
 	}
 }
+
+// ============ Cookie Tests ============
+
+func TestParseCookie(t *testing.T) {
+	pairs := ParseCookie(" JSESSIONID=abc ;39ce7=def;; bogus; empty=; cf_clearance = xyz ")
+
+	want := []CookiePair{
+		{Name: "JSESSIONID", Value: "abc"},
+		{Name: "39ce7", Value: "def"},
+		{Name: "cf_clearance", Value: "xyz"},
+	}
+	if len(pairs) != len(want) {
+		t.Fatalf("ParseCookie() returned %d pairs, want %d", len(pairs), len(want))
+	}
+	for i, p := range pairs {
+		if p != want[i] {
+			t.Errorf("pair %d = %+v, want %+v", i, p, want[i])
+		}
+	}
+}
+
+func TestNormalizeCookie(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"a=1; b=2", "a=1; b=2"},
+		{"  a=1;b=2 ;", "a=1; b=2"},
+		{"a = 1;;junk", "a=1"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeCookie(tt.in); got != tt.want {
+			t.Errorf("NormalizeCookie(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCookieValue(t *testing.T) {
+	cookie := "JSESSIONID=abc; 39ce7=def"
+
+	if got := CookieValue(cookie, CookieSession); got != "abc" {
+		t.Errorf("CookieValue(JSESSIONID) = %q, want abc", got)
+	}
+	if got := CookieValue(cookie, CookieCFClearance); got != "" {
+		t.Errorf("CookieValue(cf_clearance) = %q, want empty", got)
+	}
+}
+
+func TestHasSessionCookies(t *testing.T) {
+	globalConfig = &Config{Cookie: "JSESSIONID=abc; 39ce7=def"}
+	if !HasSessionCookies() {
+		t.Error("HasSessionCookies() should be true with both session cookies")
+	}
+	if HasCFClearance() {
+		t.Error("HasCFClearance() should be false without cf_clearance")
+	}
+
+	globalConfig = &Config{Cookie: "JSESSIONID=abc; cf_clearance=xyz"}
+	if HasSessionCookies() {
+		t.Error("HasSessionCookies() should be false without 39ce7")
+	}
+	if !HasCFClearance() {
+		t.Error("HasCFClearance() should be true with cf_clearance")
+	}
+}
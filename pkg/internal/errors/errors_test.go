@@ -334,3 +334,31 @@ func TestNew_DoesNotModifyRegistry(t *testing.T) {
 		t.Error("New() should not allow modification of Registry")
 	}
 }
+
+func TestMultiError(t *testing.T) {
+	errBad := errors.New("bad page")
+
+	m := NewMultiError(3)
+	m.Add("A", nil)
+	if m.Err() != nil {
+		t.Fatalf("Err() = %v, want nil with no failures", m.Err())
+	}
+
+	m.Add("B", errBad)
+	if m.Len() != 1 || !m.Partial() {
+		t.Errorf("Len() = %d, Partial() = %v, want 1, true", m.Len(), m.Partial())
+	}
+	err := m.Err()
+	if !errors.Is(err, errBad) {
+		t.Error("errors.Is should find the item error")
+	}
+	if want := "1 of 3 failed: B: bad page"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	m.Add("C", errBad)
+	m.Add("D", errBad)
+	if m.Partial() {
+		t.Error("Partial() should be false when every item failed")
+	}
+}
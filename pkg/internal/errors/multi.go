@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiError collects the per-item failures of a bulk operation, so the
+// operation can carry on past them and report them all at the end
+type MultiError struct {
+	Total  int // Number of items attempted
+	Errors []error
+}
+
+// NewMultiError returns an empty MultiError for total items
+func NewMultiError(total int) *MultiError {
+	return &MultiError{Total: total}
+}
+
+// Add records the failure of item; nil errors are ignored
+func (m *MultiError) Add(item string, err error) {
+	if err == nil {
+		return
+	}
+	m.Errors = append(m.Errors, fmt.Errorf("%s: %w", item, err))
+}
+
+// Len returns the number of failed items
+func (m *MultiError) Len() int {
+	return len(m.Errors)
+}
+
+// Partial returns true if some, but not all, items failed
+func (m *MultiError) Partial() bool {
+	return len(m.Errors) > 0 && len(m.Errors) < m.Total
+}
+
+// Err returns m if any item failed, nil otherwise
+func (m *MultiError) Err() error {
+	if len(m.Errors) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error implements the error interface
+func (m *MultiError) Error() string {
+	msgs := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d of %d failed: %s", len(m.Errors), m.Total, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the item errors, so errors.Is and errors.As look through them
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
@@ -62,21 +62,20 @@ func (c *Checker) Run(ctx context.Context) *Report {
 
 func (c *Checker) runCheck(ctx context.Context, check Check, report *Report) {
 	result := check.Check(ctx)
-	report.Results = append(report.Results, result)
 
-	switch result.Status {
-	case StatusCritical:
-		// Try auto-fix if available
-		if result.Recoverable && result.Action == ActionAutoFix {
-			if af, ok := check.(AutoFixable); ok {
-				if err := af.AutoFix(ctx); err == nil {
-					result.Message += " (auto-fixed)"
-					result.Status = StatusHealthy
-					return
-				}
+	// Try auto-fix if available
+	if result.Status == StatusCritical && result.Recoverable && result.Action == ActionAutoFix {
+		if af, ok := check.(AutoFixable); ok {
+			if err := af.AutoFix(ctx); err == nil {
+				result.Message += " (auto-fixed)"
+				result.Status = StatusHealthy
 			}
 		}
+	}
+	report.Results = append(report.Results, result)
 
+	switch result.Status {
+	case StatusCritical:
 		// Check if critical
 		isCritical := true
 		if cr, ok := check.(Critical); ok {
@@ -102,6 +101,32 @@ func (c *Checker) runCheck(ctx context.Context, check Check, report *Report) {
 	}
 }
 
+// FixResult is the outcome of an auto-fix attempt
+type FixResult struct {
+	Name string
+	Err  error
+}
+
+// FixAll runs AutoFix for every unhealthy result in report that is
+// recoverable with ActionAutoFix. Run the checks again to confirm the fixes.
+func (c *Checker) FixAll(ctx context.Context, report *Report) []FixResult {
+	var fixes []FixResult
+	for _, result := range report.Results {
+		if result.Status == StatusHealthy || !result.Recoverable || result.Action != ActionAutoFix {
+			continue
+		}
+		for _, check := range c.checks {
+			af, ok := check.(AutoFixable)
+			if !ok || check.Name() != result.Name {
+				continue
+			}
+			fixes = append(fixes, FixResult{Name: result.Name, Err: af.AutoFix(ctx)})
+			break
+		}
+	}
+	return fixes
+}
+
 // QuickCheck runs a fast subset of checks
 func (c *Checker) QuickCheck(ctx context.Context) bool {
 	for _, check := range c.checks {
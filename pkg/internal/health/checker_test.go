@@ -309,3 +309,70 @@ func TestChecker_Run_WithContext(t *testing.T) {
 		t.Fatal("Run() returned nil")
 	}
 }
+
+func TestChecker_Run_RecordsAutoFixedResult(t *testing.T) {
+	checker := NewChecker()
+	checker.AddCheck(&MockAutoFixableCheck{
+		MockCheck: MockCheck{
+			name:     "Fixable",
+			category: "internal",
+			result: Result{
+				Name:        "Fixable",
+				Status:      StatusCritical,
+				Message:     "Broken",
+				Recoverable: true,
+				Action:      ActionAutoFix,
+			},
+		},
+	})
+
+	report := checker.Run(context.Background())
+
+	if got := report.Results[0]; got.Status != StatusHealthy || got.Message != "Broken (auto-fixed)" {
+		t.Errorf("Results[0] = %v %q, want healthy auto-fixed result", got.Status, got.Message)
+	}
+}
+
+func TestChecker_FixAll(t *testing.T) {
+	fixable := &MockAutoFixableCheck{
+		MockCheck: MockCheck{
+			name:     "Fixable",
+			category: "external",
+			result: Result{
+				Name:        "Fixable",
+				Status:      StatusDegraded,
+				Recoverable: true,
+				Action:      ActionAutoFix,
+			},
+		},
+	}
+	manual := &MockAutoFixableCheck{
+		MockCheck: MockCheck{
+			name:     "Manual",
+			category: "external",
+			result: Result{
+				Name:        "Manual",
+				Status:      StatusDegraded,
+				Recoverable: true,
+				Action:      ActionManualFix,
+			},
+		},
+	}
+
+	checker := NewChecker()
+	checker.AddCheck(fixable)
+	checker.AddCheck(manual)
+
+	report := checker.Run(context.Background())
+	fixes := checker.FixAll(context.Background(), report)
+
+	if len(fixes) != 1 || fixes[0].Name != "Fixable" || fixes[0].Err != nil {
+		t.Errorf("FixAll() = %+v, want one successful fix of Fixable", fixes)
+	}
+	if !fixable.fixCalled {
+		t.Error("FixAll() should call AutoFix on auto-fixable results")
+	}
+	if manual.fixCalled {
+		t.Error("FixAll() should skip results that need a manual fix")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/runner"
 	"github.com/harshit-vibes/cf/pkg/internal/schema"
 	"github.com/harshit-vibes/cf/pkg/internal/workspace"
 )
@@ -12,6 +13,16 @@ import (
 // ConfigCheck checks the configuration
 type ConfigCheck struct{}
 
+// validateCompilers checks the configured commands as cf test merges them
+// into the defaults
+func validateCompilers(compilers map[string]config.Compiler) error {
+	commands := make(map[string]runner.Command, len(compilers))
+	for lang, c := range compilers {
+		commands[lang] = runner.Command(c)
+	}
+	return runner.ValidateCommands(runner.MergeCommands(commands))
+}
+
 func (c *ConfigCheck) Name() string     { return "Configuration" }
 func (c *ConfigCheck) Category() string { return "internal" }
 
@@ -31,6 +42,18 @@ func (c *ConfigCheck) Check(ctx context.Context) Result {
 		}
 	}
 
+	if err := validateCompilers(config.GetCompilers()); err != nil {
+		return Result{
+			Name:     c.Name(),
+			Category: c.Category(),
+			Status:   StatusDegraded,
+			Message:  "Invalid compilers config",
+			Details:  err.Error(),
+			Action:   ActionUserPrompt,
+			Duration: time.Since(start),
+		}
+	}
+
 	if !config.HasHandle() {
 		return Result{
 			Name:     c.Name(),
@@ -59,6 +82,10 @@ func (c *ConfigCheck) AutoFix(ctx context.Context) error {
 // CookieCheck checks if the browser cookie is configured
 type CookieCheck struct{}
 
+// ClearanceRefreshWindow is how long before cf_clearance expires the cookie
+// check starts warning
+const ClearanceRefreshWindow = 15 * time.Minute
+
 func (c *CookieCheck) Name() string     { return "Cookie" }
 func (c *CookieCheck) Category() string { return "internal" }
 
@@ -77,6 +104,31 @@ func (c *CookieCheck) Check(ctx context.Context) Result {
 		}
 	}
 
+	if config.HasCFClearance() && config.GetCFClearanceUA() == "" {
+		return Result{
+			Name:     c.Name(),
+			Category: c.Category(),
+			Status:   StatusDegraded,
+			Message:  "cf_clearance set without its User-Agent",
+			Details:  "Cloudflare rejects cf_clearance from other browsers. Run: cf config set cf_clearance_ua 'YOUR_BROWSER_USER_AGENT'",
+			Action:   ActionUserPrompt,
+			Duration: time.Since(start),
+		}
+	}
+
+	// Warn before a submission runs into an expired clearance
+	if config.CFClearanceNeedsRefresh(ClearanceRefreshWindow) {
+		return Result{
+			Name:     c.Name(),
+			Category: c.Category(),
+			Status:   StatusDegraded,
+			Message:  config.GetCFClearanceStatus(),
+			Details:  "Submitting will fail once it expires. Copy a fresh cf_clearance from your browser: cf setup",
+			Action:   ActionUserPrompt,
+			Duration: time.Since(start),
+		}
+	}
+
 	return Result{
 		Name:     c.Name(),
 		Category: c.Category(),
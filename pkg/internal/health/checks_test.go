@@ -4,7 +4,9 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/harshit-vibes/cf/pkg/internal/config"
 	"github.com/harshit-vibes/cf/pkg/internal/workspace"
@@ -39,6 +41,19 @@ func TestConfigCheck_Check(t *testing.T) {
 	}
 }
 
+func TestConfigCheck_Check_InvalidCompilers(t *testing.T) {
+	orig := config.Get()
+	defer config.SetGlobalConfig(orig)
+	config.SetGlobalConfig(&config.Config{Compilers: map[string]config.Compiler{
+		"cpp": {Compile: "clang++ -O2 -o {bin} {source}", Run: "{bin}"},
+	}})
+
+	result := (&ConfigCheck{}).Check(context.Background())
+	if result.Status != StatusDegraded || !strings.Contains(result.Details, "{source}") {
+		t.Errorf("Check() = %s %q, want degraded with the unknown placeholder", result.Status, result.Details)
+	}
+}
+
 func TestConfigCheck_AutoFix(t *testing.T) {
 	check := &ConfigCheck{}
 
@@ -105,6 +120,33 @@ func TestCookieCheck_Check_WithCookie(t *testing.T) {
 	}
 }
 
+func TestCookieCheck_Check_ClearanceExpiring(t *testing.T) {
+	expires := time.Now().Add(5 * time.Minute).UTC().Format(time.RFC3339)
+	config.SetGlobalConfig(&config.Config{
+		Cookie:             "JSESSIONID=test; cf_clearance=test",
+		CFClearanceUA:      "Mozilla/5.0",
+		CFClearanceExpires: expires,
+	})
+
+	result := (&CookieCheck{}).Check(context.Background())
+	if result.Status != StatusDegraded {
+		t.Errorf("Status = %v, want %v", result.Status, StatusDegraded)
+	}
+	if result.Message != "cf_clearance expires in 5m" {
+		t.Errorf("Message = %q, want the clearance status", result.Message)
+	}
+
+	// A clearance with hours left is fine
+	config.SetGlobalConfig(&config.Config{
+		Cookie:             "JSESSIONID=test; cf_clearance=test",
+		CFClearanceUA:      "Mozilla/5.0",
+		CFClearanceExpires: time.Now().Add(3 * time.Hour).UTC().Format(time.RFC3339),
+	})
+	if result := (&CookieCheck{}).Check(context.Background()); result.Status != StatusHealthy {
+		t.Errorf("Status = %v for a healthy clearance, want %v", result.Status, StatusHealthy)
+	}
+}
+
 // ============ Workspace Tests ============
 
 func TestWorkspaceCheck_Name(t *testing.T) {
@@ -428,3 +470,17 @@ func TestCheckerWithoutAutoFix(t *testing.T) {
 		t.Errorf("Report.OverallStatus = %v, want %v", report.OverallStatus, StatusHealthy)
 	}
 }
+
+func TestCookieCheck_Check_ClearanceWithoutUA(t *testing.T) {
+	config.SetGlobalConfig(&config.Config{CFHandle: "testuser", Cookie: "JSESSIONID=a; cf_clearance=b"})
+
+	result := (&CookieCheck{}).Check(context.Background())
+	if result.Status != StatusDegraded || !strings.Contains(result.Details, "cf_clearance_ua") {
+		t.Errorf("Check() = %v %q, want degraded with a cf_clearance_ua hint", result.Status, result.Details)
+	}
+
+	config.SetGlobalConfig(&config.Config{CFHandle: "testuser", Cookie: "JSESSIONID=a; cf_clearance=b", CFClearanceUA: "Mozilla/5.0"})
+	if result := (&CookieCheck{}).Check(context.Background()); result.Status != StatusHealthy {
+		t.Errorf("Check() with stored UA = %v, want healthy", result.Status)
+	}
+}
@@ -0,0 +1,85 @@
+// Package notify shows desktop notifications
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// timeout bounds a notifier run, so a hung notification daemon can't stall the caller
+const timeout = 5 * time.Second
+
+// Bell is where the terminal bell is written when no notifier is available
+var Bell io.Writer = os.Stdout
+
+// lookPath finds notifier binaries; replaced in tests
+var lookPath = exec.LookPath
+
+// Send shows a desktop notification with notify-send on Linux and BSD,
+// osascript on macOS and a PowerShell toast on Windows. Without a notifier,
+// or if it fails, the terminal bell rings instead; the error is only for
+// reporting and callers can carry on.
+func Send(title, message string) error {
+	args := command(runtime.GOOS, title, message)
+	if args == nil {
+		fmt.Fprint(Bell, "\a")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if out, err := exec.CommandContext(ctx, args[0], args[1:]...).CombinedOutput(); err != nil {
+		fmt.Fprint(Bell, "\a")
+		return fmt.Errorf("%s failed: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// command returns the notifier command line for goos, or nil if its
+// notifier is not installed
+func command(goos, title, message string) []string {
+	var args []string
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		args = []string{"osascript", "-e", script}
+	case "windows":
+		args = []string{"powershell", "-NoProfile", "-NonInteractive", "-Command", toastScript(title, message)}
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		args = []string{"notify-send", "--app-name=cf", title, message}
+	default:
+		return nil
+	}
+
+	if _, err := lookPath(args[0]); err != nil {
+		return nil
+	}
+	return args
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// toastScript is a PowerShell script showing a Windows toast notification
+func toastScript(title, message string) string {
+	quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+	return strings.Join([]string{
+		"[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null",
+		"$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)",
+		"$text = $xml.GetElementsByTagName('text')",
+		"$text.Item(0).InnerText = " + quote(title),
+		"$text.Item(1).InnerText = " + quote(message),
+		"$toast = [Windows.UI.Notifications.ToastNotification]::new($xml)",
+		"[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('cf').Show($toast)",
+	}, "; ")
+}
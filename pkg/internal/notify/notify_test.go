@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// withPath makes lookPath find only the given binaries
+func withPath(t *testing.T, found ...string) {
+	t.Helper()
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+	lookPath = func(name string) (string, error) {
+		for _, f := range found {
+			if f == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func TestCommand(t *testing.T) {
+	withPath(t, "notify-send", "osascript", "powershell")
+
+	tests := []struct {
+		goos string
+		want string
+	}{
+		{"linux", "notify-send"},
+		{"freebsd", "notify-send"},
+		{"darwin", "osascript"},
+		{"windows", "powershell"},
+		{"plan9", ""},
+	}
+	for _, tt := range tests {
+		args := command(tt.goos, "1325A", "Accepted")
+		got := ""
+		if args != nil {
+			got = args[0]
+		}
+		if got != tt.want {
+			t.Errorf("command(%s) = %v, want %s", tt.goos, args, tt.want)
+		}
+	}
+}
+
+func TestCommand_MissingNotifier(t *testing.T) {
+	withPath(t)
+
+	for _, goos := range []string{"linux", "darwin", "windows"} {
+		if args := command(goos, "title", "message"); args != nil {
+			t.Errorf("command(%s) = %v without the notifier installed, want nil", goos, args)
+		}
+	}
+}
+
+func TestCommand_Quoting(t *testing.T) {
+	withPath(t, "notify-send", "osascript", "powershell")
+
+	mac := command("darwin", `say "hi"`, `it's \ done`)
+	if want := `display notification "it's \\ done" with title "say \"hi\""`; mac[2] != want {
+		t.Errorf("osascript script = %s, want %s", mac[2], want)
+	}
+
+	win := command("windows", "it's", "ok")
+	if script := win[len(win)-1]; !strings.Contains(script, "InnerText = 'it''s'") {
+		t.Errorf("toast script does not escape quotes: %s", script)
+	}
+
+	linux := command("linux", "title; rm -rf /", "message")
+	if linux[2] != "title; rm -rf /" {
+		t.Errorf("notify-send args = %v, want the title as a single argument", linux)
+	}
+}
+
+func TestSend_BellWithoutNotifier(t *testing.T) {
+	withPath(t)
+	var bell bytes.Buffer
+	orig := Bell
+	Bell = &bell
+	defer func() { Bell = orig }()
+
+	if err := Send("title", "message"); err != nil {
+		t.Errorf("Send() error = %v, want nil with the bell fallback", err)
+	}
+	if bell.String() != "\a" {
+		t.Errorf("bell = %q, want \\a", bell.String())
+	}
+}
@@ -0,0 +1,293 @@
+// Package output renders command results as tables, JSON or CSV
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// Output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatTable, FormatJSON, FormatCSV}
+
+const colorReset = "\033[0m"
+
+// Column describes one field of a rendered row
+type Column struct {
+	Header string
+	Width  int  // Table width; longer values are truncated, 0 sizes to content
+	Right  bool // Right-align in tables
+	Value  func(row any) string
+	Color  func(row any) string // Optional ANSI color for table cells
+}
+
+// Col creates a column whose value is computed from a row of type T
+func Col[T any](header string, width int, value func(T) string) Column {
+	return Column{
+		Header: header,
+		Width:  width,
+		Value:  func(row any) string { return value(row.(T)) },
+	}
+}
+
+// AlignRight returns the column right-aligned
+func (c Column) AlignRight() Column {
+	c.Right = true
+	return c
+}
+
+// WithColor returns the column with a per-row table color
+func WithColor[T any](c Column, color func(T) string) Column {
+	c.Color = func(row any) string { return color(row.(T)) }
+	return c
+}
+
+// Validate returns an error if format is not supported
+func Validate(format string) error {
+	for _, f := range Formats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output format %q (use %s)", format, strings.Join(Formats, ", "))
+}
+
+// Render writes v in the given format
+// A slice renders one row per element; any other value renders as a single
+// record (a key/value list in table format). JSON output encodes v as-is and
+// ignores columns.
+func Render(w io.Writer, format string, v any, columns []Column) error {
+	if err := Validate(format); err != nil {
+		return err
+	}
+
+	rows, isList := toRows(v)
+
+	switch format {
+	case FormatJSON:
+		if isList && len(rows) == 0 {
+			v = []any{} // Encode empty lists as [] rather than null
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case FormatCSV:
+		return renderCSV(w, rows, columns)
+	default:
+		if !isList {
+			return renderRecord(w, rows[0], columns)
+		}
+		return renderTable(w, rows, columns)
+	}
+}
+
+// Stream writes rows that arrive over time, such as the results of polling
+// JSON rows are written one compact object per line (NDJSON) and CSV writes
+// its header only before the first row, so the output stays parseable as a
+// whole. Each table batch is rendered as its own table.
+type Stream struct {
+	w       io.Writer
+	format  string
+	columns []Column
+	started bool
+}
+
+// NewStream creates a stream writing in the given format
+func NewStream(w io.Writer, format string, columns []Column) (*Stream, error) {
+	if err := Validate(format); err != nil {
+		return nil, err
+	}
+	return &Stream{w: w, format: format, columns: columns}, nil
+}
+
+// Write appends the rows of v, a slice, to the stream
+func (s *Stream) Write(v any) error {
+	rows, _ := toRows(v)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	switch s.format {
+	case FormatJSON:
+		enc := json.NewEncoder(s.w)
+		for _, row := range rows {
+			if err := enc.Encode(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatCSV:
+		cw := csv.NewWriter(s.w)
+		if !s.started {
+			if err := cw.Write(csvHeader(s.columns)); err != nil {
+				return err
+			}
+			s.started = true
+		}
+		if err := writeCSVRows(cw, rows, s.columns); err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return renderTable(s.w, rows, s.columns)
+	}
+}
+
+// toRows splits v into rows, reporting whether it was a list
+func toRows(v any) ([]any, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []any{v}, false
+	}
+
+	rows := make([]any, rv.Len())
+	for i := range rows {
+		rows[i] = rv.Index(i).Interface()
+	}
+	return rows, true
+}
+
+func renderCSV(w io.Writer, rows []any, columns []Column) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader(columns)); err != nil {
+		return err
+	}
+	if err := writeCSVRows(cw, rows, columns); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvHeader returns the column headers as a CSV record
+func csvHeader(columns []Column) []string {
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.Header
+	}
+	return header
+}
+
+// writeCSVRows writes one CSV record per row
+func writeCSVRows(cw *csv.Writer, rows []any, columns []Column) error {
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, c := range columns {
+			record[i] = c.Value(row)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderTable(w io.Writer, rows []any, columns []Column) error {
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		widths[i] = c.Width
+		if widths[i] > 0 {
+			continue
+		}
+		widths[i] = utf8.RuneCountInString(c.Header)
+		for _, row := range rows {
+			if n := utf8.RuneCountInString(c.Value(row)); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	header := make([]string, len(columns))
+	total := 0
+	for i, c := range columns {
+		header[i] = pad(c.Header, widths[i], c.Right)
+		total += widths[i]
+	}
+	total += 2 * (len(columns) - 1)
+
+	if _, err := fmt.Fprintln(w, strings.TrimRight(strings.Join(header, "  "), " ")); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, strings.Repeat("─", total)); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = colorize(pad(c.Value(row), widths[i], c.Right), c, row)
+		}
+		if _, err := fmt.Fprintln(w, strings.TrimRight(strings.Join(cells, "  "), " ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderRecord writes a single value as an aligned "Header: value" list
+func renderRecord(w io.Writer, row any, columns []Column) error {
+	labelWidth := 0
+	for _, c := range columns {
+		if n := utf8.RuneCountInString(c.Header) + 1; n > labelWidth {
+			labelWidth = n
+		}
+	}
+
+	for _, c := range columns {
+		value := c.Value(row)
+		if value == "" {
+			continue
+		}
+		if c.Width > 0 {
+			value = pad(value, c.Width, false)
+		}
+		line := fmt.Sprintf("  %-*s %s", labelWidth, c.Header+":", colorize(strings.TrimRight(value, " "), c, row))
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pad truncates or pads s to exactly width runes
+func pad(s string, width int, right bool) string {
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		if width <= 3 {
+			return string([]rune(s)[:width])
+		}
+		return string([]rune(s)[:width-3]) + "..."
+	}
+
+	fill := strings.Repeat(" ", width-n)
+	if right {
+		return fill + s
+	}
+	return s + fill
+}
+
+func colorize(s string, c Column, row any) string {
+	if c.Color == nil {
+		return s
+	}
+	color := c.Color(row)
+	if color == "" {
+		return s
+	}
+	return color + s + colorReset
+}
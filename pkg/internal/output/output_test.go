@@ -0,0 +1,177 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type testRow struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+func testColumns() []Column {
+	return []Column{
+		Col("Name", 0, func(r testRow) string { return r.Name }),
+		Col("Score", 5, func(r testRow) string { return strings.Repeat("*", r.Score) }).AlignRight(),
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, f := range Formats {
+		if err := Validate(f); err != nil {
+			t.Errorf("Validate(%q) error = %v", f, err)
+		}
+	}
+	if err := Validate("yaml"); err == nil {
+		t.Error("Validate(yaml) should fail")
+	}
+}
+
+func TestRender_Table(t *testing.T) {
+	rows := []testRow{{"alice", 2}, {"bob", 7}}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatTable, rows, testColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "Name   Score\n" +
+		"────────────\n" +
+		"alice     **\n" +
+		"bob    **...\n"
+	if buf.String() != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestRender_TableColor(t *testing.T) {
+	cols := []Column{
+		WithColor(Col("Name", 0, func(r testRow) string { return r.Name }), func(r testRow) string {
+			if r.Score > 5 {
+				return "\033[32m"
+			}
+			return ""
+		}),
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatTable, []testRow{{"alice", 2}, {"bob", 7}}, cols); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[2] != "alice" {
+		t.Errorf("uncolored row = %q, want %q", lines[2], "alice")
+	}
+	if lines[3] != "\033[32mbob  \033[0m" {
+		t.Errorf("colored row = %q", lines[3])
+	}
+}
+
+func TestRender_Record(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatTable, testRow{"alice", 0}, testColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	// Empty values are left out of records
+	if got, want := buf.String(), "  Name:  alice\n"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestRender_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatJSON, []testRow{{"alice", 2}}, testColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	var decoded []testRow
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	if len(decoded) != 1 || decoded[0] != (testRow{"alice", 2}) {
+		t.Errorf("decoded = %+v", decoded)
+	}
+}
+
+func TestRender_JSONEmptyList(t *testing.T) {
+	var buf bytes.Buffer
+	var rows []testRow
+	if err := Render(&buf, FormatJSON, rows, testColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("Render() = %q, want []", got)
+	}
+}
+
+func TestRender_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	rows := []testRow{{"alice, jr", 2}, {"bob", 7}}
+	if err := Render(&buf, FormatCSV, rows, testColumns()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	// CSV values are never truncated
+	want := "Name,Score\n\"alice, jr\",**\nbob,*******\n"
+	if buf.String() != want {
+		t.Errorf("Render() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestStream_JSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	stream, err := NewStream(&buf, FormatJSON, testColumns())
+	if err != nil {
+		t.Fatalf("NewStream() error = %v", err)
+	}
+	if err := stream.Write([]testRow{{"alice", 2}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := stream.Write([]testRow{{"bob", 7}, {"carol", 1}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		var row testRow
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			t.Errorf("line %q is not a JSON object: %v", line, err)
+		}
+	}
+}
+
+func TestStream_CSVHeaderOnce(t *testing.T) {
+	var buf bytes.Buffer
+	stream, err := NewStream(&buf, FormatCSV, testColumns())
+	if err != nil {
+		t.Fatalf("NewStream() error = %v", err)
+	}
+	if err := stream.Write([]testRow(nil)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	for _, rows := range [][]testRow{{{"alice", 2}}, {{"bob", 7}}} {
+		if err := stream.Write(rows); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	want := "Name,Score\nalice,**\nbob,*******\n"
+	if buf.String() != want {
+		t.Errorf("stream = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRender_UnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, "xml", []testRow{}, testColumns()); err == nil {
+		t.Error("Render() should fail for an unknown format")
+	}
+}
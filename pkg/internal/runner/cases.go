@@ -0,0 +1,78 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// reTimeLimit matches problem time limits like "2 seconds" or "0.5 second"
+var reTimeLimit = regexp.MustCompile(`^\s*([0-9]+(?:\.[0-9]+)?)\s*seconds?\s*$`)
+
+// reTrailingNumber matches the case number in names like sample_12
+var reTrailingNumber = regexp.MustCompile(`([0-9]+)$`)
+
+// LoadTests returns the test cases in dir: each NAME.in file with its
+// NAME.out, ordered by case number so sample_2 runs before sample_10
+// Inputs without a NAME.out, e.g. of interactive problems, get an empty
+// OutputPath.
+func LoadTests(dir string) ([]TestCase, error) {
+	inputs, err := filepath.Glob(filepath.Join(dir, "*.in"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tests: %w", err)
+	}
+
+	cases := make([]TestCase, 0, len(inputs))
+	for _, in := range inputs {
+		name := strings.TrimSuffix(filepath.Base(in), ".in")
+		out := filepath.Join(dir, name+".out")
+		if _, err := os.Stat(out); os.IsNotExist(err) {
+			out = ""
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read expected output of %s: %w", name, err)
+		}
+		cases = append(cases, TestCase{Name: name, InputPath: in, OutputPath: out})
+	}
+
+	sort.SliceStable(cases, func(i, j int) bool {
+		return caseLess(cases[i].Name, cases[j].Name)
+	})
+	return cases, nil
+}
+
+// caseLess orders case names by prefix, then by trailing number
+func caseLess(a, b string) bool {
+	pa, na := splitCaseName(a)
+	pb, nb := splitCaseName(b)
+	if pa != pb {
+		return pa < pb
+	}
+	return na < nb
+}
+
+func splitCaseName(name string) (string, int) {
+	m := reTrailingNumber.FindString(name)
+	if m == "" {
+		return name, -1
+	}
+	n, _ := strconv.Atoi(m)
+	return strings.TrimSuffix(name, m), n
+}
+
+// ParseTimeLimit parses a problem time limit such as "2 seconds"
+func ParseTimeLimit(s string) (time.Duration, bool) {
+	m := reTimeLimit.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	secs, err := strconv.ParseFloat(m[1], 64)
+	if err != nil || secs <= 0 {
+		return 0, false
+	}
+	return time.Duration(secs * float64(time.Second)), true
+}
@@ -0,0 +1,135 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ErrUnsupportedLanguage is returned for solutions without a command
+var ErrUnsupportedLanguage = errors.New("no command configured for language")
+
+// Command is how solutions of one language are built and run
+// Both are command templates split on spaces; {src} is the solution file,
+// {bin} the build output and, for Run only, {input} the sample input,
+// which is also given on stdin.
+type Command struct {
+	Compile string `mapstructure:"compile" yaml:"compile,omitempty" json:"compile,omitempty"` // empty for interpreted languages
+	Run     string `mapstructure:"run" yaml:"run" json:"run"`
+}
+
+// DefaultCommands are the commands of languages that are not configured
+// Java builds into the {bin} directory and runs its Main class, the class
+// name Codeforces expects; Kotlin builds a self-contained jar.
+var DefaultCommands = map[string]Command{
+	"c":      {Compile: "gcc -O2 -o {bin} {src} -lm", Run: "{bin}"},
+	"cpp":    {Compile: "g++ -O2 -std=c++17 -o {bin} {src}", Run: "{bin}"},
+	"go":     {Compile: "go build -o {bin} {src}", Run: "{bin}"},
+	"java":   {Compile: "javac -d {bin} {src}", Run: "java -cp {bin} Main"},
+	"kotlin": {Compile: "kotlinc {src} -include-runtime -d {bin}.jar", Run: "java -jar {bin}.jar"},
+	"rust":   {Compile: "rustc -O -o {bin} {src}", Run: "{bin}"},
+	"py":     {Run: "python3 {src}"},
+}
+
+// extLanguages maps solution file extensions to language names, the keys of
+// DefaultCommands and of the compilers config
+var extLanguages = map[string]string{
+	".c":    "c",
+	".cc":   "cpp",
+	".cpp":  "cpp",
+	".go":   "go",
+	".java": "java",
+	".kt":   "kotlin",
+	".rs":   "rust",
+	".py":   "py",
+}
+
+// rePlaceholder matches the placeholders of a command template
+var rePlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// Language returns the language of a solution file, or its extension
+// without the dot if the extension is unknown
+func Language(solution string) string {
+	ext := strings.ToLower(filepath.Ext(solution))
+	if lang, ok := extLanguages[ext]; ok {
+		return lang
+	}
+	return strings.TrimPrefix(ext, ".")
+}
+
+// Validate checks that the command can be run and only uses known placeholders
+func (c Command) Validate() error {
+	if strings.TrimSpace(c.Run) == "" {
+		return fmt.Errorf("run command is empty")
+	}
+	if err := checkPlaceholders(c.Compile, "{src}", "{bin}"); err != nil {
+		return fmt.Errorf("compile: %w", err)
+	}
+	if err := checkPlaceholders(c.Run, "{src}", "{bin}", "{input}"); err != nil {
+		return fmt.Errorf("run: %w", err)
+	}
+	return nil
+}
+
+// Merge returns c with the fields set in override replacing its own, so a
+// config entry can change only the compile or only the run command
+func (c Command) Merge(override Command) Command {
+	if strings.TrimSpace(override.Compile) != "" {
+		c.Compile = override.Compile
+	}
+	if strings.TrimSpace(override.Run) != "" {
+		c.Run = override.Run
+	}
+	return c
+}
+
+// MergeCommands returns DefaultCommands with commands merged in per language
+func MergeCommands(commands map[string]Command) map[string]Command {
+	merged := make(map[string]Command, len(DefaultCommands)+len(commands))
+	for lang, c := range DefaultCommands {
+		merged[lang] = c
+	}
+	for lang, c := range commands {
+		merged[lang] = merged[lang].Merge(c)
+	}
+	return merged
+}
+
+// ValidateCommands validates each command of a language command map
+func ValidateCommands(commands map[string]Command) error {
+	for lang, c := range commands {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", lang, err)
+		}
+	}
+	return nil
+}
+
+func checkPlaceholders(template string, allowed ...string) error {
+	for _, p := range rePlaceholder.FindAllString(template, -1) {
+		known := false
+		for _, a := range allowed {
+			known = known || p == a
+		}
+		if !known {
+			return fmt.Errorf("unknown placeholder %s in %q (use %s)", p, template, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// Expand splits a command template into arguments and fills its placeholders
+func Expand(template, src, bin, input string) []string {
+	return fill(template, strings.NewReplacer("{src}", src, "{bin}", bin, "{input}", input))
+}
+
+// fill splits template on spaces and applies r to each argument
+func fill(template string, r *strings.Replacer) []string {
+	fields := strings.Fields(template)
+	for i, f := range fields {
+		fields[i] = r.Replace(f)
+	}
+	return fields
+}
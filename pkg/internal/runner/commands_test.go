@@ -0,0 +1,147 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestExpand(t *testing.T) {
+	got := Expand("g++ -O2  -std=c++20 -o {bin} {src}", "/ws/main.cpp", "/tmp/solution", "")
+	want := []string{"g++", "-O2", "-std=c++20", "-o", "/tmp/solution", "/ws/main.cpp"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand() = %q, want %q", got, want)
+	}
+
+	got = Expand("firejail --quiet {bin} < {input}", "", "/tmp/solution", "/ws/tests/sample_1.in")
+	want = []string{"firejail", "--quiet", "/tmp/solution", "<", "/ws/tests/sample_1.in"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand() = %q, want %q", got, want)
+	}
+}
+
+func TestCommand_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     Command
+		wantErr bool
+	}{
+		{"compiled", Command{Compile: "g++ -o {bin} {src}", Run: "{bin}"}, false},
+		{"interpreted", Command{Run: "pypy3 {src}"}, false},
+		{"input in run", Command{Run: "python3 {src} {input}"}, false},
+		{"no run", Command{Compile: "g++ -o {bin} {src}"}, true},
+		{"unknown placeholder", Command{Compile: "g++ -o {out} {src}", Run: "{out}"}, true},
+		{"input in compile", Command{Compile: "g++ -o {bin} {src} {input}", Run: "{bin}"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cmd.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefaultCommands_Valid(t *testing.T) {
+	if err := ValidateCommands(DefaultCommands); err != nil {
+		t.Errorf("ValidateCommands(DefaultCommands) error = %v", err)
+	}
+	for ext, lang := range extLanguages {
+		if _, ok := DefaultCommands[lang]; !ok {
+			t.Errorf("extension %s maps to %s, which has no default command", ext, lang)
+		}
+	}
+	// Every language with a default solution file can be tested
+	for lang, file := range v1.DefaultSolutionFiles {
+		if _, ok := DefaultCommands[lang]; !ok {
+			t.Errorf("default solution file %s of %s has no default command", file, lang)
+		}
+		if got := Language(file); got != lang {
+			t.Errorf("Language(%q) = %q, want %q", file, got, lang)
+		}
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	tests := map[string]string{
+		"main.cpp":  "cpp",
+		"a.cc":      "cpp",
+		"main.rs":   "rust",
+		"Main.java": "java",
+		"main.kt":   "kotlin",
+		"x.PY":      "py",
+	}
+	for file, want := range tests {
+		if got := Language(file); got != want {
+			t.Errorf("Language(%q) = %q, want %q", file, got, want)
+		}
+	}
+}
+
+func TestMergeCommands(t *testing.T) {
+	merged := MergeCommands(map[string]Command{
+		"cpp": {Compile: "clang++ -O2 -o {bin} {src}"},
+		"py":  {Run: "pypy3 {src}"},
+		"hs":  {Compile: "ghc -o {bin} {src}", Run: "{bin}"},
+	})
+
+	if got := merged["cpp"]; got.Compile != "clang++ -O2 -o {bin} {src}" || got.Run != DefaultCommands["cpp"].Run {
+		t.Errorf("cpp = %+v, want the new compile and the default run", got)
+	}
+	if got := merged["py"]; got.Compile != "" || got.Run != "pypy3 {src}" {
+		t.Errorf("py = %+v, want only the new run", got)
+	}
+	if got := merged["hs"]; got.Run != "{bin}" {
+		t.Errorf("hs = %+v, want the new language as configured", got)
+	}
+	if merged["go"] != DefaultCommands["go"] {
+		t.Errorf("go = %+v, want the default", merged["go"])
+	}
+	if err := ValidateCommands(merged); err != nil {
+		t.Errorf("ValidateCommands() error = %v", err)
+	}
+}
+
+func TestRunTests_MissingLanguage(t *testing.T) {
+	_, err := RunTests(context.Background(), "main.hs", sampleCase(t))
+	if !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("RunTests() error = %v, want ErrUnsupportedLanguage", err)
+	}
+}
+
+func TestRunTests_WithCommands(t *testing.T) {
+	requireGo(t)
+
+	// Builds with the default go command, runs with the input as an argument
+	// instead of stdin
+	commands := map[string]Command{"go": {Compile: DefaultCommands["go"].Compile, Run: "{bin} {input}"}}
+	results, err := RunTests(context.Background(), filepath.Join("testdata", "double_arg.go"), sampleCase(t),
+		WithCommands(commands))
+	if err != nil {
+		t.Fatalf("RunTests() error = %v", err)
+	}
+	if !results[0].Passed() {
+		t.Errorf("Verdict = %s, want %s (output %q)", results[0].Verdict, VerdictAccepted, results[0].Output)
+	}
+
+	// Only the run command is set: the default build is kept
+	commands = map[string]Command{"go": {Run: "{bin} {input}"}}
+	results, err = RunTests(context.Background(), filepath.Join("testdata", "double_arg.go"), sampleCase(t),
+		WithCommands(commands))
+	if err != nil {
+		t.Fatalf("RunTests() with only a run command error = %v", err)
+	}
+	if !results[0].Passed() {
+		t.Errorf("Verdict = %s, want %s (output %q)", results[0].Verdict, VerdictAccepted, results[0].Output)
+	}
+
+	commands = map[string]Command{"go": {Run: "{bin} {output}"}}
+	if _, err := RunTests(context.Background(), "main.go", nil, WithCommands(commands)); err == nil {
+		t.Error("RunTests() should reject a command with an unknown placeholder")
+	}
+}
@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Comparison is how a solution output is compared to the expected output
+type Comparison string
+
+const (
+	// CompareExact requires the same bytes, ignoring trailing newlines
+	CompareExact Comparison = "exact"
+	// CompareTokens compares the whitespace-separated tokens, like most
+	// Codeforces checkers
+	CompareTokens Comparison = "tokens"
+	// CompareFloat compares tokens, accepting numbers within FloatEpsilon
+	CompareFloat Comparison = "float"
+)
+
+// DefaultComparison is used when a problem sets no comparison
+const DefaultComparison = CompareTokens
+
+// FloatEpsilon is the absolute or relative error CompareFloat accepts
+const FloatEpsilon = 1e-6
+
+// Comparisons lists the supported comparisons
+var Comparisons = []Comparison{CompareExact, CompareTokens, CompareFloat}
+
+// ParseComparison returns the comparison named s; empty is DefaultComparison
+func ParseComparison(s string) (Comparison, error) {
+	if s == "" {
+		return DefaultComparison, nil
+	}
+	for _, c := range Comparisons {
+		if string(c) == strings.ToLower(s) {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("unknown comparison %q (want exact, tokens or float)", s)
+}
+
+// Match returns true if got is accepted as the expected output
+func (c Comparison) Match(expected, got string) bool {
+	switch c {
+	case CompareExact:
+		return strings.TrimRight(expected, "\r\n") == strings.TrimRight(got, "\r\n")
+	case CompareFloat:
+		return sameTokens(expected, got, sameNumber)
+	default:
+		return sameTokens(expected, got, func(a, b string) bool { return a == b })
+	}
+}
+
+// sameTokens compares the whitespace-separated tokens of a and b with same
+func sameTokens(a, b string, same func(a, b string) bool) bool {
+	ta, tb := strings.Fields(a), strings.Fields(b)
+	if len(ta) != len(tb) {
+		return false
+	}
+	for i := range ta {
+		if !same(ta[i], tb[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameNumber compares two tokens as numbers within FloatEpsilon, absolute
+// or relative to the expected one; tokens that aren't numbers must be equal
+func sameNumber(expected, got string) bool {
+	if expected == got {
+		return true
+	}
+	e, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return false
+	}
+	g, err := strconv.ParseFloat(got, 64)
+	if err != nil || math.IsNaN(g) {
+		return false
+	}
+	diff := math.Abs(e - g)
+	return diff <= FloatEpsilon || diff <= FloatEpsilon*math.Abs(e)
+}
@@ -0,0 +1,84 @@
+// Package runner runs solutions against local sample tests
+package runner
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorReset = "\033[0m"
+)
+
+// DiffString returns a line-by-line diff of expected and got output, or ""
+// if they are identical. Missing lines are "-" (green), lines the solution
+// printed instead are "+" (red), and the first differing line is marked with
+// ">". Trailing spaces and tabs are shown as "·" and "→" since Codeforces
+// output comparison can be whitespace-sensitive. Output that only differs in
+// trailing newlines is reported as such instead of as a full diff.
+func DiffString(expected, got string) string {
+	if expected == got {
+		return ""
+	}
+
+	trimmedExpected := strings.TrimRight(expected, "\n")
+	trimmedGot := strings.TrimRight(got, "\n")
+	if trimmedExpected == trimmedGot {
+		return fmt.Sprintf("output matches except for trailing newlines: expected %d, got %d\n",
+			len(expected)-len(trimmedExpected), len(got)-len(trimmedGot))
+	}
+
+	expLines := strings.Split(trimmedExpected, "\n")
+	gotLines := strings.Split(trimmedGot, "\n")
+
+	var sb strings.Builder
+	sb.WriteString("--- expected\n+++ got\n")
+
+	first := true
+	for i := 0; i < len(expLines) || i < len(gotLines); i++ {
+		exp, expOK := line(expLines, i)
+		act, gotOK := line(gotLines, i)
+
+		if expOK && gotOK && exp == act {
+			sb.WriteString(fmt.Sprintf("  %4d   %s\n", i+1, showWhitespace(exp)))
+			continue
+		}
+
+		marker := " "
+		if first {
+			marker = ">"
+			first = false
+		}
+
+		sb.WriteString(fmt.Sprintf("%s %4d %s- %s%s\n", marker, i+1, colorGreen, lineText(exp, expOK), colorReset))
+		sb.WriteString(fmt.Sprintf("       %s+ %s%s\n", colorRed, lineText(act, gotOK), colorReset))
+	}
+
+	return sb.String()
+}
+
+// line returns lines[i] and whether it exists
+func line(lines []string, i int) (string, bool) {
+	if i < len(lines) {
+		return lines[i], true
+	}
+	return "", false
+}
+
+func lineText(s string, ok bool) string {
+	if !ok {
+		return "(no line)"
+	}
+	return showWhitespace(s)
+}
+
+// showWhitespace makes trailing spaces and tabs visible
+func showWhitespace(s string) string {
+	trimmed := strings.TrimRight(s, " \t")
+	trailing := s[len(trimmed):]
+	trailing = strings.ReplaceAll(trailing, " ", "·")
+	trailing = strings.ReplaceAll(trailing, "\t", "→")
+	return trimmed + trailing
+}
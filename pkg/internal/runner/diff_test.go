@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffString_Equal(t *testing.T) {
+	if got := DiffString("1 2 3\n", "1 2 3\n"); got != "" {
+		t.Errorf("DiffString() = %q, want empty", got)
+	}
+}
+
+func TestDiffString_TrailingNewline(t *testing.T) {
+	got := DiffString("1 2 3\n", "1 2 3")
+	want := "output matches except for trailing newlines: expected 1, got 0\n"
+	if got != want {
+		t.Errorf("DiffString() = %q, want %q", got, want)
+	}
+}
+
+func TestDiffString_FirstDifferingLine(t *testing.T) {
+	got := DiffString("3\n1 2 3\nYES\n", "3\n1 2 4\nNO\n")
+
+	want := "--- expected\n+++ got\n" +
+		"     1   3\n" +
+		">    2 " + colorGreen + "- 1 2 3" + colorReset + "\n" +
+		"       " + colorRed + "+ 1 2 4" + colorReset + "\n" +
+		"     3 " + colorGreen + "- YES" + colorReset + "\n" +
+		"       " + colorRed + "+ NO" + colorReset + "\n"
+	if got != want {
+		t.Errorf("DiffString() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDiffString_MissingAndExtraLines(t *testing.T) {
+	got := DiffString("1\n2\n", "1\n")
+	if !strings.Contains(got, "- 2") || !strings.Contains(got, "+ (no line)") {
+		t.Errorf("DiffString() should show a missing line, got:\n%s", got)
+	}
+
+	got = DiffString("1\n", "1\n2\n")
+	if !strings.Contains(got, "- (no line)") || !strings.Contains(got, "+ 2") {
+		t.Errorf("DiffString() should show an extra line, got:\n%s", got)
+	}
+}
+
+func TestDiffString_TrailingWhitespace(t *testing.T) {
+	got := DiffString("1 2\n", "1 2 \n")
+	if !strings.Contains(got, "+ 1 2·") {
+		t.Errorf("DiffString() should show trailing space, got:\n%s", got)
+	}
+}
+
+func TestShowWhitespace(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"abc", "abc"},
+		{"a b  ", "a b··"},
+		{"a\t", "a→"},
+		{"  lead", "  lead"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := showWhitespace(tt.input); got != tt.want {
+			t.Errorf("showWhitespace(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
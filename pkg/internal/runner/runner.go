@@ -0,0 +1,355 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is the per-case time limit when none is configured
+const DefaultTimeout = 2 * time.Second
+
+// checkerTimeout is the time limit of a checker run on one case
+const checkerTimeout = 10 * time.Second
+
+// MaxConcurrency caps parallel cases, since each runs its own solution
+// process with its own memory
+var MaxConcurrency = runtime.NumCPU()
+
+// Verdict is the outcome of one test case, named like the judge verdicts
+type Verdict string
+
+const (
+	VerdictAccepted     Verdict = "OK"
+	VerdictWrongAnswer  Verdict = "WRONG_ANSWER"
+	VerdictTimeLimit    Verdict = "TIME_LIMIT_EXCEEDED"
+	VerdictRuntimeError Verdict = "RUNTIME_ERROR"
+	VerdictCompileError Verdict = "COMPILATION_ERROR"
+
+	// VerdictManualReview is given to cases without an expected output and
+	// no checker, whose output has to be checked by hand
+	VerdictManualReview Verdict = "MANUAL_REVIEW"
+)
+
+// TestCase is a sample input with its expected output
+type TestCase struct {
+	Name       string // e.g. sample_1
+	InputPath  string
+	OutputPath string // Empty if the case has no expected output
+}
+
+// TestResult is the outcome of running a solution on one test case
+type TestResult struct {
+	Name     string
+	Verdict  Verdict
+	Duration time.Duration
+
+	Output   string
+	Expected string
+	Diff     string // Set for WrongAnswer, see DiffString
+	Checker  string // Output of the checker, if one judged the case
+
+	// Stderr of the solution for RuntimeError, of the compiler for CompileError
+	Stderr   string
+	ExitCode int
+}
+
+// Passed returns true if the solution was accepted on the case
+func (r TestResult) Passed() bool {
+	return r.Verdict == VerdictAccepted
+}
+
+// Option configures RunTests
+type Option func(*options)
+
+type options struct {
+	timeout     time.Duration
+	concurrency int
+	commands    map[string]Command
+	checker     string
+	checkerDir  string
+	comparison  Comparison
+}
+
+// WithTimeout sets the time limit of each test case
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// WithConcurrency runs up to n cases in parallel, capped at MaxConcurrency
+// Results keep the order of the cases. Timings of parallel cases compete
+// for the CPU, so keep n low when a solution is close to the time limit.
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithCommands sets the build and run commands per language, on top of
+// DefaultCommands; a command without a compile or run template keeps the
+// default one (see Command.Merge)
+func WithCommands(commands map[string]Command) Option {
+	return func(o *options) {
+		for lang, c := range commands {
+			o.commands[lang] = o.commands[lang].Merge(c)
+		}
+	}
+}
+
+// WithChecker judges outputs with a checker command instead of comparing
+// them to the expected output, for problems with several correct answers.
+// The command template may use {input}, {output} (the solution output) and
+// {answer} (the expected output, empty if there is none); it runs in dir and
+// accepts the output by exiting with status 0.
+func WithChecker(command, dir string) Option {
+	return func(o *options) {
+		o.checker = command
+		o.checkerDir = dir
+	}
+}
+
+// WithComparison sets how outputs are compared to the expected outputs when
+// there is no checker; the default is DefaultComparison
+func WithComparison(c Comparison) Option {
+	return func(o *options) {
+		if c != "" {
+			o.comparison = c
+		}
+	}
+}
+
+// ValidateChecker checks that a checker command only uses known placeholders
+func ValidateChecker(command string) error {
+	if strings.TrimSpace(command) == "" {
+		return fmt.Errorf("checker command is empty")
+	}
+	return checkPlaceholders(command, "{input}", "{output}", "{answer}")
+}
+
+// RunTests builds solution and runs it on each case. A solution that does
+// not compile gets CompileError on every case. The error is only set when
+// the tests could not be run at all, e.g. for an unsupported language or a
+// missing compiler.
+func RunTests(ctx context.Context, solution string, cases []TestCase, opts ...Option) ([]TestResult, error) {
+	o := options{timeout: DefaultTimeout, concurrency: 1, comparison: DefaultComparison, commands: make(map[string]Command, len(DefaultCommands))}
+	for lang, c := range DefaultCommands {
+		o.commands[lang] = c
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	name := Language(solution)
+	lang, ok := o.commands[name]
+	if !ok {
+		return nil, fmt.Errorf("%s (%s): %w", filepath.Base(solution), name, ErrUnsupportedLanguage)
+	}
+	if err := lang.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s command: %w", name, err)
+	}
+	// Keep the parsed value, since Match only knows the lowercase names
+	comparison, err := ParseComparison(string(o.comparison))
+	if err != nil {
+		return nil, err
+	}
+	o.comparison = comparison
+	if o.checker != "" {
+		if err := ValidateChecker(o.checker); err != nil {
+			return nil, fmt.Errorf("invalid checker: %w", err)
+		}
+	}
+
+	buildDir, err := os.MkdirTemp("", "cf-run-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build dir: %w", err)
+	}
+	defer os.RemoveAll(buildDir)
+
+	src, err := filepath.Abs(solution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve solution path: %w", err)
+	}
+	bin := filepath.Join(buildDir, "solution")
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+
+	if strings.TrimSpace(lang.Compile) != "" {
+		stderr, err := build(ctx, Expand(lang.Compile, src, bin, ""), buildDir)
+		if err != nil {
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) {
+				return nil, fmt.Errorf("failed to run compiler: %w", err)
+			}
+			results := make([]TestResult, len(cases))
+			for i, tc := range cases {
+				results[i] = TestResult{Name: tc.Name, Verdict: VerdictCompileError, Stderr: stderr, ExitCode: exitErr.ExitCode()}
+			}
+			return results, nil
+		}
+	}
+
+	run := func(input string) []string { return Expand(lang.Run, src, bin, input) }
+	return runCases(ctx, run, cases, o, buildDir)
+}
+
+// runCases runs the cases with a bounded worker pool; each case has its own
+// process and timeout, so a slow case only holds up its own worker
+// Solution outputs for the checker are written to tmpDir.
+func runCases(ctx context.Context, run func(input string) []string, cases []TestCase, o options, tmpDir string) ([]TestResult, error) {
+	concurrency := min(o.concurrency, MaxConcurrency, len(cases))
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]TestResult, len(cases))
+	errs := make([]error, len(cases))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = runCase(ctx, run(cases[i].InputPath), cases[i], o, tmpDir)
+			}
+		}()
+	}
+
+	for i := range cases {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// build runs the compile command and returns its output
+func build(ctx context.Context, args []string, dir string) (string, error) {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return out.String(), err
+}
+
+// runCase runs the solution on one case and judges its output
+func runCase(ctx context.Context, args []string, tc TestCase, o options, tmpDir string) (TestResult, error) {
+	result := TestResult{Name: tc.Name}
+
+	input, err := os.Open(tc.InputPath)
+	if err != nil {
+		return result, fmt.Errorf("failed to open %s: %w", tc.InputPath, err)
+	}
+	defer input.Close()
+
+	if tc.OutputPath != "" {
+		expected, err := os.ReadFile(tc.OutputPath)
+		if err != nil {
+			return result, fmt.Errorf("failed to read %s: %w", tc.OutputPath, err)
+		}
+		result.Expected = string(expected)
+	}
+
+	caseCtx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(caseCtx, args[0], args[1:]...)
+	cmd.Stdin = input
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait on pipes held open by children of a killed solution
+	cmd.WaitDelay = 100 * time.Millisecond
+
+	start := time.Now()
+	err = cmd.Run()
+	result.Duration = time.Since(start)
+	result.Output = stdout.String()
+	result.Stderr = stderr.String()
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		return result, ctx.Err()
+	case caseCtx.Err() == context.DeadlineExceeded:
+		result.Verdict = VerdictTimeLimit
+	case errors.As(err, &exitErr):
+		result.Verdict = VerdictRuntimeError
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		return result, fmt.Errorf("failed to run solution: %w", err)
+	case o.checker != "":
+		return runChecker(ctx, result, tc, o, tmpDir)
+	case tc.OutputPath == "":
+		result.Verdict = VerdictManualReview
+	case o.comparison.Match(result.Expected, result.Output):
+		result.Verdict = VerdictAccepted
+	default:
+		result.Verdict = VerdictWrongAnswer
+		result.Diff = DiffString(result.Expected, result.Output)
+	}
+	return result, nil
+}
+
+// runChecker judges the solution output of result with the checker
+func runChecker(ctx context.Context, result TestResult, tc TestCase, o options, tmpDir string) (TestResult, error) {
+	output := filepath.Join(tmpDir, tc.Name+".out")
+	if err := os.WriteFile(output, []byte(result.Output), 0644); err != nil {
+		return result, fmt.Errorf("failed to write solution output: %w", err)
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, checkerTimeout)
+	defer cancel()
+
+	args := fill(o.checker, strings.NewReplacer("{input}", tc.InputPath, "{output}", output, "{answer}", tc.OutputPath))
+	cmd := exec.CommandContext(checkCtx, args[0], args[1:]...)
+	cmd.Dir = o.checkerDir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	result.Checker = strings.TrimSpace(out.String())
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		return result, ctx.Err()
+	case checkCtx.Err() == context.DeadlineExceeded:
+		return result, fmt.Errorf("checker timed out on %s", tc.Name)
+	case err == nil:
+		result.Verdict = VerdictAccepted
+	case errors.As(err, &exitErr):
+		result.Verdict = VerdictWrongAnswer
+		if tc.OutputPath != "" {
+			result.Diff = DiffString(result.Expected, result.Output)
+		}
+	default:
+		return result, fmt.Errorf("failed to run checker: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,346 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sampleCase writes one test case doubling 21
+func sampleCase(t *testing.T) []TestCase {
+	t.Helper()
+	dir := t.TempDir()
+	in := filepath.Join(dir, "sample_1.in")
+	out := filepath.Join(dir, "sample_1.out")
+	if err := os.WriteFile(in, []byte("21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(out, []byte("42\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return []TestCase{{Name: "sample_1", InputPath: in, OutputPath: out}}
+}
+
+func requireGo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not in PATH")
+	}
+}
+
+func TestRunTests_Verdicts(t *testing.T) {
+	requireGo(t)
+
+	tests := []struct {
+		fixture string
+		want    Verdict
+		check   func(t *testing.T, r TestResult)
+	}{
+		{"double.go", VerdictAccepted, nil},
+		{"wrong.go", VerdictWrongAnswer, func(t *testing.T, r TestResult) {
+			if !strings.Contains(r.Diff, "43") {
+				t.Errorf("Diff = %q, want the solution output", r.Diff)
+			}
+		}},
+		{"crash.go", VerdictRuntimeError, func(t *testing.T, r TestResult) {
+			if r.ExitCode != 3 || !strings.Contains(r.Stderr, "index out of range") {
+				t.Errorf("ExitCode = %d, Stderr = %q, want 3 and the panic message", r.ExitCode, r.Stderr)
+			}
+		}},
+		{"slow.go", VerdictTimeLimit, func(t *testing.T, r TestResult) {
+			if r.Duration > 5*time.Second {
+				t.Errorf("Duration = %v, want the solution killed at the timeout", r.Duration)
+			}
+		}},
+		{"broken.go", VerdictCompileError, func(t *testing.T, r TestResult) {
+			if !strings.Contains(r.Stderr, "undefined") {
+				t.Errorf("Stderr = %q, want the compiler error", r.Stderr)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			results, err := RunTests(context.Background(), filepath.Join("testdata", tt.fixture), sampleCase(t),
+				WithTimeout(500*time.Millisecond))
+			if err != nil {
+				t.Fatalf("RunTests() error = %v", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("RunTests() returned %d results, want 1", len(results))
+			}
+			if results[0].Verdict != tt.want {
+				t.Fatalf("Verdict = %s, want %s (stderr: %s)", results[0].Verdict, tt.want, results[0].Stderr)
+			}
+			if tt.check != nil {
+				tt.check(t, results[0])
+			}
+		})
+	}
+}
+
+func TestRunTests_UnsupportedLanguage(t *testing.T) {
+	if _, err := RunTests(context.Background(), "solution.cobol", nil); err == nil {
+		t.Error("RunTests() should reject an unknown extension")
+	}
+}
+
+func TestComparison_Match(t *testing.T) {
+	tests := []struct {
+		name       string
+		comparison Comparison
+		expected   string
+		got        string
+		want       bool
+	}{
+		{"exact same", CompareExact, "1 2\n3\n", "1 2\n3", true},
+		{"exact inner whitespace", CompareExact, "1 2\n", "1  2\n", false},
+		{"exact trailing spaces", CompareExact, "1 2\n", "1 2 \n", false},
+		{"tokens whitespace", CompareTokens, "1 2\n3\n", "1 2   \r\n3\n\n", true},
+		{"tokens inner whitespace", CompareTokens, "1 2\n", "1\n2", true},
+		{"tokens differ", CompareTokens, "1 2\n", "1 3\n", false},
+		{"tokens extra token", CompareTokens, "1 2\n", "1 2 3\n", false},
+		{"tokens numbers", CompareTokens, "0.5\n", "0.50\n", false},
+		{"float within epsilon", CompareFloat, "0.333333\n", "0.3333333333\n", true},
+		{"float relative error", CompareFloat, "1000000000\n", "1000000100\n", true},
+		{"float too far", CompareFloat, "0.5\n", "0.51\n", false},
+		{"float words", CompareFloat, "YES 0.5\n", "YES 0.5000001\n", true},
+		{"float word differs", CompareFloat, "YES 0.5\n", "NO 0.5\n", false},
+		{"float nan", CompareFloat, "0.5\n", "NaN\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.comparison.Match(tt.expected, tt.got); got != tt.want {
+				t.Errorf("%s.Match(%q, %q) = %v, want %v", tt.comparison, tt.expected, tt.got, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseComparison(t *testing.T) {
+	if c, err := ParseComparison(""); err != nil || c != CompareTokens {
+		t.Errorf("ParseComparison(\"\") = %q, %v, want tokens", c, err)
+	}
+	if c, err := ParseComparison("Float"); err != nil || c != CompareFloat {
+		t.Errorf("ParseComparison(Float) = %q, %v, want float", c, err)
+	}
+	if _, err := ParseComparison("lines"); err == nil {
+		t.Error("ParseComparison() should reject an unknown comparison")
+	}
+}
+
+func TestRunTests_Comparison(t *testing.T) {
+	requireGo(t)
+
+	// third.go prints a third of the input with 9 decimals: 7.333333333
+	tests := []struct {
+		fixture    string
+		expected   string
+		comparison Comparison
+		want       Verdict
+	}{
+		{"third.go", "7.3333333\n", CompareFloat, VerdictAccepted},
+		{"third.go", "7.3333333\n", CompareExact, VerdictWrongAnswer},
+		{"third.go", "7.3333333\n", CompareTokens, VerdictWrongAnswer},
+		{"third.go", "7.33\n", CompareFloat, VerdictWrongAnswer},
+		{"double.go", "  44 \n\n", CompareTokens, VerdictAccepted},
+		{"double.go", "  44 \n\n", CompareExact, VerdictWrongAnswer},
+		{"double.go", "44", CompareExact, VerdictAccepted},
+		// Names are case-insensitive, as in problem.yaml
+		{"double.go", "  44 \n\n", "EXACT", VerdictWrongAnswer},
+		{"third.go", "7.3333333\n", "Float", VerdictAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.fixture, tt.comparison), func(t *testing.T) {
+			dir := t.TempDir()
+			in := filepath.Join(dir, "sample_1.in")
+			out := filepath.Join(dir, "sample_1.out")
+			if err := os.WriteFile(in, []byte("22\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(out, []byte(tt.expected), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cases := []TestCase{{Name: "sample_1", InputPath: in, OutputPath: out}}
+
+			results, err := RunTests(context.Background(), filepath.Join("testdata", tt.fixture), cases, WithComparison(tt.comparison))
+			if err != nil {
+				t.Fatalf("RunTests() error = %v", err)
+			}
+			if results[0].Verdict != tt.want {
+				t.Errorf("Verdict = %s with output %q, want %s", results[0].Verdict, results[0].Output, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunTests_UnknownComparison(t *testing.T) {
+	if _, err := RunTests(context.Background(), "main.py", nil, WithComparison("lines")); err == nil {
+		t.Error("RunTests() should reject an unknown comparison")
+	}
+}
+
+func TestLoadTests_Order(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"sample_10", "sample_2", "sample_1"} {
+		for _, ext := range []string{".in", ".out"} {
+			if err := os.WriteFile(filepath.Join(dir, name+ext), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	cases, err := LoadTests(dir)
+	if err != nil {
+		t.Fatalf("LoadTests() error = %v", err)
+	}
+	var names []string
+	for _, c := range cases {
+		names = append(names, c.Name)
+	}
+	if got := strings.Join(names, ","); got != "sample_1,sample_2,sample_10" {
+		t.Errorf("LoadTests() order = %s, want sample_1,sample_2,sample_10", got)
+	}
+}
+
+func TestParseTimeLimit(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"2 seconds", 2 * time.Second, true},
+		{"1 second", time.Second, true},
+		{"0.5 seconds", 500 * time.Millisecond, true},
+		{"", 0, false},
+		{"fast", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseTimeLimit(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseTimeLimit(%q) = %v, %v, want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRunTests_Concurrent(t *testing.T) {
+	requireGo(t)
+
+	orig := MaxConcurrency
+	MaxConcurrency = 4
+	defer func() { MaxConcurrency = orig }()
+
+	// Three cases meet at a barrier, which they only pass if they run at
+	// the same time; the fourth one times out and must not hold them up
+	dir := t.TempDir()
+	barrier := t.TempDir()
+	var cases []TestCase
+	for i, n := range []int{0, 3, 3, 3} {
+		name := fmt.Sprintf("sample_%d", i+1)
+		in := filepath.Join(dir, name+".in")
+		out := filepath.Join(dir, name+".out")
+		if err := os.WriteFile(in, []byte(fmt.Sprintf("%s %d\n", barrier, n)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(out, []byte(fmt.Sprintf("%d\n", n)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cases = append(cases, TestCase{Name: name, InputPath: in, OutputPath: out})
+	}
+
+	results, err := RunTests(context.Background(), filepath.Join("testdata", "barrier.go"), cases,
+		WithTimeout(2*time.Second), WithConcurrency(4))
+	if err != nil {
+		t.Fatalf("RunTests() error = %v", err)
+	}
+
+	want := []Verdict{VerdictTimeLimit, VerdictAccepted, VerdictAccepted, VerdictAccepted}
+	for i, r := range results {
+		if r.Name != cases[i].Name || r.Verdict != want[i] {
+			t.Errorf("results[%d] = %s %s, want %s %s", i, r.Name, r.Verdict, cases[i].Name, want[i])
+		}
+	}
+}
+
+func TestLoadTests_MissingOutput(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"sample_1.in", "sample_1.out", "sample_2.in"} {
+		if err := os.WriteFile(filepath.Join(dir, file), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases, err := LoadTests(dir)
+	if err != nil {
+		t.Fatalf("LoadTests() error = %v", err)
+	}
+	if len(cases) != 2 || cases[0].OutputPath == "" || cases[1].OutputPath != "" {
+		t.Errorf("LoadTests() = %+v, want sample_2 without an output", cases)
+	}
+}
+
+func TestRunTests_ManualReview(t *testing.T) {
+	requireGo(t)
+
+	cases := sampleCase(t)
+	cases[0].OutputPath = ""
+	results, err := RunTests(context.Background(), filepath.Join("testdata", "double.go"), cases)
+	if err != nil {
+		t.Fatalf("RunTests() error = %v", err)
+	}
+	if r := results[0]; r.Verdict != VerdictManualReview || strings.TrimSpace(r.Output) != "42" {
+		t.Errorf("result = %s with output %q, want %s with the solution output", r.Verdict, r.Output, VerdictManualReview)
+	}
+}
+
+func TestRunTests_Checker(t *testing.T) {
+	requireGo(t)
+
+	checker := filepath.Join(t.TempDir(), "checker")
+	if out, err := exec.Command("go", "build", "-o", checker, filepath.Join("testdata", "checker.go")).CombinedOutput(); err != nil {
+		t.Fatalf("failed to build checker: %v\n%s", err, out)
+	}
+	withChecker := WithChecker(checker+" {input} {output} {answer}", "")
+
+	t.Run("pass", func(t *testing.T) {
+		// The checker decides, so a different expected output doesn't matter
+		cases := sampleCase(t)
+		if err := os.WriteFile(cases[0].OutputPath, []byte("another answer\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		results, err := RunTests(context.Background(), filepath.Join("testdata", "double.go"), cases, withChecker)
+		if err != nil {
+			t.Fatalf("RunTests() error = %v", err)
+		}
+		if !results[0].Passed() {
+			t.Errorf("Verdict = %s (%s), want %s", results[0].Verdict, results[0].Checker, VerdictAccepted)
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		cases := sampleCase(t)
+		cases[0].OutputPath = ""
+		results, err := RunTests(context.Background(), filepath.Join("testdata", "wrong.go"), cases, withChecker)
+		if err != nil {
+			t.Fatalf("RunTests() error = %v", err)
+		}
+		r := results[0]
+		if r.Verdict != VerdictWrongAnswer || !strings.Contains(r.Checker, "found 43") {
+			t.Errorf("result = %s with checker output %q, want %s with the checker message", r.Verdict, r.Checker, VerdictWrongAnswer)
+		}
+	})
+
+	t.Run("unknown placeholder", func(t *testing.T) {
+		_, err := RunTests(context.Background(), "main.py", nil, WithChecker(checker+" {expected}", ""))
+		if err == nil {
+			t.Error("RunTests() should reject a checker with an unknown placeholder")
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Waits at a barrier in dir until n runs have arrived, then prints n, so it
+// only finishes in time when the runs overlap; n = 0 never finishes in time
+func main() {
+	var dir string
+	var n int
+	fmt.Scan(&dir, &n)
+	if n == 0 {
+		time.Sleep(10 * time.Second)
+	}
+
+	f, err := os.CreateTemp(dir, "arrived-")
+	if err != nil {
+		os.Exit(1)
+	}
+	f.Close()
+	for {
+		if entries, _ := os.ReadDir(dir); len(entries) >= n {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	fmt.Println(n)
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Accepts any output that is twice the input, ignoring the answer file
+func main() {
+	var n, got int
+	in, _ := os.Open(os.Args[1])
+	fmt.Fscan(in, &n)
+	out, _ := os.Open(os.Args[2])
+	if _, err := fmt.Fscan(out, &got); err != nil || got != 2*n {
+		fmt.Printf("wrong answer: expected %d, found %d\n", 2*n, got)
+		os.Exit(1)
+	}
+}
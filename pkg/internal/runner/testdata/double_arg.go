@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Doubles the number in the file named by the first argument
+func main() {
+	f, err := os.Open(os.Args[1])
+	if err != nil {
+		panic(err)
+	}
+	var n int
+	fmt.Fscan(f, &n)
+	fmt.Println(n * 2)
+}
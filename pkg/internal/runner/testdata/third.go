@@ -0,0 +1,9 @@
+package main
+
+import "fmt"
+
+func main() {
+	var n int
+	fmt.Scan(&n)
+	fmt.Printf("%.9f\n", float64(n)/3)
+}
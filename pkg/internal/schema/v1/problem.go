@@ -29,6 +29,14 @@ type Problem struct {
 	// Sample test cases
 	Samples []Sample `yaml:"samples" json:"samples"`
 
+	// Checker is a command judging sample outputs, for interactive and
+	// multi-answer problems; see runner.WithChecker for its placeholders
+	Checker string `yaml:"checker,omitempty" json:"checker,omitempty"`
+
+	// Compare is how sample outputs are compared without a checker: exact,
+	// tokens (the default) or float; see runner.Comparison
+	Compare string `yaml:"compare,omitempty" json:"compare,omitempty"`
+
 	// User practice data
 	Practice PracticeData `yaml:"practice" json:"practice"`
 
@@ -45,6 +53,10 @@ type ProblemMetadata struct {
 	Rating      int      `yaml:"rating" json:"rating"`
 	Tags        []string `yaml:"tags" json:"tags"`
 	SolvedCount int      `yaml:"solvedCount,omitempty" json:"solvedCount,omitempty"`
+
+	// RatingEstimated is set when Rating was estimated from contest
+	// standings because the API has no rating for the problem
+	RatingEstimated bool `yaml:"ratingEstimated,omitempty" json:"ratingEstimated,omitempty"`
 }
 
 // ProblemLimits holds problem constraints
@@ -79,6 +91,7 @@ const (
 	StatusUnseen    PracticeStatus = "unseen"
 	StatusAttempted PracticeStatus = "attempted"
 	StatusSolved    PracticeStatus = "solved"
+	StatusTodo      PracticeStatus = "todo"
 )
 
 // UserNotes holds user's personal notes
@@ -88,6 +101,7 @@ type UserNotes struct {
 	Approach   string   `yaml:"approach,omitempty" json:"approach,omitempty"`
 	Reminder   string   `yaml:"reminder,omitempty" json:"reminder,omitempty"`
 	Review     bool     `yaml:"review,omitempty" json:"review,omitempty"`
+	Pinned     bool     `yaml:"pinned,omitempty" json:"pinned,omitempty"` // Actively being worked on
 }
 
 // NewProblem creates a new problem with defaults
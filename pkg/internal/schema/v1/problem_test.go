@@ -1,10 +1,12 @@
 package v1
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/harshit-vibes/cf/pkg/internal/schema"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNewProblem(t *testing.T) {
@@ -300,3 +302,23 @@ func TestProblem_EmptyNotes(t *testing.T) {
 		t.Errorf("Notes.CustomTags should be empty by default")
 	}
 }
+
+func TestUserNotes_PinnedBackwardCompatible(t *testing.T) {
+	// Problems saved before pins existed have no pinned key
+	var notes UserNotes
+	if err := yaml.Unmarshal([]byte("approach: greedy\nreview: true\n"), &notes); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if notes.Pinned {
+		t.Error("Pinned should default to false")
+	}
+
+	// Unpinned notes do not write the key
+	data, err := yaml.Marshal(UserNotes{Approach: "greedy"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "pinned") {
+		t.Errorf("unpinned notes should omit pinned, got:\n%s", data)
+	}
+}
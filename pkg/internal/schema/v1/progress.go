@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"sort"
 	"time"
 
 	"github.com/harshit-vibes/cf/pkg/internal/schema"
@@ -21,6 +22,9 @@ type Progress struct {
 	// Tag distribution
 	TagDistribution map[string]int `yaml:"tagDistribution" json:"tagDistribution"`
 
+	// Sum of ratings of solved problems per tag, used for mastery scoring
+	TagRatingSum map[string]int `yaml:"tagRatingSum,omitempty" json:"tagRatingSum,omitempty"`
+
 	// Streak tracking
 	CurrentStreak int        `yaml:"currentStreak" json:"currentStreak"`
 	LongestStreak int        `yaml:"longestStreak" json:"longestStreak"`
@@ -28,6 +32,36 @@ type Progress struct {
 
 	// Daily entries
 	Daily []DailyProgress `yaml:"daily,omitempty" json:"daily,omitempty"`
+
+	// Weekly snapshots of the totals, oldest first, for trend reports
+	Snapshots []ProgressSnapshot `yaml:"snapshots,omitempty" json:"snapshots,omitempty"`
+}
+
+// MaxSnapshots bounds Progress.Snapshots to a year of weekly snapshots
+const MaxSnapshots = 52
+
+// ProgressSnapshot is the lifetime totals of a Progress at a point in time
+type ProgressSnapshot struct {
+	Taken          time.Time `yaml:"taken" json:"taken"`
+	TotalSolved    int       `yaml:"totalSolved" json:"totalSolved"`
+	TotalAttempted int       `yaml:"totalAttempted" json:"totalAttempted"`
+	TotalTime      int       `yaml:"totalTime" json:"totalTime"` // seconds
+}
+
+// TrendReport compares the last period with the one before it
+// Complete is false when there are not enough snapshots to go back two
+// periods; Previous is then zero.
+type TrendReport struct {
+	Period   time.Duration
+	Current  ProgressSnapshot // Change over the last period
+	Previous ProgressSnapshot // Change over the period before
+	Complete bool
+}
+
+// SolvedChange returns how many more problems were solved in the last period
+// than in the one before; negative means fewer
+func (r TrendReport) SolvedChange() int {
+	return r.Current.TotalSolved - r.Previous.TotalSolved
 }
 
 // DailyProgress represents a single day's progress
@@ -45,12 +79,19 @@ func NewProgress() *Progress {
 		Schema:             schema.NewSchemaHeader(schema.TypeProgress),
 		RatingDistribution: make(map[string]int),
 		TagDistribution:    make(map[string]int),
+		TagRatingSum:       make(map[string]int),
 		Daily:              []DailyProgress{},
 	}
 }
 
-// AddSolved records a solved problem
+// AddSolved records a problem solved now
 func (p *Progress) AddSolved(problemID string, rating int, tags []string, timeSpent int) {
+	p.AddSolvedAt(problemID, rating, tags, timeSpent, time.Now())
+}
+
+// AddSolvedAt records a problem solved at the given time
+// Solves must be added in chronological order for streaks to be correct
+func (p *Progress) AddSolvedAt(problemID string, rating int, tags []string, timeSpent int, at time.Time) {
 	p.TotalSolved++
 	p.TotalTime += timeSpent
 
@@ -59,14 +100,21 @@ func (p *Progress) AddSolved(problemID string, rating int, tags []string, timeSp
 	p.RatingDistribution[ratingBucket]++
 
 	// Update tag distribution
+	if p.TagRatingSum == nil {
+		p.TagRatingSum = make(map[string]int)
+	}
 	for _, tag := range tags {
+		if _, ok := p.TagRatingSum[tag]; !ok {
+			// Seed from solves recorded before ratings were tracked
+			p.TagRatingSum[tag] = p.TagDistribution[tag] * minMasteryRating
+		}
 		p.TagDistribution[tag]++
+		p.TagRatingSum[tag] += masteryRating(rating)
 	}
 
 	// Update streak
-	now := time.Now()
 	if p.LastActivity != nil {
-		daysDiff := daysBetween(*p.LastActivity, now)
+		daysDiff := daysBetween(*p.LastActivity, at)
 		if daysDiff == 1 {
 			p.CurrentStreak++
 		} else if daysDiff > 1 {
@@ -80,21 +128,198 @@ func (p *Progress) AddSolved(problemID string, rating int, tags []string, timeSp
 		p.LongestStreak = p.CurrentStreak
 	}
 
-	p.LastActivity = &now
+	p.LastActivity = &at
 
 	// Update daily
-	p.updateDaily(problemID, true, timeSpent)
+	p.updateDaily(at, problemID, true, timeSpent)
 }
 
 // AddAttempted records an attempted problem
 func (p *Progress) AddAttempted(problemID string, timeSpent int) {
 	p.TotalAttempted++
 	p.TotalTime += timeSpent
-	p.updateDaily(problemID, false, timeSpent)
+	p.updateDaily(time.Now(), problemID, false, timeSpent)
+}
+
+// Snapshot records the current totals and returns them
+func (p *Progress) Snapshot() ProgressSnapshot {
+	return p.SnapshotAt(time.Now())
+}
+
+// SnapshotAt records the totals as of at. A snapshot in the same ISO week
+// is replaced, so there is one per week, and only the last MaxSnapshots
+// are kept.
+func (p *Progress) SnapshotAt(at time.Time) ProgressSnapshot {
+	snap := ProgressSnapshot{
+		Taken:          at,
+		TotalSolved:    p.TotalSolved,
+		TotalAttempted: p.TotalAttempted,
+		TotalTime:      p.TotalTime,
+	}
+
+	if n := len(p.Snapshots); n > 0 && sameWeek(p.Snapshots[n-1].Taken, at) {
+		p.Snapshots[n-1] = snap
+		return snap
+	}
+
+	p.Snapshots = append(p.Snapshots, snap)
+	if len(p.Snapshots) > MaxSnapshots {
+		p.Snapshots = p.Snapshots[len(p.Snapshots)-MaxSnapshots:]
+	}
+	return snap
+}
+
+// Trend reports the change in totals over the last period, ending at the
+// latest snapshot, and over the period before. Each period starts at the
+// snapshot taken closest to its start.
+func (p *Progress) Trend(period time.Duration) TrendReport {
+	report := TrendReport{Period: period}
+	if len(p.Snapshots) < 2 || period <= 0 {
+		return report
+	}
+
+	latest := p.Snapshots[len(p.Snapshots)-1]
+	start, ok := p.closestSnapshot(latest.Taken.Add(-period), latest.Taken)
+	if !ok {
+		return report
+	}
+	report.Current = snapshotDelta(start, latest)
+
+	if before, ok := p.closestSnapshot(start.Taken.Add(-period), start.Taken); ok {
+		report.Previous = snapshotDelta(before, start)
+		report.Complete = true
+	}
+	return report
+}
+
+// closestSnapshot returns the snapshot taken before end that is closest to t
+func (p *Progress) closestSnapshot(t, end time.Time) (ProgressSnapshot, bool) {
+	var best ProgressSnapshot
+	found := false
+	for _, s := range p.Snapshots {
+		if !s.Taken.Before(end) {
+			continue
+		}
+		if !found || absDuration(s.Taken.Sub(t)) < absDuration(best.Taken.Sub(t)) {
+			best, found = s, true
+		}
+	}
+	return best, found
+}
+
+// snapshotDelta returns the change in totals from a to b, taken at b
+func snapshotDelta(a, b ProgressSnapshot) ProgressSnapshot {
+	return ProgressSnapshot{
+		Taken:          b.Taken,
+		TotalSolved:    b.TotalSolved - a.TotalSolved,
+		TotalAttempted: b.TotalAttempted - a.TotalAttempted,
+		TotalTime:      b.TotalTime - a.TotalTime,
+	}
+}
+
+func sameWeek(a, b time.Time) bool {
+	ay, aw := a.ISOWeek()
+	by, bw := b.ISOWeek()
+	return ay == by && aw == bw
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// SolvedByDay returns the number of problems solved per YYYY-MM-DD date
+func (p *Progress) SolvedByDay() map[string]int {
+	days := make(map[string]int, len(p.Daily))
+	for _, d := range p.Daily {
+		if d.Solved > 0 {
+			days[d.Date] += d.Solved
+		}
+	}
+	return days
+}
+
+// Streaks computes the current and longest solve streaks from Daily as of now
+// Like AddSolved, a streak is still current if the last solve was yesterday,
+// so today counts once something is solved but does not break it before then.
+func (p *Progress) Streaks(now time.Time) (current, longest int) {
+	var days []time.Time
+	for date := range p.SolvedByDay() {
+		if t, err := time.Parse("2006-01-02", date); err == nil {
+			days = append(days, t)
+		}
+	}
+	if len(days) == 0 {
+		return 0, 0
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	run := 0
+	for i, day := range days {
+		if i > 0 && daysBetween(days[i-1], day) == 1 {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+
+	if daysBetween(days[len(days)-1], now) <= 1 {
+		current = run
+	}
+	return current, longest
+}
+
+// TagMastery returns a score per tag in TagDistribution, weighted by the
+// ratings of the problems solved in it: each solve adds rating/1000, so
+// five 800s score 4.0 and two 2000s score 4.0
+// Solves recorded before ratings were tracked count as 800
+func (p *Progress) TagMastery() map[string]float64 {
+	mastery := make(map[string]float64, len(p.TagDistribution))
+	for tag, count := range p.TagDistribution {
+		sum, ok := p.TagRatingSum[tag]
+		if !ok {
+			sum = count * minMasteryRating
+		}
+		mastery[tag] = float64(sum) / 1000
+	}
+	return mastery
+}
+
+// TagsByMastery returns the tags in TagDistribution from strongest to weakest
+func (p *Progress) TagsByMastery() []string {
+	mastery := p.TagMastery()
+	tags := make([]string, 0, len(mastery))
+	for tag := range mastery {
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if mastery[tags[i]] != mastery[tags[j]] {
+			return mastery[tags[i]] > mastery[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+
+	return tags
+}
+
+// minMasteryRating is the lowest Codeforces problem rating, used for unrated problems
+const minMasteryRating = 800
+
+func masteryRating(rating int) int {
+	if rating < minMasteryRating {
+		return minMasteryRating
+	}
+	return rating
 }
 
-func (p *Progress) updateDaily(problemID string, solved bool, timeSpent int) {
-	today := time.Now().Format("2006-01-02")
+func (p *Progress) updateDaily(at time.Time, problemID string, solved bool, timeSpent int) {
+	today := at.Format("2006-01-02")
 
 	// Find or create today's entry
 	var todayEntry *DailyProgress
@@ -454,3 +454,204 @@ func TestProgress_StreakReset(t *testing.T) {
 		t.Errorf("LongestStreak = %v, want 10 (unchanged)", p.LongestStreak)
 	}
 }
+
+func TestProgress_AddSolved_TagRatingSum(t *testing.T) {
+	p := NewProgress()
+
+	p.AddSolved("1A", 1500, []string{"dp", "greedy"}, 100)
+	p.AddSolved("2A", 2000, []string{"dp"}, 100)
+	p.AddSolved("3A", 0, []string{"greedy"}, 100) // unrated counts as 800
+
+	if p.TagRatingSum["dp"] != 3500 {
+		t.Errorf("TagRatingSum[dp] = %v, want 3500", p.TagRatingSum["dp"])
+	}
+	if p.TagRatingSum["greedy"] != 2300 {
+		t.Errorf("TagRatingSum[greedy] = %v, want 2300", p.TagRatingSum["greedy"])
+	}
+}
+
+func TestProgress_TagMastery(t *testing.T) {
+	p := NewProgress()
+
+	for i := 0; i < 5; i++ {
+		p.AddSolved("1A", 800, []string{"implementation"}, 0)
+	}
+	p.AddSolved("2A", 2000, []string{"dp"}, 0)
+	p.AddSolved("3A", 2400, []string{"dp", "geometry"}, 0)
+
+	mastery := p.TagMastery()
+	want := map[string]float64{"implementation": 4.0, "dp": 4.4, "geometry": 2.4}
+	for tag, score := range want {
+		if mastery[tag] != score {
+			t.Errorf("TagMastery()[%s] = %v, want %v", tag, mastery[tag], score)
+		}
+	}
+
+	tags := p.TagsByMastery()
+	if len(tags) != 3 || tags[0] != "dp" || tags[2] != "geometry" {
+		t.Errorf("TagsByMastery() = %v, want [dp implementation geometry]", tags)
+	}
+}
+
+func TestProgress_TagMastery_LegacyYAML(t *testing.T) {
+	// Progress files written before tagRatingSum existed
+	data := []byte("totalSolved: 3\ntagDistribution:\n  math: 3\n")
+
+	var p Progress
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+
+	if got := p.TagMastery()["math"]; got != 2.4 {
+		t.Errorf("TagMastery()[math] = %v, want 2.4", got)
+	}
+
+	p.RatingDistribution = map[string]int{}
+	p.AddSolved("1A", 1200, []string{"dp", "math"}, 0)
+	if p.TagRatingSum["dp"] != 1200 {
+		t.Errorf("TagRatingSum[dp] = %v, want 1200", p.TagRatingSum["dp"])
+	}
+	if p.TagRatingSum["math"] != 3600 {
+		t.Errorf("TagRatingSum[math] = %v, want 3600 (3 legacy solves + 1200)", p.TagRatingSum["math"])
+	}
+
+	out, err := yaml.Marshal(&p)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	var decoded Progress
+	if err := yaml.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if decoded.TagRatingSum["dp"] != 1200 {
+		t.Errorf("round-tripped TagRatingSum[dp] = %v, want 1200", decoded.TagRatingSum["dp"])
+	}
+}
+
+func TestProgress_AddSolvedAt(t *testing.T) {
+	p := NewProgress()
+
+	day := time.Date(2024, 3, 1, 20, 0, 0, 0, time.Local)
+	p.AddSolvedAt("1A", 800, nil, 0, day)
+	p.AddSolvedAt("2A", 800, nil, 0, day.AddDate(0, 0, 1))
+	p.AddSolvedAt("3A", 800, nil, 0, day.AddDate(0, 0, 3))
+
+	if p.CurrentStreak != 1 {
+		t.Errorf("CurrentStreak = %v, want 1", p.CurrentStreak)
+	}
+	if p.LongestStreak != 2 {
+		t.Errorf("LongestStreak = %v, want 2", p.LongestStreak)
+	}
+	if len(p.Daily) != 3 || p.Daily[0].Date != "2024-03-01" {
+		t.Errorf("Daily = %+v, want 3 entries starting 2024-03-01", p.Daily)
+	}
+}
+
+func TestProgress_Streaks(t *testing.T) {
+	p := NewProgress()
+	p.Daily = []DailyProgress{
+		{Date: "2024-03-01", Solved: 1},
+		{Date: "2024-03-02", Solved: 2},
+		{Date: "2024-03-03", Solved: 1},
+		{Date: "2024-03-05", Attempted: 1}, // Attempts alone do not count
+		{Date: "2024-03-08", Solved: 1},
+		{Date: "2024-03-09", Solved: 1},
+	}
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		wantCurrent int
+	}{
+		{"solved today", time.Date(2024, 3, 9, 23, 0, 0, 0, time.Local), 2},
+		{"today not solved yet", time.Date(2024, 3, 10, 8, 0, 0, 0, time.Local), 2},
+		{"streak broken", time.Date(2024, 3, 11, 8, 0, 0, 0, time.Local), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := p.Streaks(tt.now)
+			if current != tt.wantCurrent {
+				t.Errorf("current = %v, want %v", current, tt.wantCurrent)
+			}
+			if longest != 3 {
+				t.Errorf("longest = %v, want 3", longest)
+			}
+		})
+	}
+}
+
+func TestProgress_Streaks_Empty(t *testing.T) {
+	current, longest := NewProgress().Streaks(time.Now())
+	if current != 0 || longest != 0 {
+		t.Errorf("Streaks() = %v, %v, want 0, 0", current, longest)
+	}
+}
+
+func TestProgress_Trend(t *testing.T) {
+	p := NewProgress()
+	start := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC) // Monday
+	week := 7 * 24 * time.Hour
+
+	for i, solved := range []int{10, 18, 30} {
+		p.TotalSolved = solved
+		p.SnapshotAt(start.Add(time.Duration(i) * week))
+	}
+
+	r := p.Trend(week)
+	if !r.Complete {
+		t.Fatal("Trend() Complete = false, want true")
+	}
+	if r.Current.TotalSolved != 12 {
+		t.Errorf("Current.TotalSolved = %d, want 12", r.Current.TotalSolved)
+	}
+	if r.Previous.TotalSolved != 8 {
+		t.Errorf("Previous.TotalSolved = %d, want 8", r.Previous.TotalSolved)
+	}
+	if got := r.SolvedChange(); got != 4 {
+		t.Errorf("SolvedChange() = %d, want 4", got)
+	}
+}
+
+func TestProgress_Trend_NotEnoughSnapshots(t *testing.T) {
+	p := NewProgress()
+	p.SnapshotAt(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC))
+
+	if r := p.Trend(7 * 24 * time.Hour); r.Complete || !r.Current.Taken.IsZero() {
+		t.Errorf("Trend() = %+v, want empty report", r)
+	}
+}
+
+func TestProgress_SnapshotAt_SameWeek(t *testing.T) {
+	p := NewProgress()
+	monday := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	p.TotalSolved = 5
+	p.SnapshotAt(monday)
+	p.TotalSolved = 7
+	p.SnapshotAt(monday.AddDate(0, 0, 3))
+
+	if len(p.Snapshots) != 1 {
+		t.Fatalf("len(Snapshots) = %d, want 1", len(p.Snapshots))
+	}
+	if p.Snapshots[0].TotalSolved != 7 {
+		t.Errorf("Snapshots[0].TotalSolved = %d, want 7", p.Snapshots[0].TotalSolved)
+	}
+}
+
+func TestProgress_SnapshotAt_Bounded(t *testing.T) {
+	p := NewProgress()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < MaxSnapshots+10; i++ {
+		p.TotalSolved = i
+		p.SnapshotAt(start.AddDate(0, 0, 7*i))
+	}
+
+	if len(p.Snapshots) != MaxSnapshots {
+		t.Fatalf("len(Snapshots) = %d, want %d", len(p.Snapshots), MaxSnapshots)
+	}
+	if p.Snapshots[0].TotalSolved != 10 {
+		t.Errorf("oldest snapshot TotalSolved = %d, want 10", p.Snapshots[0].TotalSolved)
+	}
+}
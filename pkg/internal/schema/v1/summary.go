@@ -0,0 +1,53 @@
+package v1
+
+// WorkspaceStats is a rollup of a workspace's problems, derived from their
+// own practice data rather than from synced progress
+type WorkspaceStats struct {
+	Total     int `yaml:"total" json:"total"`
+	Solved    int `yaml:"solved" json:"solved"`
+	Attempted int `yaml:"attempted" json:"attempted"`
+	Todo      int `yaml:"todo" json:"todo"`
+	Unseen    int `yaml:"unseen" json:"unseen"`
+	TotalTime int `yaml:"totalTime" json:"totalTime"` // seconds, over all problems
+
+	// Solved problems per rating bucket, unrated ones left out, and per tag
+	RatingDistribution map[string]int `yaml:"ratingDistribution" json:"ratingDistribution"`
+	TagDistribution    map[string]int `yaml:"tagDistribution" json:"tagDistribution"`
+}
+
+// SummarizeProblems rolls problems up into WorkspaceStats, bucketing ratings
+// the same way Progress does. A problem without a status counts as unseen.
+func SummarizeProblems(problems []*Problem) *WorkspaceStats {
+	stats := &WorkspaceStats{
+		RatingDistribution: make(map[string]int),
+		TagDistribution:    make(map[string]int),
+	}
+
+	for _, p := range problems {
+		stats.Total++
+		stats.TotalTime += p.Practice.TimeSpent
+
+		switch p.Practice.Status {
+		case StatusSolved:
+			stats.Solved++
+		case StatusAttempted:
+			stats.Attempted++
+		case StatusTodo:
+			stats.Todo++
+		default:
+			stats.Unseen++
+		}
+
+		if p.Practice.Status != StatusSolved {
+			continue
+		}
+		if p.Metadata.Rating > 0 {
+			stats.RatingDistribution[getRatingBucket(p.Metadata.Rating)]++
+		}
+		for _, tag := range p.Metadata.Tags {
+			stats.TagDistribution[tag]++
+		}
+	}
+
+	return stats
+}
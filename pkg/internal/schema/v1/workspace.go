@@ -2,6 +2,9 @@
 package v1
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/harshit-vibes/cf/pkg/internal/schema"
@@ -34,6 +37,46 @@ type CFConfig struct {
 	Handle          string   `yaml:"handle" json:"handle"`
 	DefaultLanguage string   `yaml:"defaultLanguage" json:"defaultLanguage"`
 	PreferredTags   []string `yaml:"preferredTags,omitempty" json:"preferredTags,omitempty"`
+
+	// Solution filename pattern per language, overriding DefaultSolutionFiles
+	SolutionFiles map[string]string `yaml:"solutionFiles,omitempty" json:"solutionFiles,omitempty"`
+}
+
+// DefaultSolutionFiles are the solution filename patterns used for languages
+// without one in CFConfig.SolutionFiles
+// Patterns may use {contest}, {index} and {id} (e.g. 1325A).
+var DefaultSolutionFiles = map[string]string{
+	"c":      "main.c",
+	"cpp":    "main.cpp",
+	"go":     "main.go",
+	"java":   "Main.java",
+	"kotlin": "main.kt",
+	"py":     "main.py",
+	"rust":   "main.rs",
+}
+
+// SolutionFile returns the solution filename for a problem in lang
+// Languages without a pattern use "main.<lang>". Directory parts of the
+// pattern are dropped, so the file always lands in the solutions directory.
+func (c CFConfig) SolutionFile(lang string, contestID int, index string) string {
+	if lang == "" {
+		lang = c.DefaultLanguage
+	}
+
+	pattern, ok := c.SolutionFiles[lang]
+	if !ok {
+		pattern, ok = DefaultSolutionFiles[lang]
+	}
+	if !ok {
+		pattern = "main." + lang
+	}
+
+	name := strings.NewReplacer(
+		"{contest}", fmt.Sprint(contestID),
+		"{index}", index,
+		"{id}", fmt.Sprintf("%d%s", contestID, index),
+	).Replace(pattern)
+	return filepath.Base(name)
 }
 
 // PracticeConfig holds practice session settings
@@ -192,3 +192,31 @@ func TestPracticeConfig_ZeroValues(t *testing.T) {
 		t.Errorf("WeeklyGoal should be 0, got %v", cfg.WeeklyGoal)
 	}
 }
+
+func TestCFConfig_SolutionFile(t *testing.T) {
+	cfg := CFConfig{
+		DefaultLanguage: "cpp",
+		SolutionFiles: map[string]string{
+			"py":  "{index}.py",
+			"cpp": "{id}.cpp",
+			"go":  "../{contest}/main.go",
+		},
+	}
+
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"py", "A.py"},
+		{"cpp", "1325A.cpp"},
+		{"", "1325A.cpp"},
+		{"go", "main.go"},
+		{"java", "Main.java"},
+		{"ocaml", "main.ocaml"},
+	}
+	for _, tt := range tests {
+		if got := cfg.SolutionFile(tt.lang, 1325, "A"); got != tt.want {
+			t.Errorf("SolutionFile(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+// StatementFile is the problem statement saved next to problem.yaml
+const StatementFile = "statement.md"
+
+// ProblemAudit lists what a saved problem is missing
+type ProblemAudit struct {
+	Problem          *v1.Problem
+	MissingStatement bool // No statement.md, or an empty one
+	NoSamples        bool
+	NoRating         bool
+	NoTags           bool
+}
+
+// Complete returns true if nothing is missing
+func (a ProblemAudit) Complete() bool {
+	return !a.MissingStatement && !a.NoSamples && !a.NoRating && !a.NoTags
+}
+
+// NeedsScrape returns true if fixing the problem needs its page scraped
+func (a ProblemAudit) NeedsScrape() bool {
+	return a.MissingStatement || a.NoSamples
+}
+
+// NeedsMetadata returns true if fixing the problem needs the API
+func (a ProblemAudit) NeedsMetadata() bool {
+	return a.NoRating || a.NoTags
+}
+
+// Missing names the missing pieces, e.g. [statement tags]
+func (a ProblemAudit) Missing() []string {
+	var missing []string
+	for _, m := range []struct {
+		name    string
+		missing bool
+	}{
+		{"statement", a.MissingStatement},
+		{"samples", a.NoSamples},
+		{"rating", a.NoRating},
+		{"tags", a.NoTags},
+	} {
+		if m.missing {
+			missing = append(missing, m.name)
+		}
+	}
+	return missing
+}
+
+// AuditProblems checks every saved problem for a statement, samples, a
+// rating and tags, returning how many problems were checked and the
+// incomplete ones in ListProblems order
+func (w *Workspace) AuditProblems() (int, []ProblemAudit, error) {
+	listed, err := w.ListProblems()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var incomplete []ProblemAudit
+	for _, p := range listed {
+		// The index has no samples; read the full problem.yaml
+		problem, err := w.LoadProblem(p.Platform, p.ContestID, p.Index)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		audit := ProblemAudit{
+			Problem:          problem,
+			MissingStatement: !w.hasStatement(problem),
+			NoSamples:        len(problem.Samples) == 0,
+			NoRating:         problem.Metadata.Rating == 0,
+			NoTags:           len(problem.Metadata.Tags) == 0,
+		}
+		if !audit.Complete() {
+			incomplete = append(incomplete, audit)
+		}
+	}
+	return len(listed), incomplete, nil
+}
+
+// hasStatement returns true if the problem has a non-empty statement.md
+func (w *Workspace) hasStatement(p *v1.Problem) bool {
+	info, err := os.Stat(filepath.Join(w.ProblemPath(p.Platform, p.ContestID, p.Index), StatementFile))
+	return err == nil && info.Size() > 0
+}
@@ -0,0 +1,72 @@
+package workspace
+
+import (
+	"slices"
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_AuditProblems(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	complete := v1.NewProblem(1, "A", "Complete")
+	complete.Metadata = v1.ProblemMetadata{Rating: 800, Tags: []string{"math"}}
+	complete.Samples = []v1.Sample{{Index: 1, Input: "1", Output: "1"}}
+
+	noStatement := v1.NewProblem(2, "A", "No Statement")
+	noStatement.Metadata = complete.Metadata
+	noStatement.Samples = complete.Samples
+
+	noSamples := v1.NewProblem(3, "A", "No Samples")
+	noSamples.Metadata = complete.Metadata
+
+	noMetadata := v1.NewProblem(4, "A", "No Metadata")
+	noMetadata.Samples = complete.Samples
+
+	for _, p := range []*v1.Problem{complete, noStatement, noSamples, noMetadata} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+	for _, p := range []*v1.Problem{complete, noSamples, noMetadata} {
+		if err := ws.SaveStatement(p, "Print the answer."); err != nil {
+			t.Fatalf("SaveStatement() error = %v", err)
+		}
+	}
+
+	checked, incomplete, err := ws.AuditProblems()
+	if err != nil {
+		t.Fatalf("AuditProblems() error = %v", err)
+	}
+	if checked != 4 {
+		t.Errorf("AuditProblems() checked %d problems, want 4", checked)
+	}
+
+	missing := make(map[string][]string)
+	for _, a := range incomplete {
+		missing[a.Problem.ID] = a.Missing()
+	}
+	want := map[string][]string{
+		noStatement.ID: {"statement"},
+		noSamples.ID:   {"samples"},
+		noMetadata.ID:  {"rating", "tags"},
+	}
+	if len(missing) != len(want) {
+		t.Errorf("AuditProblems() incomplete = %v, want %v", missing, want)
+	}
+	for id, w := range want {
+		if !slices.Equal(missing[id], w) {
+			t.Errorf("%s missing %v, want %v", id, missing[id], w)
+		}
+	}
+
+	for _, a := range incomplete {
+		if a.Problem.ID == noMetadata.ID && (a.NeedsScrape() || !a.NeedsMetadata()) {
+			t.Errorf("%s should only need metadata", a.Problem.ID)
+		}
+	}
+}
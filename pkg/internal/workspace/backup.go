@@ -0,0 +1,259 @@
+package workspace
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotEmpty is returned when restoring into a directory that has files
+var ErrNotEmpty = errors.New("directory is not empty")
+
+// Backup writes the whole workspace to a gzipped tar archive at destPath
+// Paths in the archive are relative to the workspace root
+func (w *Workspace) Backup(destPath string) error {
+	if !w.Exists() {
+		return fmt.Errorf("workspace not initialized")
+	}
+
+	absDest, err := filepath.Abs(destPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve backup path: %w", err)
+	}
+
+	f, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	if err := w.writeArchive(f, absDest); err != nil {
+		f.Close()
+		os.Remove(destPath)
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return nil
+}
+
+// writeArchive tars and gzips the workspace into out, skipping the archive
+// itself when it is written inside the workspace
+func (w *Workspace) writeArchive(out io.Writer, skip string) error {
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.WalkDir(w.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(w.root, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		if abs, _ := filepath.Abs(p); abs == skip {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil // Skip symlinks and special files
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// RestoreOption configures Restore
+type RestoreOption func(*restoreOptions)
+
+type restoreOptions struct {
+	overwrite bool
+}
+
+// WithOverwrite allows restoring into a directory that already has files
+// Files from the archive replace existing ones; others are left alone
+func WithOverwrite() RestoreOption {
+	return func(o *restoreOptions) {
+		o.overwrite = true
+	}
+}
+
+// Restore extracts a backup made by Backup into the workspace root
+// The archive is checked for a valid workspace.yaml before anything is
+// written, and the root must be empty unless WithOverwrite is given.
+func (w *Workspace) Restore(archivePath string, opts ...RestoreOption) error {
+	var o restoreOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if err := verifyArchive(archivePath); err != nil {
+		return fmt.Errorf("invalid backup %s: %w", archivePath, err)
+	}
+
+	if !o.overwrite {
+		entries, err := os.ReadDir(w.root)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", w.root, err)
+		}
+		if len(entries) > 0 {
+			return fmt.Errorf("restore to %s: %w", w.root, ErrNotEmpty)
+		}
+	}
+
+	if err := os.MkdirAll(w.root, 0755); err != nil {
+		return fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	err := readArchive(archivePath, func(hdr *tar.Header, r io.Reader) error {
+		target := filepath.Join(w.root, filepath.FromSlash(hdr.Name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			return os.MkdirAll(target, 0755)
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, r); err != nil {
+				f.Close()
+				return err
+			}
+			return f.Close()
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to extract backup: %w", err)
+	}
+
+	return w.Load()
+}
+
+// verifyArchive checks that every entry stays inside the destination and
+// that the archive carries a compatible workspace.yaml at its root
+func verifyArchive(archivePath string) error {
+	var manifest []byte
+
+	err := readArchive(archivePath, func(hdr *tar.Header, r io.Reader) error {
+		if hdr.Name == ManifestFile && hdr.Typeflag == tar.TypeReg {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			manifest = data
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if manifest == nil {
+		return fmt.Errorf("no %s in archive", ManifestFile)
+	}
+
+	var ws v1.Workspace
+	if err := yaml.Unmarshal(manifest, &ws); err != nil {
+		return fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	version, err := schema.ParseVersion(ws.Schema.Version)
+	if err != nil {
+		return fmt.Errorf("invalid schema version: %w", err)
+	}
+	if !schema.CurrentVersion.IsCompatible(version) {
+		return fmt.Errorf("incompatible schema version: %s (current: %s)", version, schema.CurrentVersion)
+	}
+
+	return nil
+}
+
+// readArchive calls fn for each entry of a gzipped tar archive
+// Entries with absolute paths or paths leaving the root are rejected
+func readArchive(archivePath string, fn func(*tar.Header, io.Reader) error) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("unsafe path in archive: %s", hdr.Name)
+		}
+		hdr.Name = name
+
+		if err := fn(hdr, tr); err != nil {
+			return err
+		}
+	}
+}
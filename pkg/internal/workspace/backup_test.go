@@ -0,0 +1,158 @@
+package workspace
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_BackupRestore(t *testing.T) {
+	ws := New(filepath.Join(t.TempDir(), "ws"))
+	if err := ws.Init("Test", "tourist"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	problem := v1.NewProblem(1325, "A", "EhAb AnD gCd")
+	problem.Notes.Approach = "print 1 and x-1"
+	if err := ws.SaveProblem(problem); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	if err := ws.Backup(archive); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	restored := New(filepath.Join(t.TempDir(), "restored"))
+	if err := restored.Restore(archive); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	if err := restored.Validate(); err != nil {
+		t.Errorf("restored workspace is invalid: %v", err)
+	}
+	loaded, err := restored.LoadProblem("codeforces", 1325, "A")
+	if err != nil {
+		t.Fatalf("LoadProblem() error = %v", err)
+	}
+	if loaded.Notes.Approach != "print 1 and x-1" {
+		t.Errorf("restored notes = %q", loaded.Notes.Approach)
+	}
+}
+
+func TestWorkspace_Backup_InsideWorkspace(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "tourist"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	// The archive must not try to include itself
+	archive := filepath.Join(ws.Root(), "backup.tar.gz")
+	if err := ws.Backup(archive); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	restored := New(filepath.Join(t.TempDir(), "restored"))
+	if err := restored.Restore(archive); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(restored.Root(), "backup.tar.gz")); !os.IsNotExist(err) {
+		t.Error("backup should not contain itself")
+	}
+}
+
+func TestWorkspace_Restore_NotEmpty(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "tourist"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	if err := ws.Backup(archive); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	dest := t.TempDir()
+	keep := filepath.Join(dest, "keep.txt")
+	if err := os.WriteFile(keep, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := New(dest).Restore(archive)
+	if !errors.Is(err, ErrNotEmpty) {
+		t.Fatalf("Restore() error = %v, want ErrNotEmpty", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, ManifestFile)); !os.IsNotExist(err) {
+		t.Error("Restore() should not write anything into a non-empty directory")
+	}
+
+	if err := New(dest).Restore(archive, WithOverwrite()); err != nil {
+		t.Fatalf("Restore(WithOverwrite) error = %v", err)
+	}
+	if _, err := os.Stat(keep); err != nil {
+		t.Error("overwrite should leave files not in the archive alone")
+	}
+}
+
+// writeTestArchive writes a gzipped tar with the given files
+func writeTestArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	archive := filepath.Join(t.TempDir(), "test.tar.gz")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return archive
+}
+
+func TestWorkspace_Restore_InvalidArchive(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"no manifest", map[string]string{"problems/a.txt": "x"}, "no workspace.yaml"},
+		{"bad version", map[string]string{ManifestFile: "_schema:\n  version: 9.0.0\n"}, "incompatible schema version"},
+		{"unsafe path", map[string]string{"../escape.txt": "x", ManifestFile: "_schema:\n  version: 1.0.0\n"}, "unsafe path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := writeTestArchive(t, tt.files)
+			dest := filepath.Join(t.TempDir(), "dest")
+
+			err := New(dest).Restore(archive)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Restore() error = %v, want %q", err, tt.want)
+			}
+			if _, err := os.Stat(dest); !os.IsNotExist(err) {
+				t.Error("Restore() should not extract an invalid archive")
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// ContestFile holds cached contest details next to its problems
+const ContestFile = "contest.yaml"
+
+// contestInfo is the content of contest.yaml
+type contestInfo struct {
+	ProblemCount int `yaml:"problemCount"`
+}
+
+// ContestSummary is the practice progress for a single contest
+type ContestSummary struct {
+	ContestID int `json:"contestId"`
+	Solved    int `json:"solved"`
+	Saved     int `json:"saved"` // problems of the contest in the workspace
+	Total     int `json:"total"` // problems in the contest, or Saved if the problem set is not cached
+}
+
+// ContestPath returns the directory holding a contest's problems
+func (w *Workspace) ContestPath(platform string, contestID int) string {
+	return filepath.Join(w.ProblemsPath(), platform, "contest", fmt.Sprintf("%d", contestID))
+}
+
+// SaveContestProblemCount caches the number of problems in a contest
+func (w *Workspace) SaveContestProblemCount(platform string, contestID int, count int) error {
+	contestDir := w.ContestPath(platform, contestID)
+	if err := os.MkdirAll(contestDir, 0755); err != nil {
+		return fmt.Errorf("failed to create contest dir: %w", err)
+	}
+
+	data, err := yaml.Marshal(&contestInfo{ProblemCount: count})
+	if err != nil {
+		return fmt.Errorf("failed to marshal contest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(contestDir, ContestFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write contest: %w", err)
+	}
+
+	return nil
+}
+
+// contestProblemCount returns the cached problem count of a contest, or 0 if unknown
+func (w *Workspace) contestProblemCount(platform string, contestID int) int {
+	data, err := os.ReadFile(filepath.Join(w.ContestPath(platform, contestID), ContestFile))
+	if err != nil {
+		return 0
+	}
+
+	var info contestInfo
+	if err := yaml.Unmarshal(data, &info); err != nil {
+		return 0
+	}
+	return info.ProblemCount
+}
+
+// ListByContest groups workspace problems by contest ID, ordered by index within each contest
+func (w *Workspace) ListByContest() (map[int][]*v1.Problem, error) {
+	problems, err := w.ListProblems()
+	if err != nil {
+		return nil, err
+	}
+
+	byContest := make(map[int][]*v1.Problem)
+	for _, p := range problems {
+		byContest[p.ContestID] = append(byContest[p.ContestID], p)
+	}
+
+	for _, list := range byContest {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Index < list[j].Index
+		})
+	}
+
+	return byContest, nil
+}
+
+// ContestProgress returns solved/total counts per contest, newest contest first
+func (w *Workspace) ContestProgress() ([]ContestSummary, error) {
+	byContest, err := w.ListByContest()
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]ContestSummary, 0, len(byContest))
+	for contestID, problems := range byContest {
+		summary := ContestSummary{ContestID: contestID, Saved: len(problems)}
+		for _, p := range problems {
+			if p.Practice.Status == v1.StatusSolved {
+				summary.Solved++
+			}
+		}
+
+		summary.Total = w.contestProblemCount(problems[0].Platform, contestID)
+		if summary.Total < summary.Saved {
+			summary.Total = summary.Saved
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ContestID > summaries[j].ContestID
+	})
+
+	return summaries, nil
+}
@@ -0,0 +1,91 @@
+package workspace
+
+import (
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_ListByContest(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	for _, p := range []*v1.Problem{
+		v1.NewProblem(1325, "B", "B"),
+		v1.NewProblem(1325, "A", "A"),
+		v1.NewProblem(1000, "A", "A"),
+	} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+
+	byContest, err := ws.ListByContest()
+	if err != nil {
+		t.Fatalf("ListByContest() error = %v", err)
+	}
+	if len(byContest) != 2 {
+		t.Fatalf("ListByContest() returned %d contests, want 2", len(byContest))
+	}
+	if got := byContest[1325]; len(got) != 2 || got[0].Index != "A" || got[1].Index != "B" {
+		t.Errorf("ListByContest()[1325] not ordered by index: %v", got)
+	}
+}
+
+func TestWorkspace_ContestProgress(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	for _, p := range []*v1.Problem{
+		v1.NewProblem(1325, "A", "A"),
+		v1.NewProblem(1325, "B", "B"),
+		v1.NewProblem(1325, "C", "C"),
+		v1.NewProblem(1000, "A", "A"),
+	} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+	ws.MarkSolved("codeforces", 1325, "A", 0)
+	ws.MarkSolved("codeforces", 1325, "C", 0)
+	ws.MarkAttempted("codeforces", 1000, "A", 0)
+
+	if err := ws.SaveContestProblemCount("codeforces", 1325, 6); err != nil {
+		t.Fatalf("SaveContestProblemCount() error = %v", err)
+	}
+
+	summaries, err := ws.ContestProgress()
+	if err != nil {
+		t.Fatalf("ContestProgress() error = %v", err)
+	}
+
+	want := []ContestSummary{
+		{ContestID: 1325, Solved: 2, Saved: 3, Total: 6},
+		{ContestID: 1000, Solved: 0, Saved: 1, Total: 1},
+	}
+	if len(summaries) != len(want) {
+		t.Fatalf("ContestProgress() returned %d summaries, want %d", len(summaries), len(want))
+	}
+	for i := range want {
+		if summaries[i] != want[i] {
+			t.Errorf("ContestProgress()[%d] = %+v, want %+v", i, summaries[i], want[i])
+		}
+	}
+}
+
+func TestWorkspace_ContestProgress_Empty(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	summaries, err := ws.ContestProgress()
+	if err != nil {
+		t.Fatalf("ContestProgress() error = %v", err)
+	}
+	if len(summaries) != 0 {
+		t.Errorf("ContestProgress() = %v, want empty", summaries)
+	}
+}
@@ -0,0 +1,131 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// DailyFile holds the problem-of-the-day archive inside the stats directory
+const DailyFile = "daily.yaml"
+
+// DailyPick is the problem picked for a single day
+type DailyPick struct {
+	Date      string `yaml:"date" json:"date"`           // YYYY-MM-DD
+	ProblemID string `yaml:"problemId" json:"problemId"` // e.g. 1325A
+
+	// Status is the practice status of the problem, if it is in the workspace
+	Status v1.PracticeStatus `yaml:"-" json:"status,omitempty"`
+}
+
+// dailyArchive is the content of daily.yaml
+type dailyArchive struct {
+	Picks []DailyPick `yaml:"picks"`
+}
+
+// DailyPath returns the path of the daily pick archive
+func (w *Workspace) DailyPath() string {
+	return filepath.Join(w.StatsPath(), DailyFile)
+}
+
+// RecordDailyPick stores the problem picked for date, replacing any earlier
+// pick for the same date
+func (w *Workspace) RecordDailyPick(date string, problemID string) error {
+	archive, err := w.loadDaily()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := range archive.Picks {
+		if archive.Picks[i].Date == date {
+			archive.Picks[i].ProblemID = problemID
+			replaced = true
+		}
+	}
+	if !replaced {
+		archive.Picks = append(archive.Picks, DailyPick{Date: date, ProblemID: problemID})
+	}
+
+	sort.Slice(archive.Picks, func(i, j int) bool {
+		return archive.Picks[i].Date < archive.Picks[j].Date
+	})
+
+	if err := os.MkdirAll(w.StatsPath(), 0755); err != nil {
+		return fmt.Errorf("failed to create stats dir: %w", err)
+	}
+	data, err := yaml.Marshal(archive)
+	if err != nil {
+		return fmt.Errorf("failed to marshal daily picks: %w", err)
+	}
+	if err := os.WriteFile(w.DailyPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write daily picks: %w", err)
+	}
+
+	return nil
+}
+
+// DailyPickFor returns the pick recorded for date, or false if there is none
+func (w *Workspace) DailyPickFor(date string) (DailyPick, bool, error) {
+	archive, err := w.loadDaily()
+	if err != nil {
+		return DailyPick{}, false, err
+	}
+
+	for _, p := range archive.Picks {
+		if p.Date == date {
+			return p, true, nil
+		}
+	}
+	return DailyPick{}, false, nil
+}
+
+// DailyPicks returns all recorded picks, newest first, with Status taken
+// from the practice data of workspace problems
+func (w *Workspace) DailyPicks() ([]DailyPick, error) {
+	archive, err := w.loadDaily()
+	if err != nil {
+		return nil, err
+	}
+
+	problems, err := w.ListProblems()
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]v1.PracticeStatus, len(problems))
+	for _, p := range problems {
+		status[fmt.Sprintf("%d%s", p.ContestID, p.Index)] = p.Practice.Status
+	}
+
+	picks := archive.Picks
+	for i := range picks {
+		picks[i].Status = status[picks[i].ProblemID]
+	}
+
+	sort.Slice(picks, func(i, j int) bool {
+		return picks[i].Date > picks[j].Date
+	})
+
+	return picks, nil
+}
+
+// loadDaily reads daily.yaml, returning an empty archive if it does not exist
+func (w *Workspace) loadDaily() (*dailyArchive, error) {
+	data, err := os.ReadFile(w.DailyPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &dailyArchive{}, nil
+		}
+		return nil, fmt.Errorf("failed to read daily picks: %w", err)
+	}
+
+	var archive dailyArchive
+	if err := yaml.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("failed to parse daily picks: %w", err)
+	}
+	return &archive, nil
+}
@@ -0,0 +1,64 @@
+package workspace
+
+import (
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_DailyPicks(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	solved := v1.NewProblem(1325, "A", "EhAb AnD gCd")
+	solved.Practice.Status = v1.StatusSolved
+	if err := ws.SaveProblem(solved); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+
+	for _, pick := range []struct{ date, id string }{
+		{"2024-03-02", "4A"},
+		{"2024-03-01", "1325A"},
+		{"2024-03-02", "1000B"}, // Replaces the first pick for the day
+	} {
+		if err := ws.RecordDailyPick(pick.date, pick.id); err != nil {
+			t.Fatalf("RecordDailyPick() error = %v", err)
+		}
+	}
+
+	picks, err := ws.DailyPicks()
+	if err != nil {
+		t.Fatalf("DailyPicks() error = %v", err)
+	}
+	if len(picks) != 2 {
+		t.Fatalf("DailyPicks() returned %d picks, want 2", len(picks))
+	}
+	if picks[0].Date != "2024-03-02" || picks[0].ProblemID != "1000B" || picks[0].Status != "" {
+		t.Errorf("picks[0] = %+v, want replaced pick 1000B without status", picks[0])
+	}
+	if picks[1].ProblemID != "1325A" || picks[1].Status != v1.StatusSolved {
+		t.Errorf("picks[1] = %+v, want solved 1325A", picks[1])
+	}
+
+	pick, ok, err := ws.DailyPickFor("2024-03-01")
+	if err != nil || !ok || pick.ProblemID != "1325A" {
+		t.Errorf("DailyPickFor() = %+v, %v, %v, want 1325A", pick, ok, err)
+	}
+	if _, ok, _ := ws.DailyPickFor("2024-03-05"); ok {
+		t.Error("DailyPickFor() should report no pick for an unrecorded date")
+	}
+}
+
+func TestWorkspace_DailyPicks_Empty(t *testing.T) {
+	ws := New(t.TempDir())
+
+	picks, err := ws.DailyPicks()
+	if err != nil {
+		t.Fatalf("DailyPicks() error = %v", err)
+	}
+	if len(picks) != 0 {
+		t.Errorf("DailyPicks() = %v, want empty", picks)
+	}
+}
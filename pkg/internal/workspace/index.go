@@ -0,0 +1,225 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// IndexFile is the problem index cache inside the problems directory
+const IndexFile = ".index.yaml"
+
+// problemIndex caches summary fields of every problem.yaml, keyed by
+// the problem directory relative to the problems root
+type problemIndex struct {
+	Problems map[string]indexEntry `yaml:"problems"`
+}
+
+// indexEntry holds the summary fields of a single problem
+type indexEntry struct {
+	ID        string             `yaml:"id"`
+	Platform  string             `yaml:"platform"`
+	ContestID int                `yaml:"contestId"`
+	Index     string             `yaml:"index"`
+	Name      string             `yaml:"name"`
+	URL       string             `yaml:"url"`
+	Metadata  v1.ProblemMetadata `yaml:"metadata"`
+	Status    v1.PracticeStatus  `yaml:"status"`
+	Pinned    bool               `yaml:"pinned,omitempty"`
+}
+
+func newIndexEntry(p *v1.Problem) indexEntry {
+	return indexEntry{
+		ID:        p.ID,
+		Platform:  p.Platform,
+		ContestID: p.ContestID,
+		Index:     p.Index,
+		Name:      p.Name,
+		URL:       p.URL,
+		Metadata:  p.Metadata,
+		Status:    p.Practice.Status,
+		Pinned:    p.Notes.Pinned,
+	}
+}
+
+func (e indexEntry) problem() *v1.Problem {
+	return &v1.Problem{
+		ID:        e.ID,
+		Platform:  e.Platform,
+		ContestID: e.ContestID,
+		Index:     e.Index,
+		Name:      e.Name,
+		URL:       e.URL,
+		Metadata:  e.Metadata,
+		Practice:  v1.PracticeData{Status: e.Status},
+		Notes:     v1.UserNotes{Pinned: e.Pinned},
+	}
+}
+
+// IndexPath returns the path to the problem index cache
+func (w *Workspace) IndexPath() string {
+	return filepath.Join(w.ProblemsPath(), IndexFile)
+}
+
+// RebuildIndex parses every problem.yaml and rewrites the index from scratch
+func (w *Workspace) RebuildIndex() error {
+	files, _, err := w.problemFiles()
+	if err != nil {
+		return err
+	}
+
+	idx, _ := w.parseProblems(files)
+	return w.saveIndex(idx)
+}
+
+// listIndexedProblems returns problems from the index if it is up to date,
+// and otherwise parses every problem.yaml and rewrites the index
+func (w *Workspace) listIndexedProblems() ([]*v1.Problem, error) {
+	files, newest, err := w.problemFiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	if idx, ok := w.freshIndex(files, newest); ok {
+		problems := make([]*v1.Problem, 0, len(files))
+		for _, key := range files {
+			problems = append(problems, idx.Problems[key].problem())
+		}
+		return problems, nil
+	}
+
+	idx, problems := w.parseProblems(files)
+	_ = w.saveIndex(idx) // The index is only a cache; listing still succeeds without it
+	return problems, nil
+}
+
+// problemFiles lists the index keys of all problem.yaml files and the newest mtime among them
+func (w *Workspace) problemFiles() ([]string, time.Time, error) {
+	var files []string
+	var newest time.Time
+
+	root := w.ProblemsPath()
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip errors
+		}
+
+		if info.Name() == "problem.yaml" && !info.IsDir() {
+			rel, err := filepath.Rel(root, filepath.Dir(path))
+			if err != nil {
+				return nil
+			}
+			files = append(files, filepath.ToSlash(rel))
+			if info.ModTime().After(newest) {
+				newest = info.ModTime()
+			}
+		}
+
+		return nil
+	})
+
+	return files, newest, err
+}
+
+// freshIndex loads the index and reports whether it covers exactly files
+// and is not older than the newest of them
+func (w *Workspace) freshIndex(files []string, newest time.Time) (*problemIndex, bool) {
+	info, err := os.Stat(w.IndexPath())
+	if err != nil || info.ModTime().Before(newest) {
+		return nil, false
+	}
+
+	idx, err := w.loadIndex()
+	if err != nil || len(idx.Problems) != len(files) {
+		return nil, false
+	}
+
+	for _, key := range files {
+		if _, ok := idx.Problems[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return idx, true
+}
+
+// parseProblems reads the given problem files and builds an index of them
+// Unreadable files are skipped, which leaves the index stale until they are fixed
+func (w *Workspace) parseProblems(files []string) (*problemIndex, []*v1.Problem) {
+	idx := &problemIndex{Problems: make(map[string]indexEntry, len(files))}
+	problems := make([]*v1.Problem, 0, len(files))
+
+	for _, key := range files {
+		path := filepath.Join(w.ProblemsPath(), filepath.FromSlash(key), "problem.yaml")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue // Skip read errors
+		}
+
+		var problem v1.Problem
+		if err := yaml.Unmarshal(data, &problem); err != nil {
+			continue // Skip parse errors
+		}
+
+		entry := newIndexEntry(&problem)
+		idx.Problems[key] = entry
+		// Summaries either way, so callers see the same fields as from the index
+		problems = append(problems, entry.problem())
+	}
+
+	return idx, problems
+}
+
+// updateIndex records a saved problem in the index
+// A missing or unreadable index is left for ListProblems to rebuild
+func (w *Workspace) updateIndex(problem *v1.Problem) error {
+	idx, err := w.loadIndex()
+	if err != nil {
+		return nil
+	}
+
+	rel, err := filepath.Rel(w.ProblemsPath(), w.ProblemPath(problem.Platform, problem.ContestID, problem.Index))
+	if err != nil {
+		return nil
+	}
+
+	idx.Problems[filepath.ToSlash(rel)] = newIndexEntry(problem)
+	return w.saveIndex(idx)
+}
+
+func (w *Workspace) loadIndex() (*problemIndex, error) {
+	data, err := os.ReadFile(w.IndexPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	var idx problemIndex
+	if err := yaml.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("failed to parse index: %w", err)
+	}
+	if idx.Problems == nil {
+		idx.Problems = make(map[string]indexEntry)
+	}
+
+	return &idx, nil
+}
+
+func (w *Workspace) saveIndex(idx *problemIndex) error {
+	data, err := yaml.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index: %w", err)
+	}
+
+	if err := os.WriteFile(w.IndexPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+
+	return nil
+}
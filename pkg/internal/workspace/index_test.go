@@ -0,0 +1,239 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func newIndexedWorkspace(t *testing.T, problems ...*v1.Problem) *Workspace {
+	t.Helper()
+
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	for _, p := range problems {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+	return ws
+}
+
+func problemNames(problems []*v1.Problem) map[string]string {
+	names := make(map[string]string, len(problems))
+	for _, p := range problems {
+		names[p.ID] = p.Name
+	}
+	return names
+}
+
+func TestWorkspace_ListProblems_BuildsIndex(t *testing.T) {
+	ws := newIndexedWorkspace(t,
+		v1.NewProblem(1325, "A", "EhAb AnD gCd"),
+		v1.NewProblem(1325, "B", "CopyCopyCopyCopyCopy"),
+	)
+
+	if _, err := os.Stat(ws.IndexPath()); !os.IsNotExist(err) {
+		t.Fatal("SaveProblem() should not create the index on its own")
+	}
+
+	problems, err := ws.ListProblems()
+	if err != nil {
+		t.Fatalf("ListProblems() error = %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("ListProblems() returned %d problems, want 2", len(problems))
+	}
+
+	idx, err := ws.loadIndex()
+	if err != nil {
+		t.Fatalf("loadIndex() error = %v", err)
+	}
+	entry, ok := idx.Problems["codeforces/contest/1325/A"]
+	if !ok {
+		t.Fatalf("index missing 1325A, got keys %v", idx.Problems)
+	}
+	if entry.Name != "EhAb AnD gCd" {
+		t.Errorf("index entry name = %q, want %q", entry.Name, "EhAb AnD gCd")
+	}
+}
+
+func TestWorkspace_ListProblems_UsesFreshIndex(t *testing.T) {
+	ws := newIndexedWorkspace(t, v1.NewProblem(1325, "A", "EhAb AnD gCd"))
+	if err := ws.RebuildIndex(); err != nil {
+		t.Fatalf("RebuildIndex() error = %v", err)
+	}
+
+	// Change the cached name only; a fresh index must be served as-is
+	idx, _ := ws.loadIndex()
+	entry := idx.Problems["codeforces/contest/1325/A"]
+	entry.Name = "From Index"
+	idx.Problems["codeforces/contest/1325/A"] = entry
+	if err := ws.saveIndex(idx); err != nil {
+		t.Fatalf("saveIndex() error = %v", err)
+	}
+
+	problems, err := ws.ListProblems()
+	if err != nil {
+		t.Fatalf("ListProblems() error = %v", err)
+	}
+	if got := problemNames(problems)["1325A"]; got != "From Index" {
+		t.Errorf("ListProblems() name = %q, want indexed name", got)
+	}
+}
+
+func TestWorkspace_ListProblems_StaleIndex(t *testing.T) {
+	ws := newIndexedWorkspace(t, v1.NewProblem(1325, "A", "EhAb AnD gCd"))
+	if err := ws.RebuildIndex(); err != nil {
+		t.Fatalf("RebuildIndex() error = %v", err)
+	}
+
+	idx, _ := ws.loadIndex()
+	entry := idx.Problems["codeforces/contest/1325/A"]
+	entry.Name = "Stale"
+	idx.Problems["codeforces/contest/1325/A"] = entry
+	if err := ws.saveIndex(idx); err != nil {
+		t.Fatalf("saveIndex() error = %v", err)
+	}
+
+	// A problem.yaml edited after the index was written makes it stale
+	problemPath := filepath.Join(ws.ProblemPath("codeforces", 1325, "A"), "problem.yaml")
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(problemPath, future, future); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	problems, err := ws.ListProblems()
+	if err != nil {
+		t.Fatalf("ListProblems() error = %v", err)
+	}
+	if got := problemNames(problems)["1325A"]; got != "EhAb AnD gCd" {
+		t.Errorf("ListProblems() name = %q, want name from problem.yaml", got)
+	}
+}
+
+func TestWorkspace_ListProblems_NewProblemDir(t *testing.T) {
+	ws := newIndexedWorkspace(t, v1.NewProblem(1325, "A", "EhAb AnD gCd"))
+	if err := ws.RebuildIndex(); err != nil {
+		t.Fatalf("RebuildIndex() error = %v", err)
+	}
+
+	// A problem copied in by hand is not in the index
+	dir := ws.ProblemPath("codeforces", 1000, "A")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("id: 1000A\nplatform: codeforces\ncontestId: 1000\nindex: A\nname: Copied\n")
+	if err := os.WriteFile(filepath.Join(dir, "problem.yaml"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(dir, "problem.yaml"), old, old)
+
+	problems, err := ws.ListProblems()
+	if err != nil {
+		t.Fatalf("ListProblems() error = %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("ListProblems() returned %d problems, want 2", len(problems))
+	}
+}
+
+func TestWorkspace_ListProblems_CorruptIndex(t *testing.T) {
+	ws := newIndexedWorkspace(t,
+		v1.NewProblem(1325, "A", "EhAb AnD gCd"),
+		v1.NewProblem(1325, "B", "CopyCopyCopyCopyCopy"),
+	)
+	if err := os.WriteFile(ws.IndexPath(), []byte("problems: [not: valid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := ws.ListProblems()
+	if err != nil {
+		t.Fatalf("ListProblems() error = %v", err)
+	}
+	if len(problems) != 2 {
+		t.Errorf("ListProblems() returned %d problems, want 2", len(problems))
+	}
+
+	if _, err := ws.loadIndex(); err != nil {
+		t.Errorf("ListProblems() should rewrite a corrupt index, got %v", err)
+	}
+}
+
+func TestWorkspace_UpdatePractice_UpdatesIndex(t *testing.T) {
+	ws := newIndexedWorkspace(t, v1.NewProblem(1325, "A", "EhAb AnD gCd"))
+	if err := ws.RebuildIndex(); err != nil {
+		t.Fatalf("RebuildIndex() error = %v", err)
+	}
+
+	practice := &v1.PracticeData{Status: v1.StatusSolved, AttemptCount: 1}
+	if err := ws.UpdatePractice("codeforces", 1325, "A", practice); err != nil {
+		t.Fatalf("UpdatePractice() error = %v", err)
+	}
+
+	idx, err := ws.loadIndex()
+	if err != nil {
+		t.Fatalf("loadIndex() error = %v", err)
+	}
+	if got := idx.Problems["codeforces/contest/1325/A"].Status; got != v1.StatusSolved {
+		t.Errorf("index status = %q, want %q", got, v1.StatusSolved)
+	}
+
+	problems, _ := ws.ListProblems()
+	if len(problems) != 1 || problems[0].Practice.Status != v1.StatusSolved {
+		t.Errorf("ListProblems() should report solved status from the index")
+	}
+}
+
+func TestWorkspace_RebuildIndex_Empty(t *testing.T) {
+	ws := newIndexedWorkspace(t)
+	if err := ws.RebuildIndex(); err != nil {
+		t.Fatalf("RebuildIndex() error = %v", err)
+	}
+
+	idx, err := ws.loadIndex()
+	if err != nil {
+		t.Fatalf("loadIndex() error = %v", err)
+	}
+	if len(idx.Problems) != 0 {
+		t.Errorf("index has %d entries, want 0", len(idx.Problems))
+	}
+}
+
+func TestWorkspace_ListProblems_SummariesOnBothPaths(t *testing.T) {
+	p := v1.NewProblem(1325, "A", "EhAb AnD gCd")
+	p.Samples = []v1.Sample{{Index: 1, Input: "1", Output: "1"}}
+	p.Practice = v1.PracticeData{Status: v1.StatusSolved, TimeSpent: 600}
+	p.Notes.Pinned = true
+	ws := newIndexedWorkspace(t, p)
+
+	// The first call parses problem.yaml, the second reads the index
+	for call := 1; call <= 2; call++ {
+		problems, err := ws.ListProblems()
+		if err != nil || len(problems) != 1 {
+			t.Fatalf("call %d: ListProblems() = %d problems, %v", call, len(problems), err)
+		}
+		got := problems[0]
+		if got.Practice.Status != v1.StatusSolved || !got.Notes.Pinned {
+			t.Errorf("call %d: summary fields missing: %+v", call, got)
+		}
+		if len(got.Samples) != 0 || got.Practice.TimeSpent != 0 {
+			t.Errorf("call %d: ListProblems() should only fill summary fields, got samples %d and time %d",
+				call, len(got.Samples), got.Practice.TimeSpent)
+		}
+	}
+
+	pinned, err := ws.ListPinned()
+	if err != nil || len(pinned) != 1 {
+		t.Fatalf("ListPinned() = %d problems, %v", len(pinned), err)
+	}
+	if len(pinned[0].Samples) != 1 || pinned[0].Practice.TimeSpent != 600 {
+		t.Errorf("ListPinned() should return full records, got %+v", pinned[0])
+	}
+}
@@ -0,0 +1,183 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// ListsDir holds the problem lists, one YAML file per list
+const ListsDir = "lists"
+
+// ErrListNotFound is returned when a problem list does not exist
+var ErrListNotFound = errors.New("problem list not found")
+
+// reListName matches names that are safe to use as a file name
+var reListName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ProblemList is a named, curated list of problems such as a coach's sheet
+// Only references are stored; completion comes from the practice data of
+// the workspace problems.
+type ProblemList struct {
+	Name      string             `yaml:"name" json:"name"`
+	CreatedAt time.Time          `yaml:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `yaml:"updatedAt" json:"updatedAt"`
+	Problems  []cfapi.ProblemRef `yaml:"problems" json:"problems"`
+}
+
+// ListsPath returns the problem lists directory path
+func (w *Workspace) ListsPath() string {
+	return filepath.Join(w.root, ListsDir)
+}
+
+// ListPath returns the path of the named problem list
+func (w *Workspace) ListPath(name string) string {
+	return filepath.Join(w.ListsPath(), name+".yaml")
+}
+
+// SaveList stores refs as the named list, replacing its problems if the
+// list exists. Duplicate references are dropped, keeping the first.
+func (w *Workspace) SaveList(name string, refs []cfapi.ProblemRef) error {
+	if !reListName.MatchString(name) {
+		return fmt.Errorf("invalid list name %q (use letters, digits, '-', '_' and '.')", name)
+	}
+
+	now := time.Now()
+	list := &ProblemList{Name: name, CreatedAt: now}
+	if existing, err := w.LoadList(name); err == nil {
+		list.CreatedAt = existing.CreatedAt
+	} else if !errors.Is(err, ErrListNotFound) {
+		return err
+	}
+	list.UpdatedAt = now
+
+	seen := make(map[cfapi.ProblemRef]bool, len(refs))
+	for _, r := range refs {
+		r.Index = strings.ToUpper(r.Index)
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		list.Problems = append(list.Problems, r)
+	}
+
+	if err := os.MkdirAll(w.ListsPath(), 0755); err != nil {
+		return fmt.Errorf("failed to create lists dir: %w", err)
+	}
+	data, err := yaml.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("failed to marshal list: %w", err)
+	}
+	if err := os.WriteFile(w.ListPath(name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write list: %w", err)
+	}
+
+	return nil
+}
+
+// LoadList loads the named problem list
+func (w *Workspace) LoadList(name string) (*ProblemList, error) {
+	if !reListName.MatchString(name) {
+		return nil, fmt.Errorf("list %q: %w", name, ErrListNotFound)
+	}
+
+	data, err := os.ReadFile(w.ListPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("list %q: %w", name, ErrListNotFound)
+		}
+		return nil, fmt.Errorf("failed to read list: %w", err)
+	}
+
+	var list ProblemList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse list %s: %w", w.ListPath(name), err)
+	}
+	return &list, nil
+}
+
+// ListNames returns the names of all problem lists, sorted
+func (w *Workspace) ListNames() ([]string, error) {
+	entries, err := os.ReadDir(w.ListsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read lists dir: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ListEntry is a problem of a list with its workspace record, if any
+type ListEntry struct {
+	cfapi.ProblemRef `yaml:",inline"`
+
+	Name   string            `yaml:"name,omitempty" json:"name,omitempty"`
+	Rating int               `yaml:"rating,omitempty" json:"rating,omitempty"`
+	Status v1.PracticeStatus `yaml:"status" json:"status"`
+}
+
+// ListEntries returns the problems of the named list in list order, with
+// name, rating and practice status from the workspace problems. Problems not
+// in the workspace are reported as unseen.
+func (w *Workspace) ListEntries(name string) ([]ListEntry, error) {
+	list, err := w.LoadList(name)
+	if err != nil {
+		return nil, err
+	}
+
+	problems, err := w.ListProblems()
+	if err != nil {
+		return nil, err
+	}
+	byRef := make(map[cfapi.ProblemRef]*v1.Problem, len(problems))
+	for _, p := range problems {
+		if p.Platform == "codeforces" {
+			byRef[cfapi.ProblemRef{ContestID: p.ContestID, Index: p.Index}] = p
+		}
+	}
+
+	entries := make([]ListEntry, len(list.Problems))
+	for i, r := range list.Problems {
+		entries[i] = ListEntry{ProblemRef: r, Status: v1.StatusUnseen}
+		if p, ok := byRef[r]; ok {
+			entries[i].Name = p.Name
+			entries[i].Rating = p.Metadata.Rating
+			entries[i].Status = p.Practice.Status
+		}
+	}
+	return entries, nil
+}
+
+// ListProgress returns how many problems of the named list are solved
+func (w *Workspace) ListProgress(name string) (solved, total int, err error) {
+	entries, err := w.ListEntries(name)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, e := range entries {
+		if e.Status == v1.StatusSolved {
+			solved++
+		}
+	}
+	return solved, len(entries), nil
+}
@@ -0,0 +1,97 @@
+package workspace
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_SaveList_RoundTrip(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	refs := []cfapi.ProblemRef{{ContestID: 1325, Index: "A"}, {ContestID: 1352, Index: "c"}, {ContestID: 1325, Index: "A"}}
+	if err := ws.SaveList("cses", refs); err != nil {
+		t.Fatalf("SaveList() error = %v", err)
+	}
+
+	list, err := ws.LoadList("cses")
+	if err != nil {
+		t.Fatalf("LoadList() error = %v", err)
+	}
+	want := []cfapi.ProblemRef{{ContestID: 1325, Index: "A"}, {ContestID: 1352, Index: "C"}}
+	if len(list.Problems) != len(want) {
+		t.Fatalf("Problems = %v, want %v", list.Problems, want)
+	}
+	for i := range want {
+		if list.Problems[i] != want[i] {
+			t.Errorf("Problems[%d] = %v, want %v", i, list.Problems[i], want[i])
+		}
+	}
+
+	names, err := ws.ListNames()
+	if err != nil || len(names) != 1 || names[0] != "cses" {
+		t.Errorf("ListNames() = %v, %v, want [cses]", names, err)
+	}
+}
+
+func TestWorkspace_LoadList_NotFound(t *testing.T) {
+	ws := New(t.TempDir())
+
+	for _, name := range []string{"missing", "../escape"} {
+		if _, err := ws.LoadList(name); !errors.Is(err, ErrListNotFound) {
+			t.Errorf("LoadList(%q) error = %v, want ErrListNotFound", name, err)
+		}
+	}
+	if err := ws.SaveList("../escape", nil); err == nil {
+		t.Error("SaveList() should reject names with path separators")
+	}
+}
+
+func TestWorkspace_ListProgress(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	solved := v1.NewProblem(1325, "A", "EhAb AnD gCd")
+	solved.Practice.Status = v1.StatusSolved
+	attempted := v1.NewProblem(1325, "B", "CopyCopyCopyCopyCopy")
+	attempted.Practice.Status = v1.StatusAttempted
+	for _, p := range []*v1.Problem{solved, attempted} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+
+	// 1352C is not in the workspace and counts as unsolved
+	if err := ws.SaveList("sheet", []cfapi.ProblemRef{{ContestID: 1325, Index: "A"}, {ContestID: 1325, Index: "B"}, {ContestID: 1352, Index: "C"}}); err != nil {
+		t.Fatalf("SaveList() error = %v", err)
+	}
+
+	solvedCount, total, err := ws.ListProgress("sheet")
+	if err != nil {
+		t.Fatalf("ListProgress() error = %v", err)
+	}
+	if solvedCount != 1 || total != 3 {
+		t.Errorf("ListProgress() = %d/%d, want 1/3", solvedCount, total)
+	}
+
+	entries, err := ws.ListEntries("sheet")
+	if err != nil {
+		t.Fatalf("ListEntries() error = %v", err)
+	}
+	want := []v1.PracticeStatus{v1.StatusSolved, v1.StatusAttempted, v1.StatusUnseen}
+	for i := range want {
+		if entries[i].Status != want[i] {
+			t.Errorf("entries[%d].Status = %v, want %v", i, entries[i].Status, want[i])
+		}
+	}
+	if entries[0].Name != "EhAb AnD gCd" || entries[2].Name != "" {
+		t.Errorf("entry names = %q, %q, want workspace name and empty", entries[0].Name, entries[2].Name)
+	}
+}
@@ -1,8 +1,10 @@
 package workspace
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
@@ -172,7 +174,13 @@ func TestWorkspace_LoadProblem_InvalidYAML(t *testing.T) {
 
 	_, err = ws.LoadProblem("codeforces", 1, "A")
 	if err == nil {
-		t.Error("LoadProblem() should error on invalid YAML")
+		t.Fatal("LoadProblem() should error on invalid YAML")
+	}
+	if errors.Is(err, ErrProblemNotFound) {
+		t.Errorf("LoadProblem() error = %v, corrupt file should not be reported as missing", err)
+	}
+	if !strings.Contains(err.Error(), "load problem codeforces/1/A: "+problemPath) {
+		t.Errorf("LoadProblem() error = %v, want problem reference and path", err)
 	}
 }
 
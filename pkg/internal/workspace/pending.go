@@ -0,0 +1,107 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PendingFile holds submissions that could not be sent, in the workspace root
+const PendingFile = "pending-submissions.yaml"
+
+// PendingSubmission is a submission queued to be sent once Codeforces is reachable
+type PendingSubmission struct {
+	ContestID  int       `yaml:"contestId"`
+	Index      string    `yaml:"index"`
+	LanguageID int       `yaml:"languageId"`
+	Source     string    `yaml:"source"`
+	SourceFile string    `yaml:"sourceFile,omitempty"`
+	QueuedAt   time.Time `yaml:"queuedAt"`
+	LastError  string    `yaml:"lastError,omitempty"`
+}
+
+// ProblemID returns the problem ID, e.g. 1325A
+func (p *PendingSubmission) ProblemID() string {
+	return fmt.Sprintf("%d%s", p.ContestID, p.Index)
+}
+
+// pendingQueue is the content of pending-submissions.yaml
+type pendingQueue struct {
+	Submissions []PendingSubmission `yaml:"submissions"`
+}
+
+// PendingPath returns the path of the pending submission queue
+func (w *Workspace) PendingPath() string {
+	return filepath.Join(w.root, PendingFile)
+}
+
+// QueueSubmission adds p to the pending queue. A queued submission of the
+// same source to the same problem and language is replaced rather than
+// queued twice.
+func (w *Workspace) QueueSubmission(p PendingSubmission) error {
+	pending, err := w.PendingSubmissions()
+	if err != nil {
+		return err
+	}
+	if p.QueuedAt.IsZero() {
+		p.QueuedAt = time.Now()
+	}
+
+	replaced := false
+	for i := range pending {
+		if samePending(pending[i], p) {
+			pending[i] = p
+			replaced = true
+		}
+	}
+	if !replaced {
+		pending = append(pending, p)
+	}
+	return w.SavePendingSubmissions(pending)
+}
+
+// PendingSubmissions returns the queued submissions, oldest first
+func (w *Workspace) PendingSubmissions() ([]PendingSubmission, error) {
+	data, err := os.ReadFile(w.PendingPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read pending submissions: %w", err)
+	}
+
+	var queue pendingQueue
+	if err := yaml.Unmarshal(data, &queue); err != nil {
+		return nil, fmt.Errorf("failed to parse pending submissions: %w", err)
+	}
+	return queue.Submissions, nil
+}
+
+// SavePendingSubmissions replaces the queue, removing the file once it is empty
+func (w *Workspace) SavePendingSubmissions(pending []PendingSubmission) error {
+	if len(pending) == 0 {
+		if err := os.Remove(w.PendingPath()); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to clear pending submissions: %w", err)
+		}
+		return nil
+	}
+
+	data, err := yaml.Marshal(pendingQueue{Submissions: pending})
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending submissions: %w", err)
+	}
+	// Sources may be unpublished contest solutions
+	if err := os.WriteFile(w.PendingPath(), data, 0600); err != nil {
+		return fmt.Errorf("failed to write pending submissions: %w", err)
+	}
+	return nil
+}
+
+// samePending returns true if a and b submit the same source to the same problem
+func samePending(a, b PendingSubmission) bool {
+	return a.ContestID == b.ContestID && a.Index == b.Index &&
+		a.LanguageID == b.LanguageID && a.Source == b.Source
+}
@@ -0,0 +1,47 @@
+package workspace
+
+import (
+	"os"
+	"testing"
+)
+
+func TestWorkspace_PendingSubmissions(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	pending, err := ws.PendingSubmissions()
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("PendingSubmissions() = %v, %v, want empty queue", pending, err)
+	}
+
+	first := PendingSubmission{ContestID: 1325, Index: "A", LanguageID: 54, Source: "int main() {}"}
+	for _, p := range []PendingSubmission{
+		first,
+		{ContestID: 4, Index: "A", LanguageID: 31, Source: "print('YES')"},
+		{ContestID: 1325, Index: "A", LanguageID: 54, Source: "int main() {}", LastError: "timeout"}, // Same submission again
+	} {
+		if err := ws.QueueSubmission(p); err != nil {
+			t.Fatalf("QueueSubmission() error = %v", err)
+		}
+	}
+
+	pending, err = ws.PendingSubmissions()
+	if err != nil {
+		t.Fatalf("PendingSubmissions() error = %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("PendingSubmissions() returned %d submissions, want 2", len(pending))
+	}
+	if pending[0].ProblemID() != "1325A" || pending[0].LastError != "timeout" || pending[0].QueuedAt.IsZero() {
+		t.Errorf("pending[0] = %+v, want the requeued 1325A", pending[0])
+	}
+
+	if err := ws.SavePendingSubmissions(nil); err != nil {
+		t.Fatalf("SavePendingSubmissions() error = %v", err)
+	}
+	if _, err := os.Stat(ws.PendingPath()); !os.IsNotExist(err) {
+		t.Errorf("queue file still exists after clearing: %v", err)
+	}
+}
@@ -1,18 +1,31 @@
 package workspace
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
 	"gopkg.in/yaml.v3"
 )
 
 // SaveProblem saves a problem to the workspace
+// A newly added problem also gets a starter solution file if there is a
+// template for the default language, see StarterTemplatePath.
 func (w *Workspace) SaveProblem(problem *v1.Problem) error {
 	problemDir := w.ProblemPath(problem.Platform, problem.ContestID, problem.Index)
+	isNew := !w.ProblemExists(problem.Platform, problem.ContestID, problem.Index)
+
+	// The checker is set by hand, keep it when the problem is fetched again
+	if !isNew && problem.Checker == "" {
+		if existing, err := w.LoadProblem(problem.Platform, problem.ContestID, problem.Index); err == nil {
+			problem.Checker = existing.Checker
+		}
+	}
 
 	// Create directory
 	if err := os.MkdirAll(problemDir, 0755); err != nil {
@@ -42,6 +55,14 @@ func (w *Workspace) SaveProblem(problem *v1.Problem) error {
 		if err := os.WriteFile(inputPath, []byte(sample.Input), 0644); err != nil {
 			return fmt.Errorf("failed to write sample input: %w", err)
 		}
+		// Samples of interactive problems have no fixed output; without a
+		// .out file cf test leaves them to a checker or manual review
+		if sample.Output == "" {
+			if err := os.Remove(outputPath); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove sample output: %w", err)
+			}
+			continue
+		}
 		if err := os.WriteFile(outputPath, []byte(sample.Output), 0644); err != nil {
 			return fmt.Errorf("failed to write sample output: %w", err)
 		}
@@ -53,22 +74,86 @@ func (w *Workspace) SaveProblem(problem *v1.Problem) error {
 		return fmt.Errorf("failed to create solutions dir: %w", err)
 	}
 
+	if isNew {
+		if err := w.writeStarter(problem); err != nil {
+			return err
+		}
+	}
+
+	return w.updateIndex(problem)
+}
+
+// SolutionPath returns the canonical solution file of a problem in lang, or
+// in the workspace's default language if lang is empty
+func (w *Workspace) SolutionPath(platform string, contestID int, index, lang string) string {
+	name := w.codeforcesConfig().SolutionFile(lang, contestID, index)
+	return filepath.Join(w.ProblemPath(platform, contestID, index), "solutions", name)
+}
+
+// StarterTemplatePath returns the template a solution file with the given
+// name is created from, e.g. templates/template.cpp for main.cpp
+func (w *Workspace) StarterTemplatePath(solutionFile string) string {
+	return filepath.Join(w.TemplatesPath(), "template"+filepath.Ext(solutionFile))
+}
+
+// writeStarter copies the template for the default language to the problem's
+// solution file. Nothing is written without a template, and an existing
+// solution is never overwritten.
+func (w *Workspace) writeStarter(problem *v1.Problem) error {
+	path := w.SolutionPath(problem.Platform, problem.ContestID, problem.Index, "")
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	starter, err := os.ReadFile(w.StarterTemplatePath(path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read solution template: %w", err)
+	}
+
+	if err := os.WriteFile(path, starter, 0644); err != nil {
+		return fmt.Errorf("failed to write solution file: %w", err)
+	}
 	return nil
 }
 
+// codeforcesConfig returns the Codeforces settings of the manifest, or the
+// defaults if the manifest cannot be read
+func (w *Workspace) codeforcesConfig() v1.CFConfig {
+	if w.manifest != nil {
+		return w.manifest.Codeforces
+	}
+	if manifest, err := w.LoadManifest(); err == nil {
+		return manifest.Codeforces
+	}
+	return v1.DefaultWorkspace().Codeforces
+}
+
+// ErrProblemNotFound is returned when a problem is not in the workspace
+var ErrProblemNotFound = errors.New("problem not found in workspace")
+
 // LoadProblem loads a problem from the workspace
+// A missing problem.yaml is reported as ErrProblemNotFound; other errors
+// name the file so a corrupt problem can be found and fixed
 func (w *Workspace) LoadProblem(platform string, contestID int, index string) (*v1.Problem, error) {
 	problemDir := w.ProblemPath(platform, contestID, index)
 	problemPath := filepath.Join(problemDir, "problem.yaml")
 
+	ref := fmt.Sprintf("%s/%d/%s", platform, contestID, index)
+
 	data, err := os.ReadFile(problemPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read problem: %w", err)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("load problem %s: %w (fetch it with 'cf problem fetch %d %s')", ref, ErrProblemNotFound, contestID, index)
+		}
+		return nil, fmt.Errorf("load problem %s: %w", ref, err)
 	}
 
 	var problem v1.Problem
 	if err := yaml.Unmarshal(data, &problem); err != nil {
-		return nil, fmt.Errorf("failed to parse problem: %w", err)
+		return nil, fmt.Errorf("load problem %s: %s: %w (fix the file or delete it and fetch the problem again)", ref, problemPath, err)
 	}
 
 	return &problem, nil
@@ -81,45 +166,19 @@ func (w *Workspace) ProblemExists(platform string, contestID int, index string)
 	return err == nil
 }
 
-// ListProblems lists all problems in the workspace
+// ListProblems lists all problems in the workspace as summaries. Only ID,
+// Platform, ContestID, Index, Name, URL, Metadata, Practice.Status and
+// Notes.Pinned are filled, whether or not the problem index was fresh; the
+// rest, such as samples, limits, time spent and notes, are zero. Use
+// LoadProblem for the full record.
 func (w *Workspace) ListProblems() ([]*v1.Problem, error) {
-	var problems []*v1.Problem
-
-	problemsRoot := w.ProblemsPath()
-
-	err := filepath.Walk(problemsRoot, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip errors
-		}
-
-		if info.Name() == "problem.yaml" && !info.IsDir() {
-			data, err := os.ReadFile(path)
-			if err != nil {
-				return nil // Skip read errors
-			}
-
-			var problem v1.Problem
-			if err := yaml.Unmarshal(data, &problem); err != nil {
-				return nil // Skip parse errors
-			}
-
-			problems = append(problems, &problem)
-		}
-
-		return nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	return problems, nil
+	return w.listIndexedProblems()
 }
 
 // SaveStatement saves the problem statement as markdown
 func (w *Workspace) SaveStatement(problem *v1.Problem, statement string) error {
 	problemDir := w.ProblemPath(problem.Platform, problem.ContestID, problem.Index)
-	statementPath := filepath.Join(problemDir, "statement.md")
+	statementPath := filepath.Join(problemDir, StatementFile)
 
 	// Format statement
 	md := formatStatement(problem, statement)
@@ -134,7 +193,7 @@ func (w *Workspace) SaveStatement(problem *v1.Problem, statement string) error {
 // LoadStatement loads the problem statement
 func (w *Workspace) LoadStatement(platform string, contestID int, index string) (string, error) {
 	problemDir := w.ProblemPath(platform, contestID, index)
-	statementPath := filepath.Join(problemDir, "statement.md")
+	statementPath := filepath.Join(problemDir, StatementFile)
 
 	data, err := os.ReadFile(statementPath)
 	if err != nil {
@@ -166,6 +225,110 @@ func (w *Workspace) UpdatePractice(platform string, contestID int, index string,
 	return w.SaveProblem(problem)
 }
 
+// MarkSolved records a solving attempt of timeSpent seconds and marks the problem solved
+func (w *Workspace) MarkSolved(platform string, contestID int, index string, timeSpent int) error {
+	return w.modifyPractice(platform, contestID, index, func(p *v1.PracticeData) {
+		now := recordAttempt(p, timeSpent)
+		p.Status = v1.StatusSolved
+		if p.SolvedAt == nil {
+			p.SolvedAt = &now
+		}
+	})
+}
+
+// MarkAttempted records an unsuccessful attempt of timeSpent seconds
+// A problem that is already solved stays solved
+func (w *Workspace) MarkAttempted(platform string, contestID int, index string, timeSpent int) error {
+	return w.modifyPractice(platform, contestID, index, func(p *v1.PracticeData) {
+		recordAttempt(p, timeSpent)
+		if p.Status != v1.StatusSolved {
+			p.Status = v1.StatusAttempted
+		}
+	})
+}
+
+// MarkTodo marks the problem as one to come back to, keeping its attempt history
+func (w *Workspace) MarkTodo(platform string, contestID int, index string) error {
+	return w.modifyPractice(platform, contestID, index, func(p *v1.PracticeData) {
+		p.Status = v1.StatusTodo
+	})
+}
+
+// modifyPractice loads a problem, applies fn to its practice data and saves it
+func (w *Workspace) modifyPractice(platform string, contestID int, index string, fn func(*v1.PracticeData)) error {
+	problem, err := w.LoadProblem(platform, contestID, index)
+	if err != nil {
+		return err
+	}
+
+	fn(&problem.Practice)
+	return w.SaveProblem(problem)
+}
+
+// recordAttempt counts one attempt and returns its time
+func recordAttempt(p *v1.PracticeData, timeSpent int) time.Time {
+	now := time.Now()
+	p.AttemptCount++
+	p.TimeSpent += timeSpent
+	if p.FirstAttempt == nil {
+		p.FirstAttempt = &now
+	}
+	return now
+}
+
+// Pin marks a problem as one being actively worked on
+func (w *Workspace) Pin(platform string, contestID int, index string) error {
+	return w.setPinned(platform, contestID, index, true)
+}
+
+// Unpin clears the pin of a problem
+func (w *Workspace) Unpin(platform string, contestID int, index string) error {
+	return w.setPinned(platform, contestID, index, false)
+}
+
+func (w *Workspace) setPinned(platform string, contestID int, index string, pinned bool) error {
+	problem, err := w.LoadProblem(platform, contestID, index)
+	if err != nil {
+		return err
+	}
+
+	problem.Notes.Pinned = pinned
+	return w.SaveProblem(problem)
+}
+
+// ListPinned lists pinned problems as full records
+func (w *Workspace) ListPinned() ([]*v1.Problem, error) {
+	problems, err := w.ListProblems()
+	if err != nil {
+		return nil, err
+	}
+
+	var pinned []*v1.Problem
+	for _, p := range problems {
+		if !p.Notes.Pinned {
+			continue
+		}
+		// Pinned problems are rendered whole with -o json
+		problem, err := w.LoadProblem(p.Platform, p.ContestID, p.Index)
+		if err != nil {
+			return nil, err
+		}
+		pinned = append(pinned, problem)
+	}
+	return pinned, nil
+}
+
+// UpdateMetadata updates platform metadata for a problem
+func (w *Workspace) UpdateMetadata(platform string, contestID int, index string, metadata *v1.ProblemMetadata) error {
+	problem, err := w.LoadProblem(platform, contestID, index)
+	if err != nil {
+		return err
+	}
+
+	problem.Metadata = *metadata
+	return w.SaveProblem(problem)
+}
+
 func formatStatement(problem *v1.Problem, statement string) string {
 	var sb strings.Builder
 
@@ -1,6 +1,7 @@
 package workspace
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -60,6 +61,104 @@ func TestWorkspace_SaveProblem(t *testing.T) {
 	}
 }
 
+func TestWorkspace_SaveProblem_StarterFromPattern(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	ws.Manifest().Codeforces.DefaultLanguage = "py"
+	ws.Manifest().Codeforces.SolutionFiles = map[string]string{"py": "{index}.py"}
+	if err := ws.SaveManifest(); err != nil {
+		t.Fatalf("SaveManifest() error = %v", err)
+	}
+
+	template := "import sys\n"
+	if err := os.WriteFile(filepath.Join(ws.TemplatesPath(), "template.py"), []byte(template), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	// A fresh manager reads the patterns from workspace.yaml
+	ws = New(tmpDir)
+	if err := ws.SaveProblem(v1.NewProblem(1325, "B", "CopyCopyCopyCopyCopy")); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+
+	path := ws.SolutionPath("codeforces", 1325, "B", "")
+	if filepath.Base(path) != "B.py" {
+		t.Errorf("SolutionPath() = %s, want B.py", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("SaveProblem() did not create %s: %v", path, err)
+	}
+	if string(data) != template {
+		t.Errorf("starter = %q, want %q", data, template)
+	}
+
+	// Saving again must not overwrite the user's solution
+	if err := os.WriteFile(path, []byte("solved\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := ws.SaveProblem(v1.NewProblem(1325, "B", "CopyCopyCopyCopyCopy")); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "solved\n" {
+		t.Errorf("SaveProblem() overwrote the solution: %q", data)
+	}
+}
+
+func TestWorkspace_SaveProblem_NoTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := ws.SaveProblem(v1.NewProblem(1325, "A", "EhAb AnD gCd")); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+
+	if _, err := os.Stat(ws.SolutionPath("codeforces", 1325, "A", "")); !os.IsNotExist(err) {
+		t.Errorf("SaveProblem() created a starter without a template (err = %v)", err)
+	}
+}
+
+func TestWorkspace_SaveProblem_InteractiveAndChecker(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	problem := v1.NewProblem(1520, "F1", "Guess the K-th Zero")
+	problem.Samples = []v1.Sample{{Index: 1, Input: "6 1\n"}}
+	problem.Checker = "python3 check.py {input} {output}"
+	if err := ws.SaveProblem(problem); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+
+	testsDir := filepath.Join(ws.ProblemPath("codeforces", 1520, "F1"), "tests")
+	if _, err := os.Stat(filepath.Join(testsDir, "sample_1.out")); !os.IsNotExist(err) {
+		t.Errorf("SaveProblem() wrote an expected output for a sample without one (err = %v)", err)
+	}
+
+	// Fetching the problem again keeps the hand-written checker
+	refetched := v1.NewProblem(1520, "F1", "Guess the K-th Zero")
+	if err := ws.SaveProblem(refetched); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+	loaded, err := ws.LoadProblem("codeforces", 1520, "F1")
+	if err != nil {
+		t.Fatalf("LoadProblem() error = %v", err)
+	}
+	if loaded.Checker != problem.Checker {
+		t.Errorf("Checker = %q after refetch, want %q", loaded.Checker, problem.Checker)
+	}
+}
+
 func TestWorkspace_LoadProblem(t *testing.T) {
 	tmpDir := t.TempDir()
 	ws := New(tmpDir)
@@ -110,7 +209,13 @@ func TestWorkspace_LoadProblem_NotFound(t *testing.T) {
 
 	_, err = ws.LoadProblem("codeforces", 9999, "Z")
 	if err == nil {
-		t.Error("LoadProblem() should return error for non-existent problem")
+		t.Fatal("LoadProblem() should return error for non-existent problem")
+	}
+	if !errors.Is(err, ErrProblemNotFound) {
+		t.Errorf("LoadProblem() error = %v, want ErrProblemNotFound", err)
+	}
+	if !strings.Contains(err.Error(), "codeforces/9999/Z") {
+		t.Errorf("LoadProblem() error = %v, want problem reference", err)
 	}
 }
 
@@ -343,6 +448,45 @@ func TestWorkspace_SaveNotes(t *testing.T) {
 	}
 }
 
+func TestWorkspace_UpdateMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	problem := v1.NewProblem(1325, "A", "Test")
+	problem.Practice.Status = v1.StatusSolved
+	if err := ws.SaveProblem(problem); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+
+	metadata := &v1.ProblemMetadata{Rating: 1200, Tags: []string{"greedy"}}
+	if err := ws.UpdateMetadata("codeforces", 1325, "A", metadata); err != nil {
+		t.Fatalf("UpdateMetadata() error = %v", err)
+	}
+
+	loaded, err := ws.LoadProblem("codeforces", 1325, "A")
+	if err != nil {
+		t.Fatalf("LoadProblem() error = %v", err)
+	}
+	if loaded.Metadata.Rating != 1200 || len(loaded.Metadata.Tags) != 1 {
+		t.Errorf("Metadata = %+v, want rating 1200 and one tag", loaded.Metadata)
+	}
+	if loaded.Practice.Status != v1.StatusSolved {
+		t.Errorf("Practice.Status = %v, want %v", loaded.Practice.Status, v1.StatusSolved)
+	}
+}
+
+func TestWorkspace_UpdateMetadata_NotFound(t *testing.T) {
+	ws := New(t.TempDir())
+
+	if err := ws.UpdateMetadata("codeforces", 1, "A", &v1.ProblemMetadata{}); err == nil {
+		t.Error("UpdateMetadata() should fail for a missing problem")
+	}
+}
+
 func TestWorkspace_UpdatePractice(t *testing.T) {
 	tmpDir := t.TempDir()
 	ws := New(tmpDir)
@@ -386,6 +530,97 @@ func TestWorkspace_UpdatePractice(t *testing.T) {
 	}
 }
 
+func TestWorkspace_MarkSolved(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := ws.SaveProblem(v1.NewProblem(1325, "A", "Test")); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+
+	if err := ws.MarkAttempted("codeforces", 1325, "A", 120); err != nil {
+		t.Fatalf("MarkAttempted() error = %v", err)
+	}
+	loaded, _ := ws.LoadProblem("codeforces", 1325, "A")
+	if loaded.Practice.Status != v1.StatusAttempted {
+		t.Errorf("Practice.Status = %v, want %v", loaded.Practice.Status, v1.StatusAttempted)
+	}
+	if loaded.Practice.FirstAttempt == nil {
+		t.Error("MarkAttempted() should set FirstAttempt")
+	}
+	if loaded.Practice.SolvedAt != nil {
+		t.Error("MarkAttempted() should not set SolvedAt")
+	}
+
+	if err := ws.MarkSolved("codeforces", 1325, "A", 180); err != nil {
+		t.Fatalf("MarkSolved() error = %v", err)
+	}
+	loaded, _ = ws.LoadProblem("codeforces", 1325, "A")
+	if loaded.Practice.Status != v1.StatusSolved {
+		t.Errorf("Practice.Status = %v, want %v", loaded.Practice.Status, v1.StatusSolved)
+	}
+	if loaded.Practice.AttemptCount != 2 {
+		t.Errorf("Practice.AttemptCount = %v, want 2", loaded.Practice.AttemptCount)
+	}
+	if loaded.Practice.TimeSpent != 300 {
+		t.Errorf("Practice.TimeSpent = %v, want 300", loaded.Practice.TimeSpent)
+	}
+	if loaded.Practice.SolvedAt == nil {
+		t.Error("MarkSolved() should set SolvedAt")
+	}
+
+	// Another failed attempt must not downgrade a solved problem
+	if err := ws.MarkAttempted("codeforces", 1325, "A", 60); err != nil {
+		t.Fatalf("MarkAttempted() error = %v", err)
+	}
+	loaded, _ = ws.LoadProblem("codeforces", 1325, "A")
+	if loaded.Practice.Status != v1.StatusSolved {
+		t.Errorf("Practice.Status = %v, want to stay %v", loaded.Practice.Status, v1.StatusSolved)
+	}
+	if loaded.Practice.AttemptCount != 3 {
+		t.Errorf("Practice.AttemptCount = %v, want 3", loaded.Practice.AttemptCount)
+	}
+}
+
+func TestWorkspace_MarkTodo(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := ws.SaveProblem(v1.NewProblem(1325, "A", "Test")); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+	if err := ws.MarkAttempted("codeforces", 1325, "A", 120); err != nil {
+		t.Fatalf("MarkAttempted() error = %v", err)
+	}
+
+	if err := ws.MarkTodo("codeforces", 1325, "A"); err != nil {
+		t.Fatalf("MarkTodo() error = %v", err)
+	}
+	loaded, _ := ws.LoadProblem("codeforces", 1325, "A")
+	if loaded.Practice.Status != v1.StatusTodo {
+		t.Errorf("Practice.Status = %v, want %v", loaded.Practice.Status, v1.StatusTodo)
+	}
+	if loaded.Practice.AttemptCount != 1 || loaded.Practice.TimeSpent != 120 {
+		t.Errorf("MarkTodo() should keep attempt history, got %+v", loaded.Practice)
+	}
+}
+
+func TestWorkspace_Mark_NotFound(t *testing.T) {
+	ws := New(t.TempDir())
+
+	if err := ws.MarkSolved("codeforces", 999999, "Z", 0); err == nil {
+		t.Error("MarkSolved() should error when problem not found")
+	}
+	if err := ws.MarkAttempted("codeforces", 999999, "Z", 0); err == nil {
+		t.Error("MarkAttempted() should error when problem not found")
+	}
+	if err := ws.MarkTodo("codeforces", 999999, "Z"); err == nil {
+		t.Error("MarkTodo() should error when problem not found")
+	}
+}
+
 func TestFormatStatement(t *testing.T) {
 	problem := v1.NewProblem(1325, "A", "EhAb AnD gCd")
 	problem.Metadata.Rating = 800
@@ -441,3 +676,50 @@ func TestFormatStatement(t *testing.T) {
 		t.Error("formatStatement() should include Codeforces link")
 	}
 }
+
+func TestWorkspace_PinUnpin(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	for _, p := range []*v1.Problem{v1.NewProblem(1325, "A", "First"), v1.NewProblem(1325, "B", "Second")} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+
+	if err := ws.Pin("codeforces", 1325, "B"); err != nil {
+		t.Fatalf("Pin() error = %v", err)
+	}
+
+	loaded, err := ws.LoadProblem("codeforces", 1325, "B")
+	if err != nil {
+		t.Fatalf("LoadProblem() error = %v", err)
+	}
+	if !loaded.Notes.Pinned {
+		t.Error("pin should be saved in problem.yaml")
+	}
+
+	pinned, err := ws.ListPinned()
+	if err != nil {
+		t.Fatalf("ListPinned() error = %v", err)
+	}
+	if len(pinned) != 1 || pinned[0].Index != "B" {
+		t.Errorf("ListPinned() = %v, want only 1325B", pinned)
+	}
+
+	if err := ws.Unpin("codeforces", 1325, "B"); err != nil {
+		t.Fatalf("Unpin() error = %v", err)
+	}
+	pinned, err = ws.ListPinned()
+	if err != nil {
+		t.Fatalf("ListPinned() error = %v", err)
+	}
+	if len(pinned) != 0 {
+		t.Errorf("ListPinned() after Unpin = %v, want none", pinned)
+	}
+
+	if err := ws.Pin("codeforces", 9999, "Z"); !errors.Is(err, ErrProblemNotFound) {
+		t.Errorf("Pin() on missing problem error = %v, want ErrProblemNotFound", err)
+	}
+}
@@ -0,0 +1,51 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// ProgressFile holds the practice progress inside the stats directory
+const ProgressFile = "progress.yaml"
+
+// ProgressPath returns the path of the progress file
+func (w *Workspace) ProgressPath() string {
+	return filepath.Join(w.StatsPath(), ProgressFile)
+}
+
+// LoadProgress reads the progress file
+// The error wraps os.ErrNotExist if no progress has been saved yet
+func (w *Workspace) LoadProgress() (*v1.Progress, error) {
+	data, err := os.ReadFile(w.ProgressPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read progress: %w", err)
+	}
+
+	var progress v1.Progress
+	if err := yaml.Unmarshal(data, &progress); err != nil {
+		return nil, fmt.Errorf("failed to parse progress: %w", err)
+	}
+
+	return &progress, nil
+}
+
+// SaveProgress writes the progress file
+func (w *Workspace) SaveProgress(progress *v1.Progress) error {
+	if err := os.MkdirAll(w.StatsPath(), 0755); err != nil {
+		return fmt.Errorf("failed to create stats dir: %w", err)
+	}
+
+	data, err := yaml.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to marshal progress: %w", err)
+	}
+	if err := os.WriteFile(w.ProgressPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write progress: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_Progress_RoundTrip(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	progress := v1.NewProgress()
+	progress.AddSolved("1325A", 800, []string{"math"}, 60)
+	if err := ws.SaveProgress(progress); err != nil {
+		t.Fatalf("SaveProgress() error = %v", err)
+	}
+
+	loaded, err := ws.LoadProgress()
+	if err != nil {
+		t.Fatalf("LoadProgress() error = %v", err)
+	}
+	if loaded.TotalSolved != 1 || len(loaded.Daily) != 1 {
+		t.Errorf("LoadProgress() = %+v, want one solve", loaded)
+	}
+}
+
+func TestWorkspace_LoadProgress_Missing(t *testing.T) {
+	ws := New(t.TempDir())
+
+	_, err := ws.LoadProgress()
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadProgress() error = %v, want os.ErrNotExist", err)
+	}
+}
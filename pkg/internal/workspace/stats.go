@@ -0,0 +1,24 @@
+package workspace
+
+import (
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+// Stats rolls up the practice data of every saved problem
+func (w *Workspace) Stats() (*v1.WorkspaceStats, error) {
+	listed, err := w.ListProblems()
+	if err != nil {
+		return nil, err
+	}
+
+	// The index has no time spent; read the full problem.yaml
+	problems := make([]*v1.Problem, 0, len(listed))
+	for _, p := range listed {
+		problem, err := w.LoadProblem(p.Platform, p.ContestID, p.Index)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, problem)
+	}
+	return v1.SummarizeProblems(problems), nil
+}
@@ -0,0 +1,94 @@
+package workspace
+
+import (
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_Stats_Empty(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	stats, err := ws.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.Total != 0 || stats.Solved != 0 || stats.TotalTime != 0 {
+		t.Errorf("Stats() of an empty workspace = %+v, want zeros", stats)
+	}
+	if stats.RatingDistribution == nil || len(stats.RatingDistribution) != 0 {
+		t.Errorf("RatingDistribution = %v, want an empty map", stats.RatingDistribution)
+	}
+}
+
+func TestWorkspace_Stats_MixedStatuses(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	solvedEasy := v1.NewProblem(1, "A", "Easy")
+	solvedEasy.Metadata = v1.ProblemMetadata{Rating: 800, Tags: []string{"math", "greedy"}}
+	solvedEasy.Practice = v1.PracticeData{Status: v1.StatusSolved, TimeSpent: 600}
+
+	solvedHard := v1.NewProblem(2, "C", "Hard")
+	solvedHard.Metadata = v1.ProblemMetadata{Rating: 1500, Tags: []string{"math"}}
+	solvedHard.Practice = v1.PracticeData{Status: v1.StatusSolved, TimeSpent: 1800}
+
+	solvedUnrated := v1.NewProblem(3, "A", "Gym")
+	solvedUnrated.Practice = v1.PracticeData{Status: v1.StatusSolved}
+
+	attempted := v1.NewProblem(4, "B", "Tried")
+	attempted.Metadata = v1.ProblemMetadata{Rating: 1200, Tags: []string{"dp"}}
+	attempted.Practice = v1.PracticeData{Status: v1.StatusAttempted, TimeSpent: 900}
+
+	todo := v1.NewProblem(5, "A", "Later")
+	todo.Practice = v1.PracticeData{Status: v1.StatusTodo}
+
+	unseen := v1.NewProblem(6, "A", "New")
+
+	for _, p := range []*v1.Problem{solvedEasy, solvedHard, solvedUnrated, attempted, todo, unseen} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+
+	stats, err := ws.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+
+	// The first call writes the index; the second must not lose the
+	// practice data the index leaves out
+	stats, err = ws.Stats()
+	if err != nil {
+		t.Fatalf("second Stats() error = %v", err)
+	}
+
+	if stats.Total != 6 || stats.Solved != 3 || stats.Attempted != 1 || stats.Todo != 1 || stats.Unseen != 1 {
+		t.Errorf("Stats() counts = %+v, want 6 total, 3 solved, 1 attempted, 1 todo, 1 unseen", stats)
+	}
+	if stats.TotalTime != 3300 {
+		t.Errorf("TotalTime = %d, want 3300 including attempted problems", stats.TotalTime)
+	}
+
+	wantRatings := map[string]int{"800-999": 1, "1400-1599": 1}
+	if len(stats.RatingDistribution) != len(wantRatings) {
+		t.Errorf("RatingDistribution = %v, want %v", stats.RatingDistribution, wantRatings)
+	}
+	for bucket, n := range wantRatings {
+		if stats.RatingDistribution[bucket] != n {
+			t.Errorf("RatingDistribution[%s] = %d, want %d", bucket, stats.RatingDistribution[bucket], n)
+		}
+	}
+
+	if stats.TagDistribution["math"] != 2 || stats.TagDistribution["greedy"] != 1 {
+		t.Errorf("TagDistribution = %v, want math 2 and greedy 1", stats.TagDistribution)
+	}
+	if _, ok := stats.TagDistribution["dp"]; ok {
+		t.Error("TagDistribution should only count solved problems")
+	}
+}
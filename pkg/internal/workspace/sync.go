@@ -0,0 +1,67 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SyncFile holds the submission sync state inside the stats directory
+const SyncFile = "sync.yaml"
+
+// SyncState records how far a handle's submissions have been synced
+type SyncState struct {
+	Handle string `yaml:"handle"`
+	// SubmissionCursor is the highest submission ID synced so far
+	SubmissionCursor int `yaml:"submissionCursor"`
+	// Solved lists problem IDs already counted in the progress
+	Solved []string `yaml:"solved,omitempty"`
+}
+
+// SyncPath returns the path of the sync state file
+func (w *Workspace) SyncPath() string {
+	return filepath.Join(w.StatsPath(), SyncFile)
+}
+
+// LoadSyncState reads the sync state for handle
+// A missing file, or one written for another handle, gives an empty state
+func (w *Workspace) LoadSyncState(handle string) (*SyncState, error) {
+	empty := &SyncState{Handle: handle}
+
+	data, err := os.ReadFile(w.SyncPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return empty, nil
+		}
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+
+	var state SyncState
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state: %w", err)
+	}
+
+	if state.Handle != handle {
+		return empty, nil
+	}
+	return &state, nil
+}
+
+// SaveSyncState writes the sync state file
+func (w *Workspace) SaveSyncState(state *SyncState) error {
+	if err := os.MkdirAll(w.StatsPath(), 0755); err != nil {
+		return fmt.Errorf("failed to create stats dir: %w", err)
+	}
+
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync state: %w", err)
+	}
+	if err := os.WriteFile(w.SyncPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+
+	return nil
+}
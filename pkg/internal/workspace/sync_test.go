@@ -0,0 +1,43 @@
+package workspace
+
+import (
+	"testing"
+)
+
+func TestWorkspace_SyncState(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "tourist"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	state, err := ws.LoadSyncState("tourist")
+	if err != nil {
+		t.Fatalf("LoadSyncState() error = %v", err)
+	}
+	if state.SubmissionCursor != 0 || state.Handle != "tourist" {
+		t.Errorf("LoadSyncState() = %+v, want empty state", state)
+	}
+
+	state.SubmissionCursor = 12345
+	state.Solved = []string{"1325A"}
+	if err := ws.SaveSyncState(state); err != nil {
+		t.Fatalf("SaveSyncState() error = %v", err)
+	}
+
+	loaded, err := ws.LoadSyncState("tourist")
+	if err != nil {
+		t.Fatalf("LoadSyncState() error = %v", err)
+	}
+	if loaded.SubmissionCursor != 12345 || len(loaded.Solved) != 1 {
+		t.Errorf("LoadSyncState() = %+v, want saved state", loaded)
+	}
+
+	// Another handle starts over
+	other, err := ws.LoadSyncState("petr")
+	if err != nil {
+		t.Fatalf("LoadSyncState() error = %v", err)
+	}
+	if other.SubmissionCursor != 0 {
+		t.Errorf("LoadSyncState(other) cursor = %d, want 0", other.SubmissionCursor)
+	}
+}
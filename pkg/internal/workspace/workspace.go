@@ -2,6 +2,7 @@
 package workspace
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -46,6 +47,31 @@ func (w *Workspace) Exists() bool {
 	return err == nil
 }
 
+// ErrWorkspaceNotFound is returned when no workspace.yaml is found
+var ErrWorkspaceNotFound = errors.New("workspace not found")
+
+// DiscoverWorkspace finds the workspace containing startDir by walking up
+// from it to the filesystem root, like git does for .git. The returned
+// workspace is rooted at the directory holding workspace.yaml.
+func DiscoverWorkspace(startDir string) (*Workspace, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", startDir, err)
+	}
+
+	for {
+		ws := New(dir)
+		if ws.Exists() {
+			return ws, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("%w in %s or any parent directory", ErrWorkspaceNotFound, startDir)
+		}
+		dir = parent
+	}
+}
+
 // Init initializes a new workspace
 func (w *Workspace) Init(name, handle string) error {
 	// Create root directory
@@ -154,13 +180,7 @@ func (w *Workspace) ProblemsPath() string {
 
 // ProblemPath returns the path for a specific problem
 func (w *Workspace) ProblemPath(platform string, contestID int, index string) string {
-	return filepath.Join(
-		w.ProblemsPath(),
-		platform,
-		"contest",
-		fmt.Sprintf("%d", contestID),
-		index,
-	)
+	return filepath.Join(w.ContestPath(platform, contestID), index)
 }
 
 // TemplatesPath returns the templates directory path
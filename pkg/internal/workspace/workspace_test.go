@@ -1,6 +1,7 @@
 package workspace
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -49,6 +50,45 @@ func TestWorkspace_Exists(t *testing.T) {
 	}
 }
 
+func TestDiscoverWorkspace(t *testing.T) {
+	root := t.TempDir()
+	ws := New(root)
+	if err := ws.Init("Test Workspace", "testuser"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	problemDir := ws.ProblemPath("codeforces", 1325, "A")
+	if err := os.MkdirAll(problemDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := DiscoverWorkspace(problemDir)
+	if err != nil {
+		t.Fatalf("DiscoverWorkspace() error = %v", err)
+	}
+	want, _ := filepath.Abs(root)
+	if found.Root() != want {
+		t.Errorf("Root() = %v, want %v", found.Root(), want)
+	}
+
+	found, err = DiscoverWorkspace(root)
+	if err != nil || found.Root() != want {
+		t.Errorf("DiscoverWorkspace(root) = %v, %v", found, err)
+	}
+}
+
+func TestDiscoverWorkspace_NotFound(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "a", "b")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := DiscoverWorkspace(dir)
+	if !errors.Is(err, ErrWorkspaceNotFound) {
+		t.Errorf("DiscoverWorkspace() error = %v, want ErrWorkspaceNotFound", err)
+	}
+}
+
 func TestWorkspace_Init(t *testing.T) {
 	tmpDir := t.TempDir()
 	wsRoot := filepath.Join(tmpDir, "my-workspace")
@@ -19,6 +19,14 @@ import (
 	"github.com/harshit-vibes/cf/pkg/tui/views"
 )
 
+const (
+	// contestTickInterval is how often the header countdown is refreshed
+	contestTickInterval = time.Minute
+
+	// contestWindow is how far ahead the header looks for the next contest
+	contestWindow = 7 * 24 * time.Hour
+)
+
 // App is the main application model
 type App struct {
 	// State
@@ -26,6 +34,7 @@ type App struct {
 	loading     bool
 	statusMsg   string
 	err         error
+	quitting    bool
 
 	// Dimensions
 	width  int
@@ -44,13 +53,20 @@ type App struct {
 	settings    views.SettingsModel
 
 	// Data
-	client *cfapi.Client
-	handle string
-	user   *cfapi.User
+	client      *cfapi.Client
+	handle      string
+	user        *cfapi.User
+	offline     bool
+	nextContest *cfapi.Contest
 }
 
-// New creates a new App instance
+// New creates a new App instance with the default keybindings
 func New() *App {
+	return NewWithKeys(DefaultKeyMap())
+}
+
+// NewWithKeys creates a new App instance with the given keybindings
+func NewWithKeys(keys KeyMap) *App {
 	// Get handle from config
 	handle := config.GetCFHandle()
 
@@ -64,7 +80,7 @@ func New() *App {
 
 	return &App{
 		currentView: ViewDashboard,
-		keys:        DefaultKeyMap(),
+		keys:        keys,
 		help:        help.New(),
 		spinner:     s,
 		client:      client,
@@ -84,6 +100,8 @@ func (a *App) Init() tea.Cmd {
 	return tea.Batch(
 		a.spinner.Tick,
 		a.loadInitialData(),
+		a.loadNextContest(),
+		contestTick(),
 	)
 }
 
@@ -107,6 +125,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, a.keys.Quit):
+			a.quitting = true
 			return a, tea.Quit
 
 		case key.Matches(msg, a.keys.Tab1):
@@ -161,13 +180,23 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.err = msg.Err
 		a.loading = false
 
+	case OfflineMsg:
+		a.offline = true
+		a.loading = false
+		a.err = nil
+		if len(msg.Problems) > 0 {
+			a.problems.SetProblems(msg.Problems)
+		}
+
 	case UserLoadedMsg:
+		a.offline = false
 		a.user = &msg.User
 		a.loading = false
 		a.profile.SetUser(&msg.User)
 		a.dashboard.SetUser(&msg.User)
 
 	case ProblemsLoadedMsg:
+		a.offline = false
 		a.problems.SetProblems(msg.Problems)
 		a.loading = false
 
@@ -180,6 +209,15 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.profile.SetRatingHistory(msg.RatingChanges)
 		a.loading = false
 
+	case NextContestMsg:
+		a.nextContest = msg.Contest
+
+	case ContestTickMsg:
+		// Stop re-arming the tick once quitting so the loop ends with the program
+		if !a.quitting {
+			cmds = append(cmds, a.loadNextContest(), contestTick())
+		}
+
 	case StatsLoadedMsg:
 		a.dashboard.SetStats(msg.TotalSolved, msg.RecentSolved, msg.Streak)
 		a.loading = false
@@ -190,6 +228,16 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 
+	// Views use the default navigation keys, so translate custom bindings
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		msg = a.keys.navigationKey(keyMsg)
+	}
+
+	// Views expect mouse positions relative to the content area
+	if mouseMsg, ok := msg.(tea.MouseMsg); ok {
+		msg = a.contentMouse(mouseMsg)
+	}
+
 	// Update current view
 	switch a.currentView {
 	case ViewDashboard:
@@ -246,12 +294,18 @@ func (a *App) renderHeader() string {
 	title := " - Codeforces CLI"
 
 	var status string
+	if a.offline {
+		status = styles.WarningStyle.Render("● offline (r to retry)") + "  "
+	}
 	if a.loading {
-		status = a.spinner.View() + " " + a.statusMsg
+		status += a.spinner.View() + " " + a.statusMsg
 	} else if a.err != nil {
-		status = styles.ErrorStyle.Render("Error: " + a.err.Error())
+		status += styles.ErrorStyle.Render("Error: " + a.err.Error())
 	} else if a.handle != "" {
-		status = styles.SubtitleStyle.Render("@" + a.handle)
+		status += styles.SubtitleStyle.Render("@" + a.handle)
+	}
+	if countdown := a.contestCountdown(); countdown != "" {
+		status = styles.WarningStyle.Render(countdown) + "  " + status
 	}
 
 	left := logo + title
@@ -267,6 +321,34 @@ func (a *App) renderHeader() string {
 	)
 }
 
+// contestCountdown returns the header note for the next contest, if any
+func (a *App) contestCountdown() string {
+	if a.nextContest == nil {
+		return ""
+	}
+	until := time.Until(a.nextContest.StartTime())
+	if until <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("Next: %s in %s", a.nextContest.Name, formatCountdown(until))
+}
+
+// formatCountdown formats the time left before a contest, e.g. 2d 5h or 3h 20m
+func formatCountdown(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
 func (a *App) renderTabBar() string {
 	tabs := []View{ViewDashboard, ViewProblems, ViewSubmissions, ViewProfile, ViewSettings}
 	var renderedTabs []string
@@ -300,6 +382,13 @@ func (a *App) renderContent() string {
 	}
 }
 
+// contentMouse offsets a mouse event to the content area below the header and tabs
+func (a *App) contentMouse(msg tea.MouseMsg) tea.MouseMsg {
+	msg.X -= styles.AppStyle.GetPaddingLeft()
+	msg.Y -= styles.AppStyle.GetPaddingTop() + lipgloss.Height(a.renderHeader()) + lipgloss.Height(a.renderTabBar())
+	return msg
+}
+
 func (a *App) renderFooter() string {
 	helpView := a.help.View(a.keys)
 	return styles.FooterStyle.Render(helpView)
@@ -324,6 +413,9 @@ func (a *App) loadUser() tea.Cmd {
 		defer cancel()
 
 		users, err := a.client.GetUserInfo(ctx, []string{a.handle})
+		if cfapi.IsNetworkError(err) {
+			return offlineFallback(err)
+		}
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -346,6 +438,9 @@ func (a *App) loadSubmissions() tea.Cmd {
 		defer cancel()
 
 		subs, err := a.client.GetUserSubmissions(ctx, a.handle, 1, 100)
+		if cfapi.IsNetworkError(err) {
+			return offlineFallback(err)
+		}
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -368,6 +463,9 @@ func (a *App) loadProblems() tea.Cmd {
 		}
 
 		problems, err := a.client.FilterProblems(ctx, minRating, maxRating, nil, false, "")
+		if cfapi.IsNetworkError(err) {
+			return offlineFallback(err)
+		}
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -399,6 +497,29 @@ func (a *App) loadRating() tea.Cmd {
 	}
 }
 
+// loadNextContest fetches the next upcoming contest for the header countdown
+// The contest list is cached by the client, so calling it every tick only
+// hits the API when the cache expires. Errors keep the previous countdown.
+func (a *App) loadNextContest() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		contest, err := a.client.NextContest(ctx, contestWindow)
+		if err != nil {
+			return nil
+		}
+		return NextContestMsg{Contest: contest}
+	}
+}
+
+// contestTick schedules the next countdown refresh
+func contestTick() tea.Cmd {
+	return tea.Tick(contestTickInterval, func(time.Time) tea.Msg {
+		return ContestTickMsg{}
+	})
+}
+
 func (a *App) refreshCurrentView() tea.Cmd {
 	switch a.currentView {
 	case ViewDashboard:
@@ -416,8 +537,15 @@ func (a *App) refreshCurrentView() tea.Cmd {
 
 // Run starts the TUI application
 func Run() error {
-	app := New()
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	keys := DefaultKeyMap()
+	if path, err := config.KeysFilePath(); err == nil {
+		if keys, err = LoadKeyMap(path); err != nil {
+			return fmt.Errorf("load key bindings: %w", err)
+		}
+	}
+
+	app := NewWithKeys(keys)
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	_, err := p.Run()
 	return err
 }
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+)
+
+func TestFormatCountdown(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{45 * time.Minute, "45m"},
+		{3*time.Hour + 20*time.Minute, "3h 20m"},
+		{2*24*time.Hour + 5*time.Hour + 10*time.Minute, "2d 5h"},
+	}
+	for _, tt := range tests {
+		if got := formatCountdown(tt.d); got != tt.want {
+			t.Errorf("formatCountdown(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestApp_ContestCountdown(t *testing.T) {
+	a := New()
+	a.Update(NextContestMsg{Contest: &cfapi.Contest{
+		Name:             "Codeforces Round 1000",
+		StartTimeSeconds: time.Now().Add(3*time.Hour + 20*time.Minute + 30*time.Second).Unix(),
+	}})
+	if got := a.contestCountdown(); got != "Next: Codeforces Round 1000 in 3h 20m" {
+		t.Errorf("contestCountdown() = %q", got)
+	}
+
+	a.Update(NextContestMsg{})
+	if got := a.contestCountdown(); got != "" {
+		t.Errorf("contestCountdown() = %q after clearing, want empty", got)
+	}
+}
+
+func TestApp_ContestTickStopsOnQuit(t *testing.T) {
+	a := New()
+	a.quitting = true
+	if _, cmd := a.Update(ContestTickMsg{}); cmd != nil {
+		t.Error("Update(ContestTickMsg) re-armed the tick after quit")
+	}
+}
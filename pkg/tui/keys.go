@@ -1,6 +1,15 @@
 package tui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
 
 // KeyMap defines the keybindings for the application
 type KeyMap struct {
@@ -157,3 +166,141 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.Help, k.Quit},
 	}
 }
+
+// bindings maps action names to their bindings
+func (k *KeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":        &k.Up,
+		"down":      &k.Down,
+		"left":      &k.Left,
+		"right":     &k.Right,
+		"page_up":   &k.PageUp,
+		"page_down": &k.PageDown,
+		"home":      &k.Home,
+		"end":       &k.End,
+		"tab1":      &k.Tab1,
+		"tab2":      &k.Tab2,
+		"tab3":      &k.Tab3,
+		"tab4":      &k.Tab4,
+		"tab5":      &k.Tab5,
+		"next_tab":  &k.NextTab,
+		"prev_tab":  &k.PrevTab,
+		"enter":     &k.Enter,
+		"back":      &k.Back,
+		"refresh":   &k.Refresh,
+		"search":    &k.Search,
+		"filter":    &k.Filter,
+		"sort":      &k.Sort,
+		"open":      &k.Open,
+		"help":      &k.Help,
+		"quit":      &k.Quit,
+	}
+}
+
+// keyList is a list of keys that also accepts a single key in YAML
+type keyList []string
+
+// UnmarshalYAML accepts either "k" or ["k", "up"]
+func (l *keyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = keyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*l = keys
+	return nil
+}
+
+// LoadKeyMap returns the default keybindings merged with overrides from path
+// A missing file yields the defaults
+func LoadKeyMap(path string) (KeyMap, error) {
+	keys := DefaultKeyMap()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return keys, nil
+	}
+	if err != nil {
+		return keys, fmt.Errorf("read key bindings: %w", err)
+	}
+
+	var overrides map[string]keyList
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return keys, fmt.Errorf("parse key bindings: %w", err)
+	}
+
+	if err := keys.apply(overrides); err != nil {
+		return DefaultKeyMap(), fmt.Errorf("%s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// apply merges overrides into the keymap and checks for conflicts
+func (k *KeyMap) apply(overrides map[string]keyList) error {
+	bindings := k.bindings()
+
+	for action, keys := range overrides {
+		b, ok := bindings[action]
+		if !ok {
+			return fmt.Errorf("unknown action %q", action)
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("action %q has no keys", action)
+		}
+		b.SetKeys(keys...)
+		b.SetHelp(strings.Join(keys, "/"), b.Help().Desc)
+	}
+
+	return k.validate()
+}
+
+// validate returns an error if a key is bound to more than one action
+func (k *KeyMap) validate() error {
+	bindings := k.bindings()
+
+	actions := make([]string, 0, len(bindings))
+	for action := range bindings {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	owner := make(map[string]string)
+	for _, action := range actions {
+		for _, keyStr := range bindings[action].Keys() {
+			if other, ok := owner[keyStr]; ok {
+				return fmt.Errorf("key %q is bound to both %q and %q", keyStr, other, action)
+			}
+			owner[keyStr] = action
+		}
+	}
+	return nil
+}
+
+// navigationKey maps a key matching a navigation binding to its canonical key
+// Views handle the canonical keys, so custom bindings work inside them too
+func (k KeyMap) navigationKey(msg tea.KeyMsg) tea.KeyMsg {
+	switch {
+	case key.Matches(msg, k.Up):
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case key.Matches(msg, k.Down):
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case key.Matches(msg, k.Left):
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case key.Matches(msg, k.Right):
+		return tea.KeyMsg{Type: tea.KeyRight}
+	case key.Matches(msg, k.PageUp):
+		return tea.KeyMsg{Type: tea.KeyPgUp}
+	case key.Matches(msg, k.PageDown):
+		return tea.KeyMsg{Type: tea.KeyPgDown}
+	case key.Matches(msg, k.Home):
+		return tea.KeyMsg{Type: tea.KeyHome}
+	case key.Matches(msg, k.End):
+		return tea.KeyMsg{Type: tea.KeyEnd}
+	case key.Matches(msg, k.Enter):
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	return msg
+}
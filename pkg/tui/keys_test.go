@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeKeysFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write keys file: %v", err)
+	}
+	return path
+}
+
+func TestDefaultKeyMap_NoConflicts(t *testing.T) {
+	keys := DefaultKeyMap()
+	if err := keys.validate(); err != nil {
+		t.Errorf("DefaultKeyMap() has conflicts: %v", err)
+	}
+}
+
+func TestLoadKeyMap_MissingFile(t *testing.T) {
+	keys, err := LoadKeyMap(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadKeyMap() error = %v", err)
+	}
+	if got := keys.Quit.Keys(); len(got) != 2 || got[0] != "q" {
+		t.Errorf("Quit keys = %v, want defaults", got)
+	}
+}
+
+func TestLoadKeyMap_Overrides(t *testing.T) {
+	path := writeKeysFile(t, "quit: x\nup: [\"up\", \"c\"]\n")
+
+	keys, err := LoadKeyMap(path)
+	if err != nil {
+		t.Fatalf("LoadKeyMap() error = %v", err)
+	}
+
+	if got := keys.Quit.Keys(); len(got) != 1 || got[0] != "x" {
+		t.Errorf("Quit keys = %v, want [x]", got)
+	}
+	if got := keys.Up.Help().Key; got != "up/c" {
+		t.Errorf("Up help key = %q, want up/c", got)
+	}
+	if got := keys.Up.Help().Desc; got != "up" {
+		t.Errorf("Up help desc = %q, want up", got)
+	}
+	// Untouched actions keep their defaults
+	if got := keys.Down.Keys(); len(got) != 2 || got[1] != "j" {
+		t.Errorf("Down keys = %v, want defaults", got)
+	}
+}
+
+func TestLoadKeyMap_UnknownAction(t *testing.T) {
+	path := writeKeysFile(t, "jump: z\n")
+
+	_, err := LoadKeyMap(path)
+	if err == nil || !strings.Contains(err.Error(), `unknown action "jump"`) {
+		t.Errorf("Expected unknown action error, got %v", err)
+	}
+}
+
+func TestLoadKeyMap_Conflict(t *testing.T) {
+	path := writeKeysFile(t, "search: q\n")
+
+	_, err := LoadKeyMap(path)
+	if err == nil || !strings.Contains(err.Error(), `key "q" is bound to both`) {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+}
+
+func TestLoadKeyMap_InvalidYAML(t *testing.T) {
+	path := writeKeysFile(t, "quit: [unterminated\n")
+
+	if _, err := LoadKeyMap(path); err == nil {
+		t.Error("Expected error for invalid YAML")
+	}
+}
+
+func TestKeyMap_NavigationKey(t *testing.T) {
+	keys := DefaultKeyMap()
+	keys.apply(map[string]keyList{"down": {"n"}})
+
+	msg := keys.navigationKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if msg.Type != tea.KeyDown {
+		t.Errorf("navigationKey(n) = %v, want down", msg)
+	}
+
+	other := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+	if got := keys.navigationKey(other); got.String() != "x" {
+		t.Errorf("navigationKey(x) = %v, want unchanged", got)
+	}
+}
@@ -96,11 +96,27 @@ type RatingLoadedMsg struct {
 	RatingChanges []cfapi.RatingChange
 }
 
+// OfflineMsg is sent when the API is unreachable
+// Problems holds the local workspace problems shown instead
+type OfflineMsg struct {
+	Err      error
+	Problems []cfapi.Problem
+}
+
 // ContestsLoadedMsg is sent when contests are loaded
 type ContestsLoadedMsg struct {
 	Contests []cfapi.Contest
 }
 
+// NextContestMsg is sent when the next upcoming contest is loaded
+// Contest is nil when no contest starts within the countdown window.
+type NextContestMsg struct {
+	Contest *cfapi.Contest
+}
+
+// ContestTickMsg is sent periodically to refresh the contest countdown
+type ContestTickMsg struct{}
+
 // StatsLoadedMsg is sent when statistics are loaded
 type StatsLoadedMsg struct {
 	TotalSolved      int
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+// offlineFallback builds an OfflineMsg with problems from the local workspace
+func offlineFallback(err error) tea.Msg {
+	problems, _ := workspaceProblems(config.GetWorkspacePath())
+	return OfflineMsg{Err: err, Problems: problems}
+}
+
+// workspaceProblems loads saved problems from the workspace at path
+func workspaceProblems(path string) ([]cfapi.Problem, error) {
+	ws := workspace.New(path)
+	if !ws.Exists() {
+		return nil, nil
+	}
+
+	saved, err := ws.ListProblems()
+	if err != nil {
+		return nil, err
+	}
+
+	problems := make([]cfapi.Problem, len(saved))
+	for i, p := range saved {
+		problems[i] = cfapi.Problem{
+			ContestID: p.ContestID,
+			Index:     p.Index,
+			Name:      p.Name,
+			Rating:    p.Metadata.Rating,
+			Tags:      p.Metadata.Tags,
+		}
+	}
+
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].ContestID != problems[j].ContestID {
+			return problems[i].ContestID > problems[j].ContestID
+		}
+		return problems[i].Index < problems[j].Index
+	})
+
+	return problems, nil
+}
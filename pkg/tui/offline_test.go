@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+func TestWorkspaceProblems(t *testing.T) {
+	dir := t.TempDir()
+	ws := workspace.New(dir)
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	older := v1.NewProblem(1, "A", "Older")
+	newer := v1.NewProblem(2000, "B", "Newer")
+	newer.Metadata = v1.ProblemMetadata{Rating: 1500, Tags: []string{"dp"}}
+	for _, p := range []*v1.Problem{older, newer} {
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+
+	problems, err := workspaceProblems(dir)
+	if err != nil {
+		t.Fatalf("workspaceProblems() error = %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("workspaceProblems() returned %d problems, want 2", len(problems))
+	}
+	if problems[0].ProblemID() != "2000B" || problems[0].Rating != 1500 {
+		t.Errorf("problems[0] = %+v, want 2000B rated 1500", problems[0])
+	}
+}
+
+func TestWorkspaceProblems_NoWorkspace(t *testing.T) {
+	problems, err := workspaceProblems(t.TempDir())
+	if err != nil || problems != nil {
+		t.Errorf("workspaceProblems() = %v, %v; want nil, nil", problems, err)
+	}
+}
+
+func TestApp_OfflineBanner(t *testing.T) {
+	app := New()
+
+	app.Update(OfflineMsg{
+		Err:      errors.New("dial tcp: no route to host"),
+		Problems: []cfapi.Problem{{ContestID: 1, Index: "A", Name: "Local"}},
+	})
+	if !app.offline {
+		t.Fatal("OfflineMsg should switch the app to offline mode")
+	}
+	if !strings.Contains(app.renderHeader(), "offline") {
+		t.Error("header should show the offline banner")
+	}
+
+	// A successful network load clears offline mode
+	app.Update(ProblemsLoadedMsg{Problems: []cfapi.Problem{{ContestID: 1, Index: "A"}}})
+	if app.offline {
+		t.Error("ProblemsLoadedMsg should clear offline mode")
+	}
+}
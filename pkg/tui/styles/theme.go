@@ -100,6 +100,32 @@ func GetRankName(rating int) string {
 	}
 }
 
+// GetRankShort returns the abbreviated rank for a rating, e.g. LGM
+func GetRankShort(rating int) string {
+	switch {
+	case rating >= 3000:
+		return "LGM"
+	case rating >= 2600:
+		return "IGM"
+	case rating >= 2400:
+		return "GM"
+	case rating >= 2300:
+		return "IM"
+	case rating >= 2100:
+		return "M"
+	case rating >= 1900:
+		return "CM"
+	case rating >= 1600:
+		return "E"
+	case rating >= 1400:
+		return "S"
+	case rating >= 1200:
+		return "P"
+	default:
+		return "N"
+	}
+}
+
 // GetVerdictColor returns the color for a verdict
 func GetVerdictColor(verdict string) lipgloss.Color {
 	switch verdict {
@@ -22,9 +22,10 @@ type DashboardModel struct {
 	submissions []cfapi.Submission
 
 	// Stats
-	totalSolved  int
-	recentSolved int
-	streak       int
+	totalSolved    int
+	recentSolved   int
+	streak         int
+	acceptanceRate float64
 }
 
 // NewDashboardModel creates a new dashboard model
@@ -67,6 +68,7 @@ func (m *DashboardModel) calculateStats() {
 			}
 		}
 	}
+	m.acceptanceRate = cfapi.AcceptanceRate(m.submissions)
 }
 
 // Init initializes the model
@@ -124,10 +126,14 @@ func (m DashboardModel) renderStatsCards() string {
 	}
 
 	// Solved card
+	solvedSubtext := "unique problems"
+	if len(m.submissions) > 0 {
+		solvedSubtext = fmt.Sprintf("%.0f%% acceptance", m.acceptanceRate*100)
+	}
 	solvedCard := m.renderCard(
 		"📊 Problems Solved",
 		fmt.Sprintf("%d", m.totalSolved),
-		"unique problems",
+		solvedSubtext,
 		styles.ColorSuccess,
 		cardWidth,
 	)
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/harshit-vibes/cf/pkg/external/cfapi"
 	"github.com/harshit-vibes/cf/pkg/tui/styles"
@@ -117,14 +118,59 @@ func (m ProblemsModel) Update(msg tea.Msg) (ProblemsModel, tea.Cmd) {
 				_ = p.URL()
 			}
 		}
+
+	case tea.MouseMsg:
+		switch {
+		case msg.Button == tea.MouseButtonWheelUp:
+			m.table.MoveUp(1)
+		case msg.Button == tea.MouseButtonWheelDown:
+			m.table.MoveDown(1)
+		case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress:
+			if i, ok := m.rowAt(msg.X, msg.Y); ok {
+				m.table.SetCursor(i)
+			}
+		}
+		return m, nil
 	}
 
 	m.table, cmd = m.table.Update(msg)
 	return m, cmd
 }
 
-// View renders the problems view
-func (m ProblemsModel) View() string {
+// rowAt returns the index of the problem rendered at view position x, y
+func (m ProblemsModel) rowAt(x, y int) (int, bool) {
+	if len(m.problems) == 0 {
+		return 0, false
+	}
+
+	tableView := m.table.View()
+	if x < 0 || x >= lipgloss.Width(tableView) {
+		return 0, false
+	}
+
+	// Rows follow the title block and the table header
+	lines := strings.Split(tableView, "\n")
+	bodyTop := lipgloss.Height(m.header()) - 1 + len(lines) - m.table.Height()
+	line := y - (lipgloss.Height(m.header()) - 1)
+	if y < bodyTop || line >= len(lines) {
+		return 0, false
+	}
+
+	// The visible window is not exposed by the table, so match the ID column
+	fields := strings.Fields(ansi.Strip(lines[line]))
+	if len(fields) == 0 {
+		return 0, false
+	}
+	for i := range m.problems {
+		if m.problems[i].ProblemID() == fields[0] {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// header renders the title block above the table
+func (m ProblemsModel) header() string {
 	var b strings.Builder
 
 	b.WriteString(styles.TitleStyle.Render("📝 Problem Browser"))
@@ -132,6 +178,15 @@ func (m ProblemsModel) View() string {
 	b.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf("  %d problems loaded", len(m.problems))))
 	b.WriteString("\n\n")
 
+	return b.String()
+}
+
+// View renders the problems view
+func (m ProblemsModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.header())
+
 	if m.loading {
 		b.WriteString("  Loading problems...")
 		return b.String()
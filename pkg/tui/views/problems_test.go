@@ -0,0 +1,105 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+)
+
+func newTestProblemsModel() ProblemsModel {
+	m := NewProblemsModel()
+	m.SetSize(120, 30)
+	m.SetProblems([]cfapi.Problem{
+		{ContestID: 1, Index: "A", Name: "First"},
+		{ContestID: 1, Index: "B", Name: "Second"},
+		{ContestID: 2, Index: "A1", Name: "Third"},
+	})
+	return m
+}
+
+// lineOf returns the view line containing text
+func lineOf(t *testing.T, view, text string) int {
+	t.Helper()
+	for i, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, text) {
+			return i
+		}
+	}
+	t.Fatalf("%q not found in view", text)
+	return -1
+}
+
+func TestProblemsModel_MouseClickSelectsRow(t *testing.T) {
+	m := newTestProblemsModel()
+	y := lineOf(t, m.View(), "Third")
+
+	m, _ = m.Update(tea.MouseMsg{X: 2, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+
+	if got := m.table.Cursor(); got != 2 {
+		t.Errorf("Cursor() = %d, want 2", got)
+	}
+}
+
+func TestProblemsModel_MouseClickOutsideIgnored(t *testing.T) {
+	m := newTestProblemsModel()
+	width := lipgloss.Width(m.table.View())
+
+	clicks := []tea.MouseMsg{
+		{X: 2, Y: 0, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress},
+		{X: width + 5, Y: lineOf(t, m.View(), "Third"), Button: tea.MouseButtonLeft, Action: tea.MouseActionPress},
+		{X: 2, Y: 500, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress},
+	}
+	for _, click := range clicks {
+		m, _ = m.Update(click)
+		if got := m.table.Cursor(); got != 0 {
+			t.Errorf("click at %d,%d moved cursor to %d", click.X, click.Y, got)
+		}
+	}
+}
+
+func TestProblemsModel_MouseWheel(t *testing.T) {
+	m := newTestProblemsModel()
+
+	m, _ = m.Update(tea.MouseMsg{Button: tea.MouseButtonWheelDown})
+	if got := m.table.Cursor(); got != 1 {
+		t.Errorf("Cursor() after wheel down = %d, want 1", got)
+	}
+
+	m, _ = m.Update(tea.MouseMsg{Button: tea.MouseButtonWheelUp})
+	if got := m.table.Cursor(); got != 0 {
+		t.Errorf("Cursor() after wheel up = %d, want 0", got)
+	}
+}
+
+func TestProblemsModel_KeyboardStillWorks(t *testing.T) {
+	m := newTestProblemsModel()
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := m.table.Cursor(); got != 1 {
+		t.Errorf("Cursor() after down = %d, want 1", got)
+	}
+}
+
+func TestSubmissionsModel_MouseClickSelectsRow(t *testing.T) {
+	m := NewSubmissionsModel()
+	m.SetSize(120, 30)
+	m.SetSubmissions([]cfapi.Submission{
+		{Problem: cfapi.Problem{ContestID: 1, Index: "A", Name: "First"}},
+		{Problem: cfapi.Problem{ContestID: 1, Index: "B", Name: "Second"}},
+	})
+	y := lineOf(t, m.View(), "Second")
+
+	m, _ = m.Update(tea.MouseMsg{X: 2, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if got := m.table.Cursor(); got != 1 {
+		t.Errorf("Cursor() = %d, want 1", got)
+	}
+
+	m, _ = m.Update(tea.MouseMsg{X: 2, Y: y + 10, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	if got := m.table.Cursor(); got != 1 {
+		t.Errorf("click below rows moved cursor to %d", got)
+	}
+}
@@ -103,13 +103,51 @@ func (m SubmissionsModel) Init() tea.Cmd {
 
 // Update handles messages
 func (m SubmissionsModel) Update(msg tea.Msg) (SubmissionsModel, tea.Cmd) {
+	if msg, ok := msg.(tea.MouseMsg); ok {
+		switch {
+		case msg.Button == tea.MouseButtonWheelUp:
+			m.table.MoveUp(1)
+		case msg.Button == tea.MouseButtonWheelDown:
+			m.table.MoveDown(1)
+		case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress:
+			if i, ok := m.rowAt(msg.X, msg.Y); ok {
+				m.table.SetCursor(i)
+			}
+		}
+		return m, nil
+	}
+
 	var cmd tea.Cmd
 	m.table, cmd = m.table.Update(msg)
 	return m, cmd
 }
 
-// View renders the submissions view
-func (m SubmissionsModel) View() string {
+// rowAt returns the index of the submission rendered at view position x, y
+func (m SubmissionsModel) rowAt(x, y int) (int, bool) {
+	if x < 0 || x >= m.width {
+		return 0, false
+	}
+
+	// Rows follow the title block and the table header, starting from the first submission
+	top := lipgloss.Height(m.header()) - 1 + lipgloss.Height(m.tableHeader())
+	i := y - top
+	if i < 0 || i >= m.visibleRows() {
+		return 0, false
+	}
+	return i, true
+}
+
+// visibleRows returns how many submissions fit in the table
+func (m SubmissionsModel) visibleRows() int {
+	end := len(m.submissions)
+	if end > m.height-8 {
+		end = m.height - 8
+	}
+	return end
+}
+
+// header renders the title block above the table
+func (m SubmissionsModel) header() string {
 	var b strings.Builder
 
 	b.WriteString(styles.TitleStyle.Render("📤 Submissions"))
@@ -117,6 +155,23 @@ func (m SubmissionsModel) View() string {
 	b.WriteString(styles.SubtitleStyle.Render(fmt.Sprintf("  %d submissions", len(m.submissions))))
 	b.WriteString("\n\n")
 
+	return b.String()
+}
+
+// tableHeader renders the column headings
+func (m SubmissionsModel) tableHeader() string {
+	header := fmt.Sprintf("  %-14s %-10s %-35s %-8s %-8s %s",
+		"Time", "Problem", "Name", "Verdict", "Time", "Language",
+	)
+	return styles.TableHeaderStyle.Render(header)
+}
+
+// View renders the submissions view
+func (m SubmissionsModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.header())
+
 	if m.loading {
 		b.WriteString("  Loading submissions...")
 		return b.String()
@@ -140,17 +195,11 @@ func (m SubmissionsModel) renderTable() string {
 	var b strings.Builder
 
 	// Header
-	header := fmt.Sprintf("  %-14s %-10s %-35s %-8s %-8s %s",
-		"Time", "Problem", "Name", "Verdict", "Time", "Language",
-	)
-	b.WriteString(styles.TableHeaderStyle.Render(header))
+	b.WriteString(m.tableHeader())
 	b.WriteString("\n")
 
 	start := 0
-	end := len(m.submissions)
-	if end > m.height-8 {
-		end = m.height - 8
-	}
+	end := m.visibleRows()
 
 	cursor := m.table.Cursor()
 